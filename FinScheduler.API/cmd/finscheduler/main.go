@@ -3,20 +3,27 @@ package main
 import (
 	"context"
 	"finscheduler/database"
+	"finscheduler/internal/auth"
 	featurehttp "finscheduler/internal/features/http"
 	"finscheduler/internal/features/services"
 	"finscheduler/internal/health"
 	"finscheduler/internal/infra"
+	"finscheduler/internal/jobs"
 	"finscheduler/internal/logging"
 	"finscheduler/internal/metrics"
+	"finscheduler/internal/openapi"
 	"finscheduler/internal/persistence"
 	"finscheduler/internal/profiles"
+	"finscheduler/internal/ratelimit"
 	"finscheduler/internal/traces"
 	"fmt"
 	"log"
 	"log/slog"
-	"net/http"
+	"net"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -25,53 +32,58 @@ import (
 )
 
 func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run starts the API and blocks until it has shut down. Errors are returned
+// rather than fatal so that every deferred shutdown has run when main exits.
+func run() error {
 	ctx := context.Background()
 	cfg, err := infra.LoadConfig()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	connectionString := cfg.ConnectionString
 
 	mp, err := metrics.InitMetrics(ctx, cfg)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer func() {
-		err = mp.Shutdown(ctx)
-		if err != nil {
-			log.Fatalf("failed to shutdown meter: %v", err)
+		if err := mp.Shutdown(ctx); err != nil {
+			log.Printf("failed to shutdown meter: %v", err)
 		}
 	}()
 	metrics.InitInstruments()
 
 	tp, err := traces.InitTracer(ctx, cfg)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer func() {
-		err = tp.Shutdown(ctx)
-		if err != nil {
-			log.Fatalf("failed to shutdown meter: %v", err)
+		if err := tp.Shutdown(ctx); err != nil {
+			log.Printf("failed to shutdown tracer: %v", err)
 		}
 	}()
 
 	prof, err := profiles.InitProfiler(cfg)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	if prof != nil {
 		defer func() {
-			err = prof.Stop()
-			if err != nil {
-				log.Fatalf("failed to shutdown profiler: %v", err)
+			if err := prof.Stop(); err != nil {
+				log.Printf("failed to shutdown profiler: %v", err)
 			}
 		}()
 	}
 
 	db, err := sqlx.Open("pgx", connectionString)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer db.Close()
 
@@ -93,31 +105,59 @@ func main() {
 	logger := slog.New(logging.NewCustomLoggingHandler(stdoutHandler)).
 		With("service", cfg.Observability.ServiceName, "env", cfg.Env)
 
+	tokens, err := auth.NewTokenIssuer(cfg.Auth)
+	if err != nil {
+		return err
+	}
 	uow := persistence.NewUnitOfWork(db, logger)
 
+	authService := services.NewAuthService(uow, tokens, logger)
 	itemsService := services.NewItemsService(uow, logger)
 	tagsService := services.NewTagsService(uow, logger)
+	householdsService := services.NewHouseholdsService(uow, logger)
+	apiKeysService := services.NewApiKeysService(uow, logger)
+	auditService := services.NewAuditService(uow, logger)
+	idempotencyKeysService := services.NewIdempotencyKeysService(uow, logger)
+
+	authenticator := auth.NewAuthenticator(tokens, logger).AcceptAPIKeys(apiKeysService)
+
+	rateLimitStore, err := ratelimit.NewStore(cfg.RateLimit.Store, db)
+	if err != nil {
+		return err
+	}
+	limiter := ratelimit.NewLimiter(cfg.RateLimit, rateLimitStore, logger)
 
+	authHandler := featurehttp.NewAuthHandler(authService, logger)
 	tagsHandler := featurehttp.NewTagsHandler(tagsService, logger)
 	itemsHandler := featurehttp.NewItemsHandler(itemsService, logger)
+	householdsHandler := featurehttp.NewHouseholdsHandler(householdsService, logger)
+	apiKeysHandler := featurehttp.NewApiKeysHandler(apiKeysService, logger)
+	auditHandler := featurehttp.NewAuditHandler(auditService, logger)
 
 	r := chi.NewRouter()
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.CORSSettings.AllowedOrigins,
 		AllowedMethods:   cfg.CORSSettings.AllowedMethods,
 		AllowedHeaders:   cfg.CORSSettings.AllowedHeaders,
+		ExposedHeaders:   []string{"ETag", "Idempotent-Replayed", "API-Version", "Deprecation", "Sunset", "Link", "WWW-Authenticate", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		AllowCredentials: cfg.CORSSettings.AllowCredentials,
 	}))
 	r.Use(traces.TraceParentPropagationMiddleware)
 	if cfg.Observability.Metrics.Enabled {
 		r.Handle(cfg.Observability.Metrics.ExportEndpoint, metrics.Handler())
 	}
-	health.SetupHealthChecks(r, db)
-	r.Route("/api/items", func(r chi.Router) {
-		itemsHandler.RegisterEndpoints(r)
-	})
-	r.Route("/api/tags", func(r chi.Router) {
-		tagsHandler.RegisterEndpoints(r)
+	readiness := health.NewReadiness()
+	health.SetupHealthChecks(r, db, readiness)
+
+	document := openapi.NewDocument()
+	validator := openapi.NewValidator(document, cfg.Server.MaxBodyBytes)
+	featurehttp.RegisterVersions(r, featurehttp.APIVersion{
+		Name: "v1",
+		Routes: func(router chi.Router) {
+			openapi.SetupSpecification(router, document)
+			featurehttp.AuthRoutes(authHandler, limiter.Middleware, validator.Middleware)(router)
+			featurehttp.Protected(authenticator.Middleware, featurehttp.V1Routes(itemsHandler, tagsHandler, householdsHandler, apiKeysHandler, auditHandler, limiter.Middleware, validator.Middleware))(router)
+		},
 	})
 
 	logger.Info("starting http server",
@@ -128,9 +168,37 @@ func main() {
 		"traces_enabled", cfg.Observability.Traces.Enabled,
 		"trace_export_endpoint", cfg.Observability.Traces.ExportEndpoint,
 		"profiling_enabled", cfg.Observability.Profiling.Enabled,
+		"read_timeout", cfg.Server.ReadTimeout.String(),
+		"write_timeout", cfg.Server.WriteTimeout.String(),
+		"idle_timeout", cfg.Server.IdleTimeout.String(),
+		"trash_retention_days", cfg.Trash.RetentionDays,
+		"trash_purge_interval", cfg.Trash.PurgeInterval.String(),
+		"idempotency_purge_interval", cfg.Idempotency.PurgeInterval.String(),
 	)
-	err = http.ListenAndServe(fmt.Sprintf(":%d", cfg.ServerPort), r)
+
+	// Returning from run instead of exiting lets the deferred shutdowns of the
+	// profiler, tracer, meter and database run on SIGTERM.
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := newHTTPServer(cfg, r)
+	listener, err := net.Listen("tcp", server.Addr)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+
+	// The background jobs are stopped and awaited before the database closes.
+	var jobsGroup sync.WaitGroup
+	jobsGroup.Go(func() { jobs.NewTrashPurge(itemsService, cfg.Trash, logger).Run(signalCtx) })
+	jobsGroup.Go(func() { jobs.NewIdempotencyPurge(idempotencyKeysService, cfg.Idempotency, logger).Run(signalCtx) })
+
+	serveErr := serve(signalCtx, server, listener, readiness, cfg.Server, logger)
+	if serveErr != nil {
+		logger.Error("http server stopped with an error", "error", serveErr)
 	}
+
+	stop()
+	jobsGroup.Wait()
+
+	return serveErr
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"finscheduler/internal/health"
+	"finscheduler/internal/infra"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+func newHTTPServer(cfg *infra.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.ServerPort),
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+}
+
+// serve runs server on listener until ctx is cancelled, then drains it:
+// readiness fails for the drain delay so the ingress stops routing new
+// requests, after which in-flight requests get up to the shutdown timeout to
+// finish.
+func serve(ctx context.Context, server *http.Server, listener net.Listener, readiness *health.Readiness, cfg infra.ServerConfig, logger *slog.Logger) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutdown signal received, draining http server",
+		"drain_delay", cfg.DrainDelay.String(),
+		"shutdown_timeout", cfg.ShutdownTimeout.String(),
+	)
+	readiness.StartDraining()
+	server.SetKeepAlivesEnabled(false)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-time.After(cfg.DrainDelay):
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown http server: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	logger.Info("http server stopped")
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"finscheduler/internal/health"
+	"finscheduler/internal/infra"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_ShouldDrainAndFinishInFlightRequestsOnCancel(t *testing.T) {
+	// Arrange
+	readiness := health.NewReadiness()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := chi.NewRouter()
+	health.SetupHealthChecks(router, nil, readiness)
+	router.Get("/slow", func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	listener, listenErr := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, listenErr)
+	address := listener.Addr().String()
+	cfg := infra.ServerConfig{DrainDelay: 500 * time.Millisecond, ShutdownTimeout: 5 * time.Second}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, &http.Server{Handler: router}, listener, readiness, cfg, slog.Default())
+	}()
+
+	slowStatus := make(chan int, 1)
+	go func() {
+		response, err := http.Get("http://" + address + "/slow")
+		if err != nil {
+			slowStatus <- 0
+			return
+		}
+		defer response.Body.Close()
+		slowStatus <- response.StatusCode
+	}()
+	<-started
+
+	// Act
+	cancel()
+	require.Eventually(t, readiness.IsDraining, time.Second, 5*time.Millisecond)
+	readyResponse, readyErr := http.Get("http://" + address + "/readyz")
+	require.NoError(t, readyErr)
+	readyBody, _ := io.ReadAll(readyResponse.Body)
+	readyResponse.Body.Close()
+
+	// Shutdown closes the listener once the drain delay is over; the slow
+	// request is only released after that, so it completes during Shutdown.
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return true
+		}
+		conn.Close()
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+	close(release)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, readyResponse.StatusCode)
+	assert.Equal(t, "shutting down\n", string(readyBody))
+	assert.Equal(t, http.StatusOK, <-slowStatus)
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after the in-flight request finished")
+	}
+}
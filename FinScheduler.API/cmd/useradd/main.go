@@ -0,0 +1,65 @@
+// Command useradd creates a local account that can log in through
+// /api/auth/login. The password is read from standard input so that it does
+// not end up in the shell history:
+//
+//	echo "$PASSWORD" | useradd -email user@example.com
+package main
+
+import (
+	"bufio"
+	"context"
+	"finscheduler/database"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/infra"
+	"finscheduler/internal/persistence"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+func main() {
+	email := flag.String("email", "", "email address of the new user")
+	flag.Parse()
+
+	cfg, err := infra.LoadConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		log.Fatalf("failed to read password from stdin: %v", err)
+	}
+
+	create := domains.UserCreate{Email: *email, Password: strings.TrimRight(password, "\r\n")}
+	if err := create.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := sqlx.Open("pgx", cfg.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	// Only the users table is needed, and the migration that assigns existing
+	// items and tags to the first account must run after it exists.
+	database.RunMigrationsUpTo(cfg.ConnectionString, database.UsersVersion)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	service := services.NewAuthService(persistence.NewUnitOfWork(db, logger), nil, logger)
+
+	userID, err := service.Register(context.Background(), &create)
+	if err != nil {
+		log.Fatalf("failed to create user: %v", err)
+	}
+
+	fmt.Println(userID)
+}
@@ -7,14 +7,13 @@ import (
 	"log"
 )
 
+// UsersVersion is the migration that creates the users table. Later
+// migrations may need an account to exist, so tools that create accounts
+// stop there.
+const UsersVersion uint = 9
+
 func RunMigrations(databaseUrl string) {
-	m, err := migrate.New(
-		"file://database/postgres",
-		databaseUrl,
-	)
-	if err != nil {
-		log.Fatalf("migrate init error: %v", err)
-	}
+	m := newMigrate(databaseUrl)
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
 		log.Fatalf("migrate up error: %v", err)
@@ -24,3 +23,38 @@ func RunMigrations(databaseUrl string) {
 		log.Println("migrations applied successfully")
 	}
 }
+
+// RunMigrationsUpTo applies migrations up to and including version. It never
+// migrates down, so a database already past version is left as it is.
+func RunMigrationsUpTo(databaseUrl string, version uint) {
+	m := newMigrate(databaseUrl)
+
+	current, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		log.Fatalf("migrate version error: %v", err)
+	}
+	if dirty {
+		log.Fatalf("migrate version error: database is dirty at version %d", current)
+	}
+	if err == nil && current >= version {
+		log.Printf("no changes to migrate")
+		return
+	}
+
+	if err := m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		log.Fatalf("migrate up error: %v", err)
+	}
+	log.Println("migrations applied successfully")
+}
+
+func newMigrate(databaseUrl string) *migrate.Migrate {
+	m, err := migrate.New(
+		"file://database/postgres",
+		databaseUrl,
+	)
+	if err != nil {
+		log.Fatalf("migrate init error: %v", err)
+	}
+
+	return m
+}
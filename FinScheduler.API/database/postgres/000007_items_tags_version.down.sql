@@ -0,0 +1,5 @@
+ALTER TABLE tags
+DROP COLUMN IF EXISTS version;
+
+ALTER TABLE items
+DROP COLUMN IF EXISTS version;
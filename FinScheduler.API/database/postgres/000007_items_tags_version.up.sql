@@ -0,0 +1,5 @@
+ALTER TABLE items
+ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE tags
+ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,16 @@
+CREATE TABLE idempotency_keys
+(
+    scope         TEXT      NOT NULL,
+    key           TEXT      NOT NULL,
+    request_hash  TEXT      NOT NULL,
+    status_code   INTEGER   NULL,
+    resource_id   UUID      NULL,
+    response_body BYTEA     NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at    TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at
+    ON idempotency_keys (expires_at);
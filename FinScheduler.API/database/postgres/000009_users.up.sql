@@ -0,0 +1,8 @@
+CREATE TABLE users
+(
+    id            UUID PRIMARY KEY,
+    email         TEXT      NOT NULL UNIQUE,
+    password_hash TEXT      NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMP NULL
+);
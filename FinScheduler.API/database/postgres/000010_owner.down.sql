@@ -0,0 +1,36 @@
+DROP INDEX IF EXISTS idx_tags_lookup_owner_id_lower_name_id;
+CREATE INDEX IF NOT EXISTS idx_tags_lookup_lower_name_id
+    ON tags (LOWER(name), id)
+    WHERE is_active = true;
+
+DROP INDEX IF EXISTS idx_items_owner_id_created_at_id;
+CREATE INDEX IF NOT EXISTS idx_items_created_at_id
+    ON items (created_at DESC, id DESC);
+
+ALTER TABLE price_history
+    DROP CONSTRAINT fk_price_history_owner_id_item_id,
+    ADD CONSTRAINT price_history_item_id_fkey
+        FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE,
+    DROP COLUMN owner_id;
+
+ALTER TABLE tag_to_item
+    DROP CONSTRAINT fk_tag_to_item_owner_id_tag_id,
+    DROP CONSTRAINT fk_tag_to_item_owner_id_item_id,
+    ADD CONSTRAINT tag_to_item_item_id_fkey
+        FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE,
+    ADD CONSTRAINT tag_to_item_tag_id_fkey
+        FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE,
+    DROP COLUMN owner_id;
+
+-- Restoring UNIQUE (name) fails while two owners share a name.
+ALTER TABLE tags
+    DROP CONSTRAINT uq_tags_owner_id_id,
+    DROP CONSTRAINT uq_tags_owner_id_name,
+    ADD CONSTRAINT tags_name_key UNIQUE (name),
+    DROP COLUMN owner_id;
+
+ALTER TABLE items
+    DROP CONSTRAINT uq_items_owner_id_id,
+    DROP CONSTRAINT uq_items_owner_id_name,
+    ADD CONSTRAINT items_name_key UNIQUE (name),
+    DROP COLUMN owner_id;
@@ -0,0 +1,77 @@
+ALTER TABLE items
+ADD COLUMN owner_id UUID REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE tags
+ADD COLUMN owner_id UUID REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE tag_to_item
+ADD COLUMN owner_id UUID;
+
+ALTER TABLE price_history
+ADD COLUMN owner_id UUID;
+
+-- Rows created before accounts existed belong to the first account.
+DO $$
+DECLARE
+    first_user_id UUID;
+BEGIN
+    SELECT id INTO first_user_id FROM users ORDER BY created_at, id LIMIT 1;
+
+    IF first_user_id IS NULL THEN
+        IF EXISTS (SELECT 1 FROM items) OR EXISTS (SELECT 1 FROM tags) THEN
+            RAISE EXCEPTION 'items and tags need an owner: create an account with useradd before upgrading';
+        END IF;
+        RETURN;
+    END IF;
+
+    UPDATE items SET owner_id = first_user_id;
+    UPDATE tags SET owner_id = first_user_id;
+END $$;
+
+UPDATE tag_to_item AS tti
+SET owner_id = i.owner_id
+FROM items AS i
+WHERE i.id = tti.item_id;
+
+UPDATE price_history AS ph
+SET owner_id = i.owner_id
+FROM items AS i
+WHERE i.id = ph.item_id;
+
+ALTER TABLE items
+    ALTER COLUMN owner_id SET NOT NULL,
+    DROP CONSTRAINT items_name_key,
+    ADD CONSTRAINT uq_items_owner_id_name UNIQUE (owner_id, name),
+    ADD CONSTRAINT uq_items_owner_id_id UNIQUE (owner_id, id);
+
+ALTER TABLE tags
+    ALTER COLUMN owner_id SET NOT NULL,
+    DROP CONSTRAINT tags_name_key,
+    ADD CONSTRAINT uq_tags_owner_id_name UNIQUE (owner_id, name),
+    ADD CONSTRAINT uq_tags_owner_id_id UNIQUE (owner_id, id);
+
+-- Links and history reference their rows through (owner_id, id), so an item
+-- can never be tagged with, or priced for, another user's rows.
+ALTER TABLE tag_to_item
+    ALTER COLUMN owner_id SET NOT NULL,
+    DROP CONSTRAINT tag_to_item_item_id_fkey,
+    DROP CONSTRAINT tag_to_item_tag_id_fkey,
+    ADD CONSTRAINT fk_tag_to_item_owner_id_item_id
+        FOREIGN KEY (owner_id, item_id) REFERENCES items (owner_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_tag_to_item_owner_id_tag_id
+        FOREIGN KEY (owner_id, tag_id) REFERENCES tags (owner_id, id) ON DELETE CASCADE;
+
+ALTER TABLE price_history
+    ALTER COLUMN owner_id SET NOT NULL,
+    DROP CONSTRAINT price_history_item_id_fkey,
+    ADD CONSTRAINT fk_price_history_owner_id_item_id
+        FOREIGN KEY (owner_id, item_id) REFERENCES items (owner_id, id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_items_created_at_id;
+CREATE INDEX idx_items_owner_id_created_at_id
+    ON items (owner_id, created_at DESC, id DESC);
+
+DROP INDEX IF EXISTS idx_tags_lookup_lower_name_id;
+CREATE INDEX idx_tags_lookup_owner_id_lower_name_id
+    ON tags (owner_id, LOWER(name), id)
+    WHERE is_active = true;
@@ -0,0 +1,69 @@
+-- Rows go back to the owner of their household. This fails when an owner has
+-- two households with items or tags of the same name.
+ALTER TABLE price_history
+    DROP CONSTRAINT fk_price_history_household_id_item_id;
+
+ALTER TABLE tag_to_item
+    DROP CONSTRAINT fk_tag_to_item_household_id_item_id,
+    DROP CONSTRAINT fk_tag_to_item_household_id_tag_id;
+
+ALTER TABLE items
+    DROP CONSTRAINT fk_items_household_id;
+
+ALTER TABLE tags
+    DROP CONSTRAINT fk_tags_household_id;
+
+ALTER TABLE price_history RENAME COLUMN household_id TO owner_id;
+ALTER TABLE tag_to_item RENAME COLUMN household_id TO owner_id;
+
+ALTER TABLE items RENAME COLUMN household_id TO owner_id;
+ALTER TABLE items RENAME CONSTRAINT uq_items_household_id_name TO uq_items_owner_id_name;
+ALTER TABLE items RENAME CONSTRAINT uq_items_household_id_id TO uq_items_owner_id_id;
+ALTER INDEX idx_items_household_id_created_at_id RENAME TO idx_items_owner_id_created_at_id;
+
+ALTER TABLE tags RENAME COLUMN household_id TO owner_id;
+ALTER TABLE tags RENAME CONSTRAINT uq_tags_household_id_name TO uq_tags_owner_id_name;
+ALTER TABLE tags RENAME CONSTRAINT uq_tags_household_id_id TO uq_tags_owner_id_id;
+ALTER INDEX idx_tags_lookup_household_id_lower_name_id RENAME TO idx_tags_lookup_owner_id_lower_name_id;
+
+UPDATE items AS i
+SET owner_id = h.owner_id
+FROM households AS h
+WHERE h.id = i.owner_id;
+
+UPDATE tags AS t
+SET owner_id = h.owner_id
+FROM households AS h
+WHERE h.id = t.owner_id;
+
+UPDATE tag_to_item AS tti
+SET owner_id = h.owner_id
+FROM households AS h
+WHERE h.id = tti.owner_id;
+
+UPDATE price_history AS ph
+SET owner_id = h.owner_id
+FROM households AS h
+WHERE h.id = ph.owner_id;
+
+ALTER TABLE items
+    ADD CONSTRAINT items_owner_id_fkey
+        FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE tags
+    ADD CONSTRAINT tags_owner_id_fkey
+        FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE tag_to_item
+    ADD CONSTRAINT fk_tag_to_item_owner_id_item_id
+        FOREIGN KEY (owner_id, item_id) REFERENCES items (owner_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_tag_to_item_owner_id_tag_id
+        FOREIGN KEY (owner_id, tag_id) REFERENCES tags (owner_id, id) ON DELETE CASCADE;
+
+ALTER TABLE price_history
+    ADD CONSTRAINT fk_price_history_owner_id_item_id
+        FOREIGN KEY (owner_id, item_id) REFERENCES items (owner_id, id) ON DELETE CASCADE;
+
+DROP TABLE invitations;
+DROP TABLE household_members;
+DROP TABLE households;
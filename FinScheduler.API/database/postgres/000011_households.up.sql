@@ -0,0 +1,110 @@
+CREATE TABLE households
+(
+    id          UUID PRIMARY KEY,
+    owner_id    UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name        TEXT      NOT NULL,
+    is_personal BOOLEAN   NOT NULL DEFAULT false,
+    created_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- The personal household is used when a request does not pick one; a user
+-- has at most one.
+CREATE UNIQUE INDEX uq_households_personal_owner_id
+    ON households (owner_id)
+    WHERE is_personal;
+
+CREATE TABLE household_members
+(
+    household_id UUID      NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    user_id      UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role         TEXT      NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+    created_at   TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (household_id, user_id)
+);
+
+CREATE INDEX idx_household_members_user_id
+    ON household_members (user_id);
+
+-- Only the SHA-256 of an invitation token is stored.
+CREATE TABLE invitations
+(
+    id           UUID PRIMARY KEY,
+    household_id UUID      NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    role         TEXT      NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+    token_hash   TEXT      NOT NULL UNIQUE,
+    invited_by   UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at   TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMP NOT NULL,
+    accepted_by  UUID      NULL REFERENCES users (id) ON DELETE SET NULL,
+    accepted_at  TIMESTAMP NULL
+);
+
+INSERT INTO households (id, owner_id, name, is_personal)
+SELECT uuidv7(), id, 'Personal', true
+FROM users;
+
+INSERT INTO household_members (household_id, user_id, role)
+SELECT id, owner_id, 'admin'
+FROM households;
+
+-- Every user's rows move to their personal household.
+ALTER TABLE price_history
+    DROP CONSTRAINT fk_price_history_owner_id_item_id;
+
+ALTER TABLE tag_to_item
+    DROP CONSTRAINT fk_tag_to_item_owner_id_item_id,
+    DROP CONSTRAINT fk_tag_to_item_owner_id_tag_id;
+
+ALTER TABLE items
+    DROP CONSTRAINT items_owner_id_fkey;
+
+ALTER TABLE tags
+    DROP CONSTRAINT tags_owner_id_fkey;
+
+UPDATE items AS i
+SET owner_id = h.id
+FROM households AS h
+WHERE h.owner_id = i.owner_id AND h.is_personal;
+
+UPDATE tags AS t
+SET owner_id = h.id
+FROM households AS h
+WHERE h.owner_id = t.owner_id AND h.is_personal;
+
+UPDATE tag_to_item AS tti
+SET owner_id = h.id
+FROM households AS h
+WHERE h.owner_id = tti.owner_id AND h.is_personal;
+
+UPDATE price_history AS ph
+SET owner_id = h.id
+FROM households AS h
+WHERE h.owner_id = ph.owner_id AND h.is_personal;
+
+ALTER TABLE items RENAME COLUMN owner_id TO household_id;
+ALTER TABLE items RENAME CONSTRAINT uq_items_owner_id_name TO uq_items_household_id_name;
+ALTER TABLE items RENAME CONSTRAINT uq_items_owner_id_id TO uq_items_household_id_id;
+ALTER INDEX idx_items_owner_id_created_at_id RENAME TO idx_items_household_id_created_at_id;
+ALTER TABLE items
+    ADD CONSTRAINT fk_items_household_id
+        FOREIGN KEY (household_id) REFERENCES households (id) ON DELETE CASCADE;
+
+ALTER TABLE tags RENAME COLUMN owner_id TO household_id;
+ALTER TABLE tags RENAME CONSTRAINT uq_tags_owner_id_name TO uq_tags_household_id_name;
+ALTER TABLE tags RENAME CONSTRAINT uq_tags_owner_id_id TO uq_tags_household_id_id;
+ALTER INDEX idx_tags_lookup_owner_id_lower_name_id RENAME TO idx_tags_lookup_household_id_lower_name_id;
+ALTER TABLE tags
+    ADD CONSTRAINT fk_tags_household_id
+        FOREIGN KEY (household_id) REFERENCES households (id) ON DELETE CASCADE;
+
+ALTER TABLE tag_to_item RENAME COLUMN owner_id TO household_id;
+ALTER TABLE tag_to_item
+    ADD CONSTRAINT fk_tag_to_item_household_id_item_id
+        FOREIGN KEY (household_id, item_id) REFERENCES items (household_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_tag_to_item_household_id_tag_id
+        FOREIGN KEY (household_id, tag_id) REFERENCES tags (household_id, id) ON DELETE CASCADE;
+
+ALTER TABLE price_history RENAME COLUMN owner_id TO household_id;
+ALTER TABLE price_history
+    ADD CONSTRAINT fk_price_history_household_id_item_id
+        FOREIGN KEY (household_id, item_id) REFERENCES items (household_id, id) ON DELETE CASCADE;
@@ -0,0 +1,15 @@
+-- Only the SHA-256 of a key is stored; prefix is the first characters of the
+-- key, kept so that users can tell their keys apart.
+CREATE TABLE api_keys
+(
+    id           UUID PRIMARY KEY,
+    user_id      UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         TEXT      NOT NULL,
+    prefix       TEXT      NOT NULL,
+    key_hash     TEXT      NOT NULL UNIQUE,
+    scopes       TEXT      NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    CONSTRAINT uq_api_keys_user_id_name UNIQUE (user_id, name)
+);
@@ -0,0 +1,19 @@
+DROP POLICY IF EXISTS tenant_isolation ON price_history;
+ALTER TABLE price_history
+    NO FORCE ROW LEVEL SECURITY,
+    DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON tag_to_item;
+ALTER TABLE tag_to_item
+    NO FORCE ROW LEVEL SECURITY,
+    DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON tags;
+ALTER TABLE tags
+    NO FORCE ROW LEVEL SECURITY,
+    DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON items;
+ALTER TABLE items
+    NO FORCE ROW LEVEL SECURITY,
+    DISABLE ROW LEVEL SECURITY;
@@ -0,0 +1,37 @@
+-- Defense in depth for household isolation. Every repository query already
+-- filters on household_id; these policies hide the rows of other households
+-- should a query forget to. The household is the tenant: UnitOfWork sets
+-- app.tenant_id for the transaction of each items or tags request, and
+-- without it no row is visible.
+--
+-- FORCE applies the policies to the table owner, which the API connects as to
+-- run its migrations. Superusers and roles with BYPASSRLS still skip them, so
+-- the API must not connect as one. Later migrations that change rows of these
+-- tables have to lift FORCE around the change.
+ALTER TABLE items
+    ENABLE ROW LEVEL SECURITY,
+    FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON items
+    USING (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+ALTER TABLE tags
+    ENABLE ROW LEVEL SECURITY,
+    FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON tags
+    USING (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+ALTER TABLE tag_to_item
+    ENABLE ROW LEVEL SECURITY,
+    FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON tag_to_item
+    USING (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+ALTER TABLE price_history
+    ENABLE ROW LEVEL SECURITY,
+    FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON price_history
+    USING (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
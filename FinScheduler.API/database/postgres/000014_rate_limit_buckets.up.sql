@@ -0,0 +1,13 @@
+-- Token buckets of the rate limiter when it is configured with the postgres
+-- store. The table is unlogged: after a crash every client simply starts
+-- with a full bucket.
+CREATE UNLOGGED TABLE rate_limit_buckets
+(
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    allowed    BOOLEAN          NOT NULL,
+    updated_at TIMESTAMPTZ      NOT NULL
+);
+
+CREATE INDEX ix_rate_limit_buckets_updated_at
+    ON rate_limit_buckets (updated_at);
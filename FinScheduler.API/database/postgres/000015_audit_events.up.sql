@@ -0,0 +1,30 @@
+-- One row per create, update or delete of an item or tag and per item
+-- touched by a bulk cashback change, written in the transaction of the change.
+-- changes maps every changed field to its {before, after} values. The actor
+-- and API key are not foreign keys so that events outlive revoked keys.
+CREATE TABLE audit_events
+(
+    id           UUID PRIMARY KEY,
+    household_id UUID      NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    actor_id     UUID      NOT NULL,
+    api_key_id   UUID      NULL,
+    operation    TEXT      NOT NULL,
+    entity_type  TEXT      NOT NULL,
+    entity_id    UUID      NOT NULL,
+    changes      JSONB     NOT NULL,
+    trace_id     TEXT      NULL,
+    occurred_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_household_id_occurred_at
+    ON audit_events (household_id, occurred_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_household_id_entity
+    ON audit_events (household_id, entity_type, entity_id, occurred_at DESC);
+
+ALTER TABLE audit_events
+    ENABLE ROW LEVEL SECURITY,
+    FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON audit_events
+    USING (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
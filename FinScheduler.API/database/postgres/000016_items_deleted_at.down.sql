@@ -0,0 +1,19 @@
+-- Items in the trash are deleted for good, since they would otherwise come
+-- back. FORCE is lifted for the delete so that it sees every household.
+ALTER TABLE items
+    NO FORCE ROW LEVEL SECURITY;
+
+DELETE FROM items
+WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE items
+    FORCE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS idx_items_household_id_deleted_at_id;
+DROP INDEX IF EXISTS uq_items_household_id_name;
+
+ALTER TABLE items
+    ADD CONSTRAINT uq_items_household_id_name UNIQUE (household_id, name);
+
+ALTER TABLE items
+    DROP COLUMN deleted_at;
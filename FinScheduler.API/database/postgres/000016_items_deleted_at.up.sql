@@ -0,0 +1,17 @@
+-- Deleting an item moves it to the trash: deleted_at is set and every query
+-- of the API skips the row, while its price history and tag links are kept
+-- until the purge job deletes it for good. Names only need to be unique among
+-- the items that are not in the trash.
+ALTER TABLE items
+    ADD COLUMN deleted_at TIMESTAMP NULL;
+
+ALTER TABLE items
+    DROP CONSTRAINT uq_items_household_id_name;
+
+CREATE UNIQUE INDEX uq_items_household_id_name
+    ON items (household_id, name)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_household_id_deleted_at_id
+    ON items (household_id, deleted_at DESC, id DESC)
+    WHERE deleted_at IS NOT NULL;
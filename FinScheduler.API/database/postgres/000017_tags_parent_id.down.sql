@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tags_household_id_parent_id;
+
+ALTER TABLE tags
+    DROP CONSTRAINT IF EXISTS fk_tags_household_id_parent_id,
+    DROP CONSTRAINT IF EXISTS ck_tags_parent_id_not_id;
+
+ALTER TABLE tags
+    DROP COLUMN parent_id;
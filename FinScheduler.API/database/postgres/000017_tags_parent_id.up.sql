@@ -0,0 +1,15 @@
+-- Tags form a hierarchy: parent_id names the parent tag, which must belong to
+-- the same household. The API refuses a parent that would close a cycle, and
+-- deleting a tag first moves its children up to its own parent.
+ALTER TABLE tags
+    ADD COLUMN parent_id UUID NULL;
+
+ALTER TABLE tags
+    ADD CONSTRAINT ck_tags_parent_id_not_id
+        CHECK (parent_id <> id),
+    ADD CONSTRAINT fk_tags_household_id_parent_id
+        FOREIGN KEY (household_id, parent_id) REFERENCES tags (household_id, id);
+
+CREATE INDEX IF NOT EXISTS idx_tags_household_id_parent_id
+    ON tags (household_id, parent_id)
+    WHERE parent_id IS NOT NULL;
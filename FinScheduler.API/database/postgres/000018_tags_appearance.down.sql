@@ -0,0 +1,8 @@
+ALTER TABLE tags
+    DROP CONSTRAINT IF EXISTS ck_tags_icon,
+    DROP CONSTRAINT IF EXISTS ck_tags_color;
+
+ALTER TABLE tags
+    DROP COLUMN description,
+    DROP COLUMN icon,
+    DROP COLUMN color;
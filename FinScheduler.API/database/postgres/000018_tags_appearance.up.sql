@@ -0,0 +1,12 @@
+-- Tags carry what the web UI needs to render them as chips: an optional
+-- #rrggbb colour, an optional icon key and a description.
+ALTER TABLE tags
+    ADD COLUMN color VARCHAR(7) NULL,
+    ADD COLUMN icon VARCHAR(64) NULL,
+    ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE tags
+    ADD CONSTRAINT ck_tags_color
+        CHECK (color ~* '^#[0-9a-f]{6}$'),
+    ADD CONSTRAINT ck_tags_icon
+        CHECK (icon ~ '^[a-z0-9]+(-[a-z0-9]+)*$');
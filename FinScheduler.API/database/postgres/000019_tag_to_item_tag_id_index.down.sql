@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_tag_to_item_household_id_tag_id;
@@ -0,0 +1,4 @@
+-- Tag stats join tag_to_item by tag, which its (item_id, tag_id) primary key
+-- cannot serve.
+CREATE INDEX IF NOT EXISTS idx_tag_to_item_household_id_tag_id
+    ON tag_to_item (household_id, tag_id);
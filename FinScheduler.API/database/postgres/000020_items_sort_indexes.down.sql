@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_items_household_id_updated_at_id;
+DROP INDEX IF EXISTS idx_items_household_id_cashback_id;
+DROP INDEX IF EXISTS idx_items_household_id_price_id;
@@ -0,0 +1,14 @@
+-- Item listings can be sorted by price, cashback and update time, with id
+-- breaking ties. Sorting by name uses uq_items_household_id_name, and tags
+-- are few enough per household to sort in memory.
+CREATE INDEX IF NOT EXISTS idx_items_household_id_price_id
+    ON items (household_id, price, id)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_household_id_cashback_id
+    ON items (household_id, cashback, id)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_household_id_updated_at_id
+    ON items (household_id, updated_at, id)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	bearerScheme = "Bearer"
+	apiKeyScheme = "ApiKey"
+)
+
+// ErrInvalidAPIKey hides whether an API key is unknown, revoked or expired.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// APIKeyVerifier resolves the caller that owns an API key. It returns
+// ErrInvalidAPIKey for keys that must be rejected.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (Principal, error)
+}
+
+// Authenticator guards routes with the access tokens of a TokenIssuer and,
+// once AcceptAPIKeys was called, with API keys.
+type Authenticator struct {
+	tokens  *TokenIssuer
+	apiKeys APIKeyVerifier
+	logger  *slog.Logger
+}
+
+func NewAuthenticator(tokens *TokenIssuer, logger *slog.Logger) *Authenticator {
+	return &Authenticator{tokens: tokens, logger: logger}
+}
+
+// AcceptAPIKeys makes the middleware accept "Authorization: ApiKey" headers
+// verified by verifier.
+func (authenticator *Authenticator) AcceptAPIKeys(verifier APIKeyVerifier) *Authenticator {
+	authenticator.apiKeys = verifier
+	return authenticator
+}
+
+// Middleware rejects requests without a valid bearer access token or API key
+// with 401 and stores the caller in the request context for the next handler.
+// Every request made with an API key is logged for audit.
+func (authenticator *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		scheme, credentials, ok := authorization(r)
+		switch {
+		case ok && strings.EqualFold(scheme, bearerScheme):
+			principal, err := authenticator.bearerPrincipal(credentials)
+			if err != nil {
+				authenticator.logger.WarnContext(ctx, "Rejected bearer token", "error", err)
+				authenticator.unauthorized(w, `error="invalid_token"`, ErrInvalidToken.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(ctx, principal)))
+		case ok && authenticator.apiKeys != nil && strings.EqualFold(scheme, apiKeyScheme):
+			principal, err := authenticator.apiKeys.VerifyAPIKey(ctx, credentials)
+			if errors.Is(err, ErrInvalidAPIKey) {
+				authenticator.logger.WarnContext(ctx, "Rejected API key", "error", err)
+				authenticator.unauthorized(w, `error="invalid_token"`, ErrInvalidAPIKey.Error())
+				return
+			}
+			if err != nil {
+				authenticator.logger.ErrorContext(ctx, "API key verification failed", "error", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			authenticator.logger.InfoContext(ctx, "API key used",
+				"apiKeyID", principal.APIKeyID,
+				"userID", principal.UserID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(ctx, principal)))
+		default:
+			authenticator.unauthorized(w, "", "authentication required")
+		}
+	})
+}
+
+func (authenticator *Authenticator) bearerPrincipal(token string) (Principal, error) {
+	claims, err := authenticator.tokens.Parse(token, TokenUseAccess)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	return Principal{UserID: userID, Email: claims.Email}, nil
+}
+
+// unauthorized challenges the client with every accepted scheme. A non-empty
+// params is appended to each challenge, e.g. error="invalid_token".
+func (authenticator *Authenticator) unauthorized(w http.ResponseWriter, params string, message string) {
+	schemes := []string{bearerScheme}
+	if authenticator.apiKeys != nil {
+		schemes = append(schemes, apiKeyScheme)
+	}
+
+	for _, scheme := range schemes {
+		challenge := scheme + ` realm="finscheduler"`
+		if params != "" {
+			challenge += ", " + params
+		}
+		w.Header().Add("WWW-Authenticate", challenge)
+	}
+	http.Error(w, message, http.StatusUnauthorized)
+}
+
+// authorization splits the Authorization header into its scheme and
+// credentials. Schemes are matched case-insensitively as required by RFC 9110.
+func authorization(r *http.Request) (string, string, bool) {
+	scheme, credentials, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found {
+		return "", "", false
+	}
+
+	credentials = strings.TrimSpace(credentials)
+	return scheme, credentials, credentials != ""
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	issuer := newTestTokenIssuer(t, testSigningKey)
+	userID := uuid.New()
+	pair, err := issuer.Issue(userID, "user@example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		authorization     string
+		expectedStatus    int
+		expectedChallenge string
+	}{
+		{name: "access token", authorization: "Bearer " + pair.AccessToken, expectedStatus: http.StatusNoContent},
+		{name: "scheme is case-insensitive", authorization: "bearer " + pair.AccessToken, expectedStatus: http.StatusNoContent},
+		{name: "missing header", expectedStatus: http.StatusUnauthorized, expectedChallenge: `Bearer realm="finscheduler"`},
+		{name: "other scheme", authorization: "Basic dXNlcjpwYXNz", expectedStatus: http.StatusUnauthorized, expectedChallenge: `Bearer realm="finscheduler"`},
+		{name: "refresh token", authorization: "Bearer " + pair.RefreshToken, expectedStatus: http.StatusUnauthorized, expectedChallenge: `Bearer realm="finscheduler", error="invalid_token"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var principal Principal
+			var authenticated bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, authenticated = PrincipalFromContext(r.Context())
+				w.WriteHeader(http.StatusNoContent)
+			})
+			handler := NewAuthenticator(issuer, slog.Default()).Middleware(next)
+			request := httptest.NewRequest(http.MethodGet, "/items", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedChallenge, recorder.Header().Get("WWW-Authenticate"))
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.True(t, authenticated)
+				assert.Equal(t, Principal{UserID: userID, Email: "user@example.com"}, principal)
+			}
+		})
+	}
+}
+
+type fakeAPIKeyVerifier struct {
+	key       string
+	principal Principal
+}
+
+func (verifier fakeAPIKeyVerifier) VerifyAPIKey(_ context.Context, key string) (Principal, error) {
+	if key != verifier.key {
+		return Principal{}, ErrInvalidAPIKey
+	}
+
+	return verifier.principal, nil
+}
+
+func TestAuthenticator_Middleware_APIKeys(t *testing.T) {
+	issuer := newTestTokenIssuer(t, testSigningKey)
+	keyPrincipal := Principal{UserID: uuid.New(), APIKeyID: uuid.New(), Scopes: []Scope{ScopeItemsRead}}
+	verifier := fakeAPIKeyVerifier{key: "fsk_valid", principal: keyPrincipal}
+
+	tests := []struct {
+		name               string
+		verifier           APIKeyVerifier
+		authorization      string
+		expectedStatus     int
+		expectedChallenges []string
+	}{
+		{name: "valid key", verifier: verifier, authorization: "ApiKey fsk_valid", expectedStatus: http.StatusNoContent},
+		{name: "scheme is case-insensitive", verifier: verifier, authorization: "apikey fsk_valid", expectedStatus: http.StatusNoContent},
+		{
+			name:           "unknown key",
+			verifier:       verifier,
+			authorization:  "ApiKey fsk_unknown",
+			expectedStatus: http.StatusUnauthorized,
+			expectedChallenges: []string{
+				`Bearer realm="finscheduler", error="invalid_token"`,
+				`ApiKey realm="finscheduler", error="invalid_token"`,
+			},
+		},
+		{
+			name:               "missing header",
+			verifier:           verifier,
+			expectedStatus:     http.StatusUnauthorized,
+			expectedChallenges: []string{`Bearer realm="finscheduler"`, `ApiKey realm="finscheduler"`},
+		},
+		{
+			name:               "keys not accepted",
+			authorization:      "ApiKey fsk_valid",
+			expectedStatus:     http.StatusUnauthorized,
+			expectedChallenges: []string{`Bearer realm="finscheduler"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var principal Principal
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, _ = PrincipalFromContext(r.Context())
+				w.WriteHeader(http.StatusNoContent)
+			})
+			authenticator := NewAuthenticator(issuer, slog.Default())
+			if tt.verifier != nil {
+				authenticator.AcceptAPIKeys(tt.verifier)
+			}
+			request := httptest.NewRequest(http.MethodGet, "/items", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			authenticator.Middleware(next).ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedChallenges, recorder.Header().Values("WWW-Authenticate"))
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, keyPrincipal, principal)
+			}
+		})
+	}
+}
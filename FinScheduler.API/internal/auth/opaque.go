@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const opaqueTokenLength = 32
+
+// NewOpaqueToken returns a random URL-safe token and the hash to store in
+// its place. Only the holder of the token can present it again.
+func NewOpaqueToken() (token string, hash string, err error) {
+	raw := make([]byte, opaqueTokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken is the SHA-256 hex digest used to look a token up. A
+// plain hash is enough because the tokens carry 256 bits of entropy.
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id parameters follow the second recommended option of RFC 9106 for
+// memory-constrained environments: 64 MiB, three passes.
+const (
+	argon2Memory  uint32 = 64 * 1024
+	argon2Time    uint32 = 3
+	argon2Threads uint8  = 2
+	argon2SaltLen        = 16
+	argon2KeyLen  uint32 = 32
+)
+
+var errInvalidPasswordHash = errors.New("invalid password hash")
+
+// dummyPasswordHash is verified against when the user does not exist, so
+// that a login for an unknown email takes as long as one with a wrong password.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, err := HashPassword("finscheduler-dummy-password")
+	if err != nil {
+		panic(err)
+	}
+
+	return hash
+})
+
+// HashPassword derives an argon2id hash of password encoded in the PHC
+// string format, e.g. $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>.
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword reports whether password matches encodedHash. The parameters
+// stored in the hash are used, so hashes made with older settings still verify.
+func VerifyPassword(password string, encodedHash string) (bool, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, errInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, errInvalidPasswordHash
+	}
+
+	var memory, iterations uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false, errInvalidPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, errInvalidPasswordHash
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, errInvalidPasswordHash
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, iterations, memory, threads, uint32(len(expected)))
+
+	return subtle.ConstantTimeCompare(actual, expected) == 1, nil
+}
+
+// VerifyDummyPassword spends the same time as VerifyPassword on a real hash.
+func VerifyDummyPassword(password string) {
+	_, _ = VerifyPassword(password, dummyPasswordHash())
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashPassword_ShouldVerifyOnlyTheSamePassword(t *testing.T) {
+	// Arrange
+	hash, hashErr := HashPassword("correct horse battery staple")
+
+	// Act
+	matches, matchErr := VerifyPassword("correct horse battery staple", hash)
+	mismatches, mismatchErr := VerifyPassword("correct horse battery stapler", hash)
+
+	// Assert
+	require.NoError(t, hashErr)
+	require.NoError(t, matchErr)
+	require.NoError(t, mismatchErr)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$"))
+	assert.True(t, matches)
+	assert.False(t, mismatches)
+}
+
+func TestHashPassword_ShouldSaltEveryHash(t *testing.T) {
+	// Act
+	first, firstErr := HashPassword("correct horse battery staple")
+	second, secondErr := HashPassword("correct horse battery staple")
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.NotEqual(t, first, second)
+}
+
+func TestVerifyPassword_ShouldRejectMalformedHash(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "empty", hash: ""},
+		{name: "other algorithm", hash: "$2a$10$abcdefghijklmnopqrstuv"},
+		{name: "bad parameters", hash: "$argon2id$v=19$m=x,t=3,p=2$c2FsdA$aGFzaA"},
+		{name: "bad salt", hash: "$argon2id$v=19$m=65536,t=3,p=2$!!$aGFzaA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			matches, err := VerifyPassword("password", tt.hash)
+
+			// Assert
+			assert.ErrorIs(t, err, errInvalidPasswordHash)
+			assert.False(t, matches)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Principal is the authenticated caller of a request. APIKeyID and Scopes are
+// only set when the request was authenticated with an API key.
+type Principal struct {
+	UserID   uuid.UUID
+	Email    string
+	APIKeyID uuid.UUID
+	Scopes   []Scope
+}
+
+func (principal Principal) ViaAPIKey() bool {
+	return principal.APIKeyID != uuid.Nil
+}
+
+// HasScope reports whether the caller may act within scope. Callers who
+// logged in interactively hold every scope.
+func (principal Principal) HasScope(scope Scope) bool {
+	return !principal.ViaAPIKey() || slices.Contains(principal.Scopes, scope)
+}
+
+type principalContextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller stored by the authentication
+// middleware, or false when the request was not authenticated.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Role is a member's permission level within a household. Every role grants
+// the permissions of the roles ranked below it.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+func (role Role) Valid() bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// Includes reports whether role grants at least the permissions of required.
+func (role Role) Includes(required Role) bool {
+	return role.Valid() && roleRanks[role] >= roleRanks[required]
+}
+
+// Membership is the household a request acts on and the caller's role in it.
+type Membership struct {
+	HouseholdID uuid.UUID
+	Role        Role
+}
+
+type membershipContextKey struct{}
+
+func WithMembership(ctx context.Context, membership Membership) context.Context {
+	return context.WithValue(ctx, membershipContextKey{}, membership)
+}
+
+// MembershipFromContext returns the membership resolved for the request, or
+// false when no household was resolved.
+func MembershipFromContext(ctx context.Context) (Membership, bool) {
+	membership, ok := ctx.Value(membershipContextKey{}).(Membership)
+	return membership, ok
+}
+
+// RequireRole answers 403 unless the caller's role includes read for safe
+// methods (GET, HEAD, OPTIONS) or write for every other method. It must run
+// after the membership has been stored in the request context.
+func RequireRole(read Role, write Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			required := write
+			if isSafeMethod(r.Method) {
+				required = read
+			}
+
+			membership, ok := MembershipFromContext(r.Context())
+			if !ok || !membership.Role.Includes(required) {
+				http.Error(w, "the "+string(required)+" role is required", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
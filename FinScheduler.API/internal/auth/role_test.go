@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole_Includes(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{role: RoleViewer, required: RoleViewer, want: true},
+		{role: RoleViewer, required: RoleEditor, want: false},
+		{role: RoleEditor, required: RoleViewer, want: true},
+		{role: RoleEditor, required: RoleAdmin, want: false},
+		{role: RoleAdmin, required: RoleEditor, want: true},
+		{role: Role("owner"), required: RoleViewer, want: false},
+		{role: Role(""), required: RoleViewer, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" includes "+string(tt.required), func(t *testing.T) {
+			// Act
+			got := tt.role.Includes(tt.required)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           Role
+		method         string
+		noMembership   bool
+		expectedStatus int
+	}{
+		{name: "viewer reads", role: RoleViewer, method: http.MethodGet, expectedStatus: http.StatusNoContent},
+		{name: "viewer creates", role: RoleViewer, method: http.MethodPost, expectedStatus: http.StatusForbidden},
+		{name: "viewer updates", role: RoleViewer, method: http.MethodPut, expectedStatus: http.StatusForbidden},
+		{name: "viewer patches", role: RoleViewer, method: http.MethodPatch, expectedStatus: http.StatusForbidden},
+		{name: "viewer deletes", role: RoleViewer, method: http.MethodDelete, expectedStatus: http.StatusForbidden},
+		{name: "editor creates", role: RoleEditor, method: http.MethodPost, expectedStatus: http.StatusNoContent},
+		{name: "admin deletes", role: RoleAdmin, method: http.MethodDelete, expectedStatus: http.StatusNoContent},
+		{name: "no membership", method: http.MethodGet, noMembership: true, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			})
+			handler := RequireRole(RoleViewer, RoleEditor)(next)
+			request := httptest.NewRequest(tt.method, "/items", nil)
+			if !tt.noMembership {
+				membership := Membership{HouseholdID: uuid.New(), Role: tt.role}
+				request = request.WithContext(WithMembership(request.Context(), membership))
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestNewOpaqueToken_ShouldReturnTheHashOfTheToken(t *testing.T) {
+	// Act
+	token, hash, err := NewOpaqueToken()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Equal(t, HashOpaqueToken(token), hash)
+	assert.NotEqual(t, token, hash)
+}
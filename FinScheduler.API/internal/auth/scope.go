@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Scope limits what an API key may do. Bearer tokens from an interactive
+// login carry every scope.
+type Scope string
+
+const (
+	ScopeItemsRead  Scope = "items:read"
+	ScopeItemsWrite Scope = "items:write"
+	ScopeTagsRead   Scope = "tags:read"
+	ScopeTagsWrite  Scope = "tags:write"
+)
+
+// Scopes lists every scope an API key can be granted.
+var Scopes = []Scope{ScopeItemsRead, ScopeItemsWrite, ScopeTagsRead, ScopeTagsWrite}
+
+func (scope Scope) Valid() bool {
+	return slices.Contains(Scopes, scope)
+}
+
+// ParseScopes splits a space-separated scope list as stored in the database.
+func ParseScopes(value string) []Scope {
+	fields := strings.Fields(value)
+	scopes := make([]Scope, 0, len(fields))
+	for _, field := range fields {
+		scopes = append(scopes, Scope(field))
+	}
+
+	return scopes
+}
+
+// FormatScopes joins scopes with spaces, the OAuth 2.0 scope syntax.
+func FormatScopes(scopes []Scope) string {
+	fields := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		fields = append(fields, string(scope))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// RequireScope answers 403 when the request is authenticated with an API key
+// that lacks read for safe methods or write for every other method.
+func RequireScope(read Scope, write Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			required := write
+			if isSafeMethod(r.Method) {
+				required = read
+			}
+
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok || !principal.HasScope(required) {
+				http.Error(w, "the API key lacks the "+string(required)+" scope", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RejectAPIKeys answers 403 to requests authenticated with an API key. It
+// guards account, household and key management, which need a login.
+func RejectAPIKeys(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := PrincipalFromContext(r.Context())
+		if ok && principal.ViaAPIKey() {
+			http.Error(w, "API keys cannot be used for this operation", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScopes_ShouldRoundTripFormatScopes(t *testing.T) {
+	// Arrange
+	scopes := []Scope{ScopeItemsRead, ScopeTagsWrite}
+
+	// Act
+	formatted := FormatScopes(scopes)
+	parsed := ParseScopes(formatted)
+
+	// Assert
+	assert.Equal(t, "items:read tags:write", formatted)
+	assert.Equal(t, scopes, parsed)
+}
+
+func TestRequireScope(t *testing.T) {
+	keyID := uuid.New()
+
+	tests := []struct {
+		name           string
+		principal      *Principal
+		method         string
+		expectedStatus int
+	}{
+		{name: "bearer reads", principal: &Principal{UserID: uuid.New()}, method: http.MethodGet, expectedStatus: http.StatusNoContent},
+		{name: "bearer writes", principal: &Principal{UserID: uuid.New()}, method: http.MethodPatch, expectedStatus: http.StatusNoContent},
+		{name: "read key reads", principal: &Principal{APIKeyID: keyID, Scopes: []Scope{ScopeItemsRead}}, method: http.MethodGet, expectedStatus: http.StatusNoContent},
+		{name: "read key writes", principal: &Principal{APIKeyID: keyID, Scopes: []Scope{ScopeItemsRead}}, method: http.MethodPatch, expectedStatus: http.StatusForbidden},
+		{name: "write key writes", principal: &Principal{APIKeyID: keyID, Scopes: []Scope{ScopeItemsWrite}}, method: http.MethodPatch, expectedStatus: http.StatusNoContent},
+		{name: "write key reads", principal: &Principal{APIKeyID: keyID, Scopes: []Scope{ScopeItemsWrite}}, method: http.MethodGet, expectedStatus: http.StatusForbidden},
+		{name: "no principal", method: http.MethodGet, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			})
+			handler := RequireScope(ScopeItemsRead, ScopeItemsWrite)(next)
+			request := httptest.NewRequest(tt.method, "/items", nil)
+			if tt.principal != nil {
+				request = request.WithContext(WithPrincipal(request.Context(), *tt.principal))
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestRejectAPIKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		principal      Principal
+		expectedStatus int
+	}{
+		{name: "bearer", principal: Principal{UserID: uuid.New()}, expectedStatus: http.StatusNoContent},
+		{name: "api key", principal: Principal{UserID: uuid.New(), APIKeyID: uuid.New()}, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			})
+			request := httptest.NewRequest(http.MethodPost, "/api-keys", nil)
+			request = request.WithContext(WithPrincipal(request.Context(), tt.principal))
+
+			// Act
+			recorder := httptest.NewRecorder()
+			RejectAPIKeys(next).ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"finscheduler/internal/infra"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// minSigningKeyLength is the HS256 key size required by RFC 7518 §3.2.
+const minSigningKeyLength = 32
+
+const signingAlgorithm = "HS256"
+
+// TokenUse tells access tokens apart from refresh tokens, so a refresh token
+// cannot be presented as a bearer token and vice versa.
+type TokenUse string
+
+const (
+	TokenUseAccess  TokenUse = "access"
+	TokenUseRefresh TokenUse = "refresh"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims is the payload of the JWTs issued by TokenIssuer.
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Email     string   `json:"email"`
+	Use       TokenUse `json:"token_use"`
+	ID        string   `json:"jti"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// TokenPair is what a successful login or refresh hands out. ExpiresIn is
+// the lifetime of the access token.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+// TokenIssuer signs and verifies HS256 JWTs. Keys are identified by a kid
+// derived from the key itself, so tokens signed with a retired verification
+// key keep working after rotation.
+type TokenIssuer struct {
+	issuer          string
+	signingKeyID    string
+	keys            map[string][]byte
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	now             func() time.Time
+}
+
+func NewTokenIssuer(cfg infra.AuthConfig) (*TokenIssuer, error) {
+	if len(cfg.SigningKey) < minSigningKeyLength {
+		return nil, fmt.Errorf("auth signing key must be at least %d bytes long", minSigningKeyLength)
+	}
+
+	issuer := &TokenIssuer{
+		issuer:          cfg.Issuer,
+		signingKeyID:    keyID(cfg.SigningKey),
+		keys:            map[string][]byte{keyID(cfg.SigningKey): []byte(cfg.SigningKey)},
+		accessTokenTTL:  cfg.AccessTokenTTL,
+		refreshTokenTTL: cfg.RefreshTokenTTL,
+		now:             time.Now,
+	}
+
+	for _, key := range cfg.VerificationKeys {
+		if len(key) < minSigningKeyLength {
+			return nil, fmt.Errorf("auth verification keys must be at least %d bytes long", minSigningKeyLength)
+		}
+		issuer.keys[keyID(key)] = []byte(key)
+	}
+
+	return issuer, nil
+}
+
+// Issue signs a new access and refresh token for the user.
+func (issuer *TokenIssuer) Issue(userID uuid.UUID, email string) (*TokenPair, error) {
+	accessToken, err := issuer.sign(userID, email, TokenUseAccess, issuer.accessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := issuer.sign(userID, email, TokenUseRefresh, issuer.refreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    issuer.accessTokenTTL,
+	}, nil
+}
+
+// Parse verifies the signature, issuer, expiry and use of token and returns
+// its claims. Every failure is reported as ErrInvalidToken.
+func (issuer *TokenIssuer) Parse(token string, use TokenUse) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != signingAlgorithm {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, header.Algorithm)
+	}
+
+	key, ok := issuer.keys[header.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key", ErrInvalidToken)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(key, parts[0]+"."+parts[1])) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.Issuer != issuer.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if claims.Use != use {
+		return nil, fmt.Errorf("%w: expected %s token", ErrInvalidToken, use)
+	}
+	if !issuer.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+
+	return &claims, nil
+}
+
+func (issuer *TokenIssuer) sign(userID uuid.UUID, email string, use TokenUse, ttl time.Duration) (string, error) {
+	tokenID := make([]byte, 16)
+	if _, err := rand.Read(tokenID); err != nil {
+		return "", fmt.Errorf("generate token id: %w", err)
+	}
+
+	now := issuer.now().UTC()
+	header, err := encodeSegment(tokenHeader{Algorithm: signingAlgorithm, Type: "JWT", KeyID: issuer.signingKeyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := encodeSegment(Claims{
+		Issuer:    issuer.issuer,
+		Subject:   userID.String(),
+		Email:     email,
+		Use:       use,
+		ID:        hex.EncodeToString(tokenID),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + claims
+	signature := sign(issuer.keys[issuer.signingKeyID], signingInput)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func sign(key []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+
+	return mac.Sum(nil)
+}
+
+// keyID names a key without revealing it: the first 8 bytes of its SHA-256.
+func keyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodeSegment(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+func decodeSegment(segment string, target any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+	if err := json.Unmarshal(decoded, target); err != nil {
+		return fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+
+	return nil
+}
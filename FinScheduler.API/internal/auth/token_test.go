@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"finscheduler/internal/infra"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSigningKey = "0123456789abcdef0123456789abcdef"
+	testRetiredKey = "fedcba9876543210fedcba9876543210"
+)
+
+func newTestTokenIssuer(t *testing.T, signingKey string, verificationKeys ...string) *TokenIssuer {
+	t.Helper()
+
+	issuer, err := NewTokenIssuer(infra.AuthConfig{
+		Issuer:           "fin-scheduler-api",
+		SigningKey:       signingKey,
+		VerificationKeys: verificationKeys,
+		AccessTokenTTL:   15 * time.Minute,
+		RefreshTokenTTL:  24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	return issuer
+}
+
+func TestNewTokenIssuer_ShouldRejectShortKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  infra.AuthConfig
+	}{
+		{name: "missing signing key", cfg: infra.AuthConfig{}},
+		{name: "short signing key", cfg: infra.AuthConfig{SigningKey: "too-short"}},
+		{name: "short verification key", cfg: infra.AuthConfig{SigningKey: testSigningKey, VerificationKeys: []string{"too-short"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			issuer, err := NewTokenIssuer(tt.cfg)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, issuer)
+		})
+	}
+}
+
+func TestTokenIssuer_Issue_ShouldRoundTripClaims(t *testing.T) {
+	// Arrange
+	issuer := newTestTokenIssuer(t, testSigningKey)
+	userID := uuid.New()
+
+	// Act
+	pair, issueErr := issuer.Issue(userID, "user@example.com")
+	require.NoError(t, issueErr)
+	accessClaims, accessErr := issuer.Parse(pair.AccessToken, TokenUseAccess)
+	refreshClaims, refreshErr := issuer.Parse(pair.RefreshToken, TokenUseRefresh)
+
+	// Assert
+	require.NoError(t, accessErr)
+	require.NoError(t, refreshErr)
+	assert.Equal(t, 15*time.Minute, pair.ExpiresIn)
+	assert.Equal(t, userID.String(), accessClaims.Subject)
+	assert.Equal(t, "user@example.com", accessClaims.Email)
+	assert.Equal(t, "fin-scheduler-api", accessClaims.Issuer)
+	assert.Equal(t, accessClaims.IssuedAt+int64((15*time.Minute).Seconds()), accessClaims.ExpiresAt)
+	assert.Equal(t, refreshClaims.IssuedAt+int64((24*time.Hour).Seconds()), refreshClaims.ExpiresAt)
+	assert.NotEqual(t, accessClaims.ID, refreshClaims.ID)
+}
+
+func TestTokenIssuer_Parse_ShouldRejectInvalidTokens(t *testing.T) {
+	issuer := newTestTokenIssuer(t, testSigningKey)
+	pair, err := issuer.Issue(uuid.New(), "user@example.com")
+	require.NoError(t, err)
+
+	otherIssuer := newTestTokenIssuer(t, testRetiredKey)
+	foreign, err := otherIssuer.Issue(uuid.New(), "user@example.com")
+	require.NoError(t, err)
+
+	expiredIssuer := newTestTokenIssuer(t, testSigningKey)
+	expiredIssuer.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	expired, err := expiredIssuer.Issue(uuid.New(), "user@example.com")
+	require.NoError(t, err)
+
+	header, claims, _ := strings.Cut(pair.AccessToken, ".")
+	unsigned := "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + strings.Split(claims, ".")[0] + "."
+
+	tests := []struct {
+		name  string
+		token string
+		use   TokenUse
+	}{
+		{name: "malformed", token: "not-a-jwt", use: TokenUseAccess},
+		{name: "refresh token used as access token", token: pair.RefreshToken, use: TokenUseAccess},
+		{name: "access token used as refresh token", token: pair.AccessToken, use: TokenUseRefresh},
+		{name: "signed with an unknown key", token: foreign.AccessToken, use: TokenUseAccess},
+		{name: "expired", token: expired.AccessToken, use: TokenUseAccess},
+		{name: "alg none", token: unsigned, use: TokenUseAccess},
+		{name: "tampered signature", token: pair.AccessToken[:len(pair.AccessToken)-2] + "AA", use: TokenUseAccess},
+		{name: "swapped header", token: header + "." + strings.SplitN(foreign.AccessToken, ".", 2)[1], use: TokenUseAccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			parsed, err := issuer.Parse(tt.token, tt.use)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidToken)
+			assert.Nil(t, parsed)
+		})
+	}
+}
+
+func TestTokenIssuer_Parse_ShouldAcceptTokensOfRetiredKeys(t *testing.T) {
+	// Arrange
+	retired := newTestTokenIssuer(t, testRetiredKey)
+	pair, err := retired.Issue(uuid.New(), "user@example.com")
+	require.NoError(t, err)
+	rotated := newTestTokenIssuer(t, testSigningKey, testRetiredKey)
+
+	// Act
+	claims, parseErr := rotated.Parse(pair.AccessToken, TokenUseAccess)
+
+	// Assert
+	require.NoError(t, parseErr)
+	assert.Equal(t, "user@example.com", claims.Email)
+}
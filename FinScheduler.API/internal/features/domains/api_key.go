@@ -0,0 +1,122 @@
+package domains
+
+import (
+	"database/sql"
+	"errors"
+	"finscheduler/internal/auth"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApiKeyPrefix marks API keys so that secret scanners can recognise them.
+const ApiKeyPrefix = "fsk_"
+
+// apiKeyDisplayLength is how much of a key is kept in clear for listings.
+const apiKeyDisplayLength = len(ApiKeyPrefix) + 8
+
+const apiKeyNameMaxLength = 100
+
+// ErrApiKeyNameTaken means the user already has an API key with that name.
+var ErrApiKeyNameTaken = errors.New("an API key with that name already exists")
+
+type ApiKey struct {
+	Id         uuid.UUID    `db:"id"`
+	UserId     uuid.UUID    `db:"user_id"`
+	Name       string       `db:"name"`
+	Prefix     string       `db:"prefix"`
+	KeyHash    string       `db:"key_hash"`
+	Scopes     string       `db:"scopes"`
+	CreatedAt  time.Time    `db:"created_at"`
+	ExpiresAt  sql.NullTime `db:"expires_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+}
+
+// ApiKeyOwner is an API key together with the email of its user, which is
+// all the authentication middleware needs.
+type ApiKeyOwner struct {
+	ApiKey
+	Email string `db:"email"`
+}
+
+type ApiKeyCreate struct {
+	Name      string       `json:"name"`
+	Scopes    []auth.Scope `json:"scopes"`
+	ExpiresAt *time.Time   `json:"expiresAt"`
+}
+
+type ApiKeyDto struct {
+	Id         uuid.UUID    `json:"id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	Scopes     []auth.Scope `json:"scopes"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	ExpiresAt  *time.Time   `json:"expiresAt"`
+	LastUsedAt *time.Time   `json:"lastUsedAt"`
+}
+
+// ApiKeyCreatedDto is returned once, when the key is created. The key is not
+// stored and cannot be read back.
+type ApiKeyCreatedDto struct {
+	ApiKeyDto
+	Key string `json:"key"`
+}
+
+// ApiKeyDisplayPrefix returns the part of key that is stored in clear.
+func ApiKeyDisplayPrefix(key string) string {
+	return key[:min(len(key), apiKeyDisplayLength)]
+}
+
+// Usable reports whether the key may authenticate a request at now.
+func (key *ApiKey) Usable(now time.Time) bool {
+	return !key.ExpiresAt.Valid || now.Before(key.ExpiresAt.Time)
+}
+
+func NewApiKeyDto(key ApiKey) *ApiKeyDto {
+	return &ApiKeyDto{
+		Id:         key.Id,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     auth.ParseScopes(key.Scopes),
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  nullTimePointer(key.ExpiresAt),
+		LastUsedAt: nullTimePointer(key.LastUsedAt),
+	}
+}
+
+func (key *ApiKeyCreate) Validate() error {
+	name := strings.TrimSpace(key.Name)
+	if len(name) < 3 {
+		return fmt.Errorf("name must be at least 3 characters long")
+	}
+	if len(name) > apiKeyNameMaxLength {
+		return fmt.Errorf("name must be at most %d characters long", apiKeyNameMaxLength)
+	}
+	if len(key.Scopes) == 0 {
+		return fmt.Errorf("scopes must not be empty")
+	}
+	for i, scope := range key.Scopes {
+		if !scope.Valid() {
+			return fmt.Errorf("scope %q is unknown", scope)
+		}
+		if slices.Contains(key.Scopes[:i], scope) {
+			return fmt.Errorf("scope %q is listed twice", scope)
+		}
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("expiresAt must be in the future")
+	}
+
+	return nil
+}
+
+func nullTimePointer(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
@@ -0,0 +1,94 @@
+package domains
+
+import (
+	"database/sql"
+	"finscheduler/internal/auth"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyCreate_Validate(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		create      ApiKeyCreate
+		expectedErr string
+	}{
+		{name: "valid", create: ApiKeyCreate{Name: "cashback", Scopes: []auth.Scope{auth.ScopeItemsWrite}}},
+		{name: "with expiry", create: ApiKeyCreate{Name: "cashback", Scopes: []auth.Scope{auth.ScopeItemsRead, auth.ScopeTagsWrite}, ExpiresAt: &future}},
+		{name: "short name", create: ApiKeyCreate{Name: " ab ", Scopes: []auth.Scope{auth.ScopeItemsRead}}, expectedErr: "name must be at least 3 characters long"},
+		{name: "long name", create: ApiKeyCreate{Name: strings.Repeat("a", 101), Scopes: []auth.Scope{auth.ScopeItemsRead}}, expectedErr: "name must be at most 100 characters long"},
+		{name: "no scopes", create: ApiKeyCreate{Name: "cashback"}, expectedErr: "scopes must not be empty"},
+		{name: "unknown scope", create: ApiKeyCreate{Name: "cashback", Scopes: []auth.Scope{"items:delete"}}, expectedErr: `scope "items:delete" is unknown`},
+		{name: "duplicate scope", create: ApiKeyCreate{Name: "cashback", Scopes: []auth.Scope{auth.ScopeItemsRead, auth.ScopeItemsRead}}, expectedErr: `scope "items:read" is listed twice`},
+		{name: "expired", create: ApiKeyCreate{Name: "cashback", Scopes: []auth.Scope{auth.ScopeItemsRead}, ExpiresAt: &past}, expectedErr: "expiresAt must be in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.create.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestApiKey_Usable(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		key  ApiKey
+		want bool
+	}{
+		{name: "no expiry", key: ApiKey{}, want: true},
+		{name: "not yet expired", key: ApiKey{ExpiresAt: sql.NullTime{Time: now.Add(time.Hour), Valid: true}}, want: true},
+		{name: "expired", key: ApiKey{ExpiresAt: sql.NullTime{Time: now, Valid: true}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := tt.key.Usable(now)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewApiKeyDto_ShouldExposeScopesAndOptionalTimes(t *testing.T) {
+	// Arrange
+	usedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	key := ApiKey{
+		Name:       "cashback",
+		Prefix:     "fsk_abcdefgh",
+		KeyHash:    "hash",
+		Scopes:     "items:read items:write",
+		LastUsedAt: sql.NullTime{Time: usedAt, Valid: true},
+	}
+
+	// Act
+	dto := NewApiKeyDto(key)
+
+	// Assert
+	assert.Equal(t, []auth.Scope{auth.ScopeItemsRead, auth.ScopeItemsWrite}, dto.Scopes)
+	assert.Nil(t, dto.ExpiresAt)
+	assert.Equal(t, &usedAt, dto.LastUsedAt)
+}
+
+func TestApiKeyDisplayPrefix(t *testing.T) {
+	assert.Equal(t, "fsk_abcdefgh", ApiKeyDisplayPrefix("fsk_abcdefghijklmnop"))
+	assert.Equal(t, "fsk_ab", ApiKeyDisplayPrefix("fsk_ab"))
+}
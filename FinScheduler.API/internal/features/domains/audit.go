@@ -0,0 +1,250 @@
+package domains
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"finscheduler/pkg/qh"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEntityType string
+
+const (
+	AuditEntityItem AuditEntityType = "item"
+	AuditEntityTag  AuditEntityType = "tag"
+)
+
+func (entityType AuditEntityType) IsValid() bool {
+	switch entityType {
+	case AuditEntityItem, AuditEntityTag:
+		return true
+	default:
+		return false
+	}
+}
+
+type AuditOperation string
+
+const (
+	AuditOperationCreate AuditOperation = "create"
+	AuditOperationUpdate AuditOperation = "update"
+	AuditOperationDelete AuditOperation = "delete"
+	// AuditOperationRestore is recorded when an item leaves the trash.
+	AuditOperationRestore AuditOperation = "restore"
+	// AuditOperationCashbackUpdate is recorded for every item touched by
+	// UpdateCashbackByTag or UpdateCashbackByIds.
+	AuditOperationCashbackUpdate AuditOperation = "cashback_update"
+	// AuditOperationTagsUpdate is recorded for every item whose tags changed
+	// through AddTags or RemoveTags.
+	AuditOperationTagsUpdate AuditOperation = "tags_update"
+)
+
+type AuditEvent struct {
+	Id          uuid.UUID       `db:"id"`
+	HouseholdId uuid.UUID       `db:"household_id"`
+	ActorId     uuid.UUID       `db:"actor_id"`
+	ApiKeyId    uuid.NullUUID   `db:"api_key_id"`
+	Operation   AuditOperation  `db:"operation"`
+	EntityType  AuditEntityType `db:"entity_type"`
+	EntityId    uuid.UUID       `db:"entity_id"`
+	Changes     []byte          `db:"changes"`
+	TraceId     sql.NullString  `db:"trace_id"`
+	OccurredAt  time.Time       `db:"occurred_at"`
+}
+
+// AuditEventListing is an audit event with the email of its actor, which is
+// empty when the account no longer exists.
+type AuditEventListing struct {
+	AuditEvent
+	ActorEmail sql.NullString `db:"actor_email"`
+}
+
+type AuditEventDto struct {
+	Id         uuid.UUID       `json:"id"`
+	ActorId    uuid.UUID       `json:"actorId"`
+	ActorEmail *string         `json:"actorEmail"`
+	ApiKeyId   *uuid.UUID      `json:"apiKeyId"`
+	Operation  AuditOperation  `json:"operation"`
+	EntityType AuditEntityType `json:"entityType"`
+	EntityId   uuid.UUID       `json:"entityId"`
+	Changes    json.RawMessage `json:"changes"`
+	TraceId    *string         `json:"traceId"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+type AuditFilter struct {
+	EntityType *AuditEntityType
+	EntityId   *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+	Page       *int32
+	PageSize   *int32
+}
+
+// AuditSnapshot holds the audited fields of an entity, keyed by their JSON
+// names, with values that encode to JSON.
+type AuditSnapshot map[string]any
+
+// AuditChange is the value of a field before and after a change; Before is
+// null on create and After is null on delete.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+func NewAuditFilter(r *http.Request) (AuditFilter, error) {
+	queryParams := r.URL.Query()
+
+	var entityType *AuditEntityType
+	if value := qh.ParseString(queryParams, "entityType"); value != nil {
+		parsed := AuditEntityType(*value)
+		entityType = &parsed
+	}
+	entityId, err := qh.ParseUUID(queryParams, "entityId")
+	if err != nil {
+		return AuditFilter{}, err
+	}
+	from, err := qh.ParseTime(queryParams, "from")
+	if err != nil {
+		return AuditFilter{}, err
+	}
+	to, err := qh.ParseTime(queryParams, "to")
+	if err != nil {
+		return AuditFilter{}, err
+	}
+	page, err := qh.ParseInt32(queryParams, "page")
+	if err != nil {
+		return AuditFilter{}, err
+	}
+	pageSize, err := qh.ParseInt32(queryParams, "pageSize")
+	if err != nil {
+		return AuditFilter{}, err
+	}
+
+	return AuditFilter{
+		EntityType: entityType,
+		EntityId:   entityId,
+		From:       from,
+		To:         to,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+func (filter *AuditFilter) Validate() error {
+	if filter.Page == nil || *filter.Page < 0 {
+		return fmt.Errorf("page must be zero or greater")
+	}
+	if filter.PageSize == nil || *filter.PageSize <= 0 {
+		return fmt.Errorf("pageSize must be positive")
+	}
+	if filter.EntityType != nil && !filter.EntityType.IsValid() {
+		return fmt.Errorf("entityType must be one of %q, %q", AuditEntityItem, AuditEntityTag)
+	}
+	if filter.From != nil && filter.To != nil && (*filter.To).Before(*filter.From) {
+		return fmt.Errorf("to cannot be earlier than from")
+	}
+
+	return nil
+}
+
+func NewAuditEventDto(event AuditEventListing) *AuditEventDto {
+	dto := &AuditEventDto{
+		Id:         event.Id,
+		ActorId:    event.ActorId,
+		Operation:  event.Operation,
+		EntityType: event.EntityType,
+		EntityId:   event.EntityId,
+		Changes:    json.RawMessage(event.Changes),
+		OccurredAt: event.OccurredAt,
+	}
+	if event.ActorEmail.Valid {
+		dto.ActorEmail = &event.ActorEmail.String
+	}
+	if event.ApiKeyId.Valid {
+		dto.ApiKeyId = &event.ApiKeyId.UUID
+	}
+	if event.TraceId.Valid {
+		dto.TraceId = &event.TraceId.String
+	}
+
+	return dto
+}
+
+// NewItemAuditSnapshot captures the audited fields of an item and the ids of
+// its tags, sorted so that reordering them is not a change.
+func NewItemAuditSnapshot(item Item, tagIds []uuid.UUID) AuditSnapshot {
+	return AuditSnapshot{
+		"name":        item.Name,
+		"price":       item.Price.String(),
+		"description": item.Description,
+		"isActive":    item.IsActive,
+		"cashback":    item.Cashback,
+		"category":    item.Category,
+		"tagIds":      sortedTagIds(tagIds),
+	}
+}
+
+// NewItemTagsAuditSnapshot captures only the tags of an item, for changes
+// that touch nothing else.
+func NewItemTagsAuditSnapshot(tagIds []uuid.UUID) AuditSnapshot {
+	return AuditSnapshot{"tagIds": sortedTagIds(tagIds)}
+}
+
+func sortedTagIds(tagIds []uuid.UUID) []string {
+	tags := make([]string, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		tags = append(tags, tagId.String())
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+func NewTagAuditSnapshot(tag Tag) AuditSnapshot {
+	return AuditSnapshot{
+		"name":        tag.Name,
+		"isActive":    tag.IsActive,
+		"parentId":    tag.ParentId,
+		"color":       tag.Color,
+		"icon":        tag.Icon,
+		"description": tag.Description,
+	}
+}
+
+// NewAuditChanges returns the fields whose JSON encoding differs between
+// before and after. A nil snapshot stands for an entity that does not exist,
+// so every field of the other one is a change.
+func NewAuditChanges(before AuditSnapshot, after AuditSnapshot) (map[string]AuditChange, error) {
+	changes := make(map[string]AuditChange)
+	for field, value := range after {
+		changes[field] = AuditChange{Before: before[field], After: value}
+	}
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = AuditChange{Before: value}
+		}
+	}
+
+	for field, change := range changes {
+		beforeJSON, err := json.Marshal(change.Before)
+		if err != nil {
+			return nil, err
+		}
+		afterJSON, err := json.Marshal(change.After)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(beforeJSON, afterJSON) {
+			delete(changes, field)
+		}
+	}
+
+	return changes, nil
+}
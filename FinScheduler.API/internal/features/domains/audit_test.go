@@ -0,0 +1,117 @@
+package domains
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   AuditSnapshot
+		after    AuditSnapshot
+		expected map[string]AuditChange
+	}{
+		{
+			name:     "create",
+			after:    AuditSnapshot{"name": "Coffee", "cashback": int32(5)},
+			expected: map[string]AuditChange{"name": {After: "Coffee"}, "cashback": {After: int32(5)}},
+		},
+		{
+			name:     "update keeps changed fields only",
+			before:   AuditSnapshot{"name": "Coffee", "cashback": int32(5), "tagIds": []string{"a"}},
+			after:    AuditSnapshot{"name": "Coffee", "cashback": int32(7), "tagIds": []string{"a"}},
+			expected: map[string]AuditChange{"cashback": {Before: int32(5), After: int32(7)}},
+		},
+		{
+			name:     "delete",
+			before:   AuditSnapshot{"name": "Coffee"},
+			expected: map[string]AuditChange{"name": {Before: "Coffee"}},
+		},
+		{
+			name:     "no change",
+			before:   AuditSnapshot{"name": "Coffee", "isActive": true},
+			after:    AuditSnapshot{"name": "Coffee", "isActive": true},
+			expected: map[string]AuditChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			changes, err := NewAuditChanges(tt.before, tt.after)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func TestNewItemAuditSnapshot_ShouldSortTagIds(t *testing.T) {
+	// Arrange
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	item := Item{Name: "Coffee", Price: decimal.RequireFromString("10.50"), Category: "FoodDrinks"}
+
+	// Act
+	snapshot := NewItemAuditSnapshot(item, []uuid.UUID{second, first})
+
+	// Assert
+	assert.Equal(t, []string{first.String(), second.String()}, snapshot["tagIds"])
+	assert.Equal(t, "10.5", snapshot["price"])
+}
+
+func TestNewItemTagsAuditSnapshot_ShouldHoldOnlySortedTagIds(t *testing.T) {
+	// Arrange
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	// Act
+	snapshot := NewItemTagsAuditSnapshot([]uuid.UUID{second, first})
+
+	// Assert
+	assert.Equal(t, AuditSnapshot{"tagIds": []string{first.String(), second.String()}}, snapshot)
+}
+
+func TestAuditFilter_Validate(t *testing.T) {
+	page := int32(0)
+	pageSize := int32(20)
+	negativePage := int32(-1)
+	item := AuditEntityItem
+	unknown := AuditEntityType("household")
+	from := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		filter      AuditFilter
+		expectedErr string
+	}{
+		{name: "valid", filter: AuditFilter{Page: &page, PageSize: &pageSize, EntityType: &item, From: &to, To: &from}},
+		{name: "missing page", filter: AuditFilter{PageSize: &pageSize}, expectedErr: "page must be zero or greater"},
+		{name: "negative page", filter: AuditFilter{Page: &negativePage, PageSize: &pageSize}, expectedErr: "page must be zero or greater"},
+		{name: "missing page size", filter: AuditFilter{Page: &page}, expectedErr: "pageSize must be positive"},
+		{name: "unknown entity type", filter: AuditFilter{Page: &page, PageSize: &pageSize, EntityType: &unknown}, expectedErr: `entityType must be one of "item", "tag"`},
+		{name: "to before from", filter: AuditFilter{Page: &page, PageSize: &pageSize, From: &from, To: &to}, expectedErr: "to cannot be earlier than from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.filter.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package domains
+
+import (
+	"database/sql"
+	"errors"
+	"finscheduler/internal/auth"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationTTL is how long an invitation link can be accepted.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// PersonalHouseholdName names the household every user gets on first use.
+const PersonalHouseholdName = "Personal"
+
+// ErrNoHousehold means a service was called without a resolved household in
+// the context, i.e. outside of the membership middleware.
+var ErrNoHousehold = errors.New("no household was resolved for the request")
+
+// ErrNotMember hides whether the household does not exist or the caller is
+// not one of its members.
+var ErrNotMember = errors.New("caller is not a member of the household")
+
+// ErrInvitationGone is returned for invitations that expired or were already
+// accepted.
+var ErrInvitationGone = errors.New("invitation has expired or was already accepted")
+
+type Household struct {
+	Id         uuid.UUID `db:"id"`
+	OwnerId    uuid.UUID `db:"owner_id"`
+	Name       string    `db:"name"`
+	IsPersonal bool      `db:"is_personal"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// HouseholdMembership is a household as seen by one of its members.
+type HouseholdMembership struct {
+	Id         uuid.UUID `db:"id"`
+	Name       string    `db:"name"`
+	IsPersonal bool      `db:"is_personal"`
+	Role       auth.Role `db:"role"`
+}
+
+type Invitation struct {
+	Id          uuid.UUID     `db:"id"`
+	HouseholdId uuid.UUID     `db:"household_id"`
+	Role        auth.Role     `db:"role"`
+	TokenHash   string        `db:"token_hash"`
+	InvitedBy   uuid.UUID     `db:"invited_by"`
+	CreatedAt   time.Time     `db:"created_at"`
+	ExpiresAt   time.Time     `db:"expires_at"`
+	AcceptedBy  uuid.NullUUID `db:"accepted_by"`
+	AcceptedAt  sql.NullTime  `db:"accepted_at"`
+}
+
+type HouseholdDto struct {
+	Id         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	IsPersonal bool      `json:"isPersonal"`
+	Role       auth.Role `json:"role"`
+}
+
+type HouseholdCreate struct {
+	Name string `json:"name"`
+}
+
+type InvitationCreate struct {
+	Role auth.Role `json:"role"`
+}
+
+// InvitationDto is returned once, when the invitation is created. The token
+// is not stored and cannot be read back. Link is the path that accepts it.
+type InvitationDto struct {
+	HouseholdId uuid.UUID `json:"householdId"`
+	Role        auth.Role `json:"role"`
+	Token       string    `json:"token"`
+	Link        string    `json:"link"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// Usable reports whether the invitation can still be accepted at now.
+func (invitation *Invitation) Usable(now time.Time) bool {
+	return !invitation.AcceptedAt.Valid && now.Before(invitation.ExpiresAt)
+}
+
+func NewHouseholdDto(membership HouseholdMembership) *HouseholdDto {
+	return &HouseholdDto{
+		Id:         membership.Id,
+		Name:       membership.Name,
+		IsPersonal: membership.IsPersonal,
+		Role:       membership.Role,
+	}
+}
+
+func (household *HouseholdCreate) Validate() error {
+	if len(strings.TrimSpace(household.Name)) < 3 {
+		return fmt.Errorf("name must be at least 3 characters long")
+	}
+
+	return nil
+}
+
+func (invitation *InvitationCreate) Validate() error {
+	if !invitation.Role.Valid() {
+		return fmt.Errorf("role must be one of viewer, editor or admin")
+	}
+
+	return nil
+}
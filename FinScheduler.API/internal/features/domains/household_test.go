@@ -0,0 +1,61 @@
+package domains
+
+import (
+	"database/sql"
+	"finscheduler/internal/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitationCreate_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		create      InvitationCreate
+		expectedErr string
+	}{
+		{name: "viewer", create: InvitationCreate{Role: auth.RoleViewer}},
+		{name: "admin", create: InvitationCreate{Role: auth.RoleAdmin}},
+		{name: "unknown role", create: InvitationCreate{Role: "owner"}, expectedErr: "role must be one of viewer, editor or admin"},
+		{name: "missing role", create: InvitationCreate{}, expectedErr: "role must be one of viewer, editor or admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.create.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestInvitation_Usable(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		invitation Invitation
+		want       bool
+	}{
+		{name: "pending", invitation: Invitation{ExpiresAt: now.Add(time.Hour)}, want: true},
+		{name: "expired", invitation: Invitation{ExpiresAt: now}, want: false},
+		{name: "accepted", invitation: Invitation{ExpiresAt: now.Add(time.Hour), AcceptedAt: sql.NullTime{Time: now, Valid: true}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := tt.invitation.Usable(now)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package domains
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyTTL bounds how long a stored response can be replayed.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+const idempotencyKeyMaxLength = 255
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+
+type IdempotencyKey struct {
+	Scope        string        `db:"scope"`
+	Key          string        `db:"key"`
+	RequestHash  string        `db:"request_hash"`
+	StatusCode   sql.NullInt32 `db:"status_code"`
+	ResourceId   uuid.NullUUID `db:"resource_id"`
+	ResponseBody []byte        `db:"response_body"`
+	CreatedAt    time.Time     `db:"created_at"`
+	ExpiresAt    time.Time     `db:"expires_at"`
+}
+
+// IdempotencyClaim identifies a retried request: Scope names the endpoint,
+// Key comes from the Idempotency-Key header and RequestHash fingerprints the
+// decoded body so that a reused key with a different payload is rejected.
+type IdempotencyClaim struct {
+	Scope       string
+	Key         string
+	RequestHash string
+}
+
+type IdempotencyKeyComplete struct {
+	StatusCode   int32
+	ResourceId   uuid.UUID
+	ResponseBody []byte
+}
+
+func (claim *IdempotencyClaim) Validate() error {
+	if claim.Scope == "" {
+		return fmt.Errorf("idempotency scope is required")
+	}
+	if claim.Key == "" {
+		return fmt.Errorf("idempotency key is required")
+	}
+	if len(claim.Key) > idempotencyKeyMaxLength {
+		return fmt.Errorf("idempotency key must be at most %d characters", idempotencyKeyMaxLength)
+	}
+	if claim.RequestHash == "" {
+		return fmt.Errorf("idempotency request hash is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package domains
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyClaimValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		claim         IdempotencyClaim
+		expectedError string
+	}{
+		{
+			name:          "valid claim",
+			claim:         IdempotencyClaim{Scope: "POST /items", Key: "retry-1", RequestHash: "abc"},
+			expectedError: "",
+		},
+		{
+			name:          "missing scope",
+			claim:         IdempotencyClaim{Key: "retry-1", RequestHash: "abc"},
+			expectedError: "idempotency scope is required",
+		},
+		{
+			name:          "missing key",
+			claim:         IdempotencyClaim{Scope: "POST /items", RequestHash: "abc"},
+			expectedError: "idempotency key is required",
+		},
+		{
+			name:          "key too long",
+			claim:         IdempotencyClaim{Scope: "POST /items", Key: strings.Repeat("k", 256), RequestHash: "abc"},
+			expectedError: "idempotency key must be at most 255 characters",
+		},
+		{
+			name:          "missing request hash",
+			claim:         IdempotencyClaim{Scope: "POST /items", Key: "retry-1"},
+			expectedError: "idempotency request hash is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			claim := tt.claim
+
+			// Act
+			err := claim.Validate()
+
+			// Assert
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package domains
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"finscheduler/pkg/qh"
+	"finscheduler/pkg/rh"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +18,7 @@ import (
 
 type Item struct {
 	Id          uuid.UUID       `db:"id"`
+	HouseholdId uuid.UUID       `db:"household_id"`
 	Name        string          `db:"name"`
 	Price       decimal.Decimal `db:"price"`
 	Description string          `db:"description"`
@@ -21,12 +27,21 @@ type Item struct {
 	UpdatedAt   sql.NullTime    `db:"updated_at"`
 	Cashback    int32           `db:"cashback"`
 	Category    ItemCategory    `db:"category"`
+	Version     int32           `db:"version"`
+	DeletedAt   sql.NullTime    `db:"deleted_at"`
 }
 
+// ItemSortFields are the fields an item listing can be sorted by.
+var ItemSortFields = []string{"name", "price", "cashback", "category", "createdAt", "updatedAt"}
+
+// ErrItemNameTaken means another item of the household, not in the trash,
+// already has the name.
+var ErrItemNameTaken = errors.New("an item with that name already exists")
+
 type ItemListingDto struct {
 	Id        uuid.UUID  `json:"id"`
 	Name      string     `json:"name"`
-	Price     float64    `json:"price"`
+	Price     Money      `json:"price"`
 	IsActive  bool       `json:"isActive"`
 	UpdatedAt *time.Time `json:"updatedAt"`
 	Cashback  int32      `json:"cashback"`
@@ -34,13 +49,29 @@ type ItemListingDto struct {
 
 type ItemDetailedDto struct {
 	Name         string                 `json:"name"`
-	Price        float64                `json:"price"`
+	Price        Money                  `json:"price"`
 	Description  string                 `json:"description"`
 	IsActive     bool                   `json:"isActive"`
 	Cashback     int32                  `json:"cashback"`
 	Category     ItemCategory           `json:"category"`
-	Tags         []Lookup               `json:"tags"`
+	Tags         []TagLookupDto         `json:"tags"`
 	PriceHistory []PriceHistoryPointDto `json:"priceHistory"`
+	Version      int32                  `json:"-"`
+}
+
+// ItemTrashDto is an item in the trash, which the purge job deletes for good
+// once it has been there for the configured retention.
+type ItemTrashDto struct {
+	Id        uuid.UUID    `json:"id"`
+	Name      string       `json:"name"`
+	Price     Money        `json:"price"`
+	Category  ItemCategory `json:"category"`
+	DeletedAt time.Time    `json:"deletedAt"`
+}
+
+type ItemTrashFilter struct {
+	Page     *int32
+	PageSize *int32
 }
 
 type ItemFilter struct {
@@ -58,8 +89,17 @@ type ItemFilter struct {
 	CashbackTo   *int32
 	Categories   []*ItemCategory
 	TagIds       []*uuid.UUID
-	Page         *int32
-	PageSize     *int32
+	// IncludeDescendants widens TagIds to every tag nested under them.
+	IncludeDescendants *bool
+	Page               *int32
+	PageSize           *int32
+	// After and Before page through the listing by cursor instead of Page.
+	After  *Cursor
+	Before *Cursor
+	// IncludeCount, true unless set, runs the count of matching items.
+	IncludeCount *bool
+	// Sort replaces the newest-first order; it cannot be paged by cursor.
+	Sort []SortField
 }
 
 type ItemCreate struct {
@@ -82,6 +122,45 @@ type ItemUpdate struct {
 	TagIds      []string        `json:"tagIds"`
 }
 
+// ItemPatch is a JSON Merge Patch (RFC 7396) document for an item. Absent
+// members leave the stored value untouched and a null description clears it;
+// the other members cannot be null. TagIds is reconciled only when present.
+type ItemPatch struct {
+	Name        *string          `json:"name"`
+	Price       *decimal.Decimal `json:"price"`
+	Description Nullable[string] `json:"description"`
+	IsActive    *bool            `json:"isActive"`
+	Cashback    *int32           `json:"cashback"`
+	Category    *string          `json:"category"`
+	TagIds      *[]string        `json:"tagIds"`
+}
+
+// itemPatchRequiredMembers are the ItemPatch members that name NOT NULL
+// columns, or the tag set, and so cannot be cleared with null.
+var itemPatchRequiredMembers = []string{"name", "price", "isActive", "cashback", "category", "tagIds"}
+
+// UnmarshalJSON decodes the patch, unknown members included as errors, and
+// rejects a null for any of the required members.
+func (item *ItemPatch) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for name, value := range members {
+		for _, required := range itemPatchRequiredMembers {
+			if strings.EqualFold(name, required) && string(value) == "null" {
+				return fmt.Errorf("%s cannot be null", required)
+			}
+		}
+	}
+
+	type itemPatch ItemPatch
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode((*itemPatch)(item))
+}
+
 type ItemCashbackByTagUpdate struct {
 	Cashback int32  `json:"cashback"`
 	TagId    string `json:"tagId"`
@@ -92,6 +171,85 @@ type ItemCashbackByIdsUpdate struct {
 	ItemIds  []string `json:"itemIds"`
 }
 
+// ItemSelection picks the items of a bulk change by the criteria of the
+// GET /api/items query, without paging. A selection without criteria would
+// change every item of the household, so it needs All to say so.
+type ItemSelection struct {
+	All          bool             `json:"all"`
+	Ids          []uuid.UUID      `json:"ids"`
+	Name         *string          `json:"name"`
+	PriceFrom    *decimal.Decimal `json:"priceFrom"`
+	PriceTo      *decimal.Decimal `json:"priceTo"`
+	Description  *string          `json:"description"`
+	IsActive     *bool            `json:"isActive"`
+	CreatedFrom  *time.Time       `json:"createdFrom"`
+	CreatedTo    *time.Time       `json:"createdTo"`
+	UpdatedFrom  *time.Time       `json:"updatedFrom"`
+	UpdatedTo    *time.Time       `json:"updatedTo"`
+	CashbackFrom *int32           `json:"cashbackFrom"`
+	CashbackTo   *int32           `json:"cashbackTo"`
+	Categories   []ItemCategory   `json:"categories"`
+	TagIds       []uuid.UUID      `json:"tagIds"`
+	// IncludeDescendants widens TagIds to every tag nested under them.
+	IncludeDescendants *bool `json:"includeDescendants"`
+}
+
+// ItemTagsChange adds or removes TagIds on the items named by ItemIds or
+// matched by Filter; exactly one of the two is set.
+type ItemTagsChange struct {
+	ItemIds []string       `json:"itemIds"`
+	Filter  *ItemSelection `json:"filter"`
+	TagIds  []string       `json:"tagIds"`
+}
+
+// ItemTagsChangeResult is the number of item links a bulk tag change added
+// or removed.
+type ItemTagsChangeResult struct {
+	Changed int64 `json:"changed"`
+}
+
+// ItemBulkOperation is what a bulk change does to every selected item.
+type ItemBulkOperation string
+
+const (
+	ItemBulkSetActive   ItemBulkOperation = "setActive"
+	ItemBulkSetCategory ItemBulkOperation = "setCategory"
+	ItemBulkSetCashback ItemBulkOperation = "setCashback"
+	ItemBulkAdjustPrice ItemBulkOperation = "adjustPrice"
+	ItemBulkDelete      ItemBulkOperation = "delete"
+)
+
+// ItemBulkChange applies Operation to every item matched by Filter. The
+// operation takes its value from the member of the same name (PricePercent
+// for adjustPrice, nothing for delete); the other members must be absent.
+// A DryRun only reports the matching items.
+type ItemBulkChange struct {
+	Filter       *ItemSelection    `json:"filter"`
+	Operation    ItemBulkOperation `json:"operation"`
+	IsActive     *bool             `json:"isActive"`
+	Category     *ItemCategory     `json:"category"`
+	Cashback     *int32            `json:"cashback"`
+	PricePercent *decimal.Decimal  `json:"pricePercent"`
+	DryRun       bool              `json:"dryRun"`
+}
+
+// ItemBulkChangeResult counts the items a bulk change matched and the ones it
+// actually changed. ItemIds lists the changed items, or the matching ones on a
+// dry run.
+type ItemBulkChangeResult struct {
+	Matched  int64       `json:"matched"`
+	Affected int64       `json:"affected"`
+	ItemIds  []uuid.UUID `json:"itemIds"`
+}
+
+// ItemCashbackChange is the cashback of an item before and after a bulk
+// cashback update.
+type ItemCashbackChange struct {
+	ItemId uuid.UUID `db:"id"`
+	Before int32     `db:"previous_cashback"`
+	After  int32     `db:"cashback"`
+}
+
 func NewItemFilter(r *http.Request) (ItemFilter, error) {
 	queryParams := r.URL.Query()
 
@@ -153,24 +311,67 @@ func NewItemFilter(r *http.Request) (ItemFilter, error) {
 	if err != nil {
 		return ItemFilter{}, err
 	}
+	includeDescendants, err := qh.ParseBool(queryParams, "includeDescendants")
+	if err != nil {
+		return ItemFilter{}, err
+	}
+	after, err := ParseCursor(queryParams, "after")
+	if err != nil {
+		return ItemFilter{}, err
+	}
+	before, err := ParseCursor(queryParams, "before")
+	if err != nil {
+		return ItemFilter{}, err
+	}
+	includeCount, err := qh.ParseBool(queryParams, "includeCount")
+	if err != nil {
+		return ItemFilter{}, err
+	}
+	sort, err := ParseSort(queryParams, ItemSortFields)
+	if err != nil {
+		return ItemFilter{}, err
+	}
 
 	return ItemFilter{
-		Ids:          ids,
-		Name:         name,
-		PriceFrom:    priceFrom,
-		PriceTo:      priceTo,
-		Description:  description,
-		IsActive:     isActive,
-		CreatedFrom:  createdFrom,
-		CreatedTo:    createdTo,
-		UpdatedFrom:  updatedFrom,
-		UpdatedTo:    updatedTo,
-		CashbackFrom: cashbackFrom,
-		CashbackTo:   cashbackTo,
-		Categories:   categories,
-		TagIds:       tagIds,
-		Page:         page,
-		PageSize:     pageSize,
+		Ids:                ids,
+		Name:               name,
+		PriceFrom:          priceFrom,
+		PriceTo:            priceTo,
+		Description:        description,
+		IsActive:           isActive,
+		CreatedFrom:        createdFrom,
+		CreatedTo:          createdTo,
+		UpdatedFrom:        updatedFrom,
+		UpdatedTo:          updatedTo,
+		CashbackFrom:       cashbackFrom,
+		CashbackTo:         cashbackTo,
+		Categories:         categories,
+		TagIds:             tagIds,
+		IncludeDescendants: includeDescendants,
+		Page:               page,
+		PageSize:           pageSize,
+		After:              after,
+		Before:             before,
+		IncludeCount:       includeCount,
+		Sort:               sort,
+	}, nil
+}
+
+func NewItemTrashFilter(r *http.Request) (ItemTrashFilter, error) {
+	queryParams := r.URL.Query()
+
+	page, err := qh.ParseInt32(queryParams, "page")
+	if err != nil {
+		return ItemTrashFilter{}, err
+	}
+	pageSize, err := qh.ParseInt32(queryParams, "pageSize")
+	if err != nil {
+		return ItemTrashFilter{}, err
+	}
+
+	return ItemTrashFilter{
+		Page:     page,
+		PageSize: pageSize,
 	}, nil
 }
 
@@ -182,29 +383,20 @@ func NewItemListingDto(item Item) *ItemListingDto {
 		updatedAt = nil
 	}
 
-	price, _ := item.Price.Float64()
-
 	return &ItemListingDto{
 		Id:        item.Id,
 		Name:      item.Name,
 		IsActive:  item.IsActive,
-		Price:     price,
+		Price:     NewMoney(item.Price),
 		UpdatedAt: updatedAt,
 		Cashback:  item.Cashback,
 	}
 }
 
 func NewItemDetailedDto(item Item, tags []Tag, priceHistories []PriceHistory) *ItemDetailedDto {
-	price, _ := item.Price.Float64()
-
-	tagLookups := make([]Lookup, 0, len(tags))
+	tagLookups := make([]TagLookupDto, 0, len(tags))
 	for _, tag := range tags {
-		tagLookup := Lookup{
-			Label: tag.Name,
-			Value: tag.Id.String(),
-		}
-
-		tagLookups = append(tagLookups, tagLookup)
+		tagLookups = append(tagLookups, NewTagLookupDto(tag))
 	}
 
 	priceHistoryPoints := make([]PriceHistoryPointDto, 0, len(priceHistories))
@@ -223,11 +415,41 @@ func NewItemDetailedDto(item Item, tags []Tag, priceHistories []PriceHistory) *I
 		Name:         item.Name,
 		Description:  item.Description,
 		IsActive:     item.IsActive,
-		Price:        price,
+		Price:        NewMoney(item.Price),
 		Cashback:     item.Cashback,
 		Category:     item.Category,
 		Tags:         tagLookups,
 		PriceHistory: priceHistoryPoints,
+		Version:      item.Version,
+	}
+}
+
+func NewItemTrashDto(item Item) *ItemTrashDto {
+	return &ItemTrashDto{
+		Id:        item.Id,
+		Name:      item.Name,
+		Price:     NewMoney(item.Price),
+		Category:  item.Category,
+		DeletedAt: item.DeletedAt.Time,
+	}
+}
+
+// FormatMoney sets the JSON representation of every amount in the DTO.
+func (dto *ItemListingDto) FormatMoney(format MoneyFormat) {
+	dto.Price = dto.Price.WithFormat(format)
+}
+
+// FormatMoney sets the JSON representation of every amount in the DTO.
+func (dto *ItemTrashDto) FormatMoney(format MoneyFormat) {
+	dto.Price = dto.Price.WithFormat(format)
+}
+
+// FormatMoney sets the JSON representation of every amount in the DTO,
+// including the price history.
+func (dto *ItemDetailedDto) FormatMoney(format MoneyFormat) {
+	dto.Price = dto.Price.WithFormat(format)
+	for i := range dto.PriceHistory {
+		dto.PriceHistory[i].FormatMoney(format)
 	}
 }
 
@@ -271,13 +493,47 @@ func (item *ItemUpdate) Validate() error {
 	return nil
 }
 
+func (item *ItemPatch) Validate() error {
+	if item.Name != nil && len(*item.Name) < 3 {
+		return fmt.Errorf("name must be at least 3 characters long")
+	}
+	if item.Price != nil && item.Price.IsNegative() {
+		return fmt.Errorf("price must be zero or greater")
+	}
+	if item.Cashback != nil && *item.Cashback < 0 {
+		return fmt.Errorf("cashback must be zero or greater")
+	}
+	if item.Category != nil && !ItemCategory(*item.Category).IsValid() {
+		return fmt.Errorf("category is invalid")
+	}
+	if item.TagIds != nil {
+		if err := validateTagIds(*item.TagIds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (item *ItemFilter) Validate() error {
-	if item.Page == nil || *item.Page < 0 {
-		return fmt.Errorf("page must be zero or greater")
+	if err := validateKeyset(item.Page, item.PageSize, item.After, item.Before); err != nil {
+		return err
 	}
-	if item.PageSize == nil || *item.PageSize <= 0 {
-		return fmt.Errorf("pageSize must be positive")
+	if item.After != nil && item.After.CreatedAt == nil {
+		return fmt.Errorf("after is not a valid cursor")
+	}
+	if item.Before != nil && item.Before.CreatedAt == nil {
+		return fmt.Errorf("before is not a valid cursor")
 	}
+	if len(item.Sort) > 0 && (item.After != nil || item.Before != nil) {
+		return fmt.Errorf("sort cannot be used with after or before")
+	}
+
+	return item.validateRanges()
+}
+
+// validateRanges checks that every from/to pair of the filter is ordered.
+func (item *ItemFilter) validateRanges() error {
 	if item.PriceFrom != nil && item.PriceTo != nil && (*item.PriceTo).LessThan(*item.PriceFrom) {
 		return fmt.Errorf("priceTo cannot be less than priceFrom")
 	}
@@ -294,6 +550,168 @@ func (item *ItemFilter) Validate() error {
 	return nil
 }
 
+func (filter *ItemTrashFilter) Validate() error {
+	if filter.Page == nil || *filter.Page < 0 {
+		return fmt.Errorf("page must be zero or greater")
+	}
+	if filter.PageSize == nil || *filter.PageSize <= 0 {
+		return fmt.Errorf("pageSize must be positive")
+	}
+
+	return nil
+}
+
+func (selection *ItemSelection) Validate() error {
+	if selection.Ids != nil && len(selection.Ids) == 0 {
+		return fmt.Errorf("ids are empty")
+	}
+	if selection.Categories != nil && len(selection.Categories) == 0 {
+		return fmt.Errorf("categories are empty")
+	}
+	if selection.TagIds != nil && len(selection.TagIds) == 0 {
+		return fmt.Errorf("tagIds are empty")
+	}
+	if selection.All && selection.hasCriteria() {
+		return fmt.Errorf("all cannot be combined with other criteria")
+	}
+	if !selection.All && !selection.hasCriteria() {
+		return fmt.Errorf("filter needs at least one criterion, or all set to true")
+	}
+	for _, category := range selection.Categories {
+		if !category.IsValid() {
+			return fmt.Errorf("category is invalid: %s", category)
+		}
+	}
+
+	return selection.ItemFilter().validateRanges()
+}
+
+// hasCriteria reports whether the selection narrows the items down at all.
+// IncludeDescendants only widens TagIds, so it does not count.
+func (selection *ItemSelection) hasCriteria() bool {
+	return selection.Ids != nil || selection.Name != nil || selection.PriceFrom != nil || selection.PriceTo != nil ||
+		selection.Description != nil || selection.IsActive != nil || selection.CreatedFrom != nil ||
+		selection.CreatedTo != nil || selection.UpdatedFrom != nil || selection.UpdatedTo != nil ||
+		selection.CashbackFrom != nil || selection.CashbackTo != nil || selection.Categories != nil ||
+		selection.TagIds != nil
+}
+
+// ItemFilter converts the selection to an unpaged item filter.
+func (selection *ItemSelection) ItemFilter() *ItemFilter {
+	return &ItemFilter{
+		Ids:                rh.ReferenceSlice(selection.Ids),
+		Name:               selection.Name,
+		PriceFrom:          selection.PriceFrom,
+		PriceTo:            selection.PriceTo,
+		Description:        selection.Description,
+		IsActive:           selection.IsActive,
+		CreatedFrom:        selection.CreatedFrom,
+		CreatedTo:          selection.CreatedTo,
+		UpdatedFrom:        selection.UpdatedFrom,
+		UpdatedTo:          selection.UpdatedTo,
+		CashbackFrom:       selection.CashbackFrom,
+		CashbackTo:         selection.CashbackTo,
+		Categories:         rh.ReferenceSlice(selection.Categories),
+		TagIds:             rh.ReferenceSlice(selection.TagIds),
+		IncludeDescendants: selection.IncludeDescendants,
+	}
+}
+
+func (change *ItemTagsChange) Validate() error {
+	if change.ItemIds == nil && change.Filter == nil {
+		return fmt.Errorf("either itemIds or filter is required")
+	}
+	if change.ItemIds != nil && change.Filter != nil {
+		return fmt.Errorf("itemIds and filter cannot be combined")
+	}
+	if change.ItemIds != nil {
+		if err := validateItemIds(change.ItemIds); err != nil {
+			return err
+		}
+	}
+	if change.Filter != nil {
+		if err := change.Filter.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(change.TagIds) == 0 {
+		return fmt.Errorf("tagIds are empty")
+	}
+	if err := validateTagIds(change.TagIds); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ItemFilter returns the unpaged filter of the items to change. Call it on a
+// validated change only.
+func (change *ItemTagsChange) ItemFilter() *ItemFilter {
+	if change.Filter != nil {
+		return change.Filter.ItemFilter()
+	}
+
+	ids := make([]*uuid.UUID, 0, len(change.ItemIds))
+	for _, itemId := range change.ItemIds {
+		parsed := uuid.MustParse(itemId)
+		ids = append(ids, &parsed)
+	}
+
+	return &ItemFilter{Ids: ids}
+}
+
+func (change *ItemBulkChange) Validate() error {
+	if change.Filter == nil {
+		return fmt.Errorf("filter is required")
+	}
+	if err := change.Filter.Validate(); err != nil {
+		return err
+	}
+
+	used := map[string]bool{
+		"isActive":     change.IsActive != nil,
+		"category":     change.Category != nil,
+		"cashback":     change.Cashback != nil,
+		"pricePercent": change.PricePercent != nil,
+	}
+	var value string
+	switch change.Operation {
+	case ItemBulkSetActive:
+		value = "isActive"
+	case ItemBulkSetCategory:
+		value = "category"
+		if change.Category != nil && !change.Category.IsValid() {
+			return fmt.Errorf("category is invalid: %s", *change.Category)
+		}
+	case ItemBulkSetCashback:
+		value = "cashback"
+		if change.Cashback != nil && *change.Cashback < 0 {
+			return fmt.Errorf("cashback must be zero or greater")
+		}
+	case ItemBulkAdjustPrice:
+		value = "pricePercent"
+		if change.PricePercent != nil && change.PricePercent.LessThan(decimal.NewFromInt(-100)) {
+			return fmt.Errorf("pricePercent must be -100 or greater")
+		}
+	case ItemBulkDelete:
+	case "":
+		return fmt.Errorf("operation is empty")
+	default:
+		return fmt.Errorf("operation is invalid: %s", change.Operation)
+	}
+
+	if value != "" && !used[value] {
+		return fmt.Errorf("%s is required by %s", value, change.Operation)
+	}
+	for _, member := range []string{"isActive", "category", "cashback", "pricePercent"} {
+		if member != value && used[member] {
+			return fmt.Errorf("%s is not used by %s", member, change.Operation)
+		}
+	}
+
+	return nil
+}
+
 func (item *ItemCashbackByTagUpdate) Validate() error {
 	if item.Cashback < 0 {
 		return fmt.Errorf("cashback must be zero or greater")
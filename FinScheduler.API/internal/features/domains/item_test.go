@@ -2,6 +2,7 @@ package domains
 
 import (
 	"database/sql"
+	"encoding/json"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -36,6 +37,8 @@ func TestNewItemFilter_ShouldParseAllSupportedFields(t *testing.T) {
 		"&categories=" + string(FoodDrinks) +
 		"&categories=" + string(Travel) +
 		"&tagIds=" + tagID.String() +
+		"&includeDescendants=true" +
+		"&sort=-price,name" +
 		"&page=2" +
 		"&pageSize=50"
 	req := httptest.NewRequest("GET", requestURL, nil)
@@ -59,6 +62,7 @@ func TestNewItemFilter_ShouldParseAllSupportedFields(t *testing.T) {
 	require.NotNil(t, filter.CashbackTo)
 	require.Len(t, filter.Categories, 2)
 	require.Len(t, filter.TagIds, 1)
+	require.NotNil(t, filter.IncludeDescendants)
 	require.NotNil(t, filter.Page)
 	require.NotNil(t, filter.PageSize)
 
@@ -74,6 +78,8 @@ func TestNewItemFilter_ShouldParseAllSupportedFields(t *testing.T) {
 	assert.Equal(t, FoodDrinks, *filter.Categories[0])
 	assert.Equal(t, Travel, *filter.Categories[1])
 	assert.Equal(t, tagID, *filter.TagIds[0])
+	assert.True(t, *filter.IncludeDescendants)
+	assert.Equal(t, []SortField{{Name: "price", Descending: true}, {Name: "name"}}, filter.Sort)
 	assert.Equal(t, int32(2), *filter.Page)
 	assert.Equal(t, int32(50), *filter.PageSize)
 	assert.Equal(t, createdFrom, filter.CreatedFrom.UTC().Format(time.RFC3339))
@@ -136,7 +142,8 @@ func TestNewItemListingDto_ShouldMapOnlyListingFields(t *testing.T) {
 
 	assert.Equal(t, itemID, dto.Id)
 	assert.Equal(t, "Subscription", dto.Name)
-	assert.Equal(t, 99.95, dto.Price)
+	assert.True(t, decimal.RequireFromString("99.95").Equal(dto.Price.Amount))
+	assert.Equal(t, DefaultCurrency, dto.Price.Currency)
 	assert.True(t, dto.IsActive)
 	assert.Equal(t, updatedAt, *dto.UpdatedAt)
 	assert.Equal(t, int32(7), dto.Cashback)
@@ -164,6 +171,48 @@ func TestNewItemListingDto_ShouldNilUpdatedAt(t *testing.T) {
 	assert.Nil(t, dto.UpdatedAt)
 }
 
+func TestNewItemTrashDto_ShouldMapTrashFields(t *testing.T) {
+	// Arrange
+	itemID := uuid.New()
+	deletedAt := time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC)
+
+	item := Item{
+		Id:          itemID,
+		Name:        "Gym",
+		Price:       decimal.RequireFromString("25.00"),
+		Description: "Monthly pass",
+		IsActive:    true,
+		Category:    Sports,
+		DeletedAt:   sql.NullTime{Time: deletedAt, Valid: true},
+	}
+
+	// Act
+	dto := NewItemTrashDto(item)
+
+	// Assert
+	require.NotNil(t, dto)
+	assert.Equal(t, itemID, dto.Id)
+	assert.Equal(t, "Gym", dto.Name)
+	assert.True(t, decimal.RequireFromString("25.00").Equal(dto.Price.Amount))
+	assert.Equal(t, Sports, dto.Category)
+	assert.Equal(t, deletedAt, dto.DeletedAt)
+}
+
+func TestNewItemTrashFilter_ShouldParsePaging(t *testing.T) {
+	// Arrange
+	request := httptest.NewRequest("GET", "/items/trash?page=2&pageSize=50", nil)
+
+	// Act
+	filter, err := NewItemTrashFilter(request)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, filter.Page)
+	require.NotNil(t, filter.PageSize)
+	assert.Equal(t, int32(2), *filter.Page)
+	assert.Equal(t, int32(50), *filter.PageSize)
+}
+
 func TestNewItemDetailedDto_ShouldMapOnlyDetailedFields(t *testing.T) {
 	// Arrange
 	tagID := uuid.New()
@@ -184,11 +233,13 @@ func TestNewItemDetailedDto_ShouldMapOnlyDetailedFields(t *testing.T) {
 		Cashback:    7,
 		Category:    Subscriptions,
 	}
+	tagColor := "#1e88e5"
 	tags := []Tag{
 		{
 			Id:       tagID,
 			Name:     "Recurring",
 			IsActive: true,
+			Color:    &tagColor,
 		},
 	}
 	priceHistories := []PriceHistory{
@@ -210,21 +261,23 @@ func TestNewItemDetailedDto_ShouldMapOnlyDetailedFields(t *testing.T) {
 	require.Len(t, dto.Tags, 1)
 	require.Len(t, dto.PriceHistory, 2)
 	assert.Equal(t, "Subscription", dto.Name)
-	assert.Equal(t, 99.95, dto.Price)
+	assert.True(t, decimal.RequireFromString("99.95").Equal(dto.Price.Amount))
+	assert.Equal(t, DefaultCurrency, dto.Price.Currency)
 	assert.Equal(t, "Monthly", dto.Description)
 	assert.True(t, dto.IsActive)
 	assert.Equal(t, int32(7), dto.Cashback)
 	assert.Equal(t, Subscriptions, dto.Category)
 	assert.Equal(t, "Recurring", dto.Tags[0].Label)
 	assert.Equal(t, tagID.String(), dto.Tags[0].Value)
+	assert.Equal(t, &tagColor, dto.Tags[0].Color)
 	assert.Equal(t, newerPriceHistoryDate, dto.PriceHistory[0].Point)
-	assert.True(t, newerPriceHistoryValue.Equal(dto.PriceHistory[0].Value))
+	assert.True(t, newerPriceHistoryValue.Equal(dto.PriceHistory[0].Value.Amount))
 	require.NotNil(t, dto.PriceHistory[0].AbsoluteChange)
 	require.NotNil(t, dto.PriceHistory[0].PercentChange)
-	assert.True(t, expectedAbsoluteChange.Equal(*dto.PriceHistory[0].AbsoluteChange))
+	assert.True(t, expectedAbsoluteChange.Equal(dto.PriceHistory[0].AbsoluteChange.Amount))
 	assert.True(t, expectedPercentChange.Equal(*dto.PriceHistory[0].PercentChange))
 	assert.Equal(t, olderPriceHistoryDate, dto.PriceHistory[1].Point)
-	assert.True(t, olderPriceHistoryValue.Equal(dto.PriceHistory[1].Value))
+	assert.True(t, olderPriceHistoryValue.Equal(dto.PriceHistory[1].Value.Amount))
 	assert.Nil(t, dto.PriceHistory[1].AbsoluteChange)
 	assert.Nil(t, dto.PriceHistory[1].PercentChange)
 }
@@ -425,6 +478,147 @@ func TestItemUpdateValidate(t *testing.T) {
 	}
 }
 
+func TestItemPatchValidate(t *testing.T) {
+	name := "Coffee"
+	shortName := "No"
+	negativePrice := decimal.RequireFromString("-1")
+	negativeCashback := int32(-1)
+	invalidCategory := "Unknown"
+	duplicateTagID := uuid.New().String()
+	invalidTagIds := []string{"bad-uuid"}
+	duplicateTagIds := []string{duplicateTagID, duplicateTagID}
+
+	tests := []struct {
+		name        string
+		patch       ItemPatch
+		expectedErr string
+	}{
+		{
+			name:        "empty patch",
+			patch:       ItemPatch{},
+			expectedErr: "",
+		},
+		{
+			name:        "valid name",
+			patch:       ItemPatch{Name: &name},
+			expectedErr: "",
+		},
+		{
+			name:        "name too short",
+			patch:       ItemPatch{Name: &shortName},
+			expectedErr: "name must be at least 3 characters long",
+		},
+		{
+			name:        "price is negative",
+			patch:       ItemPatch{Price: &negativePrice},
+			expectedErr: "price must be zero or greater",
+		},
+		{
+			name:        "cashback is negative",
+			patch:       ItemPatch{Cashback: &negativeCashback},
+			expectedErr: "cashback must be zero or greater",
+		},
+		{
+			name:        "category is invalid",
+			patch:       ItemPatch{Category: &invalidCategory},
+			expectedErr: "category is invalid",
+		},
+		{
+			name:        "tag id is invalid",
+			patch:       ItemPatch{TagIds: &invalidTagIds},
+			expectedErr: "tagId is invalid: bad-uuid",
+		},
+		{
+			name:        "tag ids contain duplicates",
+			patch:       ItemPatch{TagIds: &duplicateTagIds},
+			expectedErr: "tagId is duplicated: " + duplicateTagID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			patch := tt.patch
+
+			// Act
+			err := patch.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestItemPatchUnmarshal_ShouldTellNullDescriptionFromAbsentOne(t *testing.T) {
+	// Arrange
+	description := "Morning drink"
+	tests := []struct {
+		name     string
+		body     string
+		expected Nullable[string]
+	}{
+		{name: "absent", body: `{"name":"Coffee"}`, expected: Nullable[string]{}},
+		{name: "null", body: `{"description":null}`, expected: Nullable[string]{Set: true}},
+		{name: "set", body: `{"description":"Morning drink"}`, expected: Nullable[string]{Set: true, Value: &description}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var patch ItemPatch
+			err := json.Unmarshal([]byte(tt.body), &patch)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, patch.Description)
+		})
+	}
+}
+
+func TestItemPatchUnmarshal_ShouldRejectNullRequiredMembers(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expectedErr string
+	}{
+		{name: "name", body: `{"name":null}`, expectedErr: "name cannot be null"},
+		{name: "price", body: `{"price":null}`, expectedErr: "price cannot be null"},
+		{name: "isActive", body: `{"isActive":null}`, expectedErr: "isActive cannot be null"},
+		{name: "cashback", body: `{"cashback":null}`, expectedErr: "cashback cannot be null"},
+		{name: "category", body: `{"category":null}`, expectedErr: "category cannot be null"},
+		{name: "tagIds", body: `{"tagIds":null}`, expectedErr: "tagIds cannot be null"},
+		{name: "other case", body: `{"Name":null}`, expectedErr: "name cannot be null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var patch ItemPatch
+
+			// Act
+			err := json.Unmarshal([]byte(tt.body), &patch)
+
+			// Assert
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestItemPatchUnmarshal_ShouldRejectUnknownMembers(t *testing.T) {
+	// Arrange
+	var patch ItemPatch
+
+	// Act
+	err := json.Unmarshal([]byte(`{"name":"Coffee","colour":"red"}`), &patch)
+
+	// Assert
+	require.EqualError(t, err, `json: unknown field "colour"`)
+}
+
 func TestItemFilterValidate(t *testing.T) {
 	page := int32(0)
 	pageSize := int32(20)
@@ -474,6 +668,47 @@ func TestItemFilterValidate(t *testing.T) {
 			},
 			expectedErr: "pageSize must be positive",
 		},
+		{
+			name: "after cursor instead of page",
+			mutate: func(filter *ItemFilter) {
+				filter.Page = nil
+				filter.After = &Cursor{CreatedAt: &createdFrom, Id: uuid.New()}
+			},
+			expectedErr: "",
+		},
+		{
+			name: "page with a cursor",
+			mutate: func(filter *ItemFilter) {
+				filter.Before = &Cursor{CreatedAt: &createdFrom, Id: uuid.New()}
+			},
+			expectedErr: "page cannot be used with after or before",
+		},
+		{
+			name: "after and before together",
+			mutate: func(filter *ItemFilter) {
+				filter.Page = nil
+				filter.After = &Cursor{CreatedAt: &createdFrom, Id: uuid.New()}
+				filter.Before = &Cursor{CreatedAt: &createdFrom, Id: uuid.New()}
+			},
+			expectedErr: "after and before cannot be used together",
+		},
+		{
+			name: "sort with a cursor",
+			mutate: func(filter *ItemFilter) {
+				filter.Page = nil
+				filter.After = &Cursor{CreatedAt: &createdFrom, Id: uuid.New()}
+				filter.Sort = []SortField{{Name: "price"}}
+			},
+			expectedErr: "sort cannot be used with after or before",
+		},
+		{
+			name: "cursor without creation time",
+			mutate: func(filter *ItemFilter) {
+				filter.Page = nil
+				filter.After = &Cursor{Id: uuid.New()}
+			},
+			expectedErr: "after is not a valid cursor",
+		},
 		{
 			name: "price range is reversed",
 			mutate: func(filter *ItemFilter) {
@@ -527,6 +762,49 @@ func TestItemFilterValidate(t *testing.T) {
 	}
 }
 
+func TestItemTrashFilterValidate(t *testing.T) {
+	page := int32(0)
+	pageSize := int32(20)
+	negative := int32(-1)
+	zero := int32(0)
+
+	tests := []struct {
+		name        string
+		filter      ItemTrashFilter
+		expectedErr string
+	}{
+		{
+			name:        "valid",
+			filter:      ItemTrashFilter{Page: &page, PageSize: &pageSize},
+			expectedErr: "",
+		},
+		{
+			name:        "page is negative",
+			filter:      ItemTrashFilter{Page: &negative, PageSize: &pageSize},
+			expectedErr: "page must be zero or greater",
+		},
+		{
+			name:        "page size is zero",
+			filter:      ItemTrashFilter{Page: &page, PageSize: &zero},
+			expectedErr: "pageSize must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.filter.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
 func TestItemCashbackByTagUpdateValidate(t *testing.T) {
 	valid := ItemCashbackByTagUpdate{
 		Cashback: 5,
@@ -730,3 +1008,294 @@ func TestValidateTagIds(t *testing.T) {
 		require.EqualError(t, err, expectedError)
 	})
 }
+
+func TestItemTagsChangeValidate(t *testing.T) {
+	duplicateTagID := uuid.New().String()
+	valid := ItemTagsChange{
+		ItemIds: []string{uuid.New().String()},
+		TagIds:  []string{uuid.New().String(), uuid.New().String()},
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(change *ItemTagsChange)
+		expectedErr string
+	}{
+		{
+			name:        "valid item ids",
+			mutate:      func(change *ItemTagsChange) {},
+			expectedErr: "",
+		},
+		{
+			name: "valid filter",
+			mutate: func(change *ItemTagsChange) {
+				change.ItemIds = nil
+				change.Filter = &ItemSelection{Categories: []ItemCategory{FoodDrinks}}
+			},
+			expectedErr: "",
+		},
+		{
+			name: "neither item ids nor filter",
+			mutate: func(change *ItemTagsChange) {
+				change.ItemIds = nil
+			},
+			expectedErr: "either itemIds or filter is required",
+		},
+		{
+			name: "item ids and filter combined",
+			mutate: func(change *ItemTagsChange) {
+				change.Filter = &ItemSelection{}
+			},
+			expectedErr: "itemIds and filter cannot be combined",
+		},
+		{
+			name: "filter without criteria",
+			mutate: func(change *ItemTagsChange) {
+				change.ItemIds = nil
+				change.Filter = &ItemSelection{}
+			},
+			expectedErr: "filter needs at least one criterion, or all set to true",
+		},
+		{
+			name: "item ids are empty",
+			mutate: func(change *ItemTagsChange) {
+				change.ItemIds = []string{}
+			},
+			expectedErr: "itemIds are empty",
+		},
+		{
+			name: "item id is invalid",
+			mutate: func(change *ItemTagsChange) {
+				change.ItemIds = []string{"bad-uuid"}
+			},
+			expectedErr: "itemId is invalid: bad-uuid",
+		},
+		{
+			name: "filter category is invalid",
+			mutate: func(change *ItemTagsChange) {
+				change.ItemIds = nil
+				change.Filter = &ItemSelection{Categories: []ItemCategory{"Unknown"}}
+			},
+			expectedErr: "category is invalid: Unknown",
+		},
+		{
+			name: "filter range is reversed",
+			mutate: func(change *ItemTagsChange) {
+				from := int32(10)
+				to := int32(5)
+				change.ItemIds = nil
+				change.Filter = &ItemSelection{CashbackFrom: &from, CashbackTo: &to}
+			},
+			expectedErr: "cashbackTo cannot be less than cashbackFrom",
+		},
+		{
+			name: "tag ids are empty",
+			mutate: func(change *ItemTagsChange) {
+				change.TagIds = nil
+			},
+			expectedErr: "tagIds are empty",
+		},
+		{
+			name: "tag ids contain duplicates",
+			mutate: func(change *ItemTagsChange) {
+				change.TagIds = []string{duplicateTagID, duplicateTagID}
+			},
+			expectedErr: "tagId is duplicated: " + duplicateTagID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			change := valid
+			change.ItemIds = append([]string(nil), valid.ItemIds...)
+			change.TagIds = append([]string(nil), valid.TagIds...)
+			tt.mutate(&change)
+
+			// Act
+			err := change.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestItemTagsChangeItemFilter(t *testing.T) {
+	t.Run("item ids", func(t *testing.T) {
+		// Arrange
+		itemID := uuid.New()
+		change := ItemTagsChange{ItemIds: []string{itemID.String()}}
+
+		// Act
+		filter := change.ItemFilter()
+
+		// Assert
+		require.Len(t, filter.Ids, 1)
+		assert.Equal(t, itemID, *filter.Ids[0])
+		assert.Empty(t, filter.Categories)
+	})
+
+	t.Run("selection", func(t *testing.T) {
+		// Arrange
+		name := "Coffee"
+		tagID := uuid.New()
+		includeDescendants := true
+		change := ItemTagsChange{Filter: &ItemSelection{
+			Name:               &name,
+			Categories:         []ItemCategory{FoodDrinks},
+			TagIds:             []uuid.UUID{tagID},
+			IncludeDescendants: &includeDescendants,
+		}}
+
+		// Act
+		filter := change.ItemFilter()
+
+		// Assert
+		assert.Empty(t, filter.Ids)
+		assert.Equal(t, &name, filter.Name)
+		require.Len(t, filter.Categories, 1)
+		assert.Equal(t, FoodDrinks, *filter.Categories[0])
+		require.Len(t, filter.TagIds, 1)
+		assert.Equal(t, tagID, *filter.TagIds[0])
+		assert.Equal(t, &includeDescendants, filter.IncludeDescendants)
+		assert.Nil(t, filter.Page)
+		assert.Nil(t, filter.PageSize)
+	})
+}
+
+func TestItemBulkChangeValidate(t *testing.T) {
+	isActive := false
+	category := Education
+	invalidCategory := ItemCategory("Unknown")
+	cashback := int32(5)
+	negativeCashback := int32(-1)
+	percent := decimal.NewFromInt(-10)
+	tooLowPercent := decimal.NewFromInt(-101)
+	name := "Coffee"
+
+	tests := []struct {
+		name        string
+		change      ItemBulkChange
+		expectedErr string
+	}{
+		{
+			name:        "valid set active",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkSetActive, IsActive: &isActive},
+			expectedErr: "",
+		},
+		{
+			name:        "valid set category",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkSetCategory, Category: &category},
+			expectedErr: "",
+		},
+		{
+			name:        "valid set cashback",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkSetCashback, Cashback: &cashback},
+			expectedErr: "",
+		},
+		{
+			name:        "valid adjust price",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkAdjustPrice, PricePercent: &percent},
+			expectedErr: "",
+		},
+		{
+			name:        "valid delete dry run",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkDelete, DryRun: true},
+			expectedErr: "",
+		},
+		{
+			name:        "filter is missing",
+			change:      ItemBulkChange{Operation: ItemBulkDelete},
+			expectedErr: "filter is required",
+		},
+		{
+			name:        "filter is invalid",
+			change:      ItemBulkChange{Filter: &ItemSelection{Categories: []ItemCategory{invalidCategory}}, Operation: ItemBulkDelete},
+			expectedErr: "category is invalid: Unknown",
+		},
+		{
+			name:        "filter without criteria",
+			change:      ItemBulkChange{Filter: &ItemSelection{}, Operation: ItemBulkDelete},
+			expectedErr: "filter needs at least one criterion, or all set to true",
+		},
+		{
+			name:        "filter ids are empty",
+			change:      ItemBulkChange{Filter: &ItemSelection{Ids: []uuid.UUID{}}, Operation: ItemBulkDelete},
+			expectedErr: "ids are empty",
+		},
+		{
+			name:        "filter tag ids are empty",
+			change:      ItemBulkChange{Filter: &ItemSelection{TagIds: []uuid.UUID{}}, Operation: ItemBulkDelete},
+			expectedErr: "tagIds are empty",
+		},
+		{
+			name:        "all combined with criteria",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true, Name: &name}, Operation: ItemBulkDelete},
+			expectedErr: "all cannot be combined with other criteria",
+		},
+		{
+			name:        "only include descendants",
+			change:      ItemBulkChange{Filter: &ItemSelection{IncludeDescendants: &isActive}, Operation: ItemBulkDelete},
+			expectedErr: "filter needs at least one criterion, or all set to true",
+		},
+		{
+			name:        "operation is empty",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}},
+			expectedErr: "operation is empty",
+		},
+		{
+			name:        "operation is invalid",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: "rename"},
+			expectedErr: "operation is invalid: rename",
+		},
+		{
+			name:        "value is missing",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkSetActive},
+			expectedErr: "isActive is required by setActive",
+		},
+		{
+			name:        "value of another operation",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkSetCashback, Cashback: &cashback, IsActive: &isActive},
+			expectedErr: "isActive is not used by setCashback",
+		},
+		{
+			name:        "value given to delete",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkDelete, Category: &category},
+			expectedErr: "category is not used by delete",
+		},
+		{
+			name:        "category is invalid",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkSetCategory, Category: &invalidCategory},
+			expectedErr: "category is invalid: Unknown",
+		},
+		{
+			name:        "cashback is negative",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkSetCashback, Cashback: &negativeCashback},
+			expectedErr: "cashback must be zero or greater",
+		},
+		{
+			name:        "price percent is below -100",
+			change:      ItemBulkChange{Filter: &ItemSelection{All: true}, Operation: ItemBulkAdjustPrice, PricePercent: &tooLowPercent},
+			expectedErr: "pricePercent must be -100 or greater",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.change.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
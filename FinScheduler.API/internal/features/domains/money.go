@@ -0,0 +1,110 @@
+package domains
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrency is the ISO 4217 code of every amount stored by the API.
+const DefaultCurrency = "RUB"
+
+// moneyScale matches the NUMERIC(16, 2) columns amounts are stored in.
+const moneyScale = 2
+
+const moneyFormatQueryParam = "moneyFormat"
+
+// MoneyFormat selects how Money is written to JSON.
+type MoneyFormat string
+
+const (
+	// MoneyFormatObject writes {"amount":"10.50","currency":"RUB"}.
+	MoneyFormatObject MoneyFormat = "object"
+	// MoneyFormatNumber writes the bare amount as a JSON number, for clients
+	// built before amounts carried a currency.
+	MoneyFormatNumber MoneyFormat = "number"
+)
+
+func (format MoneyFormat) IsValid() bool {
+	switch format {
+	case MoneyFormatObject, MoneyFormatNumber:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseMoneyFormat reads the optional moneyFormat query parameter.
+func ParseMoneyFormat(r *http.Request) (MoneyFormat, error) {
+	value := r.URL.Query().Get(moneyFormatQueryParam)
+	if value == "" {
+		return MoneyFormatObject, nil
+	}
+
+	format := MoneyFormat(value)
+	if !format.IsValid() {
+		return "", fmt.Errorf("invalid query parameter %q value %q", moneyFormatQueryParam, value)
+	}
+
+	return format, nil
+}
+
+// Money is an exact amount in a currency. The amount is serialized as a
+// string so clients never round it through a binary float.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+	format   MoneyFormat
+}
+
+type moneyObject struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func NewMoney(amount decimal.Decimal) Money {
+	return Money{Amount: amount, Currency: DefaultCurrency}
+}
+
+// WithFormat returns a copy of money that is written to JSON in format.
+func (money Money) WithFormat(format MoneyFormat) Money {
+	money.format = format
+	return money
+}
+
+func (money Money) MarshalJSON() ([]byte, error) {
+	amount := money.Amount.StringFixed(moneyScale)
+	if money.format == MoneyFormatNumber {
+		return []byte(amount), nil
+	}
+
+	return json.Marshal(moneyObject{Amount: amount, Currency: money.Currency})
+}
+
+// UnmarshalJSON accepts both representations written by MarshalJSON; a bare
+// number is taken to be in DefaultCurrency.
+func (money *Money) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var amount decimal.Decimal
+		if err := amount.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		*money = NewMoney(amount).WithFormat(MoneyFormatNumber)
+		return nil
+	}
+
+	var object moneyObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	amount, err := decimal.NewFromString(object.Amount)
+	if err != nil {
+		return fmt.Errorf("invalid money amount %q: %w", object.Amount, err)
+	}
+	*money = Money{Amount: amount, Currency: object.Currency}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package domains
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		money    Money
+		expected string
+	}{
+		{
+			name:     "object keeps the stored scale",
+			money:    NewMoney(decimal.RequireFromString("10.5")),
+			expected: `{"amount":"10.50","currency":"RUB"}`,
+		},
+		{
+			name:     "object keeps precision a float would lose",
+			money:    NewMoney(decimal.RequireFromString("12345678901234.07")),
+			expected: `{"amount":"12345678901234.07","currency":"RUB"}`,
+		},
+		{
+			name:     "number format writes the bare amount",
+			money:    NewMoney(decimal.RequireFromString("99.95")).WithFormat(MoneyFormatNumber),
+			expected: `99.95`,
+		},
+		{
+			name:     "negative change",
+			money:    NewMoney(decimal.RequireFromString("-1.2")),
+			expected: `{"amount":"-1.20","currency":"RUB"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			encoded, err := json.Marshal(tt.money)
+
+			// Assert
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(encoded))
+		})
+	}
+}
+
+func TestMoney_UnmarshalJSON_ShouldAcceptBothFormats(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             string
+		expectedAmount   string
+		expectedCurrency string
+	}{
+		{name: "object", data: `{"amount":"10.50","currency":"EUR"}`, expectedAmount: "10.50", expectedCurrency: "EUR"},
+		{name: "number", data: `10.5`, expectedAmount: "10.50", expectedCurrency: DefaultCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var money Money
+
+			// Act
+			err := json.Unmarshal([]byte(tt.data), &money)
+
+			// Assert
+			require.NoError(t, err)
+			assert.True(t, decimal.RequireFromString(tt.expectedAmount).Equal(money.Amount))
+			assert.Equal(t, tt.expectedCurrency, money.Currency)
+		})
+	}
+}
+
+func TestMoney_UnmarshalJSON_ShouldRejectInvalidAmount(t *testing.T) {
+	// Arrange
+	var money Money
+
+	// Act
+	err := json.Unmarshal([]byte(`{"amount":"ten","currency":"RUB"}`), &money)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestParseMoneyFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		expected    MoneyFormat
+		expectedErr bool
+	}{
+		{name: "default", target: "/items", expected: MoneyFormatObject},
+		{name: "object", target: "/items?moneyFormat=object", expected: MoneyFormatObject},
+		{name: "number", target: "/items?moneyFormat=number", expected: MoneyFormatNumber},
+		{name: "unknown", target: "/items?moneyFormat=float", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := httptest.NewRequest("GET", tt.target, nil)
+
+			// Act
+			format, err := ParseMoneyFormat(request)
+
+			// Assert
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestItemDetailedDto_FormatMoney_ShouldApplyToPriceHistory(t *testing.T) {
+	// Arrange
+	item := Item{Price: decimal.RequireFromString("12.00"), Category: Travel}
+	priceHistories := []PriceHistory{
+		{Value: decimal.RequireFromString("12.00")},
+		{Value: decimal.RequireFromString("10.00")},
+	}
+	dto := NewItemDetailedDto(item, nil, priceHistories)
+
+	// Act
+	dto.FormatMoney(MoneyFormatNumber)
+	encoded, err := json.Marshal(dto)
+
+	// Assert
+	require.NoError(t, err)
+	var decoded struct {
+		Price        json.RawMessage `json:"price"`
+		PriceHistory []struct {
+			Value          json.RawMessage `json:"value"`
+			AbsoluteChange json.RawMessage `json:"absoluteChange"`
+			PercentChange  json.RawMessage `json:"percentChange"`
+		} `json:"priceHistory"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, "12.00", string(decoded.Price))
+	assert.Equal(t, "12.00", string(decoded.PriceHistory[0].Value))
+	assert.Equal(t, "2.00", string(decoded.PriceHistory[0].AbsoluteChange))
+	assert.Equal(t, `"20"`, string(decoded.PriceHistory[0].PercentChange))
+	assert.Equal(t, "null", string(decoded.PriceHistory[1].AbsoluteChange))
+}
+
+func TestItemTrashDto_FormatMoney_ShouldApplyToPrice(t *testing.T) {
+	// Arrange
+	dto := NewItemTrashDto(Item{Price: decimal.RequireFromString("12.50"), Category: Travel})
+
+	// Act
+	dto.FormatMoney(MoneyFormatNumber)
+	encoded, err := json.Marshal(dto)
+
+	// Assert
+	require.NoError(t, err)
+	var decoded struct {
+		Price json.RawMessage `json:"price"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, "12.50", string(decoded.Price))
+}
@@ -17,8 +17,8 @@ type PriceHistory struct {
 
 type PriceHistoryPointDto struct {
 	Point          time.Time        `json:"point"`
-	Value          decimal.Decimal  `json:"value"`
-	AbsoluteChange *decimal.Decimal `json:"absoluteChange"`
+	Value          Money            `json:"value"`
+	AbsoluteChange *Money           `json:"absoluteChange"`
 	PercentChange  *decimal.Decimal `json:"percentChange"`
 }
 
@@ -29,7 +29,7 @@ type PriceHistoryUpsert struct {
 func NewPriceHistoryPointDto(priceHistory PriceHistory, previousPriceHistory *PriceHistory) *PriceHistoryPointDto {
 	dto := &PriceHistoryPointDto{
 		Point: priceHistory.RecordedAt,
-		Value: priceHistory.Value,
+		Value: NewMoney(priceHistory.Value),
 	}
 
 	if previousPriceHistory == nil {
@@ -37,7 +37,8 @@ func NewPriceHistoryPointDto(priceHistory PriceHistory, previousPriceHistory *Pr
 	}
 
 	absoluteChange := priceHistory.Value.Sub(previousPriceHistory.Value)
-	dto.AbsoluteChange = &absoluteChange
+	absoluteChangeMoney := NewMoney(absoluteChange)
+	dto.AbsoluteChange = &absoluteChangeMoney
 
 	if previousPriceHistory.Value.IsZero() {
 		return dto
@@ -49,6 +50,16 @@ func NewPriceHistoryPointDto(priceHistory PriceHistory, previousPriceHistory *Pr
 	return dto
 }
 
+// FormatMoney sets the JSON representation of the value and its change.
+// PercentChange is a ratio rather than an amount and keeps its format.
+func (dto *PriceHistoryPointDto) FormatMoney(format MoneyFormat) {
+	dto.Value = dto.Value.WithFormat(format)
+	if dto.AbsoluteChange != nil {
+		absoluteChange := dto.AbsoluteChange.WithFormat(format)
+		dto.AbsoluteChange = &absoluteChange
+	}
+}
+
 func (priceHistory *PriceHistoryUpsert) Validate() error {
 	if priceHistory.Value.IsNegative() {
 		return fmt.Errorf("value must be zero or greater")
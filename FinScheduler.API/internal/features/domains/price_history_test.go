@@ -36,10 +36,10 @@ func TestNewPriceHistoryPointDto_ShouldMapGraphFieldsAndChangesAgainstPreviousPo
 	// Assert
 	require.NotNil(t, dto)
 	assert.Equal(t, recordedAt, dto.Point)
-	assert.True(t, expectedValue.Equal(dto.Value))
+	assert.True(t, expectedValue.Equal(dto.Value.Amount))
 	require.NotNil(t, dto.AbsoluteChange)
 	require.NotNil(t, dto.PercentChange)
-	assert.True(t, expectedAbsoluteChange.Equal(*dto.AbsoluteChange))
+	assert.True(t, expectedAbsoluteChange.Equal(dto.AbsoluteChange.Amount))
 	assert.True(t, expectedPercentChange.Equal(*dto.PercentChange))
 }
 
@@ -60,7 +60,7 @@ func TestNewPriceHistoryPointDto_ShouldLeaveChangesNilWhenPreviousPointIsMissing
 	// Assert
 	require.NotNil(t, dto)
 	assert.Equal(t, recordedAt, dto.Point)
-	assert.True(t, expectedValue.Equal(dto.Value))
+	assert.True(t, expectedValue.Equal(dto.Value.Amount))
 	assert.Nil(t, dto.AbsoluteChange)
 	assert.Nil(t, dto.PercentChange)
 }
@@ -87,7 +87,7 @@ func TestNewPriceHistoryPointDto_ShouldLeavePercentChangeNilWhenPreviousValueIsZ
 	// Assert
 	require.NotNil(t, dto)
 	require.NotNil(t, dto.AbsoluteChange)
-	assert.True(t, expectedAbsoluteChange.Equal(*dto.AbsoluteChange))
+	assert.True(t, expectedAbsoluteChange.Equal(dto.AbsoluteChange.Amount))
 	assert.Nil(t, dto.PercentChange)
 }
 
@@ -1,19 +1,157 @@
 package domains
 
-import "errors"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 var ErrInvalidReference = errors.New("invalid reference")
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ErrUnauthenticated means a service was called without a caller in the
+// context, i.e. outside of the authentication middleware.
+var ErrUnauthenticated = errors.New("caller is not authenticated")
 
+// PaginatedList is a page of a listing. Count is left out when the caller
+// skipped counting, and Next and Previous are the cursors of the pages
+// around it in a keyset-paginated listing, left out at either end.
 type PaginatedList[T any] struct {
-	Data  []T   `json:"data"`
-	Count int64 `json:"count"`
+	Data     []T     `json:"data"`
+	Count    *int64  `json:"count,omitempty"`
+	Next     *string `json:"next,omitempty"`
+	Previous *string `json:"previous,omitempty"`
 }
 
 func NewPaginatedList[T any](data []T, count int64) *PaginatedList[T] {
-	return &PaginatedList[T]{Data: data, Count: count}
+	return &PaginatedList[T]{Data: data, Count: &count}
+}
+
+func NewCursorPaginatedList[T any](data []T, page PageInfo) *PaginatedList[T] {
+	return &PaginatedList[T]{
+		Data:     data,
+		Count:    page.Count,
+		Next:     page.Next.Token(),
+		Previous: page.Previous.Token(),
+	}
+}
+
+// Nullable is a member of a JSON Merge Patch document that can be cleared.
+// Set tells a member that is present, possibly null, from an absent one.
+type Nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (member *Nullable[T]) UnmarshalJSON(data []byte) error {
+	member.Set = true
+	member.Value = nil
+	if string(data) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(data, &member.Value)
+}
+
+// Cursor is the position of a row in a listing ordered by creation time and
+// id, newest first. Listings ordered by id alone leave CreatedAt nil. Clients
+// only ever see it as an opaque token.
+type Cursor struct {
+	CreatedAt *time.Time `json:"c,omitempty"`
+	Id        uuid.UUID  `json:"i"`
 }
 
-type Lookup struct {
-	Value string `json:"value" db:"value"`
-	Label string `json:"label" db:"label"`
+// PageInfo describes a listing page besides its rows: the total count, nil
+// when the caller skipped it, and the cursors to the next and previous pages,
+// nil when there are none.
+type PageInfo struct {
+	Count    *int64
+	Next     *Cursor
+	Previous *Cursor
+}
+
+// Token encodes the cursor for a client, or returns nil for a nil cursor.
+func (cursor *Cursor) Token() *string {
+	if cursor == nil {
+		return nil
+	}
+
+	encoded, _ := json.Marshal(cursor)
+	token := base64.RawURLEncoding.EncodeToString(encoded)
+	return &token
+}
+
+// ParseCursor reads the optional cursor token of the key query parameter.
+func ParseCursor(queryParams url.Values, key string) (*Cursor, error) {
+	token := queryParams.Get(key)
+	if token == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid cursor", key)
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil || cursor.Id == uuid.Nil {
+		return nil, fmt.Errorf("%s is not a valid cursor", key)
+	}
+
+	return &cursor, nil
+}
+
+// validateKeyset checks the paging parameters of a listing that accepts
+// either a page index or one of the after and before cursors.
+func validateKeyset(page *int32, pageSize *int32, after *Cursor, before *Cursor) error {
+	if after != nil && before != nil {
+		return fmt.Errorf("after and before cannot be used together")
+	}
+	if (after != nil || before != nil) && page != nil {
+		return fmt.Errorf("page cannot be used with after or before")
+	}
+	if after == nil && before == nil && (page == nil || *page < 0) {
+		return fmt.Errorf("page must be zero or greater")
+	}
+	if pageSize == nil || *pageSize <= 0 {
+		return fmt.Errorf("pageSize must be positive")
+	}
+
+	return nil
+}
+
+// SortField is one key of a client-selected listing order.
+type SortField struct {
+	Name       string
+	Descending bool
+}
+
+// ParseSort reads the optional comma-separated sort query parameter, such as
+// price,-updatedAt, where a leading dash sorts the field descending. Fields
+// outside allowed are rejected, so repositories only ever see known names.
+func ParseSort(queryParams url.Values, allowed []string) ([]SortField, error) {
+	param := queryParams.Get("sort")
+	if param == "" {
+		return nil, nil
+	}
+
+	fields := make([]SortField, 0)
+	for _, name := range strings.Split(param, ",") {
+		field := SortField{Name: strings.TrimPrefix(name, "-"), Descending: strings.HasPrefix(name, "-")}
+		if !slices.Contains(allowed, field.Name) {
+			return nil, fmt.Errorf("sort field %q is not one of %s", field.Name, strings.Join(allowed, ", "))
+		}
+		if slices.ContainsFunc(fields, func(other SortField) bool { return other.Name == field.Name }) {
+			return nil, fmt.Errorf("sort field %q is repeated", field.Name)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
 }
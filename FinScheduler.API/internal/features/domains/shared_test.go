@@ -1,7 +1,11 @@
 package domains
 
 import (
+	"net/url"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,5 +22,102 @@ func TestNewPaginatedList_ShouldReturnListWithProvidedDataAndCount(t *testing.T)
 	// Assert
 	require.NotNil(t, result)
 	assert.Equal(t, data, result.Data)
-	assert.Equal(t, count, result.Count)
+	require.NotNil(t, result.Count)
+	assert.Equal(t, count, *result.Count)
+}
+
+func TestNewCursorPaginatedList_ShouldEncodeCursorsAndKeepMissingCount(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2026, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	next := Cursor{CreatedAt: &createdAt, Id: uuid.New()}
+	page := PageInfo{Next: &next}
+
+	// Act
+	result := NewCursorPaginatedList([]string{"first"}, page)
+	parsed, parseErr := ParseCursor(url.Values{"after": {*result.Next}}, "after")
+
+	// Assert
+	require.NoError(t, parseErr)
+	assert.Nil(t, result.Count)
+	assert.Nil(t, result.Previous)
+	require.NotNil(t, parsed)
+	assert.Equal(t, next.Id, parsed.Id)
+	require.NotNil(t, parsed.CreatedAt)
+	assert.True(t, createdAt.Equal(*parsed.CreatedAt))
+}
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       url.Values
+		expectedErr string
+	}{
+		{name: "missing", query: url.Values{}},
+		{name: "not base64", query: url.Values{"before": {"not a cursor"}}, expectedErr: "before is not a valid cursor"},
+		{name: "not json", query: url.Values{"before": {"bm90IGpzb24"}}, expectedErr: "before is not a valid cursor"},
+		{name: "without id", query: url.Values{"before": {"e30"}}, expectedErr: "before is not a valid cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			cursor, err := ParseCursor(tt.query, "before")
+
+			// Assert
+			assert.Nil(t, cursor)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"name", "price", "updatedAt"}
+
+	tests := []struct {
+		name          string
+		query         url.Values
+		expected      []SortField
+		expectedError string
+	}{
+		{name: "missing", query: url.Values{}},
+		{
+			name:     "ascending and descending fields",
+			query:    url.Values{"sort": {"price,-updatedAt,name"}},
+			expected: []SortField{{Name: "price"}, {Name: "updatedAt", Descending: true}, {Name: "name"}},
+		},
+		{
+			name:          "unknown field",
+			query:         url.Values{"sort": {"price,-id"}},
+			expectedError: `sort field "id" is not one of name, price, updatedAt`,
+		},
+		{
+			name:          "empty field",
+			query:         url.Values{"sort": {"price,"}},
+			expectedError: `sort field "" is not one of name, price, updatedAt`,
+		},
+		{
+			name:          "repeated field",
+			query:         url.Values{"sort": {"price,-price"}},
+			expectedError: `sort field "price" is repeated`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			fields, err := ParseSort(tt.query, allowed)
+
+			// Assert
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedError)
+			}
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
 }
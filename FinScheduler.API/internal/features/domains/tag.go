@@ -1,36 +1,127 @@
 package domains
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"finscheduler/pkg/qh"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
+// ErrTagInUse means a tag still linked to items was deleted without force.
+var ErrTagInUse = errors.New("tag is still linked to items")
+
+// ErrTagMergeIntoItself means the source and target of a merge are the same
+// tag.
+var ErrTagMergeIntoItself = errors.New("a tag cannot be merged into itself")
+
+// ErrTagInactive means links were to be moved to an inactive tag, which never
+// has any.
+var ErrTagInactive = errors.New("tag is inactive")
+
+// ErrTagCycle means a tag was to be nested under itself or one of its
+// descendants.
+var ErrTagCycle = errors.New("a tag cannot be nested under itself or its descendants")
+
+var (
+	tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	tagIconPattern  = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+const tagIconMaxLength = 64
+
 type Tag struct {
-	Id       uuid.UUID `db:"id"`
-	Name     string    `db:"name"`
-	IsActive bool      `db:"is_active"`
+	Id          uuid.UUID  `db:"id"`
+	HouseholdId uuid.UUID  `db:"household_id"`
+	Name        string     `db:"name"`
+	IsActive    bool       `db:"is_active"`
+	Version     int32      `db:"version"`
+	ParentId    *uuid.UUID `db:"parent_id"`
+	Color       *string    `db:"color"`
+	Icon        *string    `db:"icon"`
+	Description string     `db:"description"`
 }
 
 type TagListingDto struct {
-	Id       uuid.UUID `json:"id"`
-	Name     string    `json:"name"`
-	IsActive bool      `json:"isActive"`
+	Id          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	IsActive    bool       `json:"isActive"`
+	ParentId    *uuid.UUID `json:"parentId"`
+	Color       *string    `json:"color"`
+	Icon        *string    `json:"icon"`
+	Description string     `json:"description"`
+	// Stats is only filled in when the listing is asked for withStats.
+	Stats *TagStatsDto `json:"stats,omitempty"`
 }
 
 type TagDetailedDto struct {
-	Name     string `json:"name"`
-	IsActive bool   `json:"isActive"`
+	Name        string     `json:"name"`
+	IsActive    bool       `json:"isActive"`
+	ParentId    *uuid.UUID `json:"parentId"`
+	Color       *string    `json:"color"`
+	Icon        *string    `json:"icon"`
+	Description string     `json:"description"`
+	Version     int32      `json:"-"`
 }
 
+// TagLookupDto is a tag in a selector or on an item, with what it takes to
+// render it as a chip.
+type TagLookupDto struct {
+	Value       string  `json:"value" db:"value"`
+	Label       string  `json:"label" db:"label"`
+	Color       *string `json:"color" db:"color"`
+	Icon        *string `json:"icon" db:"icon"`
+	Description string  `json:"description" db:"description"`
+}
+
+// TagSortFields are the fields a tag listing can be sorted by.
+var TagSortFields = []string{"name", "isActive"}
+
 type TagFilter struct {
-	Ids      []*uuid.UUID
-	Name     *string
-	IsActive *bool
-	Page     *int32
-	PageSize *int32
+	Ids       []*uuid.UUID
+	Name      *string
+	IsActive  *bool
+	WithStats *bool
+	Page      *int32
+	PageSize  *int32
+	// After and Before page through the listing by cursor instead of Page.
+	After  *Cursor
+	Before *Cursor
+	// IncludeCount, true unless set, runs the count of matching tags.
+	IncludeCount *bool
+	// Sort replaces the newest-first order; it cannot be paged by cursor.
+	Sort []SortField
+}
+
+// TagStats sums up the items linked to a tag, leaving out the trash.
+// AveragePrice is null and the sums are zero for a tag without items.
+type TagStats struct {
+	TagId             uuid.UUID           `db:"tag_id"`
+	ItemCount         int64               `db:"item_count"`
+	ActiveItemCount   int64               `db:"active_item_count"`
+	TotalPrice        decimal.Decimal     `db:"total_price"`
+	AveragePrice      decimal.NullDecimal `db:"average_price"`
+	TotalCashback     decimal.Decimal     `db:"total_cashback"`
+	LastItemUpdatedAt sql.NullTime        `db:"last_item_updated_at"`
+}
+
+// TagStatsDto is the usage of a tag. TotalCashback is what the linked items
+// pay back when each is bought once at its price, with cashback as a percent.
+type TagStatsDto struct {
+	ItemCount         int64      `json:"itemCount"`
+	ActiveItemCount   int64      `json:"activeItemCount"`
+	TotalPrice        Money      `json:"totalPrice"`
+	AveragePrice      *Money     `json:"averagePrice"`
+	TotalCashback     Money      `json:"totalCashback"`
+	LastItemUpdatedAt *time.Time `json:"lastItemUpdatedAt"`
 }
 
 type TagLookupFilter struct {
@@ -39,14 +130,81 @@ type TagLookupFilter struct {
 	PageSize *int32
 }
 
+// TagCreate and TagUpdate place the tag under ParentId, or at the top level
+// when it is nil. Color is a #rrggbb hex colour and Icon a lowercase,
+// dash-separated icon key; both are optional.
 type TagCreate struct {
-	Name     string `json:"name"`
-	IsActive bool   `json:"isActive"`
+	Name        string  `json:"name"`
+	IsActive    bool    `json:"isActive"`
+	ParentId    *string `json:"parentId"`
+	Color       *string `json:"color"`
+	Icon        *string `json:"icon"`
+	Description string  `json:"description"`
 }
 
 type TagUpdate struct {
-	Name     string `json:"name"`
-	IsActive bool   `json:"isActive"`
+	Name        string  `json:"name"`
+	IsActive    bool    `json:"isActive"`
+	ParentId    *string `json:"parentId"`
+	Color       *string `json:"color"`
+	Icon        *string `json:"icon"`
+	Description string  `json:"description"`
+}
+
+// TagPatch is a JSON Merge Patch (RFC 7396) document for a tag. Absent
+// members leave the stored value untouched. A null parentId moves the tag to
+// the top level, a null color or icon clears it, and a null description
+// empties it; name and isActive cannot be null.
+type TagPatch struct {
+	Name        *string          `json:"name"`
+	IsActive    *bool            `json:"isActive"`
+	ParentId    TagParent        `json:"parentId"`
+	Color       Nullable[string] `json:"color"`
+	Icon        Nullable[string] `json:"icon"`
+	Description Nullable[string] `json:"description"`
+}
+
+// tagPatchRequiredMembers are the TagPatch members that name NOT NULL columns
+// without a neutral value, and so cannot be cleared with null.
+var tagPatchRequiredMembers = []string{"name", "isActive"}
+
+// UnmarshalJSON decodes the patch, unknown members included as errors, and
+// rejects a null for any of the required members.
+func (tag *TagPatch) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for name, value := range members {
+		for _, required := range tagPatchRequiredMembers {
+			if strings.EqualFold(name, required) && string(value) == "null" {
+				return fmt.Errorf("%s cannot be null", required)
+			}
+		}
+	}
+
+	type tagPatch TagPatch
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode((*tagPatch)(tag))
+}
+
+// TagParent is the parentId member of a TagPatch. Set tells a member that is
+// present, possibly null, from an absent one.
+type TagParent struct {
+	Set bool
+	Id  *string
+}
+
+func (parent *TagParent) UnmarshalJSON(data []byte) error {
+	parent.Set = true
+	parent.Id = nil
+	if string(data) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(data, &parent.Id)
 }
 
 func NewTagFilter(r *http.Request) (TagFilter, error) {
@@ -61,6 +219,10 @@ func NewTagFilter(r *http.Request) (TagFilter, error) {
 	if err != nil {
 		return TagFilter{}, err
 	}
+	withStats, err := qh.ParseBool(queryParams, "withStats")
+	if err != nil {
+		return TagFilter{}, err
+	}
 	page, err := qh.ParseInt32(queryParams, "page")
 	if err != nil {
 		return TagFilter{}, err
@@ -69,13 +231,34 @@ func NewTagFilter(r *http.Request) (TagFilter, error) {
 	if err != nil {
 		return TagFilter{}, err
 	}
+	after, err := ParseCursor(queryParams, "after")
+	if err != nil {
+		return TagFilter{}, err
+	}
+	before, err := ParseCursor(queryParams, "before")
+	if err != nil {
+		return TagFilter{}, err
+	}
+	includeCount, err := qh.ParseBool(queryParams, "includeCount")
+	if err != nil {
+		return TagFilter{}, err
+	}
+	sort, err := ParseSort(queryParams, TagSortFields)
+	if err != nil {
+		return TagFilter{}, err
+	}
 
 	return TagFilter{
-		Ids:      ids,
-		Name:     name,
-		IsActive: isActive,
-		Page:     page,
-		PageSize: pageSize,
+		Ids:          ids,
+		Name:         name,
+		IsActive:     isActive,
+		WithStats:    withStats,
+		Page:         page,
+		PageSize:     pageSize,
+		After:        after,
+		Before:       before,
+		IncludeCount: includeCount,
+		Sort:         sort,
 	}, nil
 }
 
@@ -99,18 +282,89 @@ func NewTagLookupFilter(r *http.Request) (TagLookupFilter, error) {
 	}, nil
 }
 
+// ParseTagDeleteForce reads the optional force query parameter of a tag
+// deletion, which defaults to false.
+func ParseTagDeleteForce(r *http.Request) (bool, error) {
+	force, err := qh.ParseBool(r.URL.Query(), "force")
+	if err != nil {
+		return false, err
+	}
+
+	return force != nil && *force, nil
+}
+
 func NewTagListingDto(tag Tag) *TagListingDto {
 	return &TagListingDto{
-		Id:       tag.Id,
-		Name:     tag.Name,
-		IsActive: tag.IsActive,
+		Id:          tag.Id,
+		Name:        tag.Name,
+		IsActive:    tag.IsActive,
+		ParentId:    tag.ParentId,
+		Color:       tag.Color,
+		Icon:        tag.Icon,
+		Description: tag.Description,
 	}
 }
 
 func NewTagDetailedDto(tag Tag) *TagDetailedDto {
 	return &TagDetailedDto{
-		Name:     tag.Name,
-		IsActive: tag.IsActive,
+		Name:        tag.Name,
+		IsActive:    tag.IsActive,
+		ParentId:    tag.ParentId,
+		Color:       tag.Color,
+		Icon:        tag.Icon,
+		Description: tag.Description,
+		Version:     tag.Version,
+	}
+}
+
+func NewTagStatsDto(stats TagStats) *TagStatsDto {
+	var averagePrice *Money
+	if stats.AveragePrice.Valid {
+		average := NewMoney(stats.AveragePrice.Decimal)
+		averagePrice = &average
+	}
+
+	var lastItemUpdatedAt *time.Time
+	if stats.LastItemUpdatedAt.Valid {
+		lastItemUpdatedAt = &stats.LastItemUpdatedAt.Time
+	}
+
+	return &TagStatsDto{
+		ItemCount:         stats.ItemCount,
+		ActiveItemCount:   stats.ActiveItemCount,
+		TotalPrice:        NewMoney(stats.TotalPrice),
+		AveragePrice:      averagePrice,
+		TotalCashback:     NewMoney(stats.TotalCashback),
+		LastItemUpdatedAt: lastItemUpdatedAt,
+	}
+}
+
+// FormatMoney sets the JSON representation of every amount in the DTO.
+func (dto *TagStatsDto) FormatMoney(format MoneyFormat) {
+	dto.TotalPrice = dto.TotalPrice.WithFormat(format)
+	dto.TotalCashback = dto.TotalCashback.WithFormat(format)
+	if dto.AveragePrice != nil {
+		averagePrice := dto.AveragePrice.WithFormat(format)
+		dto.AveragePrice = &averagePrice
+	}
+}
+
+// FormatMoney sets the JSON representation of the amounts in the stats of
+// the DTO, if any.
+func (dto *TagListingDto) FormatMoney(format MoneyFormat) {
+	if dto.Stats != nil {
+		dto.Stats.FormatMoney(format)
+	}
+}
+
+// NewTagLookupDto labels a tag with its name.
+func NewTagLookupDto(tag Tag) TagLookupDto {
+	return TagLookupDto{
+		Value:       tag.Id.String(),
+		Label:       tag.Name,
+		Color:       tag.Color,
+		Icon:        tag.Icon,
+		Description: tag.Description,
 	}
 }
 
@@ -118,24 +372,71 @@ func (item *TagCreate) Validate() error {
 	if len(item.Name) < 3 {
 		return fmt.Errorf("name must be at least 3 characters long")
 	}
+	if err := validateTagAppearance(item.Color, item.Icon); err != nil {
+		return err
+	}
 
-	return nil
+	return validateParentId(item.ParentId)
 }
 
 func (item *TagUpdate) Validate() error {
 	if len(item.Name) < 3 {
 		return fmt.Errorf("name must be at least 3 characters long")
 	}
+	if err := validateTagAppearance(item.Color, item.Icon); err != nil {
+		return err
+	}
+
+	return validateParentId(item.ParentId)
+}
+
+func (item *TagPatch) Validate() error {
+	if item.Name != nil && len(*item.Name) < 3 {
+		return fmt.Errorf("name must be at least 3 characters long")
+	}
+	if err := validateTagAppearance(item.Color.Value, item.Icon.Value); err != nil {
+		return err
+	}
+
+	return validateParentId(item.ParentId.Id)
+}
+
+func validateTagAppearance(color *string, icon *string) error {
+	if color != nil && !tagColorPattern.MatchString(*color) {
+		return fmt.Errorf("color must be a hex colour like #1e88e5")
+	}
+	if icon != nil && (len(*icon) > tagIconMaxLength || !tagIconPattern.MatchString(*icon)) {
+		return fmt.Errorf("icon must be up to %d lowercase letters, digits and dashes", tagIconMaxLength)
+	}
 
 	return nil
 }
 
+// ParseParentId returns the parent named by a validated parentId member, or
+// nil for the top level.
+func ParseParentId(parentId *string) *uuid.UUID {
+	if parentId == nil {
+		return nil
+	}
+
+	parsed := uuid.MustParse(*parentId)
+	return &parsed
+}
+
+func validateParentId(parentId *string) error {
+	if parentId == nil {
+		return nil
+	}
+
+	return validateRequiredUUID(*parentId, "parentId")
+}
+
 func (item *TagFilter) Validate() error {
-	if item.Page == nil || *item.Page < 0 {
-		return fmt.Errorf("page must be zero or greater")
+	if err := validateKeyset(item.Page, item.PageSize, item.After, item.Before); err != nil {
+		return err
 	}
-	if item.PageSize == nil || *item.PageSize <= 0 {
-		return fmt.Errorf("pageSize must be positive")
+	if len(item.Sort) > 0 && (item.After != nil || item.Before != nil) {
+		return fmt.Errorf("sort cannot be used with after or before")
 	}
 
 	return nil
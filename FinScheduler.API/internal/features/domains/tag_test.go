@@ -1,10 +1,14 @@
 package domains
 
 import (
+	"database/sql"
+	"encoding/json"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -17,6 +21,7 @@ func TestNewTagsFilter_ShouldParseAllSupportedFields(t *testing.T) {
 		"&ids=" + secondID.String() +
 		"&name=groceries" +
 		"&isActive=true" +
+		"&withStats=true" +
 		"&page=2" +
 		"&pageSize=25"
 	request := httptest.NewRequest("GET", requestURL, nil)
@@ -29,6 +34,7 @@ func TestNewTagsFilter_ShouldParseAllSupportedFields(t *testing.T) {
 	require.Len(t, filter.Ids, 2)
 	require.NotNil(t, filter.Name)
 	require.NotNil(t, filter.IsActive)
+	require.NotNil(t, filter.WithStats)
 	require.NotNil(t, filter.Page)
 	require.NotNil(t, filter.PageSize)
 
@@ -36,6 +42,7 @@ func TestNewTagsFilter_ShouldParseAllSupportedFields(t *testing.T) {
 	assert.Equal(t, secondID, *filter.Ids[1])
 	assert.Equal(t, "groceries", *filter.Name)
 	assert.True(t, *filter.IsActive)
+	assert.True(t, *filter.WithStats)
 	assert.Equal(t, int32(2), *filter.Page)
 	assert.Equal(t, int32(25), *filter.PageSize)
 }
@@ -116,10 +123,17 @@ func TestNewTagLookupFilter_ShouldReturnErrorOnInvalidQueryParam(t *testing.T) {
 func TestNewTagListingDto_ShouldMapTagFields(t *testing.T) {
 	// Arrange
 	tagID := uuid.New()
+	parentID := uuid.New()
+	color := "#1e88e5"
+	icon := "repeat"
 	tag := Tag{
-		Id:       tagID,
-		Name:     "Recurring",
-		IsActive: true,
+		Id:          tagID,
+		Name:        "Recurring",
+		IsActive:    true,
+		ParentId:    &parentID,
+		Color:       &color,
+		Icon:        &icon,
+		Description: "Monthly payments",
 	}
 
 	// Act
@@ -131,6 +145,32 @@ func TestNewTagListingDto_ShouldMapTagFields(t *testing.T) {
 	assert.Equal(t, tagID, dto.Id)
 	assert.Equal(t, "Recurring", dto.Name)
 	assert.True(t, dto.IsActive)
+	assert.Equal(t, &parentID, dto.ParentId)
+	assert.Equal(t, &color, dto.Color)
+	assert.Equal(t, &icon, dto.Icon)
+	assert.Equal(t, "Monthly payments", dto.Description)
+}
+
+func TestNewTagLookupDto_ShouldMapAppearance(t *testing.T) {
+	// Arrange
+	tagID := uuid.New()
+	color := "#1e88e5"
+	tag := Tag{
+		Id:          tagID,
+		Name:        "Recurring",
+		Color:       &color,
+		Description: "Monthly payments",
+	}
+
+	// Act
+	dto := NewTagLookupDto(tag)
+
+	// Assert
+	assert.Equal(t, tagID.String(), dto.Value)
+	assert.Equal(t, "Recurring", dto.Label)
+	assert.Equal(t, &color, dto.Color)
+	assert.Nil(t, dto.Icon)
+	assert.Equal(t, "Monthly payments", dto.Description)
 }
 
 func TestNewTagDetailedDto_ShouldMapOnlyDetailedFields(t *testing.T) {
@@ -150,7 +190,58 @@ func TestNewTagDetailedDto_ShouldMapOnlyDetailedFields(t *testing.T) {
 	assert.True(t, dto.IsActive)
 }
 
+func TestNewTagStatsDto_ShouldMapStats(t *testing.T) {
+	t.Run("tag with items", func(t *testing.T) {
+		// Arrange
+		updatedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+		stats := TagStats{
+			ItemCount:         3,
+			ActiveItemCount:   2,
+			TotalPrice:        decimal.RequireFromString("30.00"),
+			AveragePrice:      decimal.NullDecimal{Decimal: decimal.RequireFromString("10.00"), Valid: true},
+			TotalCashback:     decimal.RequireFromString("1.50"),
+			LastItemUpdatedAt: sql.NullTime{Time: updatedAt, Valid: true},
+		}
+
+		// Act
+		dto := NewTagStatsDto(stats)
+
+		// Assert
+		require.NotNil(t, dto)
+		require.NotNil(t, dto.AveragePrice)
+		require.NotNil(t, dto.LastItemUpdatedAt)
+		assert.Equal(t, int64(3), dto.ItemCount)
+		assert.Equal(t, int64(2), dto.ActiveItemCount)
+		assert.True(t, decimal.RequireFromString("30").Equal(dto.TotalPrice.Amount))
+		assert.True(t, decimal.RequireFromString("10").Equal(dto.AveragePrice.Amount))
+		assert.True(t, decimal.RequireFromString("1.5").Equal(dto.TotalCashback.Amount))
+		assert.Equal(t, DefaultCurrency, dto.TotalPrice.Currency)
+		assert.Equal(t, updatedAt, *dto.LastItemUpdatedAt)
+	})
+
+	t.Run("tag without items", func(t *testing.T) {
+		// Arrange
+		stats := TagStats{TotalPrice: decimal.Zero, TotalCashback: decimal.Zero}
+
+		// Act
+		dto := NewTagStatsDto(stats)
+
+		// Assert
+		require.NotNil(t, dto)
+		assert.Equal(t, int64(0), dto.ItemCount)
+		assert.Nil(t, dto.AveragePrice)
+		assert.Nil(t, dto.LastItemUpdatedAt)
+	})
+}
+
 func TestTagCreateValidate(t *testing.T) {
+	validParentID := uuid.New().String()
+	invalidParentID := "home"
+	validColor := "#1E88e5"
+	shortColor := "#fff"
+	validIcon := "flash-on"
+	invalidIcon := "Flash On"
+
 	tests := []struct {
 		name          string
 		tagCreate     TagCreate
@@ -172,6 +263,48 @@ func TestTagCreateValidate(t *testing.T) {
 			},
 			expectedError: "name must be at least 3 characters long",
 		},
+		{
+			name: "valid parent",
+			tagCreate: TagCreate{
+				Name:     "Utilities",
+				ParentId: &validParentID,
+			},
+			expectedError: "",
+		},
+		{
+			name: "invalid parent",
+			tagCreate: TagCreate{
+				Name:     "Utilities",
+				ParentId: &invalidParentID,
+			},
+			expectedError: "parentId is invalid: home",
+		},
+		{
+			name: "valid appearance",
+			tagCreate: TagCreate{
+				Name:        "Power",
+				Color:       &validColor,
+				Icon:        &validIcon,
+				Description: "Electricity bills",
+			},
+			expectedError: "",
+		},
+		{
+			name: "color not a six digit hex",
+			tagCreate: TagCreate{
+				Name:  "Power",
+				Color: &shortColor,
+			},
+			expectedError: "color must be a hex colour like #1e88e5",
+		},
+		{
+			name: "icon not a key",
+			tagCreate: TagCreate{
+				Name: "Power",
+				Icon: &invalidIcon,
+			},
+			expectedError: "icon must be up to 64 lowercase letters, digits and dashes",
+		},
 	}
 
 	for _, tt := range tests {
@@ -234,6 +367,203 @@ func TestTagUpdateValidate(t *testing.T) {
 	}
 }
 
+func TestTagPatchValidate(t *testing.T) {
+	validName := "Transport"
+	shortName := "No"
+	isActive := false
+	nilParentID := uuid.Nil.String()
+	invalidColor := "green"
+
+	tests := []struct {
+		name          string
+		tagPatch      TagPatch
+		expectedError string
+	}{
+		{
+			name:          "empty tag patch",
+			tagPatch:      TagPatch{},
+			expectedError: "",
+		},
+		{
+			name: "valid tag patch",
+			tagPatch: TagPatch{
+				Name:     &validName,
+				IsActive: &isActive,
+			},
+			expectedError: "",
+		},
+		{
+			name: "name too short",
+			tagPatch: TagPatch{
+				Name: &shortName,
+			},
+			expectedError: "name must be at least 3 characters long",
+		},
+		{
+			name: "parent moved to top level",
+			tagPatch: TagPatch{
+				ParentId: TagParent{Set: true},
+			},
+			expectedError: "",
+		},
+		{
+			name: "nil parent",
+			tagPatch: TagPatch{
+				ParentId: TagParent{Set: true, Id: &nilParentID},
+			},
+			expectedError: "parentId is nil",
+		},
+		{
+			name: "invalid color",
+			tagPatch: TagPatch{
+				Color: Nullable[string]{Set: true, Value: &invalidColor},
+			},
+			expectedError: "color must be a hex colour like #1e88e5",
+		},
+		{
+			name: "color and icon cleared",
+			tagPatch: TagPatch{
+				Color: Nullable[string]{Set: true},
+				Icon:  Nullable[string]{Set: true},
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tagPatch := tt.tagPatch
+
+			// Act
+			err := tagPatch.Validate()
+
+			// Assert
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestTagPatchUnmarshal_ShouldTellNullParentFromAbsentOne(t *testing.T) {
+	// Arrange
+	parentID := uuid.New().String()
+	tests := []struct {
+		name     string
+		body     string
+		expected TagParent
+	}{
+		{name: "absent", body: `{"name":"Power"}`, expected: TagParent{}},
+		{name: "null", body: `{"parentId":null}`, expected: TagParent{Set: true}},
+		{name: "set", body: `{"parentId":"` + parentID + `"}`, expected: TagParent{Set: true, Id: &parentID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var patch TagPatch
+			err := json.Unmarshal([]byte(tt.body), &patch)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, patch.ParentId)
+		})
+	}
+}
+
+func TestTagPatchUnmarshal_ShouldTellNullAppearanceFromAbsentOne(t *testing.T) {
+	// Arrange
+	color := "#1e88e5"
+	tests := []struct {
+		name          string
+		body          string
+		expectedColor Nullable[string]
+		expectedIcon  Nullable[string]
+	}{
+		{name: "absent", body: `{"name":"Power"}`},
+		{name: "null", body: `{"color":null,"icon":null}`, expectedColor: Nullable[string]{Set: true}, expectedIcon: Nullable[string]{Set: true}},
+		{name: "set", body: `{"color":"#1e88e5"}`, expectedColor: Nullable[string]{Set: true, Value: &color}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var patch TagPatch
+			err := json.Unmarshal([]byte(tt.body), &patch)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedColor, patch.Color)
+			assert.Equal(t, tt.expectedIcon, patch.Icon)
+		})
+	}
+}
+
+func TestTagPatchUnmarshal_ShouldTellNullDescriptionFromAbsentOne(t *testing.T) {
+	// Arrange
+	description := "Electricity bills"
+	tests := []struct {
+		name     string
+		body     string
+		expected Nullable[string]
+	}{
+		{name: "absent", body: `{"name":"Power"}`, expected: Nullable[string]{}},
+		{name: "null", body: `{"description":null}`, expected: Nullable[string]{Set: true}},
+		{name: "set", body: `{"description":"Electricity bills"}`, expected: Nullable[string]{Set: true, Value: &description}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var patch TagPatch
+			err := json.Unmarshal([]byte(tt.body), &patch)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, patch.Description)
+		})
+	}
+}
+
+func TestTagPatchUnmarshal_ShouldRejectNullRequiredMembers(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expectedErr string
+	}{
+		{name: "name", body: `{"name":null}`, expectedErr: "name cannot be null"},
+		{name: "isActive", body: `{"isActive":null}`, expectedErr: "isActive cannot be null"},
+		{name: "other case", body: `{"ISACTIVE":null}`, expectedErr: "isActive cannot be null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var patch TagPatch
+
+			// Act
+			err := json.Unmarshal([]byte(tt.body), &patch)
+
+			// Assert
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestTagPatchUnmarshal_ShouldRejectUnknownMembers(t *testing.T) {
+	// Arrange
+	var patch TagPatch
+
+	// Act
+	err := json.Unmarshal([]byte(`{"name":"Power","colour":"#1e88e5"}`), &patch)
+
+	// Assert
+	require.EqualError(t, err, `json: unknown field "colour"`)
+}
+
 func TestTagFilterValidate(t *testing.T) {
 	validPage := int32(0)
 	validPageSize := int32(20)
@@ -261,6 +591,23 @@ func TestTagFilterValidate(t *testing.T) {
 			},
 			expectedError: "page must be zero or greater",
 		},
+		{
+			name: "before cursor instead of page",
+			filter: TagFilter{
+				PageSize: &validPageSize,
+				Before:   &Cursor{Id: uuid.New()},
+			},
+			expectedError: "",
+		},
+		{
+			name: "page with a cursor",
+			filter: TagFilter{
+				Page:     &validPage,
+				PageSize: &validPageSize,
+				After:    &Cursor{Id: uuid.New()},
+			},
+			expectedError: "page cannot be used with after or before",
+		},
 		{
 			name: "page is negative",
 			filter: TagFilter{
@@ -366,3 +713,35 @@ func TestTagLookupFilterValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTagDeleteForce(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		expected    bool
+		expectedErr bool
+	}{
+		{name: "default", target: "/tags/1", expected: false},
+		{name: "true", target: "/tags/1?force=true", expected: true},
+		{name: "false", target: "/tags/1?force=false", expected: false},
+		{name: "invalid", target: "/tags/1?force=maybe", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := httptest.NewRequest("DELETE", tt.target, nil)
+
+			// Act
+			force, err := ParseTagDeleteForce(request)
+
+			// Assert
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, force)
+		})
+	}
+}
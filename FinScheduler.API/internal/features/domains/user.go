@@ -0,0 +1,84 @@
+package domains
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const passwordMinLength = 12
+
+// ErrInvalidCredentials hides whether the email or the password was wrong.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+type User struct {
+	Id           uuid.UUID    `db:"id"`
+	Email        string       `db:"email"`
+	PasswordHash string       `db:"password_hash"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    sql.NullTime `db:"updated_at"`
+}
+
+type UserCreate struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type TokenRefresh struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// TokenDto is the OAuth 2.0 style token response of login and refresh.
+// ExpiresIn is the access token lifetime in seconds.
+type TokenDto struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+	ExpiresIn    int64  `json:"expiresIn"`
+}
+
+// NormalizeEmail lowercases the address so that lookups and the unique
+// constraint on users.email are case-insensitive.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (user *UserCreate) Validate() error {
+	if _, err := mail.ParseAddress(user.Email); err != nil {
+		return fmt.Errorf("email must be a valid address")
+	}
+	if len(user.Password) < passwordMinLength {
+		return fmt.Errorf("password must be at least %d characters long", passwordMinLength)
+	}
+
+	return nil
+}
+
+func (credentials *Credentials) Validate() error {
+	if strings.TrimSpace(credentials.Email) == "" {
+		return fmt.Errorf("email is required")
+	}
+	if credentials.Password == "" {
+		return fmt.Errorf("password is required")
+	}
+
+	return nil
+}
+
+func (refresh *TokenRefresh) Validate() error {
+	if strings.TrimSpace(refresh.RefreshToken) == "" {
+		return fmt.Errorf("refreshToken is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package domains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserCreate_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		create      UserCreate
+		expectedErr string
+	}{
+		{name: "valid", create: UserCreate{Email: "user@example.com", Password: "correct horse battery"}},
+		{name: "invalid email", create: UserCreate{Email: "user", Password: "correct horse battery"}, expectedErr: "email must be a valid address"},
+		{name: "short password", create: UserCreate{Email: "user@example.com", Password: "short"}, expectedErr: "password must be at least 12 characters long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.create.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCredentials_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials Credentials
+		expectedErr string
+	}{
+		{name: "valid", credentials: Credentials{Email: "user@example.com", Password: "secret"}},
+		{name: "missing email", credentials: Credentials{Email: " ", Password: "secret"}, expectedErr: "email is required"},
+		{name: "missing password", credentials: Credentials{Email: "user@example.com"}, expectedErr: "password is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.credentials.Validate()
+
+			// Assert
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "user@example.com", NormalizeEmail("  User@Example.COM "))
+}
@@ -0,0 +1,179 @@
+package featurehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type ApiKeysHandler struct {
+	service *services.ApiKeysService
+	logger  *slog.Logger
+}
+
+func NewApiKeysHandler(service *services.ApiKeysService, logger *slog.Logger) *ApiKeysHandler {
+	return &ApiKeysHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (handler *ApiKeysHandler) RegisterEndpoints(router chi.Router) {
+	router.Get("/", handler.GetListing)
+	router.Post("/", handler.Create)
+	router.Delete("/{id}", handler.Delete)
+}
+
+func (handler *ApiKeysHandler) GetListing(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(r.Context(), "api-keys-http")
+	traces.RecordHttpSpan(span, r, "/api-keys")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "GET /api-keys", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	keys, err := handler.service.GetListing(ctx)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "API keys listing ended in failure", "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(keys); err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *ApiKeysHandler) Create(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusCreated
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(r.Context(), "api-keys-http")
+	traces.RecordHttpSpan(span, r, "/api-keys")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /api-keys", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var create domains.ApiKeyCreate
+	if err := decodeJSON(r, &create); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := create.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	created, err := handler.service.Create(ctx, &create)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "API key creation ended in failure", "error", err)
+		if errors.Is(err, domains.ErrApiKeyNameTaken) {
+			statusCode = http.StatusConflict
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", r.URL.String(), created.Id))
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *ApiKeysHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusNoContent
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(r.Context(), "api-keys-http")
+	traces.RecordHttpSpan(span, r, "/api-keys/{id}")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "DELETE /api-keys/{id}", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	id := chi.URLParam(r, "id")
+
+	idParam, err := uuid.Parse(id)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid API key id", "id", id, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	deleted, err := handler.service.Delete(ctx, idParam)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "API key deletion ended in failure", "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if !deleted {
+		statusCode = http.StatusNotFound
+		http.Error(w, "API key not found", statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+}
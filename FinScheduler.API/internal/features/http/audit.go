@@ -0,0 +1,82 @@
+package featurehttp
+
+import (
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+)
+
+type AuditHandler struct {
+	service *services.AuditService
+	logger  *slog.Logger
+}
+
+func NewAuditHandler(service *services.AuditService, logger *slog.Logger) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (handler *AuditHandler) RegisterEndpoints(router chi.Router) {
+	router.Get("/", handler.GetListing)
+}
+
+func (handler *AuditHandler) GetListing(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("audit")
+	ctx, span := tracer.Start(r.Context(), "audit-http")
+	traces.RecordHttpSpan(span, r, "/audit")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "GET /audit", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	filter, err := domains.NewAuditFilter(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := filter.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	events, count, err := handler.service.GetListing(ctx, &filter)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Audit filtering ended in failure", "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	statusCode, err = writeConditionalJSON(w, r, domains.NewPaginatedList(events, count), listingCacheControl)
+	if err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
@@ -0,0 +1,160 @@
+package featurehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+)
+
+type AuthHandler struct {
+	service *services.AuthService
+	logger  *slog.Logger
+}
+
+func NewAuthHandler(service *services.AuthService, logger *slog.Logger) *AuthHandler {
+	return &AuthHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (handler *AuthHandler) RegisterEndpoints(router chi.Router) {
+	router.Post("/login", handler.Login)
+	router.Post("/refresh", handler.Refresh)
+}
+
+func (handler *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("auth")
+	ctx, span := tracer.Start(r.Context(), "auth-http")
+	traces.RecordHttpSpan(span, r, "/auth/login")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /auth/login", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var credentials domains.Credentials
+	if err := decodeJSON(r, &credentials); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := credentials.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	tokens, err := handler.service.Login(ctx, &credentials)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Login ended in failure", "error", err)
+		if errors.Is(err, domains.ErrInvalidCredentials) {
+			statusCode = http.StatusUnauthorized
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("auth")
+	ctx, span := tracer.Start(r.Context(), "auth-http")
+	traces.RecordHttpSpan(span, r, "/auth/refresh")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /auth/refresh", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var refresh domains.TokenRefresh
+	if err := decodeJSON(r, &refresh); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := refresh.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	tokens, err := handler.service.Refresh(ctx, &refresh)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Token refresh ended in failure", "error", err)
+		if errors.Is(err, auth.ErrInvalidToken) {
+			statusCode = http.StatusUnauthorized
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, auth.ErrInvalidToken.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
@@ -0,0 +1,298 @@
+package featurehttp
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// householdHeader picks the household of an items or tags request. Without
+// it the caller's personal household is used.
+const householdHeader = "X-Household-Id"
+
+type HouseholdsHandler struct {
+	service *services.HouseholdsService
+	logger  *slog.Logger
+}
+
+func NewHouseholdsHandler(service *services.HouseholdsService, logger *slog.Logger) *HouseholdsHandler {
+	return &HouseholdsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (handler *HouseholdsHandler) RegisterEndpoints(router chi.Router) {
+	router.Get("/", handler.GetListing)
+	router.Post("/", handler.Create)
+	router.Route("/{householdId}", func(router chi.Router) {
+		router.Use(handler.Membership)
+		router.Use(auth.RequireRole(auth.RoleAdmin, auth.RoleAdmin))
+		router.Post("/invitations", handler.CreateInvitation)
+	})
+}
+
+func (handler *HouseholdsHandler) RegisterInvitationEndpoints(router chi.Router) {
+	router.Post("/{token}/accept", handler.AcceptInvitation)
+}
+
+// Membership resolves the household of the request from the {householdId}
+// path parameter, the X-Household-Id header or, failing both, the caller's
+// personal household, and stores the caller's membership in the context.
+// Callers who are not members of the household get 403.
+func (handler *HouseholdsHandler) Membership(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		householdID, err := requestedHousehold(r)
+		if err != nil {
+			handler.logger.WarnContext(ctx, "Invalid household id", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		membership, err := handler.service.ResolveMembership(ctx, householdID)
+		if err != nil {
+			if errors.Is(err, domains.ErrNotMember) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithMembership(ctx, membership)))
+	})
+}
+
+// requestedHousehold returns nil when the request does not name a household.
+func requestedHousehold(r *http.Request) (*uuid.UUID, error) {
+	value := chi.URLParam(r, "householdId")
+	if value == "" {
+		value = strings.TrimSpace(r.Header.Get(householdHeader))
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	householdID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("household id must be a UUID")
+	}
+
+	return &householdID, nil
+}
+
+func (handler *HouseholdsHandler) GetListing(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(r.Context(), "households-http")
+	traces.RecordHttpSpan(span, r, "/households")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "GET /households", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	households, err := handler.service.GetListing(ctx)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Households listing ended in failure", "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(households); err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *HouseholdsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusCreated
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(r.Context(), "households-http")
+	traces.RecordHttpSpan(span, r, "/households")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /households", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var create domains.HouseholdCreate
+	if err := decodeJSON(r, &create); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := create.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	newHouseholdID, err := handler.service.Create(ctx, &create)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Household creation ended in failure", "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", r.URL.String(), newHouseholdID))
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(newHouseholdID); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *HouseholdsHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusCreated
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(r.Context(), "households-http")
+	traces.RecordHttpSpan(span, r, "/households/{householdId}/invitations")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /households/{householdId}/invitations", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var create domains.InvitationCreate
+	if err := decodeJSON(r, &create); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := create.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	invitation, err := handler.service.CreateInvitation(ctx, &create)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invitation creation ended in failure", "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	// The accept endpoint lives next to /households under the same version.
+	prefix, _, _ := strings.Cut(r.URL.Path, "/households/")
+	invitation.Link = fmt.Sprintf("%s/invitations/%s/accept", prefix, invitation.Token)
+
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(invitation); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *HouseholdsHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(r.Context(), "households-http")
+	traces.RecordHttpSpan(span, r, "/invitations/{token}/accept")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /invitations/{token}/accept", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	household, err := handler.service.AcceptInvitation(ctx, chi.URLParam(r, "token"))
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Accept invitation ended in failure", "error", err)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			statusCode = http.StatusNotFound
+			notFoundErr := fmt.Errorf("invitation not found")
+			traces.EnrichFailedHttpSpan(span, notFoundErr, statusCode)
+			http.Error(w, notFoundErr.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrInvitationGone) {
+			statusCode = http.StatusGone
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(household); err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
@@ -1,6 +1,7 @@
 package featurehttp
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -32,11 +33,16 @@ func NewItemsHandler(service *services.ItemsService, logger *slog.Logger) *Items
 
 func (handler *ItemsHandler) RegisterEndpoints(router chi.Router) {
 	router.Get("/", handler.GetListingInfo)
+	router.Get("/trash", handler.GetTrash)
 	router.Get("/{id}", handler.GetDetailedInfo)
 	router.Post("/", handler.Create)
+	router.Post("/{id}/restore", handler.Restore)
 	router.Patch("/cashback/tag", handler.UpdateCashbackByTag)
 	router.Patch("/cashback/items", handler.UpdateCashbackByItems)
+	router.Post("/tags:add", handler.AddTags)
+	router.Post("/tags:remove", handler.RemoveTags)
 	router.Put("/{id}", handler.Update)
+	router.Patch("/{id}", handler.Patch)
 	router.Delete("/{id}", handler.Delete)
 }
 
@@ -75,7 +81,16 @@ func (handler *ItemsHandler) GetListingInfo(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	items, count, err := handler.service.GetListingInfo(ctx, &filter)
+	moneyFormat, err := domains.ParseMoneyFormat(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	items, page, err := handler.service.GetListingInfo(ctx, &filter)
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "Items filtering ended in failure", "error", err)
 		statusCode = http.StatusInternalServerError
@@ -84,7 +99,11 @@ func (handler *ItemsHandler) GetListingInfo(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(domains.NewPaginatedList(items, count))
+	for i := range items {
+		items[i].FormatMoney(moneyFormat)
+	}
+
+	statusCode, err = writeConditionalJSON(w, r, domains.NewCursorPaginatedList(items, page), listingCacheControl)
 	if err != nil {
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
 		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
@@ -120,6 +139,15 @@ func (handler *ItemsHandler) GetDetailedInfo(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	moneyFormat, err := domains.ParseMoneyFormat(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
 	item, err := handler.service.GetDetailedInfo(ctx, idParam)
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "Get item by id ended in failure", "id", id, "error", err)
@@ -138,7 +166,10 @@ func (handler *ItemsHandler) GetDetailedInfo(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(item); err != nil {
+	item.FormatMoney(moneyFormat)
+
+	statusCode, err = writeVersionedJSON(w, item.Version, item)
+	if err != nil {
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
 		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
 		return
@@ -168,7 +199,7 @@ func (handler *ItemsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	var create domains.ItemCreate
-	if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+	if err := decodeJSON(r, &create); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
 		statusCode = http.StatusBadRequest
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -184,9 +215,33 @@ func (handler *ItemsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newItemID, err := handler.service.Create(ctx, &create)
+	claim, err := newIdempotencyClaim(r, "POST /items", &create)
+	if err == nil && claim != nil {
+		err = claim.Validate()
+	}
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid idempotency key", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	var newItemID uuid.UUID
+	var replay *domains.IdempotencyKey
+	if claim == nil {
+		newItemID, err = handler.service.Create(ctx, &create)
+	} else {
+		newItemID, replay, err = handler.service.CreateIdempotent(ctx, &create, claim)
+	}
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "Item creation ended in failure", "error", err)
+		if errors.Is(err, domains.ErrIdempotencyKeyReused) {
+			statusCode = http.StatusUnprocessableEntity
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
 		if errors.Is(err, domains.ErrInvalidReference) {
 			statusCode = http.StatusBadRequest
 			traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -201,6 +256,13 @@ func (handler *ItemsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Location", fmt.Sprintf("%s/%s", r.URL.String(), newItemID))
+	if replay != nil {
+		if statusCode, err = writeIdempotentReplay(w, replay); err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to replay stored response", "error", err)
+		}
+		return
+	}
+
 	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(newItemID); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
@@ -238,8 +300,17 @@ func (handler *ItemsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid precondition", "ifMatch", r.Header.Get("If-Match"), "error", err)
+		statusCode = preconditionStatus(err)
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
 	var update domains.ItemUpdate
-	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+	if err := decodeJSON(r, &update); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
 		statusCode = http.StatusBadRequest
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -255,9 +326,110 @@ func (handler *ItemsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	success, err := handler.service.Update(ctx, idParam, &update)
+	success, err := handler.service.Update(ctx, idParam, &update, expectedVersion)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "database error", "error", err)
+		if errors.Is(err, domains.ErrPreconditionFailed) {
+			statusCode = http.StatusPreconditionFailed
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrInvalidReference) {
+			statusCode = http.StatusBadRequest
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if !success {
+		statusCode = http.StatusNotFound
+		http.Error(w, "item not found", statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+}
+
+func (handler *ItemsHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusNoContent
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(r.Context(), "items-http")
+	traces.RecordHttpSpan(span, r, "/items/{id}")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "PATCH /items/{id}", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	if !hasContentType(r, mergePatchContentType) {
+		err := fmt.Errorf("content type must be %s", mergePatchContentType)
+		handler.logger.ErrorContext(ctx, "Unsupported content type", "contentType", r.Header.Get("Content-Type"))
+		statusCode = http.StatusUnsupportedMediaType
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	idParam, err := uuid.Parse(id)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to fetch patched entity", "id", id, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid precondition", "ifMatch", r.Header.Get("If-Match"), "error", err)
+		statusCode = preconditionStatus(err)
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	var patch domains.ItemPatch
+	if err := decodeJSON(r, &patch); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := patch.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	success, err := handler.service.Patch(ctx, idParam, &patch, expectedVersion)
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "database error", "error", err)
+		if errors.Is(err, domains.ErrPreconditionFailed) {
+			statusCode = http.StatusPreconditionFailed
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
 		if errors.Is(err, domains.ErrInvalidReference) {
 			statusCode = http.StatusBadRequest
 			traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -300,7 +472,7 @@ func (handler *ItemsHandler) UpdateCashbackByTag(w http.ResponseWriter, r *http.
 	}()
 
 	var update domains.ItemCashbackByTagUpdate
-	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+	if err := decodeJSON(r, &update); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
 		statusCode = http.StatusBadRequest
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -348,7 +520,7 @@ func (handler *ItemsHandler) UpdateCashbackByItems(w http.ResponseWriter, r *htt
 	}()
 
 	var update domains.ItemCashbackByIdsUpdate
-	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+	if err := decodeJSON(r, &update); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
 		statusCode = http.StatusBadRequest
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -375,6 +547,140 @@ func (handler *ItemsHandler) UpdateCashbackByItems(w http.ResponseWriter, r *htt
 	w.WriteHeader(statusCode)
 }
 
+func (handler *ItemsHandler) AddTags(w http.ResponseWriter, r *http.Request) {
+	handler.changeTags(w, r, "/items/tags:add", handler.service.AddTags)
+}
+
+func (handler *ItemsHandler) RemoveTags(w http.ResponseWriter, r *http.Request) {
+	handler.changeTags(w, r, "/items/tags:remove", handler.service.RemoveTags)
+}
+
+// changeTags serves a bulk tag change at path, applying it with apply.
+func (handler *ItemsHandler) changeTags(w http.ResponseWriter, r *http.Request, path string, apply func(context.Context, *domains.ItemTagsChange) (int64, error)) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(r.Context(), "items-http")
+	traces.RecordHttpSpan(span, r, path)
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST "+path, statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var change domains.ItemTagsChange
+	if err := decodeJSON(r, &change); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := change.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	changed, err := apply(ctx, &change)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Bulk tag change ended in failure", "tagIds", change.TagIds, "error", err)
+		if errors.Is(err, domains.ErrInvalidReference) {
+			statusCode = http.StatusBadRequest
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, "tagIds reference an unknown tag", statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrTagInactive) {
+			statusCode = http.StatusConflict
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, "tagIds reference an inactive tag", statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(domains.ItemTagsChangeResult{Changed: changed}); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+// BulkChange is registered by V1Routes as POST /items:bulk, beside the
+// /items subtree.
+func (handler *ItemsHandler) BulkChange(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(r.Context(), "items-http")
+	traces.RecordHttpSpan(span, r, "/items:bulk")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /items:bulk", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var change domains.ItemBulkChange
+	if err := decodeJSON(r, &change); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := change.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	result, err := handler.service.BulkChange(ctx, &change)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Bulk change ended in failure", "operation", change.Operation, "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
 func (handler *ItemsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 	statusCode := http.StatusNoContent
@@ -402,9 +708,24 @@ func (handler *ItemsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	success, err := handler.service.Delete(ctx, idParam)
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid precondition", "ifMatch", r.Header.Get("If-Match"), "error", err)
+		statusCode = preconditionStatus(err)
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	success, err := handler.service.Delete(ctx, idParam, expectedVersion)
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "database error", "error", err)
+		if errors.Is(err, domains.ErrPreconditionFailed) {
+			statusCode = http.StatusPreconditionFailed
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
 		statusCode = http.StatusInternalServerError
 		http.Error(w, err.Error(), statusCode)
 		return
@@ -418,3 +739,119 @@ func (handler *ItemsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(statusCode)
 }
+
+func (handler *ItemsHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(r.Context(), "items-http")
+	traces.RecordHttpSpan(span, r, "/items/trash")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "GET /items/trash", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	filter, err := domains.NewItemTrashFilter(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := filter.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	moneyFormat, err := domains.ParseMoneyFormat(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	items, count, err := handler.service.GetTrash(ctx, &filter)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Trash listing ended in failure", "error", err)
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	for i := range items {
+		items[i].FormatMoney(moneyFormat)
+	}
+
+	statusCode, err = writeConditionalJSON(w, r, domains.NewPaginatedList(items, count), listingCacheControl)
+	if err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *ItemsHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusNoContent
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(r.Context(), "items-http")
+	traces.RecordHttpSpan(span, r, "/items/{id}/restore")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /items/{id}/restore", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	id := chi.URLParam(r, "id")
+
+	idParam, err := uuid.Parse(id)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse item id", "id", id, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	success, err := handler.service.Restore(ctx, idParam)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Item restore ended in failure", "error", err)
+		if errors.Is(err, domains.ErrItemNameTaken) {
+			statusCode = http.StatusConflict
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if !success {
+		statusCode = http.StatusNotFound
+		http.Error(w, "item not found in the trash", statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+}
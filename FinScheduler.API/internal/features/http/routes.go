@@ -0,0 +1,223 @@
+package featurehttp
+
+import (
+	"finscheduler/internal/auth"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	apiPrefix = "/api"
+
+	apiVersionHeader  = "API-Version"
+	deprecationHeader = "Deprecation"
+	sunsetHeader      = "Sunset"
+)
+
+// versionMediaType matches vendor media types such as
+// application/vnd.finscheduler.v2+json.
+var versionMediaType = regexp.MustCompile(`^application/vnd\.finscheduler\.(v[0-9]+)\+json$`)
+
+// APIVersion is a set of endpoints mounted under /api/{Name}. Versions are
+// served side by side, so a DTO can change in a new version while clients of
+// the previous one keep working until its sunset.
+type APIVersion struct {
+	// Name is the path segment of the version, e.g. "v1".
+	Name string
+	// Deprecation, when set, is announced on every response of the version.
+	Deprecation time.Time
+	// Sunset, when set, is the date after which the version may be removed.
+	Sunset time.Time
+	// Routes registers the endpoints of the version relative to its prefix.
+	Routes func(router chi.Router)
+}
+
+// Route groups share a rate limit; their names are the keys of
+// rateLimit.groups in the configuration.
+const (
+	RateLimitGroupAuth    = "auth"
+	RateLimitGroupAccount = "account"
+	RateLimitGroupItems   = "items"
+	RateLimitGroupTags    = "tags"
+	RateLimitGroupAudit   = "audit"
+)
+
+// RateLimit returns the rate limiting middleware of a route group, such as
+// ratelimit.Limiter.Middleware.
+type RateLimit func(group string) func(http.Handler) http.Handler
+
+// NoRateLimit lets every request through.
+func NoRateLimit(string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return next
+	}
+}
+
+// NoValidation passes every request through unchecked.
+func NoValidation(next http.Handler) http.Handler {
+	return next
+}
+
+// V1Routes registers the API key, households, items, tags and audit endpoints
+// of the first API version. Items, tags and the audit log act on the household
+// resolved by HouseholdsHandler.Membership; viewers may only read them, and API
+// keys need the matching scope. API keys cannot manage keys or households, nor
+// read the audit log. Requests are rate limited, then checked by validate,
+// such as openapi.Validator.Middleware, before the household is resolved.
+func V1Routes(itemsHandler *ItemsHandler, tagsHandler *TagsHandler, householdsHandler *HouseholdsHandler, apiKeysHandler *ApiKeysHandler, auditHandler *AuditHandler, rateLimit RateLimit, validate func(http.Handler) http.Handler) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.Group(func(group chi.Router) {
+			group.Use(rateLimit(RateLimitGroupAccount))
+			group.Use(validate)
+			group.Use(auth.RejectAPIKeys)
+			group.Route("/api-keys", apiKeysHandler.RegisterEndpoints)
+			group.Route("/households", householdsHandler.RegisterEndpoints)
+			group.Route("/invitations", householdsHandler.RegisterInvitationEndpoints)
+		})
+		router.Group(func(group chi.Router) {
+			group.Use(rateLimit(RateLimitGroupItems))
+			group.Use(validate)
+			group.Use(householdsHandler.Membership)
+			group.Use(auth.RequireRole(auth.RoleViewer, auth.RoleEditor))
+			group.Use(auth.RequireScope(auth.ScopeItemsRead, auth.ScopeItemsWrite))
+			group.Route("/items", itemsHandler.RegisterEndpoints)
+			group.Post("/items:bulk", itemsHandler.BulkChange)
+		})
+		router.Group(func(group chi.Router) {
+			group.Use(rateLimit(RateLimitGroupTags))
+			group.Use(validate)
+			group.Use(householdsHandler.Membership)
+			group.Use(auth.RequireRole(auth.RoleViewer, auth.RoleEditor))
+			group.Use(auth.RequireScope(auth.ScopeTagsRead, auth.ScopeTagsWrite))
+			group.Route("/tags", tagsHandler.RegisterEndpoints)
+		})
+		router.Group(func(group chi.Router) {
+			group.Use(rateLimit(RateLimitGroupAudit))
+			group.Use(validate)
+			group.Use(auth.RejectAPIKeys)
+			group.Use(householdsHandler.Membership)
+			group.Route("/audit", auditHandler.RegisterEndpoints)
+		})
+	}
+}
+
+// AuthRoutes registers the public login and refresh endpoints under /auth,
+// rate limited per client address and then checked by validate.
+func AuthRoutes(authHandler *AuthHandler, rateLimit RateLimit, validate func(http.Handler) http.Handler) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.With(rateLimit(RateLimitGroupAuth), validate).Route("/auth", authHandler.RegisterEndpoints)
+	}
+}
+
+// Protected registers routes behind the authenticate middleware, which
+// rejects requests that carry no valid credentials.
+func Protected(authenticate func(http.Handler) http.Handler, routes func(router chi.Router)) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.Group(func(group chi.Router) {
+			group.Use(authenticate)
+			routes(group)
+		})
+	}
+}
+
+// RegisterRoutes mounts the feature endpoints as API version v1.
+func RegisterRoutes(router chi.Router, itemsHandler *ItemsHandler, tagsHandler *TagsHandler, householdsHandler *HouseholdsHandler, apiKeysHandler *ApiKeysHandler, auditHandler *AuditHandler, rateLimit RateLimit) {
+	RegisterVersions(router, APIVersion{Name: "v1", Routes: V1Routes(itemsHandler, tagsHandler, householdsHandler, apiKeysHandler, auditHandler, rateLimit, NoValidation)})
+}
+
+// RegisterVersions mounts every version under /api/{name}. The unversioned
+// /api paths are kept as aliases: they serve the version requested through an
+// application/vnd.finscheduler.{name}+json Accept media type and fall back to
+// the first version otherwise.
+func RegisterVersions(router chi.Router, versions ...APIVersion) {
+	if len(versions) == 0 {
+		return
+	}
+
+	mounted := make(map[string]http.Handler, len(versions))
+	for i, version := range versions {
+		successor := ""
+		if i+1 < len(versions) {
+			successor = versions[i+1].Name
+		}
+
+		versionRouter := chi.NewRouter()
+		versionRouter.Use(versionHeaders(version, successor))
+		version.Routes(versionRouter)
+
+		mounted[version.Name] = versionRouter
+		router.Mount(apiPrefix+"/"+version.Name, versionRouter)
+	}
+
+	router.Mount(apiPrefix, negotiateVersion(mounted, versions[0].Name))
+}
+
+// versionHeaders labels responses with the version that produced them and,
+// for deprecated versions, the Deprecation (RFC 9745) and Sunset (RFC 8594)
+// headers plus a link to the successor version.
+func versionHeaders(version APIVersion, successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(apiVersionHeader, version.Name)
+			if !version.Deprecation.IsZero() {
+				w.Header().Set(deprecationHeader, fmt.Sprintf("@%d", version.Deprecation.Unix()))
+				if successor != "" {
+					w.Header().Add("Link", fmt.Sprintf(`<%s/%s>; rel="successor-version"`, apiPrefix, successor))
+				}
+			}
+			if !version.Sunset.IsZero() {
+				w.Header().Set(sunsetHeader, version.Sunset.UTC().Format(http.TimeFormat))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// negotiateVersion dispatches an unversioned request to the version named in
+// its Accept header. A request that only accepts unknown versions gets 406.
+func negotiateVersion(versions map[string]http.Handler, defaultVersion string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		requested := requestedVersions(r)
+		if len(requested) == 0 {
+			versions[defaultVersion].ServeHTTP(w, r)
+			return
+		}
+
+		for _, name := range requested {
+			if handler, ok := versions[name]; ok {
+				handler.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		http.Error(w, fmt.Sprintf("API version %s is not supported", strings.Join(requested, ", ")), http.StatusNotAcceptable)
+	})
+}
+
+// requestedVersions lists the versions named by vendor media types in the
+// Accept header, in the order given. Other media ranges are ignored.
+func requestedVersions(r *http.Request) []string {
+	var requested []string
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+			if match := versionMediaType.FindStringSubmatch(mediaType); match != nil {
+				requested = append(requested, match[1])
+			}
+		}
+	}
+
+	return requested
+}
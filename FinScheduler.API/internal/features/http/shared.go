@@ -0,0 +1,198 @@
+package featurehttp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"finscheduler/internal/features/domains"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+const (
+	// listingCacheControl lets clients keep listings but forces revalidation
+	// through If-None-Match, since they change with every write.
+	listingCacheControl = "private, no-cache"
+	// lookupCacheControl allows a short reuse window for lookups, which change
+	// rarely and are polled by every form that offers a tag picker.
+	lookupCacheControl = "private, max-age=60, must-revalidate"
+)
+
+var (
+	errIfMatchRequired = errors.New("If-Match header is required")
+	errIfMatchInvalid  = errors.New("If-Match header must be * or a strong entity tag")
+)
+
+func hasContentType(r *http.Request, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == contentType
+}
+
+// decodeJSON decodes the request body into target and rejects members the
+// target does not declare, so a misspelt field fails instead of being dropped.
+func decodeJSON(r *http.Request, target any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(target)
+}
+
+// formatETag renders a representation of a row version as a strong entity
+// tag: the version, which If-Match compares, then a digest of the encoded
+// body, which changes with the moneyFormat and with linked rows that do not
+// bump the version, such as a renamed tag.
+func formatETag(version int32, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strconv.Quote(strconv.FormatInt(int64(version), 10) + "-" + hex.EncodeToString(sum[:8]))
+}
+
+// parseIfMatch returns the version the client expects to modify. A nil
+// version means "*", i.e. the client accepts any current representation.
+// Only the version part of the entity tag is compared, so a tag read in
+// either moneyFormat guards the same row.
+func parseIfMatch(r *http.Request) (*int32, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return nil, errIfMatchRequired
+	}
+	if value == "*" {
+		return nil, nil
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return nil, errIfMatchInvalid
+	}
+
+	versionText, _, _ := strings.Cut(unquoted, "-")
+	version, err := strconv.ParseInt(versionText, 10, 32)
+	if err != nil {
+		return nil, errIfMatchInvalid
+	}
+
+	expected := int32(version)
+	return &expected, nil
+}
+
+// preconditionStatus maps an If-Match parsing error to its response status.
+func preconditionStatus(err error) int {
+	if errors.Is(err, errIfMatchRequired) {
+		return http.StatusPreconditionRequired
+	}
+
+	return http.StatusPreconditionFailed
+}
+
+// weakETag derives a weak entity tag from an encoded representation.
+func weakETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// matchesIfNoneMatch applies the weak comparison required for If-None-Match
+// against every entity tag listed by the client.
+func matchesIfNoneMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeConditionalJSON encodes payload, tags it with a weak ETag and answers
+// 304 Not Modified when the client already holds the same representation.
+// The representation depends on the caller and the household, so caches are
+// told to key it by both. It returns the status code that was written.
+func writeConditionalJSON(w http.ResponseWriter, r *http.Request, payload any, cacheControl string) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	body = append(body, '\n')
+
+	etag := weakETag(body)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Add("Vary", "Authorization, "+householdHeader)
+
+	if matchesIfNoneMatch(r, etag) {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		return http.StatusNotModified, nil
+	}
+
+	_, err = w.Write(body)
+	return http.StatusOK, err
+}
+
+// writeVersionedJSON encodes payload and tags it with the strong ETag of the
+// row version it represents. Like writeConditionalJSON, it tells caches that
+// the representation depends on the caller and the household.
+func writeVersionedJSON(w http.ResponseWriter, version int32, payload any) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	body = append(body, '\n')
+
+	w.Header().Set("ETag", formatETag(version, body))
+	w.Header().Add("Vary", "Authorization, "+householdHeader)
+
+	_, err = w.Write(body)
+	return http.StatusOK, err
+}
+
+// newIdempotencyClaim returns nil when the request carries no Idempotency-Key.
+// The decoded payload is hashed rather than the raw body, so retries that only
+// differ in formatting are still recognised as the same request.
+func newIdempotencyClaim(r *http.Request, scope string, payload any) (*domains.IdempotencyClaim, error) {
+	key := strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if key == "" {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+
+	return &domains.IdempotencyClaim{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// writeIdempotentReplay resends a stored response and returns its status code.
+func writeIdempotentReplay(w http.ResponseWriter, replay *domains.IdempotencyKey) (int, error) {
+	statusCode := int(replay.StatusCode.Int32)
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(statusCode)
+
+	_, err := w.Write(replay.ResponseBody)
+	return statusCode, err
+}
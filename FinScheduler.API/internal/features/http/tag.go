@@ -34,8 +34,12 @@ func (handler *TagsHandler) RegisterEndpoints(router chi.Router) {
 	router.Get("/", handler.GetListingInfo)
 	router.Get("/lookup", handler.GetLookup)
 	router.Get("/{id}", handler.GetDetailedInfo)
+	router.Get("/{id}/stats", handler.GetStats)
 	router.Post("/", handler.Create)
 	router.Put("/{id}", handler.Update)
+	router.Patch("/{id}", handler.Patch)
+	router.Delete("/{id}", handler.Delete)
+	router.Post("/{id}/merge-into/{targetId}", handler.MergeInto)
 }
 
 func (handler *TagsHandler) GetListingInfo(w http.ResponseWriter, r *http.Request) {
@@ -73,7 +77,16 @@ func (handler *TagsHandler) GetListingInfo(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	tags, count, err := handler.service.GetListingInfo(ctx, &filter)
+	moneyFormat, err := domains.ParseMoneyFormat(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	tags, page, err := handler.service.GetListingInfo(ctx, &filter)
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "Tags filtering ended in failure", "error", err)
 		statusCode = http.StatusInternalServerError
@@ -82,7 +95,11 @@ func (handler *TagsHandler) GetListingInfo(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(domains.NewPaginatedList(tags, count))
+	for i := range tags {
+		tags[i].FormatMoney(moneyFormat)
+	}
+
+	statusCode, err = writeConditionalJSON(w, r, domains.NewCursorPaginatedList(tags, page), listingCacheControl)
 	if err != nil {
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
 		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
@@ -134,7 +151,7 @@ func (handler *TagsHandler) GetLookup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(domains.NewPaginatedList(tags, count))
+	statusCode, err = writeConditionalJSON(w, r, domains.NewPaginatedList(tags, count), lookupCacheControl)
 	if err != nil {
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
 		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
@@ -188,7 +205,72 @@ func (handler *TagsHandler) GetDetailedInfo(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(tag); err != nil {
+	statusCode, err = writeVersionedJSON(w, tag.Version, tag)
+	if err != nil {
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
+		return
+	}
+}
+
+func (handler *TagsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusOK
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(r.Context(), "tags-http")
+	traces.RecordHttpSpan(span, r, "/tags/{id}/stats")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "GET /tags/{id}/stats", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	id := chi.URLParam(r, "id")
+	idParam, err := uuid.Parse(id)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse tag id", "id", id, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	moneyFormat, err := domains.ParseMoneyFormat(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	stats, err := handler.service.GetStats(ctx, idParam)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Get tag stats ended in failure", "id", id, "error", err)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			statusCode = http.StatusNotFound
+			notFoundErr := fmt.Errorf("tag not found")
+			traces.EnrichFailedHttpSpan(span, notFoundErr, statusCode)
+			http.Error(w, notFoundErr.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	stats.FormatMoney(moneyFormat)
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
 		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
 		return
@@ -218,7 +300,7 @@ func (handler *TagsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	var create domains.TagCreate
-	if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+	if err := decodeJSON(r, &create); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
 		statusCode = http.StatusBadRequest
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -234,9 +316,40 @@ func (handler *TagsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newTagID, err := handler.service.Create(ctx, &create)
+	claim, err := newIdempotencyClaim(r, "POST /tags", &create)
+	if err == nil && claim != nil {
+		err = claim.Validate()
+	}
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid idempotency key", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	var newTagID uuid.UUID
+	var replay *domains.IdempotencyKey
+	if claim == nil {
+		newTagID, err = handler.service.Create(ctx, &create)
+	} else {
+		newTagID, replay, err = handler.service.CreateIdempotent(ctx, &create, claim)
+	}
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "Tag creation ended in failure", "error", err)
+		if errors.Is(err, domains.ErrIdempotencyKeyReused) {
+			statusCode = http.StatusUnprocessableEntity
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrInvalidReference) {
+			statusCode = http.StatusBadRequest
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
 		statusCode = http.StatusInternalServerError
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
 		http.Error(w, err.Error(), statusCode)
@@ -244,6 +357,13 @@ func (handler *TagsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Location", fmt.Sprintf("%s/%s", r.URL.String(), newTagID))
+	if replay != nil {
+		if statusCode, err = writeIdempotentReplay(w, replay); err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to replay stored response", "error", err)
+		}
+		return
+	}
+
 	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(newTagID); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to encode result", "error", err)
@@ -281,8 +401,17 @@ func (handler *TagsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid precondition", "ifMatch", r.Header.Get("If-Match"), "error", err)
+		statusCode = preconditionStatus(err)
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
 	var update domains.TagUpdate
-	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+	if err := decodeJSON(r, &update); err != nil {
 		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
 		statusCode = http.StatusBadRequest
 		traces.EnrichFailedHttpSpan(span, err, statusCode)
@@ -298,9 +427,269 @@ func (handler *TagsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	success, err := handler.service.Update(ctx, idParam, &update)
+	success, err := handler.service.Update(ctx, idParam, &update, expectedVersion)
 	if err != nil {
 		handler.logger.ErrorContext(ctx, "database error", "error", err)
+		if errors.Is(err, domains.ErrPreconditionFailed) {
+			statusCode = http.StatusPreconditionFailed
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrInvalidReference) {
+			statusCode = http.StatusBadRequest
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrTagCycle) {
+			statusCode = http.StatusConflict
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if !success {
+		statusCode = http.StatusNotFound
+		http.Error(w, "tag not found", statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+}
+
+func (handler *TagsHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusNoContent
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(r.Context(), "tags-http")
+	traces.RecordHttpSpan(span, r, "/tags/{id}")
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			handler.logger.ErrorContext(ctx, "Failed to close request body", "error", err)
+		}
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "PATCH /tags/{id}", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	if !hasContentType(r, mergePatchContentType) {
+		err := fmt.Errorf("content type must be %s", mergePatchContentType)
+		handler.logger.ErrorContext(ctx, "Unsupported content type", "contentType", r.Header.Get("Content-Type"))
+		statusCode = http.StatusUnsupportedMediaType
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	idParam, err := uuid.Parse(id)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to fetch patched entity", "id", id, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid precondition", "ifMatch", r.Header.Get("If-Match"), "error", err)
+		statusCode = preconditionStatus(err)
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	var patch domains.TagPatch
+	if err := decodeJSON(r, &patch); err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to decode body", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if err := patch.Validate(); err != nil {
+		handler.logger.ErrorContext(ctx, "Validation failed", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	success, err := handler.service.Patch(ctx, idParam, &patch, expectedVersion)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "database error", "error", err)
+		if errors.Is(err, domains.ErrPreconditionFailed) {
+			statusCode = http.StatusPreconditionFailed
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrInvalidReference) {
+			statusCode = http.StatusBadRequest
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrTagCycle) {
+			statusCode = http.StatusConflict
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if !success {
+		statusCode = http.StatusNotFound
+		http.Error(w, "tag not found", statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+}
+
+func (handler *TagsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusNoContent
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(r.Context(), "tags-http")
+	traces.RecordHttpSpan(span, r, "/tags/{id}")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "DELETE /tags/{id}", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	id := chi.URLParam(r, "id")
+	idParam, err := uuid.Parse(id)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to fetch deleted entity", "id", id, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	force, err := domains.ParseTagDeleteForce(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to parse query", "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Invalid precondition", "ifMatch", r.Header.Get("If-Match"), "error", err)
+		statusCode = preconditionStatus(err)
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	success, err := handler.service.Delete(ctx, idParam, force, expectedVersion)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "database error", "error", err)
+		if errors.Is(err, domains.ErrPreconditionFailed) {
+			statusCode = http.StatusPreconditionFailed
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrTagInUse) {
+			statusCode = http.StatusConflict
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error()+"; pass force=true to unlink them", statusCode)
+			return
+		}
+
+		statusCode = http.StatusInternalServerError
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if !success {
+		statusCode = http.StatusNotFound
+		http.Error(w, "tag not found", statusCode)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+}
+
+func (handler *TagsHandler) MergeInto(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	statusCode := http.StatusNoContent
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(r.Context(), "tags-http")
+	traces.RecordHttpSpan(span, r, "/tags/{id}/merge-into/{targetId}")
+	defer func() {
+		metrics.RecordHTTPDuration(ctx, start)
+		metrics.RecordHTTPRequest(ctx, r, "POST /tags/{id}/merge-into/{targetId}", statusCode)
+
+		if statusCode < 400 {
+			traces.EnrichSuccessHttpSpan(span, statusCode)
+		}
+		span.End()
+	}()
+
+	id := chi.URLParam(r, "id")
+	idParam, err := uuid.Parse(id)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to fetch merged entity", "id", id, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	targetId := chi.URLParam(r, "targetId")
+	targetIdParam, err := uuid.Parse(targetId)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "Failed to fetch merge target", "targetId", targetId, "error", err)
+		statusCode = http.StatusBadRequest
+		traces.EnrichFailedHttpSpan(span, err, statusCode)
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	success, err := handler.service.MergeInto(ctx, idParam, targetIdParam)
+	if err != nil {
+		handler.logger.ErrorContext(ctx, "database error", "error", err)
+		if errors.Is(err, domains.ErrTagMergeIntoItself) {
+			statusCode = http.StatusBadRequest
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		if errors.Is(err, domains.ErrTagInactive) {
+			statusCode = http.StatusConflict
+			traces.EnrichFailedHttpSpan(span, err, statusCode)
+			http.Error(w, "target "+err.Error(), statusCode)
+			return
+		}
+
 		statusCode = http.StatusInternalServerError
 		http.Error(w, err.Error(), statusCode)
 		return
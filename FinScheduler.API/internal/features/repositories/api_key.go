@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+)
+
+type ApiKeysRepository struct {
+	db     DBTX
+	logger *slog.Logger
+}
+
+func NewApiKeysRepository(db DBTX, logger *slog.Logger) *ApiKeysRepository {
+	return &ApiKeysRepository{db: db, logger: logger}
+}
+
+const apiKeyColumns = "k.id, k.user_id, k.name, k.prefix, k.key_hash, k.scopes, k.created_at, k.expires_at, k.last_used_at"
+
+// GetByUserId lists the user's API keys, the oldest first.
+func (repository *ApiKeysRepository) GetByUserId(ctx context.Context, userID uuid.UUID) ([]domains.ApiKey, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var keys []domains.ApiKey
+
+	query := `SELECT ` + apiKeyColumns + `
+			  FROM public.api_keys AS k
+			  WHERE k.user_id = ?
+			  ORDER BY k.created_at, k.id`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "userID", userID)
+	start := time.Now()
+	err := sqlx.SelectContext(ctx, repository.db, &keys, query, userID)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, apiKeysTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err, "userID", userID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(keys)))
+	return keys, nil
+}
+
+// GetByKeyHash returns the key with the email of its user, or sql.ErrNoRows
+// when no key has that hash.
+func (repository *ApiKeysRepository) GetByKeyHash(ctx context.Context, keyHash string) (*domains.ApiKeyOwner, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var key domains.ApiKeyOwner
+
+	query := `SELECT ` + apiKeyColumns + `, u.email
+			  FROM public.api_keys AS k
+			  INNER JOIN public.users AS u ON u.id = k.user_id
+			  WHERE k.key_hash = ?`
+	query = repository.db.Rebind(query)
+
+	// The key hash stays out of the logs.
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query)
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &key, query, keyHash)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, apiKeysTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.logger.InfoContext(ctx, "api key not found")
+		} else {
+			repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		}
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, 1)
+	return &key, nil
+}
+
+// Create stores the key; its Id and CreatedAt are assigned here. It returns
+// sql.ErrNoRows when the user already has a key with that name.
+func (repository *ApiKeysRepository) Create(ctx context.Context, key *domains.ApiKey) (*domains.ApiKey, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	if key == nil {
+		repository.logger.ErrorContext(ctx, "key should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("key should not be nil")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	newID, err := uuid.NewV7()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "uuid generation error", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	created := *key
+	created.Id = newID
+
+	query := `INSERT INTO public.api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)
+			  ON CONFLICT (user_id, name) DO NOTHING
+			  RETURNING created_at`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "newID", newID, "userID", key.UserId, "name", key.Name)
+	start := time.Now()
+	err = sqlx.GetContext(ctx, repository.db, &created.CreatedAt, query, newID, key.UserId, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.ExpiresAt)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, apiKeysTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.logger.InfoContext(ctx, "api key name is taken", "userID", key.UserId, "name", key.Name)
+		} else {
+			repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "newID", newID)
+		}
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, 1)
+	return &created, nil
+}
+
+// TouchLastUsed records that the key authenticated a request at usedAt.
+func (repository *ApiKeysRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	query := "UPDATE public.api_keys SET last_used_at = ? WHERE id = ?"
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "id", id)
+	start := time.Now()
+	res, err := repository.db.ExecContext(ctx, query, usedAt, id)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, apiKeysTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", id)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, true, metrics.DatabaseOperationUpdate)
+	traces.EnrichSuccessRepositorySpanWrite(span, affected)
+	return nil
+}
+
+// Delete revokes the user's key. It reports false when the user has no key
+// with that id.
+func (repository *ApiKeysRepository) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
+	defer span.End()
+
+	query := "DELETE FROM public.api_keys WHERE user_id = ? AND id = ?"
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "userID", userID, "id", id)
+	start := time.Now()
+	res, err := repository.db.ExecContext(ctx, query, userID, id)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, apiKeysTableName, err == nil, metrics.DatabaseOperationDelete)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "id", id)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, apiKeysTableName, true, metrics.DatabaseOperationDelete)
+	traces.EnrichSuccessRepositorySpanWrite(span, affected)
+	return affected > 0, nil
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+)
+
+type AuditEventsRepository struct {
+	db     DBTX
+	logger *slog.Logger
+}
+
+func NewAuditEventsRepository(db DBTX, logger *slog.Logger) *AuditEventsRepository {
+	return &AuditEventsRepository{db: db, logger: logger}
+}
+
+func (repository *AuditEventsRepository) GetListing(ctx context.Context, householdID uuid.UUID, filter *domains.AuditFilter) ([]domains.AuditEventListing, int64, error) {
+	tracer := otel.Tracer("audit")
+	ctx, span := tracer.Start(ctx, "audit-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var events []domains.AuditEventListing
+	var count int64 = 0
+
+	filters := []string{"a.household_id = ?"}
+	args := []interface{}{householdID}
+
+	if filter.EntityType != nil {
+		filters = append(filters, "a.entity_type = ?")
+		args = append(args, *filter.EntityType)
+	}
+
+	if filter.EntityId != nil {
+		filters = append(filters, "a.entity_id = ?")
+		args = append(args, *filter.EntityId)
+	}
+
+	if filter.From != nil {
+		filters = append(filters, "a.occurred_at >= ?")
+		args = append(args, filter.From.UTC())
+	}
+
+	if filter.To != nil {
+		filters = append(filters, "a.occurred_at <= ?")
+		args = append(args, filter.To.UTC())
+	}
+
+	where := " WHERE " + strings.Join(filters, " AND ")
+
+	var pageSize int32 = 20
+	if filter.PageSize != nil {
+		pageSize = *filter.PageSize
+	}
+	var page int32 = 0
+	if filter.Page != nil {
+		page = *filter.Page
+	}
+	offset := page * pageSize
+
+	selectQuery := fmt.Sprintf(`SELECT a.id, a.household_id, a.actor_id, a.api_key_id, a.operation, a.entity_type, a.entity_id,
+			  a.changes, a.trace_id, a.occurred_at, u.email AS actor_email
+			  FROM public.audit_events a
+			  LEFT JOIN public.users u ON u.id = a.actor_id%s
+			  ORDER BY a.occurred_at DESC, a.id DESC LIMIT ? OFFSET ?`, where)
+	selectQuery = repository.db.Rebind(selectQuery)
+	selectArgs := append(make([]interface{}, 0), args...)
+	selectArgs = append(selectArgs, pageSize, offset)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", selectQuery, "args", selectArgs)
+	selectStart := time.Now()
+	err := sqlx.SelectContext(ctx, repository.db, &events, selectQuery, selectArgs...)
+	metrics.RecordDatabaseDuration(ctx, selectStart, databaseDriver, auditEventsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, count)
+		return nil, 0, err
+	} else {
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, true, metrics.DatabaseOperationSelect)
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM public.audit_events a%s", where)
+	countQuery = repository.db.Rebind(countQuery)
+	countArgs := append(make([]interface{}, 0), args...)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", countQuery, "args", countArgs)
+	countStart := time.Now()
+	err = sqlx.GetContext(ctx, repository.db, &count, countQuery, countArgs...)
+	metrics.RecordDatabaseDuration(ctx, countStart, databaseDriver, auditEventsTableName, err == nil, metrics.DatabaseOperationCount)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on COUNT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, false, metrics.DatabaseOperationCount)
+		traces.EnrichFailedRepositorySpanRead(span, err, count)
+		return nil, 0, err
+	} else {
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, true, metrics.DatabaseOperationCount)
+	}
+
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(events)))
+	return events, count, nil
+}
+
+// Create stores event under a new id. OccurredAt defaults to now when zero.
+func (repository *AuditEventsRepository) Create(ctx context.Context, event *domains.AuditEvent) (uuid.UUID, error) {
+	tracer := otel.Tracer("audit")
+	ctx, span := tracer.Start(ctx, "audit-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	if event == nil {
+		repository.logger.ErrorContext(ctx, "event should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("event should not be nil")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	newID, err := uuid.NewV7()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "uuid generation error", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	query := `INSERT INTO public.audit_events (id, household_id, actor_id, api_key_id, operation, entity_type, entity_id, changes, trace_id, occurred_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?::jsonb, ?, ?)`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "newID", newID, "householdID", event.HouseholdId, "operation", event.Operation, "entityType", event.EntityType, "entityID", event.EntityId)
+	start := time.Now()
+	_, err = repository.db.ExecContext(ctx, query, newID, event.HouseholdId, event.ActorId, event.ApiKeyId, event.Operation, event.EntityType, event.EntityId, string(event.Changes), event.TraceId, occurredAt.UTC())
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, auditEventsTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "newID", newID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, auditEventsTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, 1)
+	return newID, nil
+}
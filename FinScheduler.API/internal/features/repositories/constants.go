@@ -6,3 +6,10 @@ const itemsTableName = "items"
 const priceHistoryTableName = "price_history"
 const tagsTableName = "tags"
 const tagsToItemTableName = "tag_to_item"
+const idempotencyKeysTableName = "idempotency_keys"
+const usersTableName = "users"
+const householdsTableName = "households"
+const householdMembersTableName = "household_members"
+const invitationsTableName = "invitations"
+const apiKeysTableName = "api_keys"
+const auditEventsTableName = "audit_events"
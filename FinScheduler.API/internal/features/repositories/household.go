@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type HouseholdsRepository struct {
+	db     DBTX
+	logger *slog.Logger
+}
+
+func NewHouseholdsRepository(db DBTX, logger *slog.Logger) *HouseholdsRepository {
+	return &HouseholdsRepository{db: db, logger: logger}
+}
+
+const membershipColumns = "h.id, h.name, h.is_personal, hm.role"
+
+// GetByUserId lists the households the user is a member of, the personal one
+// first.
+func (repository *HouseholdsRepository) GetByUserId(ctx context.Context, userID uuid.UUID) ([]domains.HouseholdMembership, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var memberships []domains.HouseholdMembership
+
+	query := `SELECT ` + membershipColumns + `
+			  FROM public.household_members AS hm
+			  INNER JOIN public.households AS h ON h.id = hm.household_id
+			  WHERE hm.user_id = ?
+			  ORDER BY h.is_personal DESC, h.created_at, h.id`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "userID", userID)
+	start := time.Now()
+	err := sqlx.SelectContext(ctx, repository.db, &memberships, query, userID)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, householdMembersTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err, "userID", userID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(memberships)))
+	return memberships, nil
+}
+
+// GetMembership returns sql.ErrNoRows when the household does not exist or
+// the user is not one of its members.
+func (repository *HouseholdsRepository) GetMembership(ctx context.Context, userID uuid.UUID, householdID uuid.UUID) (*domains.HouseholdMembership, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	query := `SELECT ` + membershipColumns + `
+			  FROM public.household_members AS hm
+			  INNER JOIN public.households AS h ON h.id = hm.household_id
+			  WHERE hm.user_id = ? AND hm.household_id = ?`
+
+	return repository.getMembership(ctx, span, query, userID, householdID)
+}
+
+// GetPersonal returns the user's personal household, or sql.ErrNoRows when
+// it has not been created yet.
+func (repository *HouseholdsRepository) GetPersonal(ctx context.Context, userID uuid.UUID) (*domains.HouseholdMembership, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	query := `SELECT ` + membershipColumns + `
+			  FROM public.household_members AS hm
+			  INNER JOIN public.households AS h ON h.id = hm.household_id
+			  WHERE hm.user_id = ? AND h.owner_id = hm.user_id AND h.is_personal`
+
+	return repository.getMembership(ctx, span, query, userID)
+}
+
+// GetIds lists every household. It is not scoped to a user, so it is only
+// meant for background jobs that visit each household in turn.
+func (repository *HouseholdsRepository) GetIds(ctx context.Context) ([]uuid.UUID, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var ids []uuid.UUID
+
+	query := "SELECT id FROM public.households ORDER BY id"
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query)
+	start := time.Now()
+	err := sqlx.SelectContext(ctx, repository.db, &ids, query)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, householdsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, householdsTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(ids)))
+	return ids, nil
+}
+
+// Create inserts a household owned by ownerID. A second personal household
+// for the same owner is not inserted and sql.ErrNoRows is returned instead.
+func (repository *HouseholdsRepository) Create(ctx context.Context, ownerID uuid.UUID, name string, isPersonal bool) (uuid.UUID, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	newID, err := uuid.NewV7()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "uuid generation error", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	query := `INSERT INTO public.households (id, owner_id, name, is_personal)
+			  VALUES (?, ?, ?, ?)
+			  ON CONFLICT (owner_id) WHERE is_personal DO NOTHING
+			  RETURNING id`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "newID", newID, "ownerID", ownerID)
+	start := time.Now()
+	var id uuid.UUID
+	err = sqlx.GetContext(ctx, repository.db, &id, query, newID, ownerID, name, isPersonal)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, householdsTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.logger.InfoContext(ctx, "personal household already exists", "ownerID", ownerID)
+		} else {
+			repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "newID", newID)
+		}
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdsTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, householdsTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, 1)
+	return id, nil
+}
+
+// AddMember returns false when the user already is a member; the existing
+// role is kept.
+func (repository *HouseholdsRepository) AddMember(ctx context.Context, householdID uuid.UUID, userID uuid.UUID, role auth.Role) (bool, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	if !role.Valid() {
+		repository.logger.ErrorContext(ctx, "role is invalid", "role", role)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("role %q is invalid", role)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	query := `INSERT INTO public.household_members (household_id, user_id, role)
+			  VALUES (?, ?, ?)
+			  ON CONFLICT (household_id, user_id) DO NOTHING`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "userID", userID, "role", role)
+	start := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, householdID, userID, role)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, householdMembersTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "householdID", householdID, "userID", userID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "householdID", householdID, "userID", userID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return rowsAffected == 1, nil
+}
+
+func (repository *HouseholdsRepository) getMembership(ctx context.Context, span trace.Span, query string, args ...any) (*domains.HouseholdMembership, error) {
+	var membership domains.HouseholdMembership
+
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "args", args)
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &membership, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, householdMembersTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.logger.InfoContext(ctx, "membership not found", "args", args)
+		} else {
+			repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		}
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, householdMembersTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, 1)
+	return &membership, nil
+}
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+)
+
+type IdempotencyKeysRepository struct {
+	db     DBTX
+	logger *slog.Logger
+}
+
+func NewIdempotencyKeysRepository(db DBTX, logger *slog.Logger) *IdempotencyKeysRepository {
+	return &IdempotencyKeysRepository{db: db, logger: logger}
+}
+
+// Claim reserves the key for the current transaction. An expired record for
+// the same key is discarded first. It returns false when a live record already
+// exists; a concurrent claim blocks until the other transaction finishes.
+func (repository *IdempotencyKeysRepository) Claim(ctx context.Context, claim *domains.IdempotencyClaim, ttl time.Duration) (bool, error) {
+	tracer := otel.Tracer("idempotency-keys")
+	ctx, span := tracer.Start(ctx, "idempotency-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	if claim == nil {
+		repository.logger.ErrorContext(ctx, "claim should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("claim should not be nil")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	now := time.Now().UTC()
+
+	deleteQuery := "DELETE FROM public.idempotency_keys WHERE scope = ? AND key = ? AND expires_at <= ?"
+	deleteQuery = repository.db.Rebind(deleteQuery)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", deleteQuery, "scope", claim.Scope, "key", claim.Key)
+	deleteStart := time.Now()
+	_, err := repository.db.ExecContext(ctx, deleteQuery, claim.Scope, claim.Key, now)
+	metrics.RecordDatabaseDuration(ctx, deleteStart, databaseDriver, idempotencyKeysTableName, err == nil, metrics.DatabaseOperationDelete)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "scope", claim.Scope, "key", claim.Key)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, true, metrics.DatabaseOperationDelete)
+
+	query := `INSERT INTO public.idempotency_keys (scope, key, request_hash, created_at, expires_at)
+			  VALUES (?, ?, ?, ?, ?)
+			  ON CONFLICT (scope, key) DO NOTHING`
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "scope", claim.Scope, "key", claim.Key)
+	start := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, claim.Scope, claim.Key, claim.RequestHash, now, now.Add(ttl))
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, idempotencyKeysTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "scope", claim.Scope, "key", claim.Key)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "scope", claim.Scope, "key", claim.Key)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return rowsAffected > 0, nil
+}
+
+func (repository *IdempotencyKeysRepository) GetByKey(ctx context.Context, scope string, key string) (*domains.IdempotencyKey, error) {
+	tracer := otel.Tracer("idempotency-keys")
+	ctx, span := tracer.Start(ctx, "idempotency-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var idempotencyKey domains.IdempotencyKey
+
+	query := `SELECT scope, key, request_hash, status_code, resource_id, response_body, created_at, expires_at
+			  FROM public.idempotency_keys
+			  WHERE scope = ? AND key = ?`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "scope", scope, "key", key)
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &idempotencyKey, query, scope, key)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, idempotencyKeysTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err, "scope", scope, "key", key)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, 1)
+	return &idempotencyKey, nil
+}
+
+func (repository *IdempotencyKeysRepository) Complete(ctx context.Context, scope string, key string, complete *domains.IdempotencyKeyComplete) (bool, error) {
+	tracer := otel.Tracer("idempotency-keys")
+	ctx, span := tracer.Start(ctx, "idempotency-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	if complete == nil {
+		repository.logger.ErrorContext(ctx, "complete should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("complete should not be nil")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	query := "UPDATE public.idempotency_keys SET status_code = ?, resource_id = ?, response_body = ? WHERE scope = ? AND key = ?"
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "scope", scope, "key", key,
+		"statusCode", complete.StatusCode, "resourceId", complete.ResourceId)
+	start := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, complete.StatusCode, complete.ResourceId, complete.ResponseBody, scope, key)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, idempotencyKeysTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "scope", scope, "key", key)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "scope", scope, "key", key)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, true, metrics.DatabaseOperationUpdate)
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return rowsAffected > 0, nil
+}
+
+// DeleteExpired deletes the records of every scope that expired at or before
+// expiredBefore and returns how many were deleted. Claim only discards an
+// expired record when its key is reused, so keys used once would otherwise
+// stay forever.
+func (repository *IdempotencyKeysRepository) DeleteExpired(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	tracer := otel.Tracer("idempotency-keys")
+	ctx, span := tracer.Start(ctx, "idempotency-keys-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
+	defer span.End()
+
+	query := "DELETE FROM public.idempotency_keys WHERE expires_at <= ?"
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "deleting expired idempotency keys:", "query", query, "expiredBefore", expiredBefore)
+	start := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, expiredBefore.UTC())
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, idempotencyKeysTableName, err == nil, metrics.DatabaseOperationDelete)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "expiredBefore", expiredBefore)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return 0, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, idempotencyKeysTableName, true, metrics.DatabaseOperationDelete)
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return rowsAffected, nil
+}
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+)
+
+type InvitationsRepository struct {
+	db     DBTX
+	logger *slog.Logger
+}
+
+func NewInvitationsRepository(db DBTX, logger *slog.Logger) *InvitationsRepository {
+	return &InvitationsRepository{db: db, logger: logger}
+}
+
+// Create stores the invitation; its Id and CreatedAt are assigned here.
+func (repository *InvitationsRepository) Create(ctx context.Context, invitation *domains.Invitation) (uuid.UUID, error) {
+	tracer := otel.Tracer("invitations")
+	ctx, span := tracer.Start(ctx, "invitations-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	if invitation == nil {
+		repository.logger.ErrorContext(ctx, "invitation should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("invitation should not be nil")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	newID, err := uuid.NewV7()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "uuid generation error", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	query := `INSERT INTO public.invitations (id, household_id, role, token_hash, invited_by, expires_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "newID", newID, "householdID", invitation.HouseholdId, "role", invitation.Role)
+	start := time.Now()
+	res, err := repository.db.ExecContext(ctx, query, newID, invitation.HouseholdId, invitation.Role, invitation.TokenHash, invitation.InvitedBy, invitation.ExpiresAt)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, invitationsTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "newID", newID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	affected, _ := res.RowsAffected()
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, affected)
+	return newID, nil
+}
+
+// GetByTokenHashForUpdate locks the invitation until the transaction ends so
+// that it cannot be accepted twice concurrently.
+func (repository *InvitationsRepository) GetByTokenHashForUpdate(ctx context.Context, tokenHash string) (*domains.Invitation, error) {
+	tracer := otel.Tracer("invitations")
+	ctx, span := tracer.Start(ctx, "invitations-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var invitation domains.Invitation
+
+	query := `SELECT id, household_id, role, token_hash, invited_by, created_at, expires_at, accepted_by, accepted_at
+			  FROM public.invitations
+			  WHERE token_hash = ?
+			  FOR UPDATE`
+	query = repository.db.Rebind(query)
+
+	// The token hash stays out of the logs.
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query)
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &invitation, query, tokenHash)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, invitationsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.logger.InfoContext(ctx, "invitation not found")
+		} else {
+			repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		}
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, 1)
+	return &invitation, nil
+}
+
+func (repository *InvitationsRepository) MarkAccepted(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	tracer := otel.Tracer("invitations")
+	ctx, span := tracer.Start(ctx, "invitations-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	query := "UPDATE public.invitations SET accepted_by = ?, accepted_at = ? WHERE id = ? AND accepted_at IS NULL"
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "id", id, "userID", userID)
+	start := time.Now()
+	res, err := repository.db.ExecContext(ctx, query, userID, time.Now().UTC(), id)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, invitationsTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", id)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		repository.logger.InfoContext(ctx, "invitation was already accepted", "id", id)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, domains.ErrInvitationGone, 0)
+		return domains.ErrInvitationGone
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, invitationsTableName, true, metrics.DatabaseOperationUpdate)
+	traces.EnrichSuccessRepositorySpanWrite(span, affected)
+	return nil
+}
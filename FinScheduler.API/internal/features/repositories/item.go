@@ -17,6 +17,16 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+// itemSortColumns maps the sortable fields of an item listing to columns.
+var itemSortColumns = map[string]string{
+	"name":      "i.name",
+	"price":     "i.price",
+	"cashback":  "i.cashback",
+	"category":  "i.category",
+	"createdAt": "i.created_at",
+	"updatedAt": "i.updated_at",
+}
+
 type ItemsRepository struct {
 	db     DBTX
 	logger *slog.Logger
@@ -26,7 +36,9 @@ func NewItemsRepository(db DBTX, logger *slog.Logger) *ItemsRepository {
 	return &ItemsRepository{db: db, logger: logger}
 }
 
-func (repository *ItemsRepository) GetListingInfo(ctx context.Context, filter *domains.ItemFilter) ([]domains.Item, int64, error) {
+// GetListingInfo returns a page of the items matching filter, newest first,
+// with the cursors around it and, unless filter opts out, the total count.
+func (repository *ItemsRepository) GetListingInfo(ctx context.Context, householdID uuid.UUID, filter *domains.ItemFilter) ([]domains.Item, domains.PageInfo, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -36,144 +48,75 @@ func (repository *ItemsRepository) GetListingInfo(ctx context.Context, filter *d
 	var count int64 = 0
 
 	itemsQuery := "FROM public.items i"
-	filters := make([]string, 0)
-	args := make([]interface{}, 0)
-
-	if filter.Ids != nil && len(filter.Ids) > 0 {
-		inQuery, inArgs, err := sqlx.In("i.id IN (?)", filter.Ids)
-
-		if err != nil {
-			repository.logger.ErrorContext(ctx, "error binding \"Ids\" array to IN filter", "error", err)
-			metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
-			traces.EnrichFailedRepositorySpanRead(span, err, count)
-			return nil, 0, err
-		}
-
-		filters = append(filters, inQuery)
-		args = append(args, inArgs...)
-	}
-
-	if filter.Name != nil && len(*filter.Name) > 0 {
-		filters = append(filters, "i.name ILIKE ?")
-		args = append(args, fmt.Sprintf("%%%s%%", *filter.Name))
-	}
-
-	if filter.PriceFrom != nil {
-		filters = append(filters, "i.price >= ?")
-		args = append(args, *filter.PriceFrom)
-	}
-
-	if filter.PriceTo != nil {
-		filters = append(filters, "i.price <= ?")
-		args = append(args, *filter.PriceTo)
-	}
-
-	if filter.Description != nil && len(*filter.Description) > 0 {
-		filters = append(filters, "i.description ILIKE ?")
-		args = append(args, fmt.Sprintf("%%%s%%", *filter.Description))
-	}
-
-	if filter.IsActive != nil {
-		filters = append(filters, "i.is_active = ?")
-		args = append(args, *filter.IsActive)
-	}
-
-	if filter.CreatedFrom != nil {
-		filters = append(filters, "i.created_at >= ?")
-		args = append(args, *filter.CreatedFrom)
-	}
-
-	if filter.CreatedTo != nil {
-		filters = append(filters, "i.created_at <= ?")
-		args = append(args, *filter.CreatedTo)
-	}
-
-	if filter.UpdatedFrom != nil {
-		filters = append(filters, "i.updated_at >= ?")
-		args = append(args, *filter.UpdatedFrom)
-	}
-
-	if filter.UpdatedTo != nil {
-		filters = append(filters, "i.updated_at <= ?")
-		args = append(args, *filter.UpdatedTo)
+	filters, args, err := itemFilterConditions(householdID, filter)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error binding item filter", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanRead(span, err, count)
+		return nil, domains.PageInfo{}, err
 	}
 
-	if filter.CashbackFrom != nil {
-		filters = append(filters, "i.cashback >= ?")
-		args = append(args, *filter.CashbackFrom)
+	if len(filters) > 0 {
+		itemsQuery += " WHERE " + strings.Join(filters, " AND ")
 	}
 
-	if filter.CashbackTo != nil {
-		filters = append(filters, "i.cashback <= ?")
-		args = append(args, *filter.CashbackTo)
+	keyColumns := []string{"i.created_at", "i.id"}
+	page := newKeyset(filter.Page, filter.PageSize, filter.After, filter.Before)
+	keyCondition, keyArgs := page.condition(keyColumns, func(cursor *domains.Cursor) []interface{} {
+		return []interface{}{*cursor.CreatedAt, cursor.Id}
+	})
+	pageQuery := itemsQuery
+	if keyCondition != "" {
+		pageQuery += " AND " + keyCondition
 	}
-
-	if filter.Categories != nil && len(filter.Categories) > 0 {
-		inQuery, inArgs, err := sqlx.In("i.category IN (?)", filter.Categories)
-
+	limit, offset := page.limitOffset()
+	orderBy := page.orderBy(keyColumns)
+	if len(filter.Sort) > 0 {
+		orderBy, err = sortOrderBy(filter.Sort, itemSortColumns, map[string]bool{"updatedAt": true}, "i.id")
 		if err != nil {
-			repository.logger.ErrorContext(ctx, "error binding \"Categories\" array to IN filter", "error", err)
+			repository.logger.ErrorContext(ctx, "error binding item sort", "error", err)
 			metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
 			traces.EnrichFailedRepositorySpanRead(span, err, count)
-			return nil, 0, err
+			return nil, domains.PageInfo{}, err
 		}
-
-		filters = append(filters, inQuery)
-		args = append(args, inArgs...)
 	}
 
-	if filter.TagIds != nil && len(filter.TagIds) > 0 {
-		inQuery, inArgs, err := sqlx.In(`EXISTS (
-			SELECT 1 FROM public.tag_to_item tti 
-			WHERE tti.item_id = i.id AND tti.tag_id IN (?)
-		)`, filter.TagIds)
-
-		if err != nil {
-			repository.logger.ErrorContext(ctx, "error binding \"TagIds\" array to IN filter", "error", err)
-			metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
-			traces.EnrichFailedRepositorySpanRead(span, err, count)
-			return nil, 0, err
-		}
-
-		filters = append(filters, inQuery)
-		args = append(args, inArgs...)
-	}
-
-	if len(filters) > 0 {
-		itemsQuery += " WHERE " + strings.Join(filters, " AND ")
-	}
-
-	var pageSize int32 = 20
-	if filter.PageSize != nil {
-		pageSize = *filter.PageSize
-	}
-	var page int32 = 0
-	if filter.Page != nil {
-		page = *filter.Page
-	}
-	offset := page * pageSize
-
 	itemsSelectQuery := fmt.Sprintf(
-		"SELECT i.id, i.name, i.price, i.is_active, i.updated_at, i.cashback %s ORDER BY i.created_at DESC, i.id DESC LIMIT ? OFFSET ?",
-		itemsQuery,
+		"SELECT i.id, i.name, i.price, i.is_active, i.created_at, i.updated_at, i.cashback %s %s LIMIT ? OFFSET ?",
+		pageQuery,
+		orderBy,
 	)
 	itemsSelectQuery = repository.db.Rebind(itemsSelectQuery)
 	itemsSelectArgs := append(make([]interface{}, 0), args...)
-	itemsSelectArgs = append(itemsSelectArgs, pageSize, offset)
+	itemsSelectArgs = append(itemsSelectArgs, keyArgs...)
+	itemsSelectArgs = append(itemsSelectArgs, limit, offset)
 
 	repository.logger.InfoContext(ctx, "executing operation:", "itemsQuery", itemsSelectQuery, "args", itemsSelectArgs)
 	itemsSelectStart := time.Now()
-	err := sqlx.SelectContext(ctx, repository.db, &items, itemsSelectQuery, itemsSelectArgs...)
+	err = sqlx.SelectContext(ctx, repository.db, &items, itemsSelectQuery, itemsSelectArgs...)
 	metrics.RecordDatabaseDuration(ctx, itemsSelectStart, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationSelect)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationSelect)
 		traces.EnrichFailedRepositorySpanRead(span, err, count)
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
 	} else {
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationSelect)
 	}
 
+	rows, pageInfo := keysetPage(page, rh.DereferenceSlice(items), func(item domains.Item) domains.Cursor {
+		createdAt := item.CreatedAt
+		return domains.Cursor{CreatedAt: &createdAt, Id: item.Id}
+	})
+	if len(filter.Sort) > 0 {
+		pageInfo.Next, pageInfo.Previous = nil, nil
+	}
+
+	if filter.IncludeCount != nil && !*filter.IncludeCount {
+		traces.EnrichSuccessRepositorySpanRead(span, int64(len(rows)))
+		return rows, pageInfo, nil
+	}
+
 	itemsCountQuery := fmt.Sprintf("SELECT COUNT(*) %s", itemsQuery)
 	itemsCountQuery = repository.db.Rebind(itemsCountQuery)
 	itemsCountArgs := append(make([]interface{}, 0), args...)
@@ -186,16 +129,17 @@ func (repository *ItemsRepository) GetListingInfo(ctx context.Context, filter *d
 		repository.logger.ErrorContext(ctx, "error on COUNT operation", "error", err)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationCount)
 		traces.EnrichFailedRepositorySpanRead(span, err, count)
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
 	} else {
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationCount)
 	}
 
-	traces.EnrichSuccessRepositorySpanRead(span, int64(len(items)))
-	return rh.DereferenceSlice(items), count, err
+	pageInfo.Count = &count
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(rows)))
+	return rows, pageInfo, err
 }
 
-func (repository *ItemsRepository) GetDetailedInfo(ctx context.Context, id uuid.UUID) (*domains.Item, error) {
+func (repository *ItemsRepository) GetDetailedInfo(ctx context.Context, householdID uuid.UUID, id uuid.UUID) (*domains.Item, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -212,12 +156,12 @@ func (repository *ItemsRepository) GetDetailedInfo(ctx context.Context, id uuid.
 		return nil, err
 	}
 
-	query := "SELECT name, price, description, is_active, cashback, category FROM public.items WHERE id = ?"
+	query := "SELECT name, price, COALESCE(description, '') AS description, is_active, cashback, category, version FROM public.items WHERE household_id = ? AND id = ? AND deleted_at IS NULL"
 	query = repository.db.Rebind(query)
 
-	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "id", id)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "id", id)
 	start := time.Now()
-	err := sqlx.GetContext(ctx, repository.db, &item, query, id)
+	err := sqlx.GetContext(ctx, repository.db, &item, query, householdID, id)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationSelect)
 
 	if err != nil {
@@ -233,7 +177,7 @@ func (repository *ItemsRepository) GetDetailedInfo(ctx context.Context, id uuid.
 	return &item, nil
 }
 
-func (repository *ItemsRepository) Create(ctx context.Context, create *domains.ItemCreate) (uuid.UUID, error) {
+func (repository *ItemsRepository) Create(ctx context.Context, householdID uuid.UUID, create *domains.ItemCreate) (uuid.UUID, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
@@ -249,16 +193,16 @@ func (repository *ItemsRepository) Create(ctx context.Context, create *domains.I
 		return uuid.Nil, err
 	}
 
-	query := "INSERT INTO public.items (id, name, price, description, is_active, created_at, cashback, category) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	query := "INSERT INTO public.items (id, household_id, name, price, description, is_active, created_at, cashback, category) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
 	query = repository.db.Rebind(query)
 	repository.logger.InfoContext(ctx, "executing operation:", "query", query)
 	start := time.Now()
-	res, err := repository.db.ExecContext(ctx, query, newID, create.Name, create.Price, create.Description, create.IsActive, now, create.Cashback, create.Category)
+	res, err := repository.db.ExecContext(ctx, query, newID, householdID, create.Name, create.Price, create.Description, create.IsActive, now, create.Cashback, create.Category)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationInsert)
 	var affected int64 = 0
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "newID",
-			newID, "name", create.Name, "price", create.Price, "description", create.Description, "isActive",
+			newID, "householdID", householdID, "name", create.Name, "price", create.Price, "description", create.Description, "isActive",
 			create.IsActive, "createdAt", now, "cashback", create.Cashback, "category", create.Category)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationInsert)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
@@ -272,7 +216,7 @@ func (repository *ItemsRepository) Create(ctx context.Context, create *domains.I
 	return newID, err
 }
 
-func (repository *ItemsRepository) Update(ctx context.Context, itemID uuid.UUID, update *domains.ItemUpdate) (bool, error) {
+func (repository *ItemsRepository) Update(ctx context.Context, householdID uuid.UUID, itemID uuid.UUID, update *domains.ItemUpdate, expectedVersion *int32) (bool, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
@@ -280,14 +224,19 @@ func (repository *ItemsRepository) Update(ctx context.Context, itemID uuid.UUID,
 
 	now := time.Now().UTC()
 
-	query := "UPDATE public.items SET name = ?, price = ?, description = ?, is_active = ?, updated_at = ?, cashback = ?, category = ? WHERE id = ?"
+	query := "UPDATE public.items SET name = ?, price = ?, description = ?, is_active = ?, updated_at = ?, cashback = ?, category = ?, version = version + 1 WHERE household_id = ? AND id = ? AND deleted_at IS NULL"
+	args := []interface{}{update.Name, update.Price, update.Description, update.IsActive,
+		sql.NullTime{Time: now, Valid: true}, update.Cashback, update.Category, householdID, itemID}
+	if expectedVersion != nil {
+		query += " AND version = ?"
+		args = append(args, *expectedVersion)
+	}
 	query = repository.db.Rebind(query)
 	repository.logger.InfoContext(ctx, "updating an item:", "id",
 		itemID, "name", update.Name, "price", update.Price, "description", update.Description, "isActive",
-		update.IsActive, "updatedAt", now, "cashback", update.Cashback, "category", update.Category)
+		update.IsActive, "updatedAt", now, "cashback", update.Cashback, "category", update.Category, "expectedVersion", expectedVersion)
 	updateStart := time.Now()
-	result, err := repository.db.ExecContext(ctx, query, update.Name, update.Price, update.Description, update.IsActive,
-		sql.NullTime{Time: now, Valid: true}, update.Cashback, update.Category, itemID)
+	result, err := repository.db.ExecContext(ctx, query, args...)
 	metrics.RecordDatabaseDuration(ctx, updateStart, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationUpdate)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id",
@@ -313,21 +262,114 @@ func (repository *ItemsRepository) Update(ctx context.Context, itemID uuid.UUID,
 	return success, err
 }
 
-func (repository *ItemsRepository) Delete(ctx context.Context, itemID uuid.UUID) (bool, error) {
+func (repository *ItemsRepository) Patch(ctx context.Context, householdID uuid.UUID, itemID uuid.UUID, patch *domains.ItemPatch, expectedVersion *int32) (bool, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
-	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
 	defer span.End()
 
-	query := "DELETE FROM public.items WHERE id = ?"
+	if patch == nil {
+		repository.logger.ErrorContext(ctx, "patch should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("patch should not be nil")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	assignments := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if patch.Name != nil {
+		assignments = append(assignments, "name = ?")
+		args = append(args, *patch.Name)
+	}
+
+	if patch.Price != nil {
+		assignments = append(assignments, "price = ?")
+		args = append(args, *patch.Price)
+	}
+
+	if patch.Description.Set {
+		assignments = append(assignments, "description = ?")
+		args = append(args, patch.Description.Value)
+	}
+
+	if patch.IsActive != nil {
+		assignments = append(assignments, "is_active = ?")
+		args = append(args, *patch.IsActive)
+	}
+
+	if patch.Cashback != nil {
+		assignments = append(assignments, "cashback = ?")
+		args = append(args, *patch.Cashback)
+	}
+
+	if patch.Category != nil {
+		assignments = append(assignments, "category = ?")
+		args = append(args, *patch.Category)
+	}
+
+	assignments = append(assignments, "updated_at = ?", "version = version + 1")
+	args = append(args, sql.NullTime{Time: now, Valid: true}, householdID, itemID)
+
+	query := fmt.Sprintf("UPDATE public.items SET %s WHERE household_id = ? AND id = ? AND deleted_at IS NULL", strings.Join(assignments, ", "))
+	if expectedVersion != nil {
+		query += " AND version = ?"
+		args = append(args, *expectedVersion)
+	}
 	query = repository.db.Rebind(query)
-	repository.logger.InfoContext(ctx, "fetching delete item:", "query", query, "id", itemID)
+	repository.logger.InfoContext(ctx, "patching an item:", "query", query, "args", args)
+	patchStart := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, args...)
+	metrics.RecordDatabaseDuration(ctx, patchStart, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", itemID, "args", args)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	success := rowsAffected > 0
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
+
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return success, err
+}
+
+// Delete moves an item to the trash. The row, its price history and its tag
+// links are kept until PurgeDeleted removes them.
+func (repository *ItemsRepository) Delete(ctx context.Context, householdID uuid.UUID, itemID uuid.UUID, expectedVersion *int32) (bool, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	now := time.Now().UTC()
+
+	query := "UPDATE public.items SET deleted_at = ?, version = version + 1 WHERE household_id = ? AND id = ? AND deleted_at IS NULL"
+	args := []interface{}{now, householdID, itemID}
+	if expectedVersion != nil {
+		query += " AND version = ?"
+		args = append(args, *expectedVersion)
+	}
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "moving an item to the trash:", "query", query, "id", itemID, "deletedAt", now, "expectedVersion", expectedVersion)
 	start := time.Now()
-	result, err := repository.db.ExecContext(ctx, query, itemID)
-	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationDelete)
+	result, err := repository.db.ExecContext(ctx, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationUpdate)
 	if err != nil {
-		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "id", itemID)
-		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationDelete)
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", itemID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
 		return false, err
 	}
@@ -335,19 +377,157 @@ func (repository *ItemsRepository) Delete(ctx context.Context, itemID uuid.UUID)
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
-		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationDelete)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
 		return false, err
 	}
 
 	success := rowsAffected > 0
-	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationDelete)
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
 
 	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
 	return success, err
 }
 
-func (repository *ItemsRepository) UpdateCashbackByTag(ctx context.Context, tagID uuid.UUID, cashback int32) (int64, error) {
+// GetTrash lists the items in the trash, the most recently deleted first.
+func (repository *ItemsRepository) GetTrash(ctx context.Context, householdID uuid.UUID, filter *domains.ItemTrashFilter) ([]domains.Item, int64, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	var items []domains.Item
+	var count int64 = 0
+
+	var pageSize int32 = 20
+	if filter.PageSize != nil {
+		pageSize = *filter.PageSize
+	}
+	var page int32 = 0
+	if filter.Page != nil {
+		page = *filter.Page
+	}
+	offset := page * pageSize
+
+	selectQuery := `SELECT id, name, price, category, deleted_at
+			  FROM public.items
+			  WHERE household_id = ? AND deleted_at IS NOT NULL
+			  ORDER BY deleted_at DESC, id DESC LIMIT ? OFFSET ?`
+	selectQuery = repository.db.Rebind(selectQuery)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", selectQuery, "householdID", householdID, "pageSize", pageSize, "offset", offset)
+	selectStart := time.Now()
+	err := sqlx.SelectContext(ctx, repository.db, &items, selectQuery, householdID, pageSize, offset)
+	metrics.RecordDatabaseDuration(ctx, selectStart, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, count)
+		return nil, 0, err
+	} else {
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationSelect)
+	}
+
+	countQuery := "SELECT COUNT(*) FROM public.items WHERE household_id = ? AND deleted_at IS NOT NULL"
+	countQuery = repository.db.Rebind(countQuery)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", countQuery, "householdID", householdID)
+	countStart := time.Now()
+	err = sqlx.GetContext(ctx, repository.db, &count, countQuery, householdID)
+	metrics.RecordDatabaseDuration(ctx, countStart, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationCount)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on COUNT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationCount)
+		traces.EnrichFailedRepositorySpanRead(span, err, count)
+		return nil, 0, err
+	} else {
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationCount)
+	}
+
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(items)))
+	return items, count, nil
+}
+
+// Restore takes an item out of the trash and returns when it had been
+// deleted; the result is not valid when the item is not in the trash.
+func (repository *ItemsRepository) Restore(ctx context.Context, householdID uuid.UUID, itemID uuid.UUID) (sql.NullTime, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	now := time.Now().UTC()
+	query := `UPDATE public.items AS i
+			  SET deleted_at = NULL, updated_at = ?, version = i.version + 1
+			  FROM (
+			  	SELECT p.id, p.deleted_at
+			  	FROM public.items p
+			  	WHERE p.household_id = ? AND p.id = ? AND p.deleted_at IS NOT NULL
+			  	FOR UPDATE
+			  ) AS previous
+			  WHERE i.id = previous.id
+			  RETURNING previous.deleted_at`
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "restoring an item:", "query", query, "householdID", householdID, "id", itemID, "updatedAt", now)
+	var deletedAt sql.NullTime
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &deletedAt, query, sql.NullTime{Time: now, Valid: true}, householdID, itemID)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil || err == sql.ErrNoRows, metrics.DatabaseOperationUpdate)
+	if err == sql.ErrNoRows {
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
+		traces.EnrichSuccessRepositorySpanWrite(span, 0)
+		return sql.NullTime{}, nil
+	}
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", itemID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return sql.NullTime{}, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
+	traces.EnrichSuccessRepositorySpanWrite(span, 1)
+	return deletedAt, nil
+}
+
+// PurgeDeleted permanently deletes the items of the household that were moved
+// to the trash before deletedBefore, together with their price history and
+// tag links, and returns how many items were deleted.
+func (repository *ItemsRepository) PurgeDeleted(ctx context.Context, householdID uuid.UUID, deletedBefore time.Time) (int64, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
+	defer span.End()
+
+	query := "DELETE FROM public.items WHERE household_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?"
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "purging deleted items:", "query", query, "householdID", householdID, "deletedBefore", deletedBefore)
+	start := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, householdID, deletedBefore.UTC())
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationDelete)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "householdID", householdID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return 0, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationDelete)
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return rowsAffected, nil
+}
+
+// UpdateCashbackByTag sets the cashback of every item linked to tagID and
+// returns the previous and new cashback of each.
+func (repository *ItemsRepository) UpdateCashbackByTag(ctx context.Context, householdID uuid.UUID, tagID uuid.UUID, cashback int32) ([]domains.ItemCashbackChange, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
@@ -359,43 +539,139 @@ func (repository *ItemsRepository) UpdateCashbackByTag(ctx context.Context, tagI
 
 		err := fmt.Errorf("tagID should not be nil")
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
-		return 0, err
+		return nil, err
 	}
 
 	now := time.Now().UTC()
 	query := `UPDATE public.items AS i
-			  SET cashback = ?, updated_at = ?
-			  WHERE EXISTS (
-			  	SELECT 1
-			  	FROM public.tag_to_item tti
-			  	WHERE tti.item_id = i.id AND tti.tag_id = ?
-			  )`
+			  SET cashback = ?, updated_at = ?, version = i.version + 1
+			  FROM (
+			  	SELECT p.id, p.cashback
+			  	FROM public.items p
+			  	WHERE p.household_id = ? AND p.deleted_at IS NULL AND EXISTS (
+			  		SELECT 1
+			  		FROM public.tag_to_item tti
+			  		WHERE tti.item_id = p.id AND tti.tag_id = ?
+			  	)
+			  	FOR UPDATE
+			  ) AS previous
+			  WHERE i.id = previous.id
+			  RETURNING i.id, previous.cashback AS previous_cashback, i.cashback`
 	query = repository.db.Rebind(query)
-	repository.logger.InfoContext(ctx, "updating cashback by tag", "query", query, "tagId", tagID, "cashback", cashback, "updatedAt", now)
+	repository.logger.InfoContext(ctx, "updating cashback by tag", "query", query, "householdID", householdID, "tagId", tagID, "cashback", cashback, "updatedAt", now)
+	var changes []domains.ItemCashbackChange
 	start := time.Now()
-	result, err := repository.db.ExecContext(ctx, query, cashback, sql.NullTime{Time: now, Valid: true}, tagID)
+	err := sqlx.SelectContext(ctx, repository.db, &changes, query, cashback, sql.NullTime{Time: now, Valid: true}, householdID, tagID)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationUpdate)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error updating cashback by tag", "error", err, "tagId", tagID, "cashback", cashback, "updatedAt", now)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
-		return 0, err
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
+	traces.EnrichSuccessRepositorySpanWrite(span, int64(len(changes)))
+	return changes, nil
+}
+
+// UpdateCashbackByIds sets the cashback of the given items and returns the
+// previous and new cashback of each one that exists.
+func (repository *ItemsRepository) UpdateCashbackByIds(ctx context.Context, householdID uuid.UUID, itemIDs []uuid.UUID, cashback int32) ([]domains.ItemCashbackChange, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	if len(itemIDs) == 0 {
+		repository.logger.ErrorContext(ctx, "itemIDs should not be empty")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("itemIDs should not be empty")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	query := `UPDATE public.items AS i
+			  SET cashback = ?, updated_at = ?, version = i.version + 1
+			  FROM (
+			  	SELECT p.id, p.cashback
+			  	FROM public.items p
+			  	WHERE p.household_id = ? AND p.deleted_at IS NULL AND p.id IN (?)
+			  	FOR UPDATE
+			  ) AS previous
+			  WHERE i.id = previous.id
+			  RETURNING i.id, previous.cashback AS previous_cashback, i.cashback`
+	query, args, err := sqlx.In(query, cashback, sql.NullTime{Time: now, Valid: true}, householdID, itemIDs)
 	if err != nil {
-		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		repository.logger.ErrorContext(ctx, "error binding itemIDs array to IN filter", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "updating cashback by ids", "query", query, "householdID", householdID, "itemIds", itemIDs, "cashback", cashback, "updatedAt", now)
+	var changes []domains.ItemCashbackChange
+	start := time.Now()
+	err = sqlx.SelectContext(ctx, repository.db, &changes, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error updating cashback by ids", "error", err, "itemIds", itemIDs, "cashback", cashback, "updatedAt", now)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
-		return 0, err
+		return nil, err
 	}
 
 	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
-	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
-	return rowsAffected, nil
+	traces.EnrichSuccessRepositorySpanWrite(span, int64(len(changes)))
+	return changes, nil
 }
 
-func (repository *ItemsRepository) UpdateCashbackByIds(ctx context.Context, itemIDs []uuid.UUID, cashback int32) (int64, error) {
+// LockByFilter returns the items of the household outside the trash that
+// match filter, ignoring its paging, and locks them for the rest of the
+// transaction.
+func (repository *ItemsRepository) LockByFilter(ctx context.Context, householdID uuid.UUID, filter *domains.ItemFilter) ([]domains.Item, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	filters, args, err := itemFilterConditions(householdID, filter)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error binding item filter", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	query := fmt.Sprintf(
+		"SELECT i.id, i.name, i.price, COALESCE(i.description, '') AS description, i.is_active, i.cashback, i.category, i.version FROM public.items i WHERE %s ORDER BY i.id FOR UPDATE",
+		strings.Join(filters, " AND "),
+	)
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "locking items by filter", "query", query, "args", args)
+	var items []domains.Item
+	start := time.Now()
+	err = sqlx.SelectContext(ctx, repository.db, &items, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(items)))
+	return items, nil
+}
+
+// BulkUpdate applies the operation of change to the given items outside the
+// trash and returns the items it changed, as they are after the change. Items
+// that already hold the value are left untouched. Deletion is BulkDelete's.
+func (repository *ItemsRepository) BulkUpdate(ctx context.Context, householdID uuid.UUID, itemIDs []uuid.UUID, change *domains.ItemBulkChange) ([]domains.Item, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
@@ -407,40 +683,215 @@ func (repository *ItemsRepository) UpdateCashbackByIds(ctx context.Context, item
 
 		err := fmt.Errorf("itemIDs should not be empty")
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
-		return 0, err
+		return nil, err
+	}
+
+	var column, expression string
+	var value interface{}
+	switch change.Operation {
+	case domains.ItemBulkSetActive:
+		column, expression, value = "is_active", "?", *change.IsActive
+	case domains.ItemBulkSetCategory:
+		column, expression, value = "category", "?", *change.Category
+	case domains.ItemBulkSetCashback:
+		column, expression, value = "cashback", "?", *change.Cashback
+	case domains.ItemBulkAdjustPrice:
+		column, expression, value = "price", "ROUND(price * (100 + ?::numeric) / 100, 2)", *change.PricePercent
+	default:
+		repository.logger.ErrorContext(ctx, "unsupported bulk operation", "operation", change.Operation)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("unsupported bulk operation: %s", change.Operation)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
 	}
 
 	now := time.Now().UTC()
-	query := "UPDATE public.items SET cashback = ?, updated_at = ? WHERE id IN (?)"
-	query, args, err := sqlx.In(query, cashback, sql.NullTime{Time: now, Valid: true}, itemIDs)
+	query := fmt.Sprintf(`UPDATE public.items
+			  SET %[1]s = %[2]s, updated_at = ?, version = version + 1
+			  WHERE household_id = ? AND deleted_at IS NULL AND id IN (?) AND %[1]s IS DISTINCT FROM %[2]s
+			  RETURNING id, name, price, COALESCE(description, '') AS description, is_active, cashback, category, version`, column, expression)
+	query, args, err := sqlx.In(query, value, sql.NullTime{Time: now, Valid: true}, householdID, itemIDs, value)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error binding itemIDs array to IN filter", "error", err)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
-		return 0, err
+		return nil, err
 	}
 
 	query = repository.db.Rebind(query)
-	repository.logger.InfoContext(ctx, "updating cashback by ids", "query", query, "itemIds", itemIDs, "cashback", cashback, "updatedAt", now)
+	repository.logger.InfoContext(ctx, "bulk updating items", "query", query, "householdID", householdID, "itemIds", itemIDs, "operation", change.Operation, "value", value, "updatedAt", now)
+	var items []domains.Item
 	start := time.Now()
-	result, err := repository.db.ExecContext(ctx, query, args...)
+	err = sqlx.SelectContext(ctx, repository.db, &items, query, args...)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationUpdate)
 	if err != nil {
-		repository.logger.ErrorContext(ctx, "error updating cashback by ids", "error", err, "itemIds", itemIDs, "cashback", cashback, "updatedAt", now)
+		repository.logger.ErrorContext(ctx, "error bulk updating items", "error", err, "itemIds", itemIDs, "operation", change.Operation)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
-		return 0, err
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
+	traces.EnrichSuccessRepositorySpanWrite(span, int64(len(items)))
+	return items, nil
+}
+
+// BulkDelete moves the given items to the trash and returns the ids of those
+// that were not in it yet.
+func (repository *ItemsRepository) BulkDelete(ctx context.Context, householdID uuid.UUID, itemIDs []uuid.UUID) ([]uuid.UUID, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	if len(itemIDs) == 0 {
+		repository.logger.ErrorContext(ctx, "itemIDs should not be empty")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("itemIDs should not be empty")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	query := "UPDATE public.items SET deleted_at = ?, version = version + 1 WHERE household_id = ? AND deleted_at IS NULL AND id IN (?) RETURNING id"
+	query, args, err := sqlx.In(query, now, householdID, itemIDs)
 	if err != nil {
-		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		repository.logger.ErrorContext(ctx, "error binding itemIDs array to IN filter", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "moving items to the trash", "query", query, "householdID", householdID, "itemIds", itemIDs, "deletedAt", now)
+	var deleted []uuid.UUID
+	start := time.Now()
+	err = sqlx.SelectContext(ctx, repository.db, &deleted, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, itemsTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "itemIds", itemIDs)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, false, metrics.DatabaseOperationUpdate)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
-		return 0, err
+		return nil, err
 	}
 
 	metrics.RecordDatabaseRequest(ctx, databaseDriver, itemsTableName, true, metrics.DatabaseOperationUpdate)
-	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
-	return rowsAffected, nil
+	traces.EnrichSuccessRepositorySpanWrite(span, int64(len(deleted)))
+	return deleted, nil
+}
+
+// itemFilterConditions returns the WHERE conditions, joined with AND, and
+// their arguments that select the items of householdID outside the trash
+// matching filter. Paging is left to the caller.
+func itemFilterConditions(householdID uuid.UUID, filter *domains.ItemFilter) ([]string, []interface{}, error) {
+	filters := []string{"i.household_id = ?", "i.deleted_at IS NULL"}
+	args := []interface{}{householdID}
+
+	if filter.Ids != nil && len(filter.Ids) > 0 {
+		inQuery, inArgs, err := sqlx.In("i.id IN (?)", filter.Ids)
+		if err != nil {
+			return nil, nil, fmt.Errorf("binding Ids to IN filter: %w", err)
+		}
+
+		filters = append(filters, inQuery)
+		args = append(args, inArgs...)
+	}
+
+	if filter.Name != nil && len(*filter.Name) > 0 {
+		filters = append(filters, "i.name ILIKE ?")
+		args = append(args, fmt.Sprintf("%%%s%%", *filter.Name))
+	}
+
+	if filter.PriceFrom != nil {
+		filters = append(filters, "i.price >= ?")
+		args = append(args, *filter.PriceFrom)
+	}
+
+	if filter.PriceTo != nil {
+		filters = append(filters, "i.price <= ?")
+		args = append(args, *filter.PriceTo)
+	}
+
+	if filter.Description != nil && len(*filter.Description) > 0 {
+		filters = append(filters, "i.description ILIKE ?")
+		args = append(args, fmt.Sprintf("%%%s%%", *filter.Description))
+	}
+
+	if filter.IsActive != nil {
+		filters = append(filters, "i.is_active = ?")
+		args = append(args, *filter.IsActive)
+	}
+
+	if filter.CreatedFrom != nil {
+		filters = append(filters, "i.created_at >= ?")
+		args = append(args, *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		filters = append(filters, "i.created_at <= ?")
+		args = append(args, *filter.CreatedTo)
+	}
+
+	if filter.UpdatedFrom != nil {
+		filters = append(filters, "i.updated_at >= ?")
+		args = append(args, *filter.UpdatedFrom)
+	}
+
+	if filter.UpdatedTo != nil {
+		filters = append(filters, "i.updated_at <= ?")
+		args = append(args, *filter.UpdatedTo)
+	}
+
+	if filter.CashbackFrom != nil {
+		filters = append(filters, "i.cashback >= ?")
+		args = append(args, *filter.CashbackFrom)
+	}
+
+	if filter.CashbackTo != nil {
+		filters = append(filters, "i.cashback <= ?")
+		args = append(args, *filter.CashbackTo)
+	}
+
+	if filter.Categories != nil && len(filter.Categories) > 0 {
+		inQuery, inArgs, err := sqlx.In("i.category IN (?)", filter.Categories)
+		if err != nil {
+			return nil, nil, fmt.Errorf("binding Categories to IN filter: %w", err)
+		}
+
+		filters = append(filters, inQuery)
+		args = append(args, inArgs...)
+	}
+
+	if filter.TagIds != nil && len(filter.TagIds) > 0 && filter.IncludeDescendants != nil && *filter.IncludeDescendants {
+		inQuery, inArgs, err := sqlx.In(`EXISTS (
+			WITH RECURSIVE descendants AS (
+				SELECT t.id FROM public.tags t WHERE t.household_id = ? AND t.id IN (?)
+				UNION
+				SELECT t.id FROM public.tags t JOIN descendants d ON t.parent_id = d.id WHERE t.household_id = ?
+			)
+			SELECT 1 FROM public.tag_to_item tti
+			WHERE tti.item_id = i.id AND tti.tag_id IN (SELECT id FROM descendants)
+		)`, householdID, filter.TagIds, householdID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("binding TagIds to IN filter: %w", err)
+		}
+
+		filters = append(filters, inQuery)
+		args = append(args, inArgs...)
+	} else if filter.TagIds != nil && len(filter.TagIds) > 0 {
+		inQuery, inArgs, err := sqlx.In(`EXISTS (
+			SELECT 1 FROM public.tag_to_item tti 
+			WHERE tti.item_id = i.id AND tti.tag_id IN (?)
+		)`, filter.TagIds)
+		if err != nil {
+			return nil, nil, fmt.Errorf("binding TagIds to IN filter: %w", err)
+		}
+
+		filters = append(filters, inQuery)
+		args = append(args, inArgs...)
+	}
+
+	return filters, args, nil
 }
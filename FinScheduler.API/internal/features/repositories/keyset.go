@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"finscheduler/internal/features/domains"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// keyset pages through a listing sorted newest first by a unique key, either
+// by page index or from one of the after and before cursors. It reads one row
+// beyond the page to find out whether another page follows.
+type keyset struct {
+	pageSize int32
+	offset   int32
+	after    *domains.Cursor
+	before   *domains.Cursor
+}
+
+func newKeyset(page *int32, pageSize *int32, after *domains.Cursor, before *domains.Cursor) keyset {
+	k := keyset{pageSize: 20, after: after, before: before}
+	if pageSize != nil {
+		k.pageSize = *pageSize
+	}
+	if page != nil && after == nil && before == nil {
+		k.offset = *page * k.pageSize
+	}
+
+	return k
+}
+
+// condition returns the comparison of the key columns with the cursor, and
+// its arguments, or an empty condition without a cursor. values returns the
+// cursor's value for each of the columns.
+func (k keyset) condition(columns []string, values func(cursor *domains.Cursor) []interface{}) (string, []interface{}) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	key := fmt.Sprintf("(%s)", strings.Join(columns, ", "))
+
+	switch {
+	case k.after != nil:
+		return fmt.Sprintf("%s < (%s)", key, placeholders), values(k.after)
+	case k.before != nil:
+		return fmt.Sprintf("%s > (%s)", key, placeholders), values(k.before)
+	default:
+		return "", nil
+	}
+}
+
+// orderBy sorts by the key columns newest first, or oldest first when reading
+// backwards from the before cursor.
+func (k keyset) orderBy(columns []string) string {
+	direction := " DESC"
+	if k.before != nil {
+		direction = " ASC"
+	}
+
+	return "ORDER BY " + strings.Join(columns, direction+", ") + direction
+}
+
+// sortOrderBy orders by the client-selected fields, mapped to their columns,
+// and then by idColumn, in the direction of the last field, so that rows with
+// equal values keep a stable order that an index on (column, id) can serve.
+// Nullable columns sort their NULLs last in either direction.
+func sortOrderBy(fields []domains.SortField, columns map[string]string, nullable map[string]bool, idColumn string) (string, error) {
+	terms := make([]string, 0, len(fields)+1)
+	direction := " ASC"
+	for _, field := range fields {
+		column, ok := columns[field.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown sort field %q", field.Name)
+		}
+
+		direction = " ASC"
+		if field.Descending {
+			direction = " DESC"
+		}
+		term := column + direction
+		if nullable[field.Name] {
+			term += " NULLS LAST"
+		}
+		terms = append(terms, term)
+	}
+	terms = append(terms, idColumn+direction)
+
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// limitOffset returns the LIMIT and OFFSET arguments of the page query.
+func (k keyset) limitOffset() (int32, int32) {
+	return k.pageSize + 1, k.offset
+}
+
+// keysetPage trims the rows read for k to the page, puts them newest first
+// and returns the cursors of the pages around it. count is left to the caller.
+func keysetPage[T any](k keyset, rows []T, cursorOf func(row T) domains.Cursor) ([]T, domains.PageInfo) {
+	var page domains.PageInfo
+
+	more := int32(len(rows)) > k.pageSize
+	if more {
+		rows = rows[:k.pageSize]
+	}
+	if k.before != nil {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, page
+	}
+
+	first := cursorOf(rows[0])
+	last := cursorOf(rows[len(rows)-1])
+	if k.before != nil {
+		page.Next = &last
+		if more {
+			page.Previous = &first
+		}
+	} else {
+		if more {
+			page.Next = &last
+		}
+		if k.after != nil || k.offset > 0 {
+			page.Previous = &first
+		}
+	}
+
+	return rows, page
+}
@@ -23,7 +23,7 @@ func NewPriceHistoriesRepository(db DBTX, logger *slog.Logger) *PriceHistoriesRe
 	return &PriceHistoriesRepository{db: db, logger: logger}
 }
 
-func (repository *PriceHistoriesRepository) GetByItemID(ctx context.Context, itemID uuid.UUID) ([]domains.PriceHistory, error) {
+func (repository *PriceHistoriesRepository) GetByItemID(ctx context.Context, householdID uuid.UUID, itemID uuid.UUID) ([]domains.PriceHistory, error) {
 	tracer := otel.Tracer("price-histories")
 	ctx, span := tracer.Start(ctx, "price-histories-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -42,13 +42,13 @@ func (repository *PriceHistoriesRepository) GetByItemID(ctx context.Context, ite
 
 	query := `SELECT recorded_at, value
 			  FROM public.price_history
-			  WHERE item_id = ?
+			  WHERE household_id = ? AND item_id = ?
 			  ORDER BY recorded_at DESC`
 	query = repository.db.Rebind(query)
 
-	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "itemID", itemID)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "itemID", itemID)
 	start := time.Now()
-	err := sqlx.SelectContext(ctx, repository.db, &priceHistories, query, itemID)
+	err := sqlx.SelectContext(ctx, repository.db, &priceHistories, query, householdID, itemID)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, priceHistoryTableName, err == nil, metrics.DatabaseOperationSelect)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err, "itemID", itemID)
@@ -62,7 +62,7 @@ func (repository *PriceHistoriesRepository) GetByItemID(ctx context.Context, ite
 	return priceHistories, nil
 }
 
-func (repository *PriceHistoriesRepository) UpsertToday(ctx context.Context, itemID uuid.UUID, upsert *domains.PriceHistoryUpsert) (*domains.PriceHistory, error) {
+func (repository *PriceHistoriesRepository) UpsertToday(ctx context.Context, householdID uuid.UUID, itemID uuid.UUID, upsert *domains.PriceHistoryUpsert) (*domains.PriceHistory, error) {
 	tracer := otel.Tracer("price-histories")
 	ctx, span := tracer.Start(ctx, "price-histories-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
@@ -96,17 +96,17 @@ func (repository *PriceHistoriesRepository) UpsertToday(ctx context.Context, ite
 
 	recordedAt := newUTCDate(time.Now().UTC())
 
-	query := `INSERT INTO public.price_history (id, item_id, recorded_at, value)
-			  VALUES (?, ?, ?, ?)
+	query := `INSERT INTO public.price_history (id, household_id, item_id, recorded_at, value)
+			  VALUES (?, ?, ?, ?, ?)
 			  ON CONFLICT ON CONSTRAINT uq_price_history_item_id_recorded_at
 			  DO UPDATE SET value = EXCLUDED.value
 			  RETURNING id, item_id, recorded_at, value`
 	query = repository.db.Rebind(query)
 
-	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "itemID", itemID, "recordedAt", recordedAt, "value", upsert.Value)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "itemID", itemID, "recordedAt", recordedAt, "value", upsert.Value)
 	start := time.Now()
 	var priceHistory domains.PriceHistory
-	err = sqlx.GetContext(ctx, repository.db, &priceHistory, query, newID, itemID, recordedAt, upsert.Value)
+	err = sqlx.GetContext(ctx, repository.db, &priceHistory, query, newID, householdID, itemID, recordedAt, upsert.Value)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, priceHistoryTableName, err == nil, metrics.DatabaseOperationUpdate)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on UPSERT operation", "error", err, "itemID", itemID, "recordedAt", recordedAt, "value", upsert.Value)
@@ -16,6 +16,12 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+// tagSortColumns maps the sortable fields of a tag listing to columns.
+var tagSortColumns = map[string]string{
+	"name":     "name",
+	"isActive": "is_active",
+}
+
 type TagsRepository struct {
 	db     DBTX
 	logger *slog.Logger
@@ -25,7 +31,9 @@ func NewTagsRepository(db DBTX, logger *slog.Logger) *TagsRepository {
 	return &TagsRepository{db: db, logger: logger}
 }
 
-func (repository *TagsRepository) GetListingInfo(ctx context.Context, filter *domains.TagFilter) ([]domains.Tag, int64, error) {
+// GetListingInfo returns a page of the tags matching filter, newest first,
+// with the cursors around it and, unless filter opts out, the total count.
+func (repository *TagsRepository) GetListingInfo(ctx context.Context, householdID uuid.UUID, filter *domains.TagFilter) ([]domains.Tag, domains.PageInfo, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -35,8 +43,8 @@ func (repository *TagsRepository) GetListingInfo(ctx context.Context, filter *do
 	var count int64 = 0
 
 	query := "FROM public.tags"
-	filters := make([]string, 0)
-	args := make([]interface{}, 0)
+	filters := []string{"household_id = ?"}
+	args := []interface{}{householdID}
 
 	if filter.Ids != nil && len(filter.Ids) > 0 {
 		inQuery, inArgs, err := sqlx.In("id IN (?)", filter.Ids)
@@ -45,7 +53,7 @@ func (repository *TagsRepository) GetListingInfo(ctx context.Context, filter *do
 			repository.logger.ErrorContext(ctx, "error binding \"Ids\" array to IN filter", "error", err)
 			metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationNone)
 			traces.EnrichFailedRepositorySpanRead(span, err, count)
-			return nil, 0, err
+			return nil, domains.PageInfo{}, err
 		}
 
 		filters = append(filters, inQuery)
@@ -66,20 +74,33 @@ func (repository *TagsRepository) GetListingInfo(ctx context.Context, filter *do
 		query += " WHERE " + strings.Join(filters, " AND ")
 	}
 
-	var pageSize int32 = 20
-	if filter.PageSize != nil {
-		pageSize = *filter.PageSize
+	keyColumns := []string{"id"}
+	page := newKeyset(filter.Page, filter.PageSize, filter.After, filter.Before)
+	keyCondition, keyArgs := page.condition(keyColumns, func(cursor *domains.Cursor) []interface{} {
+		return []interface{}{cursor.Id}
+	})
+	pageQuery := query
+	if keyCondition != "" {
+		pageQuery += " AND " + keyCondition
 	}
-	var page int32 = 0
-	if filter.Page != nil {
-		page = *filter.Page
+	limit, offset := page.limitOffset()
+	orderBy := page.orderBy(keyColumns)
+	if len(filter.Sort) > 0 {
+		var err error
+		orderBy, err = sortOrderBy(filter.Sort, tagSortColumns, nil, "id")
+		if err != nil {
+			repository.logger.ErrorContext(ctx, "error binding tag sort", "error", err)
+			metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationNone)
+			traces.EnrichFailedRepositorySpanRead(span, err, count)
+			return nil, domains.PageInfo{}, err
+		}
 	}
-	offset := page * pageSize
 
-	selectQuery := fmt.Sprintf("SELECT id, name, is_active %s ORDER BY id DESC LIMIT ? OFFSET ?", query)
+	selectQuery := fmt.Sprintf("SELECT id, name, is_active, parent_id, color, icon, description %s %s LIMIT ? OFFSET ?", pageQuery, orderBy)
 	selectQuery = repository.db.Rebind(selectQuery)
 	selectArgs := append(make([]interface{}, 0), args...)
-	selectArgs = append(selectArgs, pageSize, offset)
+	selectArgs = append(selectArgs, keyArgs...)
+	selectArgs = append(selectArgs, limit, offset)
 
 	repository.logger.InfoContext(ctx, "executing operation:", "query", selectQuery, "args", selectArgs)
 	selectStart := time.Now()
@@ -89,11 +110,23 @@ func (repository *TagsRepository) GetListingInfo(ctx context.Context, filter *do
 		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationSelect)
 		traces.EnrichFailedRepositorySpanRead(span, err, count)
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
 	} else {
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationSelect)
 	}
 
+	rows, pageInfo := keysetPage(page, tags, func(tag domains.Tag) domains.Cursor {
+		return domains.Cursor{Id: tag.Id}
+	})
+	if len(filter.Sort) > 0 {
+		pageInfo.Next, pageInfo.Previous = nil, nil
+	}
+
+	if filter.IncludeCount != nil && !*filter.IncludeCount {
+		traces.EnrichSuccessRepositorySpanRead(span, int64(len(rows)))
+		return rows, pageInfo, nil
+	}
+
 	countQuery := fmt.Sprintf("SELECT COUNT(*) %s", query)
 	countQuery = repository.db.Rebind(countQuery)
 	countArgs := append(make([]interface{}, 0), args...)
@@ -106,16 +139,17 @@ func (repository *TagsRepository) GetListingInfo(ctx context.Context, filter *do
 		repository.logger.ErrorContext(ctx, "error on COUNT operation", "error", err)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationCount)
 		traces.EnrichFailedRepositorySpanRead(span, err, count)
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
 	} else {
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationCount)
 	}
 
-	traces.EnrichSuccessRepositorySpanRead(span, int64(len(tags)))
-	return tags, count, err
+	pageInfo.Count = &count
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(rows)))
+	return rows, pageInfo, err
 }
 
-func (repository *TagsRepository) GetDetailedInfo(ctx context.Context, id uuid.UUID) (*domains.Tag, error) {
+func (repository *TagsRepository) GetDetailedInfo(ctx context.Context, householdID uuid.UUID, id uuid.UUID) (*domains.Tag, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -132,12 +166,12 @@ func (repository *TagsRepository) GetDetailedInfo(ctx context.Context, id uuid.U
 		return nil, err
 	}
 
-	query := "SELECT name, is_active FROM public.tags WHERE id = ?"
+	query := "SELECT name, is_active, version, parent_id, color, icon, description FROM public.tags WHERE household_id = ? AND id = ?"
 	query = repository.db.Rebind(query)
 
-	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "id", id)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "id", id)
 	start := time.Now()
-	err := sqlx.GetContext(ctx, repository.db, &tag, query, id)
+	err := sqlx.GetContext(ctx, repository.db, &tag, query, householdID, id)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationSelect)
 
 	if err != nil {
@@ -156,7 +190,7 @@ func (repository *TagsRepository) GetDetailedInfo(ctx context.Context, id uuid.U
 	return &tag, nil
 }
 
-func (repository *TagsRepository) GetByIds(ctx context.Context, ids []uuid.UUID) ([]domains.Tag, error) {
+func (repository *TagsRepository) GetByIds(ctx context.Context, householdID uuid.UUID, ids []uuid.UUID) ([]domains.Tag, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -177,8 +211,8 @@ func (repository *TagsRepository) GetByIds(ctx context.Context, ids []uuid.UUID)
 		return make([]domains.Tag, 0), nil
 	}
 
-	query := "SELECT * FROM public.tags WHERE id IN (?)"
-	query, inArgs, err := sqlx.In(query, ids)
+	query := "SELECT * FROM public.tags WHERE household_id = ? AND id IN (?)"
+	query, inArgs, err := sqlx.In(query, householdID, ids)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error binding \"Ids\" array to IN filter", "error", err)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationNone)
@@ -187,7 +221,7 @@ func (repository *TagsRepository) GetByIds(ctx context.Context, ids []uuid.UUID)
 	}
 	query = repository.db.Rebind(query)
 
-	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "ids", ids)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "ids", ids)
 	start := time.Now()
 	err = sqlx.SelectContext(ctx, repository.db, &tags, query, inArgs...)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationSelect)
@@ -204,28 +238,77 @@ func (repository *TagsRepository) GetByIds(ctx context.Context, ids []uuid.UUID)
 	return tags, nil
 }
 
-func (repository *TagsRepository) GetLookup(ctx context.Context, filter *domains.TagLookupFilter) ([]domains.Lookup, int64, error) {
+// GetStats sums up the items linked to each of the given tags, leaving out
+// the trash. Tags that do not exist are left out of the result.
+func (repository *TagsRepository) GetStats(ctx context.Context, householdID uuid.UUID, tagIDs []uuid.UUID) ([]domains.TagStats, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	if len(tagIDs) == 0 {
+		return make([]domains.TagStats, 0), nil
+	}
+
+	query, args, err := sqlx.In(`SELECT t.id AS tag_id,
+			  	COUNT(i.id) AS item_count,
+			  	COUNT(i.id) FILTER (WHERE i.is_active) AS active_item_count,
+			  	COALESCE(SUM(i.price), 0) AS total_price,
+			  	ROUND(AVG(i.price), 2) AS average_price,
+			  	COALESCE(ROUND(SUM(i.price * i.cashback / 100), 2), 0) AS total_cashback,
+			  	MAX(COALESCE(i.updated_at, i.created_at)) AS last_item_updated_at
+			  FROM public.tags t
+			  LEFT JOIN public.tag_to_item tti ON tti.household_id = t.household_id AND tti.tag_id = t.id
+			  LEFT JOIN public.items i ON i.household_id = tti.household_id AND i.id = tti.item_id AND i.deleted_at IS NULL
+			  WHERE t.household_id = ? AND t.id IN (?)
+			  GROUP BY t.id`, householdID, tagIDs)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error binding \"tagIDs\" array to IN filter", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+	query = repository.db.Rebind(query)
+
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "tagIDs", tagIDs)
+	stats := make([]domains.TagStats, 0, len(tagIDs))
+	start := time.Now()
+	err = sqlx.SelectContext(ctx, repository.db, &stats, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, int64(len(stats)))
+	return stats, nil
+}
+
+func (repository *TagsRepository) GetLookup(ctx context.Context, householdID uuid.UUID, filter *domains.TagLookupFilter) ([]domains.TagLookupDto, int64, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
 	defer span.End()
 
-	var tags []domains.Lookup
+	var tags []domains.TagLookupDto
 	var count int64 = 0
 
-	query := "FROM public.tags"
-	filters := make([]string, 0)
-	args := make([]interface{}, 0)
+	query := ""
+	filters := []string{"t.household_id = ?"}
+	args := []interface{}{householdID}
 
 	if filter.Name != nil && len(*filter.Name) > 0 {
-		filters = append(filters, "name ILIKE ?")
+		filters = append(filters, "t.name ILIKE ?")
 		args = append(args, fmt.Sprintf("%%%s%%", *filter.Name))
 	}
 
-	filters = append(filters, "is_active = true")
+	filters = append(filters, "t.is_active = true")
 
 	if len(filters) > 0 {
-		query += " WHERE " + strings.Join(filters, " AND ")
+		query += "WHERE " + strings.Join(filters, " AND ")
 	}
 
 	var pageSize int32 = 20
@@ -238,9 +321,22 @@ func (repository *TagsRepository) GetLookup(ctx context.Context, filter *domains
 	}
 	offset := page * pageSize
 
-	selectQuery := fmt.Sprintf("SELECT id as value, name as label %s ORDER BY LOWER(name), id LIMIT ? OFFSET ?", query)
+	// The label is the path of the tag from the top level, such as
+	// "Home / Utilities / Power".
+	selectQuery := fmt.Sprintf(`WITH RECURSIVE paths AS (
+			SELECT id, name::text AS path
+			FROM public.tags
+			WHERE household_id = ? AND parent_id IS NULL
+			UNION ALL
+			SELECT c.id, p.path || ' / ' || c.name
+			FROM public.tags c
+			JOIN paths p ON c.parent_id = p.id
+			WHERE c.household_id = ?
+		)
+		SELECT t.id as value, p.path as label, t.color, t.icon, t.description FROM paths p JOIN public.tags t ON t.id = p.id %s ORDER BY LOWER(p.path), t.id LIMIT ? OFFSET ?`, query)
 	selectQuery = repository.db.Rebind(selectQuery)
-	selectArgs := append(make([]interface{}, 0), args...)
+	selectArgs := []interface{}{householdID, householdID}
+	selectArgs = append(selectArgs, args...)
 	selectArgs = append(selectArgs, pageSize, offset)
 
 	repository.logger.InfoContext(ctx, "executing operation:", "query", selectQuery, "args", selectArgs)
@@ -256,7 +352,7 @@ func (repository *TagsRepository) GetLookup(ctx context.Context, filter *domains
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationSelect)
 	}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) %s", query)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM public.tags t %s", query)
 	countQuery = repository.db.Rebind(countQuery)
 	countArgs := append(make([]interface{}, 0), args...)
 
@@ -277,7 +373,7 @@ func (repository *TagsRepository) GetLookup(ctx context.Context, filter *domains
 	return tags, count, err
 }
 
-func (repository *TagsRepository) Create(ctx context.Context, create *domains.TagCreate) (uuid.UUID, error) {
+func (repository *TagsRepository) Create(ctx context.Context, householdID uuid.UUID, create *domains.TagCreate) (uuid.UUID, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
@@ -292,16 +388,18 @@ func (repository *TagsRepository) Create(ctx context.Context, create *domains.Ta
 		return uuid.Nil, err
 	}
 
-	query := "INSERT INTO public.tags (id, name, is_active) VALUES (?, ?, ?)"
+	parentID := domains.ParseParentId(create.ParentId)
+	query := "INSERT INTO public.tags (id, household_id, name, is_active, parent_id, color, icon, description) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
 	query = repository.db.Rebind(query)
 	repository.logger.InfoContext(ctx, "executing operation:", "query", query)
 	start := time.Now()
-	res, err := repository.db.ExecContext(ctx, query, newID, create.Name, create.IsActive)
+	res, err := repository.db.ExecContext(ctx, query, newID, householdID, create.Name, create.IsActive, parentID,
+		create.Color, create.Icon, create.Description)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationInsert)
 	var affected int64 = 0
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "newID",
-			newID, "name", create.Name, "isActive", create.IsActive)
+			newID, "householdID", householdID, "name", create.Name, "isActive", create.IsActive, "parentID", parentID)
 		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationInsert)
 		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
 		return uuid.Nil, err
@@ -314,18 +412,25 @@ func (repository *TagsRepository) Create(ctx context.Context, create *domains.Ta
 	return newID, err
 }
 
-func (repository *TagsRepository) Update(ctx context.Context, tagID uuid.UUID, update *domains.TagUpdate) (bool, error) {
+func (repository *TagsRepository) Update(ctx context.Context, householdID uuid.UUID, tagID uuid.UUID, update *domains.TagUpdate, expectedVersion *int32) (bool, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
 	defer span.End()
 
-	query := "UPDATE public.tags SET name = ?, is_active = ? WHERE id = ?"
+	parentID := domains.ParseParentId(update.ParentId)
+	query := `UPDATE public.tags SET name = ?, is_active = ?, parent_id = ?, color = ?, icon = ?, description = ?, version = version + 1
+			  WHERE household_id = ? AND id = ?`
+	args := []interface{}{update.Name, update.IsActive, parentID, update.Color, update.Icon, update.Description, householdID, tagID}
+	if expectedVersion != nil {
+		query += " AND version = ?"
+		args = append(args, *expectedVersion)
+	}
 	query = repository.db.Rebind(query)
 	repository.logger.InfoContext(ctx, "updating an tag:", "id", tagID, "name", update.Name,
-		"isActive", update.IsActive)
+		"isActive", update.IsActive, "parentID", parentID, "expectedVersion", expectedVersion)
 	updateStart := time.Now()
-	result, err := repository.db.ExecContext(ctx, query, update.Name, update.IsActive, tagID)
+	result, err := repository.db.ExecContext(ctx, query, args...)
 	metrics.RecordDatabaseDuration(ctx, updateStart, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationUpdate)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", tagID, "name",
@@ -349,3 +454,218 @@ func (repository *TagsRepository) Update(ctx context.Context, tagID uuid.UUID, u
 	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
 	return success, err
 }
+
+func (repository *TagsRepository) Patch(ctx context.Context, householdID uuid.UUID, tagID uuid.UUID, patch *domains.TagPatch, expectedVersion *int32) (bool, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	if patch == nil {
+		repository.logger.ErrorContext(ctx, "patch should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("patch should not be nil")
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	assignments := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if patch.Name != nil {
+		assignments = append(assignments, "name = ?")
+		args = append(args, *patch.Name)
+	}
+
+	if patch.IsActive != nil {
+		assignments = append(assignments, "is_active = ?")
+		args = append(args, *patch.IsActive)
+	}
+
+	if patch.ParentId.Set {
+		assignments = append(assignments, "parent_id = ?")
+		args = append(args, domains.ParseParentId(patch.ParentId.Id))
+	}
+
+	if patch.Color.Set {
+		assignments = append(assignments, "color = ?")
+		args = append(args, patch.Color.Value)
+	}
+
+	if patch.Icon.Set {
+		assignments = append(assignments, "icon = ?")
+		args = append(args, patch.Icon.Value)
+	}
+
+	if patch.Description.Set {
+		description := ""
+		if patch.Description.Value != nil {
+			description = *patch.Description.Value
+		}
+		assignments = append(assignments, "description = ?")
+		args = append(args, description)
+	}
+
+	assignments = append(assignments, "version = version + 1")
+	args = append(args, householdID, tagID)
+
+	query := fmt.Sprintf("UPDATE public.tags SET %s WHERE household_id = ? AND id = ?", strings.Join(assignments, ", "))
+	if expectedVersion != nil {
+		query += " AND version = ?"
+		args = append(args, *expectedVersion)
+	}
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "patching a tag:", "query", query, "args", args)
+	patchStart := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, args...)
+	metrics.RecordDatabaseDuration(ctx, patchStart, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", tagID, "args", args)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	success := rowsAffected > 0
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationUpdate)
+
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return success, err
+}
+
+func (repository *TagsRepository) Delete(ctx context.Context, householdID uuid.UUID, tagID uuid.UUID, expectedVersion *int32) (bool, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
+	defer span.End()
+
+	query := "DELETE FROM public.tags WHERE household_id = ? AND id = ?"
+	args := []interface{}{householdID, tagID}
+	if expectedVersion != nil {
+		query += " AND version = ?"
+		args = append(args, *expectedVersion)
+	}
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "deleting a tag:", "query", query, "id", tagID, "expectedVersion", expectedVersion)
+	start := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationDelete)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "id", tagID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return false, err
+	}
+
+	success := rowsAffected > 0
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationDelete)
+
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return success, err
+}
+
+// LockHierarchy takes a transaction-scoped lock on the tag tree of a
+// household, so that two concurrent moves cannot close a cycle between them.
+func (repository *TagsRepository) LockHierarchy(ctx context.Context, householdID uuid.UUID) error {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	query := repository.db.Rebind("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))")
+	key := "tags:" + householdID.String()
+	repository.logger.InfoContext(ctx, "locking the tag hierarchy", "query", query, "householdID", householdID)
+	start := time.Now()
+	_, err := repository.db.ExecContext(ctx, query, key)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, 1)
+	return nil
+}
+
+// IsInSubtree reports whether tagID is rootID itself or one of its
+// descendants.
+func (repository *TagsRepository) IsInSubtree(ctx context.Context, householdID uuid.UUID, rootID uuid.UUID, tagID uuid.UUID) (bool, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	query := `WITH RECURSIVE subtree AS (
+			SELECT id FROM public.tags WHERE household_id = ? AND id = ?
+			UNION
+			SELECT t.id FROM public.tags t JOIN subtree s ON t.parent_id = s.id WHERE t.household_id = ?
+		)
+		SELECT EXISTS (SELECT 1 FROM subtree WHERE id = ?)`
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "rootID", rootID, "tagID", tagID)
+	var found bool
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &found, query, householdID, rootID, householdID, tagID)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return false, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, 1)
+	return found, nil
+}
+
+// MoveChildrenUp hands the children of a tag over to its own parent, or makes
+// them roots when the tag has none, and returns their ids.
+func (repository *TagsRepository) MoveChildrenUp(ctx context.Context, householdID uuid.UUID, tagID uuid.UUID) ([]uuid.UUID, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationUpdate)
+	defer span.End()
+
+	query := `UPDATE public.tags c
+			  SET parent_id = p.parent_id, version = c.version + 1
+			  FROM public.tags p
+			  WHERE p.household_id = ? AND p.id = ? AND c.household_id = p.household_id AND c.parent_id = p.id
+			  RETURNING c.id`
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "moving tag children up", "query", query, "id", tagID)
+	childIDs := make([]uuid.UUID, 0)
+	start := time.Now()
+	err := sqlx.SelectContext(ctx, repository.db, &childIDs, query, householdID, tagID)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsTableName, err == nil, metrics.DatabaseOperationUpdate)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on UPDATE operation", "error", err, "id", tagID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, false, metrics.DatabaseOperationUpdate)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsTableName, true, metrics.DatabaseOperationUpdate)
+	traces.EnrichSuccessRepositorySpanWrite(span, int64(len(childIDs)))
+	return childIDs, nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"finscheduler/internal/features/domains"
 	"finscheduler/internal/metrics"
 	"finscheduler/internal/traces"
@@ -25,7 +26,7 @@ func NewTagToItemsRepository(db DBTX, logger *slog.Logger) *TagToItemsRepository
 	return &TagToItemsRepository{db: db, logger: logger}
 }
 
-func (repository *TagToItemsRepository) GetByItemIds(ctx context.Context, itemIds []uuid.UUID) ([]domains.TagToItem, error) {
+func (repository *TagToItemsRepository) GetByItemIds(ctx context.Context, householdID uuid.UUID, itemIds []uuid.UUID) ([]domains.TagToItem, error) {
 	tracer := otel.Tracer("tag-to-items")
 	ctx, span := tracer.Start(ctx, "tag-to-items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -48,11 +49,11 @@ func (repository *TagToItemsRepository) GetByItemIds(ctx context.Context, itemId
 
 	query := `SELECT item_id, tag_id
 			  FROM public.tag_to_item tti 
-			  WHERE tti.item_id IN (?)`
-	query, inArgs, err := sqlx.In(query, itemIds)
+			  WHERE tti.household_id = ? AND tti.item_id IN (?)`
+	query, inArgs, err := sqlx.In(query, householdID, itemIds)
 	query = repository.db.Rebind(query)
 
-	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "itemIds", itemIds)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "itemIds", itemIds)
 	start := time.Now()
 	err = sqlx.SelectContext(ctx, repository.db, &tagToItems, query, inArgs...)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationSelect)
@@ -70,7 +71,7 @@ func (repository *TagToItemsRepository) GetByItemIds(ctx context.Context, itemId
 	return tagToItems, nil
 }
 
-func (repository *TagToItemsRepository) BulkInsert(ctx context.Context, create *domains.TagToItemCreate) (bool, error) {
+func (repository *TagToItemsRepository) BulkInsert(ctx context.Context, householdID uuid.UUID, create *domains.TagToItemCreate) (bool, error) {
 	tracer := otel.Tracer("tag-to-items")
 	ctx, span := tracer.Start(ctx, "tag-to-items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
@@ -103,7 +104,7 @@ func (repository *TagToItemsRepository) BulkInsert(ctx context.Context, create *
 		return false, err
 	}
 
-	args := make([]interface{}, 0, len(create.TagIds)*2)
+	args := make([]interface{}, 0, len(create.TagIds)*3)
 	values := make([]string, 0, len(create.TagIds))
 
 	for _, tagId := range create.TagIds {
@@ -116,11 +117,11 @@ func (repository *TagToItemsRepository) BulkInsert(ctx context.Context, create *
 			return false, err
 		}
 
-		values = append(values, "(?, ?)")
-		args = append(args, create.ItemId, tagId)
+		values = append(values, "(?, ?, ?)")
+		args = append(args, householdID, create.ItemId, tagId)
 	}
 
-	query := fmt.Sprintf("INSERT INTO public.tag_to_item (item_id, tag_id) VALUES %s",
+	query := fmt.Sprintf("INSERT INTO public.tag_to_item (household_id, item_id, tag_id) VALUES %s",
 		strings.Join(values, ","))
 	query = repository.db.Rebind(query)
 	repository.logger.InfoContext(ctx, "executing operation:", "query", query)
@@ -129,7 +130,7 @@ func (repository *TagToItemsRepository) BulkInsert(ctx context.Context, create *
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationInsert)
 	var affected int64 = 0
 	if err != nil {
-		args := []any{"error", err, "itemId", create.ItemId, "tagIds", create.TagIds}
+		args := []any{"error", err, "householdID", householdID, "itemId", create.ItemId, "tagIds", create.TagIds}
 		if details, ok := dh.GetPostgresErrorDetails(err); ok && details.Code == dh.PostgresForeignKeyViolationCode {
 			args = append(args, "postgresCode", details.Code, "constraint", details.ConstraintName)
 		}
@@ -146,7 +147,7 @@ func (repository *TagToItemsRepository) BulkInsert(ctx context.Context, create *
 	return affected > 0, err
 }
 
-func (repository *TagToItemsRepository) BulkDelete(ctx context.Context, delete *domains.TagToItemDelete) (bool, error) {
+func (repository *TagToItemsRepository) BulkDelete(ctx context.Context, householdID uuid.UUID, delete *domains.TagToItemDelete) (bool, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
@@ -185,10 +186,10 @@ func (repository *TagToItemsRepository) BulkDelete(ctx context.Context, delete *
 		}
 	}
 
-	query := `DELETE FROM public.tag_to_item WHERE item_id = ? AND tag_id IN (?)`
-	query, inArgs, err := sqlx.In(query, delete.ItemId, delete.TagIds)
+	query := `DELETE FROM public.tag_to_item WHERE household_id = ? AND item_id = ? AND tag_id IN (?)`
+	query, inArgs, err := sqlx.In(query, householdID, delete.ItemId, delete.TagIds)
 	query = repository.db.Rebind(query)
-	repository.logger.InfoContext(ctx, "fetching delete tag to items:", "query", query, "itemId", delete.ItemId, "tagIds", delete.TagIds)
+	repository.logger.InfoContext(ctx, "fetching delete tag to items:", "query", query, "householdID", householdID, "itemId", delete.ItemId, "tagIds", delete.TagIds)
 	start := time.Now()
 	result, err := repository.db.ExecContext(ctx, query, inArgs...)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationDelete)
@@ -214,17 +215,17 @@ func (repository *TagToItemsRepository) BulkDelete(ctx context.Context, delete *
 	return success, err
 }
 
-func (repository *TagToItemsRepository) DeleteByTagId(ctx context.Context, tagID uuid.UUID) (bool, error) {
+func (repository *TagToItemsRepository) DeleteByTagId(ctx context.Context, householdID uuid.UUID, tagID uuid.UUID) (bool, error) {
 	tracer := otel.Tracer("tag-to-items")
 	ctx, span := tracer.Start(ctx, "tag-to-items-repository")
 	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
 	defer span.End()
 
-	query := "DELETE FROM public.tag_to_item WHERE tag_id = ?"
+	query := "DELETE FROM public.tag_to_item WHERE household_id = ? AND tag_id = ?"
 	query = repository.db.Rebind(query)
-	repository.logger.InfoContext(ctx, "fetching delete tag to items by tag id:", "query", query, "tagId", tagID)
+	repository.logger.InfoContext(ctx, "fetching delete tag to items by tag id:", "query", query, "householdID", householdID, "tagId", tagID)
 	start := time.Now()
-	result, err := repository.db.ExecContext(ctx, query, tagID)
+	result, err := repository.db.ExecContext(ctx, query, householdID, tagID)
 	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationDelete)
 	if err != nil {
 		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "tagId", tagID)
@@ -247,3 +248,190 @@ func (repository *TagToItemsRepository) DeleteByTagId(ctx context.Context, tagID
 	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
 	return success, err
 }
+
+// CountByTagId counts the items outside the trash that are linked to tagID.
+func (repository *TagToItemsRepository) CountByTagId(ctx context.Context, householdID uuid.UUID, tagID uuid.UUID) (int64, error) {
+	tracer := otel.Tracer("tag-to-items")
+	ctx, span := tracer.Start(ctx, "tag-to-items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationCount)
+	defer span.End()
+
+	var count int64 = 0
+
+	query := `SELECT COUNT(*) FROM public.tag_to_item tti
+			  INNER JOIN public.items i ON i.household_id = tti.household_id AND i.id = tti.item_id
+			  WHERE tti.household_id = ? AND tti.tag_id = ? AND i.deleted_at IS NULL`
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "householdID", householdID, "tagId", tagID)
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &count, query, householdID, tagID)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationCount)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on COUNT operation", "error", err, "tagId", tagID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, false, metrics.DatabaseOperationCount)
+		traces.EnrichFailedRepositorySpanRead(span, err, count)
+		return 0, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, true, metrics.DatabaseOperationCount)
+	traces.EnrichSuccessRepositorySpanRead(span, count)
+	return count, nil
+}
+
+// CopyToTag links targetTagID to every item linked to sourceTagID, skipping
+// the items that already have it, and returns the number of links added. The
+// links of sourceTagID are left in place.
+func (repository *TagToItemsRepository) CopyToTag(ctx context.Context, householdID uuid.UUID, sourceTagID uuid.UUID, targetTagID uuid.UUID) (int64, error) {
+	tracer := otel.Tracer("tag-to-items")
+	ctx, span := tracer.Start(ctx, "tag-to-items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	query := `INSERT INTO public.tag_to_item (household_id, tag_id, item_id)
+			  SELECT household_id, ?, item_id FROM public.tag_to_item WHERE household_id = ? AND tag_id = ?
+			  ON CONFLICT (item_id, tag_id) DO NOTHING`
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "copying tag to items:", "query", query, "householdID", householdID, "sourceTagId", sourceTagID, "targetTagId", targetTagID)
+	start := time.Now()
+	result, err := repository.db.ExecContext(ctx, query, targetTagID, householdID, sourceTagID)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "sourceTagId", sourceTagID, "targetTagId", targetTagID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error fetching affected rows", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return 0, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, rowsAffected)
+	return rowsAffected, nil
+}
+
+// AddByItemFilter links every tag of tagIDs to every item matching filter
+// that does not have it yet, and returns the links added. Items that gained a
+// tag get a new version, since their details changed.
+func (repository *TagToItemsRepository) AddByItemFilter(ctx context.Context, householdID uuid.UUID, filter *domains.ItemFilter, tagIDs []uuid.UUID) ([]domains.TagToItem, error) {
+	tracer := otel.Tracer("tag-to-items")
+	ctx, span := tracer.Start(ctx, "tag-to-items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	query, args, err := tagToItemsByItemFilterQuery(householdID, filter, "t.id", tagIDs, `WITH changed AS (
+			  	INSERT INTO public.tag_to_item (household_id, tag_id, item_id)
+			  	SELECT i.household_id, t.id, i.id FROM public.items i
+			  	INNER JOIN public.tags t ON t.household_id = i.household_id AND %s
+			  	WHERE %s
+			  	ON CONFLICT (item_id, tag_id) DO NOTHING
+			  	RETURNING tag_id, item_id
+			  ), `+touchChangedItems+`
+			  SELECT tag_id, item_id FROM changed`)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error binding item filter", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+	query = repository.db.Rebind(query)
+
+	var links []domains.TagToItem
+
+	repository.logger.InfoContext(ctx, "adding tags to items:", "query", query, "args", args)
+	start := time.Now()
+	err = sqlx.SelectContext(ctx, repository.db, &links, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "tagIds", tagIDs)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, int64(len(links)))
+	return links, nil
+}
+
+// RemoveByItemFilter unlinks every tag of tagIDs from every item matching
+// filter, and returns the links removed. Items that lost a tag get a new
+// version, since their details changed.
+func (repository *TagToItemsRepository) RemoveByItemFilter(ctx context.Context, householdID uuid.UUID, filter *domains.ItemFilter, tagIDs []uuid.UUID) ([]domains.TagToItem, error) {
+	tracer := otel.Tracer("tag-to-items")
+	ctx, span := tracer.Start(ctx, "tag-to-items-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationDelete)
+	defer span.End()
+
+	query, args, err := tagToItemsByItemFilterQuery(householdID, filter, "link.tag_id", tagIDs, `WITH changed AS (
+			  	DELETE FROM public.tag_to_item link
+			  	USING public.items i
+			  	WHERE link.household_id = i.household_id AND link.item_id = i.id AND %s AND %s
+			  	RETURNING link.tag_id, link.item_id
+			  ), `+touchChangedItems+`
+			  SELECT tag_id, item_id FROM changed`)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error binding item filter", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+	query = repository.db.Rebind(query)
+
+	var links []domains.TagToItem
+
+	repository.logger.InfoContext(ctx, "removing tags from items:", "query", query, "args", args)
+	start := time.Now()
+	err = sqlx.SelectContext(ctx, repository.db, &links, query, args...)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, tagsToItemTableName, err == nil, metrics.DatabaseOperationDelete)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on DELETE operation", "error", err, "tagIds", tagIDs)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, false, metrics.DatabaseOperationDelete)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, tagsToItemTableName, true, metrics.DatabaseOperationDelete)
+	traces.EnrichSuccessRepositorySpanWrite(span, int64(len(links)))
+	return links, nil
+}
+
+// touchChangedItems is a CTE that bumps the version and update time of the
+// items of the links in the changed CTE. It takes the update time and the
+// household as its arguments, after those of changed.
+const touchChangedItems = `touched AS (
+			  	UPDATE public.items
+			  	SET version = version + 1, updated_at = ?
+			  	WHERE household_id = ? AND id IN (SELECT item_id FROM changed)
+			  )`
+
+// tagToItemsByItemFilterQuery fills format, whose first verb takes the
+// condition of tagColumn on tagIDs and whose second takes the item filter
+// conditions on alias i, and returns the query with its arguments in order,
+// followed by those of touchChangedItems.
+func tagToItemsByItemFilterQuery(householdID uuid.UUID, filter *domains.ItemFilter, tagColumn string, tagIDs []uuid.UUID, format string) (string, []interface{}, error) {
+	if len(tagIDs) == 0 {
+		return "", nil, fmt.Errorf("tagIDs should not be empty")
+	}
+
+	tagsCondition, tagsArgs, err := sqlx.In(tagColumn+" IN (?)", tagIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	conditions, conditionsArgs, err := itemFilterConditions(householdID, filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := fmt.Sprintf(format, tagsCondition, strings.Join(conditions, " AND "))
+	args := append(tagsArgs, conditionsArgs...)
+	args = append(args, sql.NullTime{Time: time.Now().UTC(), Valid: true}, householdID)
+
+	return query, args, nil
+}
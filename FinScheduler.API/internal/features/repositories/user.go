@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type UsersRepository struct {
+	db     DBTX
+	logger *slog.Logger
+}
+
+func NewUsersRepository(db DBTX, logger *slog.Logger) *UsersRepository {
+	return &UsersRepository{db: db, logger: logger}
+}
+
+func (repository *UsersRepository) GetById(ctx context.Context, id uuid.UUID) (*domains.User, error) {
+	tracer := otel.Tracer("users")
+	ctx, span := tracer.Start(ctx, "users-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	if id == uuid.Nil {
+		repository.logger.ErrorContext(ctx, "id should not be nil")
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, usersTableName, false, metrics.DatabaseOperationNone)
+
+		err := fmt.Errorf("id should not be nil")
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	query := "SELECT id, email, password_hash, created_at, updated_at FROM public.users WHERE id = ?"
+
+	return repository.get(ctx, span, query, "id", id)
+}
+
+// GetByEmail expects an address already passed through domains.NormalizeEmail.
+func (repository *UsersRepository) GetByEmail(ctx context.Context, email string) (*domains.User, error) {
+	tracer := otel.Tracer("users")
+	ctx, span := tracer.Start(ctx, "users-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationSelect)
+	defer span.End()
+
+	query := "SELECT id, email, password_hash, created_at, updated_at FROM public.users WHERE email = ?"
+
+	return repository.get(ctx, span, query, "email", email)
+}
+
+func (repository *UsersRepository) Create(ctx context.Context, email string, passwordHash string) (uuid.UUID, error) {
+	tracer := otel.Tracer("users")
+	ctx, span := tracer.Start(ctx, "users-repository")
+	traces.RecordRepositorySpan(span, databaseDriver, metrics.DatabaseOperationInsert)
+	defer span.End()
+
+	newID, err := uuid.NewV7()
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "uuid generation error", "error", err)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, usersTableName, false, metrics.DatabaseOperationNone)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	query := "INSERT INTO public.users (id, email, password_hash) VALUES (?, ?, ?)"
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, "newID", newID)
+	start := time.Now()
+	res, err := repository.db.ExecContext(ctx, query, newID, email, passwordHash)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, usersTableName, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		repository.logger.ErrorContext(ctx, "error on INSERT operation", "error", err, "newID", newID)
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, usersTableName, false, metrics.DatabaseOperationInsert)
+		traces.EnrichFailedRepositorySpanWrite(span, err, 0)
+		return uuid.Nil, err
+	}
+
+	affected, _ := res.RowsAffected()
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, usersTableName, true, metrics.DatabaseOperationInsert)
+	traces.EnrichSuccessRepositorySpanWrite(span, affected)
+	return newID, nil
+}
+
+// get never logs the password hash it loads.
+func (repository *UsersRepository) get(ctx context.Context, span trace.Span, query string, key string, value any) (*domains.User, error) {
+	var user domains.User
+
+	query = repository.db.Rebind(query)
+	repository.logger.InfoContext(ctx, "executing operation:", "query", query, key, value)
+	start := time.Now()
+	err := sqlx.GetContext(ctx, repository.db, &user, query, value)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, usersTableName, err == nil, metrics.DatabaseOperationSelect)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.logger.InfoContext(ctx, "user not found", key, value)
+		} else {
+			repository.logger.ErrorContext(ctx, "error on SELECT operation", "error", err)
+		}
+		metrics.RecordDatabaseRequest(ctx, databaseDriver, usersTableName, false, metrics.DatabaseOperationSelect)
+		traces.EnrichFailedRepositorySpanRead(span, err, 0)
+		return nil, err
+	}
+
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, usersTableName, true, metrics.DatabaseOperationSelect)
+	traces.EnrichSuccessRepositorySpanRead(span, 1)
+	return &user, nil
+}
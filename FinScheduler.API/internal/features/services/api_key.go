@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/persistence"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type ApiKeysService struct {
+	uow    *persistence.UnitOfWork
+	logger *slog.Logger
+}
+
+const apiKeysServiceName = "api-keys"
+
+func NewApiKeysService(uow *persistence.UnitOfWork, logger *slog.Logger) *ApiKeysService {
+	return &ApiKeysService{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// GetListing lists the caller's API keys. The keys themselves are never
+// returned; only their prefix identifies them.
+func (service *ApiKeysService) GetListing(ctx context.Context) ([]domains.ApiKeyDto, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-service")
+	traces.RecordServiceSpan(span, "GetListing")
+	defer span.End()
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "GetListing", err)
+		return nil, err
+	}
+
+	var keys []domains.ApiKey
+
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		keys, err = repositories.ApiKeys.GetByUserId(ctx, userID)
+
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Get API keys failed", "userID", userID, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "GetListing", err)
+		return nil, err
+	}
+
+	dtos := make([]domains.ApiKeyDto, 0, len(keys))
+	for _, key := range keys {
+		dtos = append(dtos, *domains.NewApiKeyDto(key))
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return dtos, nil
+}
+
+// Create issues a new API key for the caller. The returned key is the only
+// copy; the database keeps its hash. domains.ErrApiKeyNameTaken is returned
+// when the caller already has a key with that name.
+func (service *ApiKeysService) Create(ctx context.Context, create *domains.ApiKeyCreate) (*domains.ApiKeyCreatedDto, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-service")
+	traces.RecordServiceSpan(span, "Create")
+	defer span.End()
+
+	if create == nil {
+		service.logger.ErrorContext(ctx, "create is nil")
+		err := fmt.Errorf("create is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "Create", err)
+		return nil, err
+	}
+
+	if err := create.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "create validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "Create", err)
+		return nil, err
+	}
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "Create", err)
+		return nil, err
+	}
+
+	token, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Token generation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "Create", err)
+		return nil, err
+	}
+	secret := domains.ApiKeyPrefix + token
+
+	key := &domains.ApiKey{
+		UserId:  userID,
+		Name:    strings.TrimSpace(create.Name),
+		Prefix:  domains.ApiKeyDisplayPrefix(secret),
+		KeyHash: auth.HashOpaqueToken(secret),
+		Scopes:  auth.FormatScopes(create.Scopes),
+	}
+	if create.ExpiresAt != nil {
+		key.ExpiresAt = sql.NullTime{Time: create.ExpiresAt.UTC(), Valid: true}
+	}
+
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		key, err = repositories.ApiKeys.Create(ctx, key)
+
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		err = domains.ErrApiKeyNameTaken
+	}
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error creating an API key", "userID", userID, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "Create", err)
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return &domains.ApiKeyCreatedDto{
+		ApiKeyDto: *domains.NewApiKeyDto(*key),
+		Key:       secret,
+	}, nil
+}
+
+// Delete revokes one of the caller's keys. It reports false when the caller
+// has no key with that id.
+func (service *ApiKeysService) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-service")
+	traces.RecordServiceSpan(span, "Delete")
+	defer span.End()
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "Delete", err)
+		return false, err
+	}
+
+	var deleted bool
+
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		deleted, err = repositories.ApiKeys.Delete(ctx, userID, id)
+
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error deleting an API key", "userID", userID, "id", id, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "Delete", err)
+		return false, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return deleted, nil
+}
+
+// VerifyAPIKey implements auth.APIKeyVerifier. Unknown and expired keys
+// return auth.ErrInvalidAPIKey; accepted keys have their last use recorded.
+func (service *ApiKeysService) VerifyAPIKey(ctx context.Context, secret string) (auth.Principal, error) {
+	tracer := otel.Tracer("api-keys")
+	ctx, span := tracer.Start(ctx, "api-keys-service")
+	traces.RecordServiceSpan(span, "VerifyAPIKey")
+	defer span.End()
+
+	var key *domains.ApiKeyOwner
+	now := time.Now().UTC()
+
+	err := service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		key, err = repositories.ApiKeys.GetByKeyHash(ctx, auth.HashOpaqueToken(secret))
+		if err != nil {
+			return err
+		}
+		if !key.Usable(now) {
+			return auth.ErrInvalidAPIKey
+		}
+
+		return repositories.ApiKeys.TouchLastUsed(ctx, key.Id, now)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		err = auth.ErrInvalidAPIKey
+	}
+	if err != nil {
+		service.logger.ErrorContext(ctx, "API key verification failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, apiKeysServiceName, "VerifyAPIKey", err)
+		return auth.Principal{}, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return auth.Principal{
+		UserID:   key.UserId,
+		Email:    key.Email,
+		APIKeyID: key.Id,
+		Scopes:   auth.ParseScopes(key.Scopes),
+	}, nil
+}
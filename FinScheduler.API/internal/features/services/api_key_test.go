@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/persistence"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiKeysServiceCreate_ShouldValidateBeforeTouchingTheDatabase(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var uow *persistence.UnitOfWork
+	service := NewApiKeysService(uow, slog.Default())
+
+	// Act
+	created, err := service.Create(ctx, &domains.ApiKeyCreate{Name: "cashback"})
+
+	// Assert
+	require.EqualError(t, err, "scopes must not be empty")
+	assert.Nil(t, created)
+}
+
+func TestApiKeysServiceCreate_ShouldReturnErrorWithoutCaller(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var uow *persistence.UnitOfWork
+	service := NewApiKeysService(uow, slog.Default())
+
+	// Act
+	created, err := service.Create(ctx, &domains.ApiKeyCreate{Name: "cashback", Scopes: []auth.Scope{auth.ScopeItemsWrite}})
+
+	// Assert
+	require.ErrorIs(t, err, domains.ErrUnauthenticated)
+	assert.Nil(t, created)
+}
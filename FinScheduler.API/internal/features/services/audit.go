@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/persistence"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type AuditService struct {
+	uow    *persistence.UnitOfWork
+	logger *slog.Logger
+}
+
+const auditServiceName = "audit"
+
+func NewAuditService(uow *persistence.UnitOfWork, logger *slog.Logger) *AuditService {
+	return &AuditService{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (service *AuditService) GetListing(ctx context.Context, filter *domains.AuditFilter) ([]domains.AuditEventDto, int64, error) {
+	tracer := otel.Tracer("audit")
+	ctx, span := tracer.Start(ctx, "audit-service")
+	traces.RecordServiceSpan(span, "GetListing")
+	defer span.End()
+
+	if filter == nil {
+		service.logger.ErrorContext(ctx, "filter is nil")
+		err := fmt.Errorf("filter is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, auditServiceName, "GetListing", err)
+		return nil, 0, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, auditServiceName, "GetListing", err)
+		return nil, 0, err
+	}
+
+	var events []domains.AuditEventDto
+	var count int64
+
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawEvents, rawEventsCount, err := repositories.AuditEvents.GetListing(ctx, householdID, filter)
+		if err != nil {
+			service.logger.ErrorContext(ctx, "Get audit events failed", "error", err)
+			return err
+		}
+
+		count = rawEventsCount
+		events = make([]domains.AuditEventDto, 0, len(rawEvents))
+		for _, event := range rawEvents {
+			events = append(events, *domains.NewAuditEventDto(event))
+		}
+
+		return nil
+	})
+	if err != nil {
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, auditServiceName, "GetListing", err)
+		return nil, 0, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return events, count, nil
+}
+
+// recordAudit stores the difference between before and after as an event of
+// the caller, in the transaction of repositories so that the event commits
+// or rolls back with the change. Writes that change nothing are not recorded.
+func recordAudit(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, operation domains.AuditOperation, entityType domains.AuditEntityType, entityID uuid.UUID, before domains.AuditSnapshot, after domains.AuditSnapshot) error {
+	changes, err := domains.NewAuditChanges(before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID == uuid.Nil {
+		return domains.ErrUnauthenticated
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	event := &domains.AuditEvent{
+		HouseholdId: householdID,
+		ActorId:     principal.UserID,
+		Operation:   operation,
+		EntityType:  entityType,
+		EntityId:    entityID,
+		Changes:     encoded,
+	}
+	if principal.ViaAPIKey() {
+		event.ApiKeyId = uuid.NullUUID{UUID: principal.APIKeyID, Valid: true}
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		event.TraceId = sql.NullString{String: spanContext.TraceID().String(), Valid: true}
+	}
+
+	_, err = repositories.AuditEvents.Create(ctx, event)
+	return err
+}
+
+// itemAuditSnapshot reads the audited state of an item and its tags.
+func itemAuditSnapshot(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, itemID uuid.UUID) (domains.AuditSnapshot, error) {
+	item, err := repositories.Items.GetDetailedInfo(ctx, householdID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	tagToItems, err := repositories.TagToItems.GetByItemIds(ctx, householdID, []uuid.UUID{itemID})
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]uuid.UUID, 0, len(tagToItems))
+	for _, tagToItem := range tagToItems {
+		tagIDs = append(tagIDs, tagToItem.TagId)
+	}
+
+	return domains.NewItemAuditSnapshot(*item, tagIDs), nil
+}
+
+// tagAuditSnapshot reads the audited state of a tag.
+func tagAuditSnapshot(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, tagID uuid.UUID) (domains.AuditSnapshot, error) {
+	tag, err := repositories.Tags.GetDetailedInfo(ctx, householdID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domains.NewTagAuditSnapshot(*tag), nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/persistence"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditServiceGetListing_ShouldReturnErrorOnNilFilter(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := slog.Default()
+	var uow *persistence.UnitOfWork
+	var filter *domains.AuditFilter
+	service := NewAuditService(uow, logger)
+
+	// Act
+	events, count, err := service.GetListing(ctx, filter)
+
+	// Assert
+	require.EqualError(t, err, "filter is nil")
+	assert.Nil(t, events)
+	assert.Zero(t, count)
+}
+
+func TestAuditServiceGetListing_ShouldRequireHousehold(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := slog.Default()
+	var uow *persistence.UnitOfWork
+	service := NewAuditService(uow, logger)
+
+	// Act
+	events, count, err := service.GetListing(ctx, &domains.AuditFilter{})
+
+	// Assert
+	require.ErrorIs(t, err, domains.ErrNoHousehold)
+	assert.Nil(t, events)
+	assert.Zero(t, count)
+}
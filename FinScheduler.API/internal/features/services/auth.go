@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/persistence"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type AuthService struct {
+	uow    *persistence.UnitOfWork
+	tokens *auth.TokenIssuer
+	logger *slog.Logger
+}
+
+const authServiceName = "auth"
+
+func NewAuthService(uow *persistence.UnitOfWork, tokens *auth.TokenIssuer, logger *slog.Logger) *AuthService {
+	return &AuthService{
+		uow:    uow,
+		tokens: tokens,
+		logger: logger,
+	}
+}
+
+// Register creates a local account. It is used by the useradd command; the
+// API does not expose self sign-up.
+func (service *AuthService) Register(ctx context.Context, create *domains.UserCreate) (uuid.UUID, error) {
+	tracer := otel.Tracer("auth")
+	ctx, span := tracer.Start(ctx, "auth-service")
+	traces.RecordServiceSpan(span, "Register")
+	defer span.End()
+
+	if create == nil {
+		service.logger.ErrorContext(ctx, "create is nil")
+		err := fmt.Errorf("create is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Register", err)
+		return uuid.Nil, err
+	}
+
+	passwordHash, err := auth.HashPassword(create.Password)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Password hashing failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Register", err)
+		return uuid.Nil, err
+	}
+
+	var newId uuid.UUID
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		newId, err = repositories.Users.Create(ctx, domains.NormalizeEmail(create.Email), passwordHash)
+
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error creating a user", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Register", err)
+		return uuid.Nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return newId, nil
+}
+
+// Login exchanges an email and password for a token pair. An unknown email
+// and a wrong password both return domains.ErrInvalidCredentials.
+func (service *AuthService) Login(ctx context.Context, credentials *domains.Credentials) (*domains.TokenDto, error) {
+	tracer := otel.Tracer("auth")
+	ctx, span := tracer.Start(ctx, "auth-service")
+	traces.RecordServiceSpan(span, "Login")
+	defer span.End()
+
+	if credentials == nil {
+		service.logger.ErrorContext(ctx, "credentials is nil")
+		err := fmt.Errorf("credentials is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Login", err)
+		return nil, err
+	}
+
+	var user *domains.User
+	err := service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		user, err = repositories.Users.GetByEmail(ctx, domains.NormalizeEmail(credentials.Email))
+
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		auth.VerifyDummyPassword(credentials.Password)
+		err = domains.ErrInvalidCredentials
+	}
+	if err == nil {
+		err = verifyPassword(credentials.Password, user.PasswordHash)
+	}
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Login failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Login", err)
+		return nil, err
+	}
+
+	tokens, err := service.issue(user)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Token issuing failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Login", err)
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return tokens, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. The user is loaded
+// again so that a deleted account cannot keep refreshing.
+func (service *AuthService) Refresh(ctx context.Context, refresh *domains.TokenRefresh) (*domains.TokenDto, error) {
+	tracer := otel.Tracer("auth")
+	ctx, span := tracer.Start(ctx, "auth-service")
+	traces.RecordServiceSpan(span, "Refresh")
+	defer span.End()
+
+	if refresh == nil {
+		service.logger.ErrorContext(ctx, "refresh is nil")
+		err := fmt.Errorf("refresh is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Refresh", err)
+		return nil, err
+	}
+
+	claims, err := service.tokens.Parse(refresh.RefreshToken, auth.TokenUseRefresh)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Refresh token rejected", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Refresh", err)
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		err = fmt.Errorf("%w: invalid subject", auth.ErrInvalidToken)
+		service.logger.ErrorContext(ctx, "Refresh token rejected", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Refresh", err)
+		return nil, err
+	}
+
+	var user *domains.User
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		user, err = repositories.Users.GetById(ctx, userID)
+
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("%w: unknown user", auth.ErrInvalidToken)
+	}
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Get user by id failed", "userID", userID, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Refresh", err)
+		return nil, err
+	}
+
+	tokens, err := service.issue(user)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Token issuing failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, authServiceName, "Refresh", err)
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return tokens, nil
+}
+
+func (service *AuthService) issue(user *domains.User) (*domains.TokenDto, error) {
+	pair, err := service.tokens.Issue(user.Id, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domains.TokenDto{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(pair.ExpiresIn.Seconds()),
+	}, nil
+}
+
+func verifyPassword(password string, passwordHash string) error {
+	matches, err := auth.VerifyPassword(password, passwordHash)
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return domains.ErrInvalidCredentials
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/persistence"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthServiceRegister_ShouldReturnErrorOnNilCreate(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var uow *persistence.UnitOfWork
+	var create *domains.UserCreate
+	service := NewAuthService(uow, nil, slog.Default())
+
+	// Act
+	newID, err := service.Register(ctx, create)
+
+	// Assert
+	require.EqualError(t, err, "create is nil")
+	assert.Equal(t, uuid.Nil, newID)
+}
+
+func TestAuthServiceLogin_ShouldReturnErrorOnNilCredentials(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var uow *persistence.UnitOfWork
+	var credentials *domains.Credentials
+	service := NewAuthService(uow, nil, slog.Default())
+
+	// Act
+	tokens, err := service.Login(ctx, credentials)
+
+	// Assert
+	require.EqualError(t, err, "credentials is nil")
+	assert.Nil(t, tokens)
+}
+
+func TestAuthServiceRefresh_ShouldReturnErrorOnNilRefresh(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	var uow *persistence.UnitOfWork
+	var refresh *domains.TokenRefresh
+	service := NewAuthService(uow, nil, slog.Default())
+
+	// Act
+	tokens, err := service.Refresh(ctx, refresh)
+
+	// Assert
+	require.EqualError(t, err, "refresh is nil")
+	assert.Nil(t, tokens)
+}
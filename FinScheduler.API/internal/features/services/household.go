@@ -0,0 +1,372 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/persistence"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type HouseholdsService struct {
+	uow    *persistence.UnitOfWork
+	logger *slog.Logger
+}
+
+const householdsServiceName = "households"
+
+func NewHouseholdsService(uow *persistence.UnitOfWork, logger *slog.Logger) *HouseholdsService {
+	return &HouseholdsService{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// GetListing lists the caller's households with the caller's role in each.
+func (service *HouseholdsService) GetListing(ctx context.Context) ([]domains.HouseholdDto, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-service")
+	traces.RecordServiceSpan(span, "GetListing")
+	defer span.End()
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "GetListing", err)
+		return nil, err
+	}
+
+	var households []domains.HouseholdDto
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		if _, err := ensurePersonalHousehold(ctx, repositories, userID); err != nil {
+			return err
+		}
+
+		memberships, err := repositories.Households.GetByUserId(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		households = make([]domains.HouseholdDto, 0, len(memberships))
+		for _, membership := range memberships {
+			households = append(households, *domains.NewHouseholdDto(membership))
+		}
+
+		return nil
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Get households failed", "userID", userID, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "GetListing", err)
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return households, nil
+}
+
+// Create adds a shared household owned by the caller, who becomes its admin.
+func (service *HouseholdsService) Create(ctx context.Context, create *domains.HouseholdCreate) (uuid.UUID, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-service")
+	traces.RecordServiceSpan(span, "Create")
+	defer span.End()
+
+	if create == nil {
+		service.logger.ErrorContext(ctx, "create is nil")
+		err := fmt.Errorf("create is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "Create", err)
+		return uuid.Nil, err
+	}
+
+	if err := create.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "create validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "Create", err)
+		return uuid.Nil, err
+	}
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "Create", err)
+		return uuid.Nil, err
+	}
+
+	var newId uuid.UUID
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		newId, err = repositories.Households.Create(ctx, userID, create.Name, false)
+		if err != nil {
+			return err
+		}
+
+		_, err = repositories.Households.AddMember(ctx, newId, userID, auth.RoleAdmin)
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error creating a household", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "Create", err)
+		return uuid.Nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return newId, nil
+}
+
+// ResolveMembership returns the caller's membership of householdID, or of
+// the caller's personal household when householdID is nil. The personal
+// household is created on first use. domains.ErrNotMember is returned when
+// the caller does not belong to the household.
+func (service *HouseholdsService) ResolveMembership(ctx context.Context, householdID *uuid.UUID) (auth.Membership, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-service")
+	traces.RecordServiceSpan(span, "ResolveMembership")
+	defer span.End()
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "ResolveMembership", err)
+		return auth.Membership{}, err
+	}
+
+	var membership *domains.HouseholdMembership
+
+	if householdID == nil {
+		err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+			var err error
+			membership, err = ensurePersonalHousehold(ctx, repositories, userID)
+
+			return err
+		})
+	} else {
+		err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+			var err error
+			membership, err = repositories.Households.GetMembership(ctx, userID, *householdID)
+
+			return err
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			err = domains.ErrNotMember
+		}
+	}
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Membership resolution failed", "userID", userID, "householdID", householdID, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "ResolveMembership", err)
+		return auth.Membership{}, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return auth.Membership{HouseholdID: membership.Id, Role: membership.Role}, nil
+}
+
+// CreateInvitation invites someone into the household of the request. The
+// returned token is the only copy; the database keeps its hash.
+func (service *HouseholdsService) CreateInvitation(ctx context.Context, create *domains.InvitationCreate) (*domains.InvitationDto, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-service")
+	traces.RecordServiceSpan(span, "CreateInvitation")
+	defer span.End()
+
+	if create == nil {
+		service.logger.ErrorContext(ctx, "create is nil")
+		err := fmt.Errorf("create is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "CreateInvitation", err)
+		return nil, err
+	}
+
+	if err := create.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "create validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "CreateInvitation", err)
+		return nil, err
+	}
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "CreateInvitation", err)
+		return nil, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "CreateInvitation", err)
+		return nil, err
+	}
+
+	token, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Token generation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "CreateInvitation", err)
+		return nil, err
+	}
+
+	invitation := &domains.Invitation{
+		HouseholdId: householdID,
+		Role:        create.Role,
+		TokenHash:   tokenHash,
+		InvitedBy:   userID,
+		ExpiresAt:   time.Now().UTC().Add(domains.InvitationTTL),
+	}
+
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		_, err := repositories.Invitations.Create(ctx, invitation)
+
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error creating an invitation", "householdID", householdID, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "CreateInvitation", err)
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return &domains.InvitationDto{
+		HouseholdId: householdID,
+		Role:        invitation.Role,
+		Token:       token,
+		ExpiresAt:   invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation makes the caller a member of the inviting household. An
+// unknown token returns sql.ErrNoRows; an expired or used one returns
+// domains.ErrInvitationGone. A caller who already is a member keeps their
+// role.
+func (service *HouseholdsService) AcceptInvitation(ctx context.Context, token string) (*domains.HouseholdDto, error) {
+	tracer := otel.Tracer("households")
+	ctx, span := tracer.Start(ctx, "households-service")
+	traces.RecordServiceSpan(span, "AcceptInvitation")
+	defer span.End()
+
+	if token == "" {
+		service.logger.ErrorContext(ctx, "token is empty")
+		err := fmt.Errorf("token is empty")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "AcceptInvitation", err)
+		return nil, err
+	}
+
+	userID, err := callerFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "caller is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "AcceptInvitation", err)
+		return nil, err
+	}
+
+	var membership *domains.HouseholdMembership
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		invitation, err := repositories.Invitations.GetByTokenHashForUpdate(ctx, auth.HashOpaqueToken(token))
+		if err != nil {
+			return err
+		}
+		if !invitation.Usable(time.Now().UTC()) {
+			return domains.ErrInvitationGone
+		}
+
+		if _, err := repositories.Households.AddMember(ctx, invitation.HouseholdId, userID, invitation.Role); err != nil {
+			return err
+		}
+		if err := repositories.Invitations.MarkAccepted(ctx, invitation.Id, userID); err != nil {
+			return err
+		}
+
+		membership, err = repositories.Households.GetMembership(ctx, userID, invitation.HouseholdId)
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Accept invitation failed", "userID", userID, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, householdsServiceName, "AcceptInvitation", err)
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return domains.NewHouseholdDto(*membership), nil
+}
+
+// ensurePersonalHousehold must run in a transaction so that the household
+// and the admin membership are created together. A concurrent first request
+// of the same user waits on the unique index and then reads its household.
+func ensurePersonalHousehold(ctx context.Context, repositories persistence.Repositories, userID uuid.UUID) (*domains.HouseholdMembership, error) {
+	membership, err := repositories.Households.GetPersonal(ctx, userID)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return membership, err
+	}
+
+	householdID, err := repositories.Households.Create(ctx, userID, domains.PersonalHouseholdName, true)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repositories.Households.GetPersonal(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := repositories.Households.AddMember(ctx, householdID, userID, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return &domains.HouseholdMembership{
+		Id:         householdID,
+		Name:       domains.PersonalHouseholdName,
+		IsPersonal: true,
+		Role:       auth.RoleAdmin,
+	}, nil
+}
+
+// callerFromContext returns the authenticated user of the request.
+func callerFromContext(ctx context.Context) (uuid.UUID, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID == uuid.Nil {
+		return uuid.Nil, domains.ErrUnauthenticated
+	}
+
+	return principal.UserID, nil
+}
+
+// householdFromContext returns the household whose items, tags and price
+// history the request may see. Every repository call is scoped to it.
+func householdFromContext(ctx context.Context) (uuid.UUID, error) {
+	membership, ok := auth.MembershipFromContext(ctx)
+	if !ok || membership.HouseholdID == uuid.Nil {
+		return uuid.Nil, domains.ErrNoHousehold
+	}
+
+	return membership.HouseholdID, nil
+}
+
+// householdScope prefixes an idempotency scope with the household so that
+// two households sending the same key do not replay each other's responses.
+func householdScope(householdID uuid.UUID, claim *domains.IdempotencyClaim) *domains.IdempotencyClaim {
+	scoped := *claim
+	scoped.Scope = householdID.String() + ":" + claim.Scope
+
+	return &scoped
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/persistence"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerFromContext(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "principal",
+			ctx:  auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID, Email: "user@example.com"}),
+			want: userID,
+		},
+		{
+			name:    "no principal",
+			ctx:     context.Background(),
+			want:    uuid.Nil,
+			wantErr: domains.ErrUnauthenticated,
+		},
+		{
+			name:    "nil user id",
+			ctx:     auth.WithPrincipal(context.Background(), auth.Principal{}),
+			want:    uuid.Nil,
+			wantErr: domains.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := callerFromContext(tt.ctx)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHouseholdFromContext(t *testing.T) {
+	householdID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "membership",
+			ctx:  auth.WithMembership(context.Background(), auth.Membership{HouseholdID: householdID, Role: auth.RoleViewer}),
+			want: householdID,
+		},
+		{
+			name:    "principal without membership",
+			ctx:     auth.WithPrincipal(context.Background(), auth.Principal{UserID: userID}),
+			want:    uuid.Nil,
+			wantErr: domains.ErrNoHousehold,
+		},
+		{
+			name:    "nil household id",
+			ctx:     auth.WithMembership(context.Background(), auth.Membership{Role: auth.RoleAdmin}),
+			want:    uuid.Nil,
+			wantErr: domains.ErrNoHousehold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := householdFromContext(tt.ctx)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHouseholdScope_ShouldPrefixScopeWithHousehold(t *testing.T) {
+	// Arrange
+	householdID := uuid.MustParse("0190a8e4-0000-7000-8000-000000000001")
+	claim := &domains.IdempotencyClaim{Scope: "POST /api/v1/items", Key: "key-1", RequestHash: "hash"}
+
+	// Act
+	scoped := householdScope(householdID, claim)
+
+	// Assert
+	assert.Equal(t, "0190a8e4-0000-7000-8000-000000000001:POST /api/v1/items", scoped.Scope)
+	assert.Equal(t, claim.Key, scoped.Key)
+	assert.Equal(t, "POST /api/v1/items", claim.Scope)
+}
+
+func TestItemsServiceGetListingInfo_ShouldReturnErrorWithoutHousehold(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := slog.Default()
+	var uow *persistence.UnitOfWork
+	service := NewItemsService(uow, logger)
+
+	// Act
+	items, count, err := service.GetListingInfo(ctx, &domains.ItemFilter{})
+
+	// Assert
+	require.ErrorIs(t, err, domains.ErrNoHousehold)
+	assert.Nil(t, items)
+	assert.Zero(t, count)
+}
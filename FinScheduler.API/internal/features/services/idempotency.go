@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/metrics"
+	"finscheduler/internal/persistence"
+	"finscheduler/internal/traces"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type IdempotencyKeysService struct {
+	uow    *persistence.UnitOfWork
+	logger *slog.Logger
+}
+
+const idempotencyKeysServiceName = "idempotency-keys"
+
+func NewIdempotencyKeysService(uow *persistence.UnitOfWork, logger *slog.Logger) *IdempotencyKeysService {
+	return &IdempotencyKeysService{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// PurgeExpired deletes the idempotency keys of every household that expired
+// at or before expiredBefore and returns how many were deleted.
+func (service *IdempotencyKeysService) PurgeExpired(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	tracer := otel.Tracer("idempotency-keys")
+	ctx, span := tracer.Start(ctx, "idempotency-keys-service")
+	traces.RecordServiceSpan(span, "PurgeExpired")
+	defer span.End()
+
+	var purged int64
+	err := service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		purged, err = repositories.IdempotencyKeys.DeleteExpired(ctx, expiredBefore)
+
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error purging expired idempotency keys", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, idempotencyKeysServiceName, "PurgeExpired", err)
+		return 0, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return purged, nil
+}
+
+// createIdempotently runs create under the given claim inside the caller's
+// transaction, so the stored response commits or rolls back with the
+// resource. A replayed request returns the stored record and skips create.
+func createIdempotently(ctx context.Context, repositories persistence.Repositories, claim *domains.IdempotencyClaim,
+	create func() (uuid.UUID, error)) (uuid.UUID, *domains.IdempotencyKey, error) {
+	claimed, err := repositories.IdempotencyKeys.Claim(ctx, claim, domains.IdempotencyKeyTTL)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	if !claimed {
+		stored, err := repositories.IdempotencyKeys.GetByKey(ctx, claim.Scope, claim.Key)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		if stored.RequestHash != claim.RequestHash {
+			return uuid.Nil, nil, domains.ErrIdempotencyKeyReused
+		}
+		if !stored.StatusCode.Valid || !stored.ResourceId.Valid {
+			return uuid.Nil, nil, fmt.Errorf("idempotency key %q has no stored response", claim.Key)
+		}
+
+		return stored.ResourceId.UUID, stored, nil
+	}
+
+	newId, err := create()
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	body, err := json.Marshal(newId)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	body = append(body, '\n')
+
+	success, err := repositories.IdempotencyKeys.Complete(ctx, claim.Scope, claim.Key, &domains.IdempotencyKeyComplete{
+		StatusCode:   http.StatusCreated,
+		ResourceId:   newId,
+		ResponseBody: body,
+	})
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	if !success {
+		return uuid.Nil, nil, fmt.Errorf("failed to store idempotent response: update affected no rows")
+	}
+
+	return newId, nil, nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"finscheduler/internal/features/domains"
 	"finscheduler/internal/metrics"
 	"finscheduler/internal/persistence"
@@ -10,6 +11,7 @@ import (
 	"finscheduler/pkg/dh"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
@@ -29,7 +31,7 @@ func NewItemsService(uow *persistence.UnitOfWork, logger *slog.Logger) *ItemsSer
 	}
 }
 
-func (service *ItemsService) GetListingInfo(ctx context.Context, filter *domains.ItemFilter) ([]domains.ItemListingDto, int64, error) {
+func (service *ItemsService) GetListingInfo(ctx context.Context, filter *domains.ItemFilter) ([]domains.ItemListingDto, domains.PageInfo, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-service")
 	traces.RecordServiceSpan(span, "GetListingInfo")
@@ -40,14 +42,22 @@ func (service *ItemsService) GetListingInfo(ctx context.Context, filter *domains
 		err := fmt.Errorf("filter is nil")
 		traces.EnrichFailedServiceSpan(span, err)
 		metrics.RecordServiceFailure(ctx, itemsServiceName, "GetListingInfo", err)
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "GetListingInfo", err)
+		return nil, domains.PageInfo{}, err
 	}
 
 	var items []domains.ItemListingDto
-	var count int64
+	var page domains.PageInfo
 
-	err := service.uow.WithoutTx(func(repositories persistence.Repositories) error {
-		rawItems, rawItemsCount, err := repositories.Items.GetListingInfo(ctx, filter)
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawItems, rawItemsPage, err := repositories.Items.GetListingInfo(ctx, householdID, filter)
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get items failed", "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -55,7 +65,7 @@ func (service *ItemsService) GetListingInfo(ctx context.Context, filter *domains
 			return err
 		}
 
-		count = rawItemsCount
+		page = rawItemsPage
 
 		if len(rawItems) == 0 {
 			items = make([]domains.ItemListingDto, 0)
@@ -70,11 +80,11 @@ func (service *ItemsService) GetListingInfo(ctx context.Context, filter *domains
 		return nil
 	})
 	if err != nil {
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
 	}
 
 	traces.EnrichSuccessServiceSpan(span)
-	return items, count, err
+	return items, page, err
 }
 
 func (service *ItemsService) GetDetailedInfo(ctx context.Context, itemID uuid.UUID) (*domains.ItemDetailedDto, error) {
@@ -91,10 +101,18 @@ func (service *ItemsService) GetDetailedInfo(ctx context.Context, itemID uuid.UU
 		return nil, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "GetDetailedInfo", err)
+		return nil, err
+	}
+
 	var item *domains.ItemDetailedDto
 
-	err := service.uow.WithoutTx(func(repositories persistence.Repositories) error {
-		rawItem, err := repositories.Items.GetDetailedInfo(ctx, itemID)
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawItem, err := repositories.Items.GetDetailedInfo(ctx, householdID, itemID)
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get item by id failed", "itemID", itemID, "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -102,7 +120,7 @@ func (service *ItemsService) GetDetailedInfo(ctx context.Context, itemID uuid.UU
 			return err
 		}
 
-		rawPriceHistories, err := repositories.PriceHistories.GetByItemID(ctx, itemID)
+		rawPriceHistories, err := repositories.PriceHistories.GetByItemID(ctx, householdID, itemID)
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get price histories by item id failed", "itemID", itemID, "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -110,7 +128,7 @@ func (service *ItemsService) GetDetailedInfo(ctx context.Context, itemID uuid.UU
 			return err
 		}
 
-		rawTagToItems, err := repositories.TagToItems.GetByItemIds(ctx, []uuid.UUID{itemID})
+		rawTagToItems, err := repositories.TagToItems.GetByItemIds(ctx, householdID, []uuid.UUID{itemID})
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get tag to items failed", "itemID", itemID, "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -123,7 +141,7 @@ func (service *ItemsService) GetDetailedInfo(ctx context.Context, itemID uuid.UU
 			tagIDs = append(tagIDs, tagToItem.TagId)
 		}
 
-		rawTags, err := repositories.Tags.GetByIds(ctx, tagIDs)
+		rawTags, err := repositories.Tags.GetByIds(ctx, householdID, tagIDs)
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get tags by ids failed", "itemID", itemID, "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -163,36 +181,22 @@ func (service *ItemsService) Create(ctx context.Context, create *domains.ItemCre
 		return uuid.Nil, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Create", err)
+		return uuid.Nil, err
+	}
+
 	var newId uuid.UUID
 	createTagIds := parseUUIDs(create.TagIds)
 
-	err := service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
 		var err error
+		newId, err = createItem(ctx, repositories, householdID, create, createTagIds)
 
-		newId, err = repositories.Items.Create(ctx, create)
-		if err != nil {
-			return err
-		}
-		if newId == uuid.Nil {
-			return fmt.Errorf("failed to create item: repository returned nil uuid")
-		}
-
-		if len(createTagIds) == 0 {
-			return nil
-		}
-
-		success, err := repositories.TagToItems.BulkInsert(ctx, &domains.TagToItemCreate{ItemId: newId, TagIds: createTagIds})
-		if err != nil {
-			if details, ok := dh.GetPostgresErrorDetails(err); ok && details.Code == dh.PostgresForeignKeyViolationCode {
-				return domains.ErrInvalidReference
-			}
-			return err
-		}
-		if !success {
-			return fmt.Errorf("failed to create item: tag to item insert affected no rows")
-		}
-
-		return nil
+		return err
 	})
 
 	if err != nil || newId == uuid.Nil {
@@ -210,7 +214,73 @@ func (service *ItemsService) Create(ctx context.Context, create *domains.ItemCre
 	return newId, err
 }
 
-func (service *ItemsService) Update(ctx context.Context, itemID uuid.UUID, update *domains.ItemUpdate) (bool, error) {
+func (service *ItemsService) CreateIdempotent(ctx context.Context, create *domains.ItemCreate, claim *domains.IdempotencyClaim) (uuid.UUID, *domains.IdempotencyKey, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-service")
+	traces.RecordServiceSpan(span, "CreateIdempotent")
+	defer span.End()
+
+	if create == nil {
+		service.logger.ErrorContext(ctx, "create is nil")
+		err := fmt.Errorf("create is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+	if claim == nil {
+		service.logger.ErrorContext(ctx, "claim is nil")
+		err := fmt.Errorf("claim is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	if err := create.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "create validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+	if err := claim.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "claim validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	var newId uuid.UUID
+	var replay *domains.IdempotencyKey
+	createTagIds := parseUUIDs(create.TagIds)
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		newId, replay, err = createIdempotently(ctx, repositories, householdScope(householdID, claim), func() (uuid.UUID, error) {
+			return createItem(ctx, repositories, householdID, create, createTagIds)
+		})
+
+		return err
+	})
+
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error creating an item idempotently", "error", err, "key", claim.Key)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return newId, replay, nil
+}
+
+func (service *ItemsService) Update(ctx context.Context, itemID uuid.UUID, update *domains.ItemUpdate, expectedVersion *int32) (bool, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-service")
 	traces.RecordServiceSpan(span, "Update")
@@ -238,11 +308,19 @@ func (service *ItemsService) Update(ctx context.Context, itemID uuid.UUID, updat
 		return false, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Update", err)
+		return false, err
+	}
+
 	var success bool
 	updateTagIds := parseUUIDs(update.TagIds)
 
-	err := service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
-		currentItem, err := repositories.Items.GetDetailedInfo(ctx, itemID)
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		currentItem, err := repositories.Items.GetDetailedInfo(ctx, householdID, itemID)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				success = false
@@ -251,66 +329,131 @@ func (service *ItemsService) Update(ctx context.Context, itemID uuid.UUID, updat
 
 			return err
 		}
+		if err = checkVersion(currentItem.Version, expectedVersion); err != nil {
+			return err
+		}
+		before, err := itemAuditSnapshot(ctx, repositories, householdID, itemID)
+		if err != nil {
+			return err
+		}
 
-		success, err = repositories.Items.Update(ctx, itemID, update)
+		success, err = repositories.Items.Update(ctx, householdID, itemID, update, expectedVersion)
 		if err != nil {
 			return err
 		}
 		if !success {
-			return nil
+			return versionConflictOrNotFound(expectedVersion)
 		}
 
 		if !currentItem.Price.Equal(update.Price) {
-			_, err = repositories.PriceHistories.UpsertToday(ctx, itemID, &domains.PriceHistoryUpsert{Value: update.Price})
+			_, err = repositories.PriceHistories.UpsertToday(ctx, householdID, itemID, &domains.PriceHistoryUpsert{Value: update.Price})
 			if err != nil {
 				return err
 			}
 		}
 
-		tagToItems, err := repositories.TagToItems.GetByItemIds(ctx, []uuid.UUID{itemID})
-		if err != nil {
+		if err = reconcileItemTags(ctx, repositories, householdID, itemID, updateTagIds); err != nil {
 			return err
 		}
 
-		var currentTagIds []uuid.UUID
-		if tagToItems != nil && len(tagToItems) > 0 {
-			for _, tagToItem := range tagToItems {
-				currentTagIds = append(currentTagIds, tagToItem.TagId)
+		return recordItemChange(ctx, repositories, householdID, itemID, before)
+	})
+
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error updating an item", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Update", err)
+		return success, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return success, nil
+}
+
+func (service *ItemsService) Patch(ctx context.Context, itemID uuid.UUID, patch *domains.ItemPatch, expectedVersion *int32) (bool, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-service")
+	traces.RecordServiceSpan(span, "Patch")
+	defer span.End()
+
+	if itemID == uuid.Nil {
+		service.logger.ErrorContext(ctx, "itemID is nil")
+		err := fmt.Errorf("itemID is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Patch", err)
+		return false, err
+	}
+	if patch == nil {
+		service.logger.ErrorContext(ctx, "patch is nil")
+		err := fmt.Errorf("patch is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Patch", err)
+		return false, err
+	}
+
+	if err := patch.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "patch validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Patch", err)
+		return false, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Patch", err)
+		return false, err
+	}
+
+	var success bool
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		currentItem, err := repositories.Items.GetDetailedInfo(ctx, householdID, itemID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				success = false
+				return nil
 			}
+
+			return err
+		}
+		if err = checkVersion(currentItem.Version, expectedVersion); err != nil {
+			return err
+		}
+		before, err := itemAuditSnapshot(ctx, repositories, householdID, itemID)
+		if err != nil {
+			return err
 		}
 
-		toDelete, toInsert := dh.Reconcile(updateTagIds, currentTagIds)
+		success, err = repositories.Items.Patch(ctx, householdID, itemID, patch, expectedVersion)
+		if err != nil {
+			return err
+		}
+		if !success {
+			return versionConflictOrNotFound(expectedVersion)
+		}
 
-		if len(toDelete) > 0 {
-			tagSuccess, err := repositories.TagToItems.BulkDelete(ctx, &domains.TagToItemDelete{ItemId: itemID, TagIds: toDelete})
+		if patch.Price != nil && !currentItem.Price.Equal(*patch.Price) {
+			_, err = repositories.PriceHistories.UpsertToday(ctx, householdID, itemID, &domains.PriceHistoryUpsert{Value: *patch.Price})
 			if err != nil {
 				return err
 			}
-			if !tagSuccess {
-				return fmt.Errorf("failed to update item: tag to item delete affected no rows")
-			}
 		}
 
-		if len(toInsert) > 0 {
-			tagSuccess, err := repositories.TagToItems.BulkInsert(ctx, &domains.TagToItemCreate{ItemId: itemID, TagIds: toInsert})
-			if err != nil {
-				if details, ok := dh.GetPostgresErrorDetails(err); ok && details.Code == dh.PostgresForeignKeyViolationCode {
-					return domains.ErrInvalidReference
-				}
+		if patch.TagIds != nil {
+			if err = reconcileItemTags(ctx, repositories, householdID, itemID, parseUUIDs(*patch.TagIds)); err != nil {
 				return err
 			}
-			if !tagSuccess {
-				return fmt.Errorf("failed to update item: tag to item insert affected no rows")
-			}
 		}
 
-		return nil
+		return recordItemChange(ctx, repositories, householdID, itemID, before)
 	})
 
 	if err != nil {
-		service.logger.ErrorContext(ctx, "error updating an item", "error", err)
+		service.logger.ErrorContext(ctx, "error patching an item", "error", err)
 		traces.EnrichFailedServiceSpan(span, err)
-		metrics.RecordServiceFailure(ctx, itemsServiceName, "Update", err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Patch", err)
 		return success, err
 	}
 
@@ -318,7 +461,7 @@ func (service *ItemsService) Update(ctx context.Context, itemID uuid.UUID, updat
 	return success, nil
 }
 
-func (service *ItemsService) Delete(ctx context.Context, itemID uuid.UUID) (bool, error) {
+func (service *ItemsService) Delete(ctx context.Context, itemID uuid.UUID, expectedVersion *int32) (bool, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-service")
 	traces.RecordServiceSpan(span, "Delete")
@@ -332,17 +475,43 @@ func (service *ItemsService) Delete(ctx context.Context, itemID uuid.UUID) (bool
 		return false, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Delete", err)
+		return false, err
+	}
+
 	var success bool
 
-	err := service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
-		var err error
-		success, err = repositories.Items.Delete(ctx, itemID)
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		currentItem, err := repositories.Items.GetDetailedInfo(ctx, householdID, itemID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				success = false
+				return nil
+			}
+
+			return err
+		}
+		if err = checkVersion(currentItem.Version, expectedVersion); err != nil {
+			return err
+		}
+		before, err := itemAuditSnapshot(ctx, repositories, householdID, itemID)
+		if err != nil {
+			return err
+		}
 
+		success, err = repositories.Items.Delete(ctx, householdID, itemID, expectedVersion)
 		if err != nil {
 			return err
 		}
+		if !success {
+			return versionConflictOrNotFound(expectedVersion)
+		}
 
-		return nil
+		return recordAudit(ctx, repositories, householdID, domains.AuditOperationDelete, domains.AuditEntityItem, itemID, before, nil)
 	})
 
 	if err != nil {
@@ -356,6 +525,166 @@ func (service *ItemsService) Delete(ctx context.Context, itemID uuid.UUID) (bool
 	return success, nil
 }
 
+func (service *ItemsService) GetTrash(ctx context.Context, filter *domains.ItemTrashFilter) ([]domains.ItemTrashDto, int64, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-service")
+	traces.RecordServiceSpan(span, "GetTrash")
+	defer span.End()
+
+	if filter == nil {
+		service.logger.ErrorContext(ctx, "filter is nil")
+		err := fmt.Errorf("filter is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "GetTrash", err)
+		return nil, 0, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "GetTrash", err)
+		return nil, 0, err
+	}
+
+	var items []domains.ItemTrashDto
+	var count int64
+
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawItems, rawItemsCount, err := repositories.Items.GetTrash(ctx, householdID, filter)
+		if err != nil {
+			service.logger.ErrorContext(ctx, "Get trash failed", "error", err)
+			return err
+		}
+
+		count = rawItemsCount
+		items = make([]domains.ItemTrashDto, 0, len(rawItems))
+		for _, item := range rawItems {
+			items = append(items, *domains.NewItemTrashDto(item))
+		}
+
+		return nil
+	})
+	if err != nil {
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "GetTrash", err)
+		return nil, 0, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return items, count, nil
+}
+
+// Restore takes an item out of the trash. It returns false when the item is
+// not in the trash and domains.ErrItemNameTaken when another item took its
+// name in the meantime.
+func (service *ItemsService) Restore(ctx context.Context, itemID uuid.UUID) (bool, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-service")
+	traces.RecordServiceSpan(span, "Restore")
+	defer span.End()
+
+	if itemID == uuid.Nil {
+		service.logger.ErrorContext(ctx, "itemID is nil")
+		err := fmt.Errorf("itemID is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Restore", err)
+		return false, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Restore", err)
+		return false, err
+	}
+
+	var success bool
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		deletedAt, err := repositories.Items.Restore(ctx, householdID, itemID)
+		if err != nil {
+			if details, ok := dh.GetPostgresErrorDetails(err); ok && details.Code == dh.PostgresUniqueViolationCode {
+				return domains.ErrItemNameTaken
+			}
+			return err
+		}
+
+		success = deletedAt.Valid
+		if !success {
+			return nil
+		}
+
+		before := domains.AuditSnapshot{"deletedAt": deletedAt.Time}
+		after := domains.AuditSnapshot{"deletedAt": nil}
+		return recordAudit(ctx, repositories, householdID, domains.AuditOperationRestore, domains.AuditEntityItem, itemID, before, after)
+	})
+
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error restoring an item", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "Restore", err)
+		return false, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return success, nil
+}
+
+// PurgeDeleted permanently deletes the items of every household that were
+// moved to the trash before deletedBefore and returns how many were deleted.
+// Each household is purged in its own transaction bound to it as the tenant;
+// a failing household does not stop the others and its error is returned.
+func (service *ItemsService) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-service")
+	traces.RecordServiceSpan(span, "PurgeDeleted")
+	defer span.End()
+
+	var householdIDs []uuid.UUID
+	err := service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		householdIDs, err = repositories.Households.GetIds(ctx)
+
+		return err
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "Get households failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "PurgeDeleted", err)
+		return 0, err
+	}
+
+	var purged int64
+	var errs []error
+	for _, householdID := range householdIDs {
+		var deleted int64
+		err = service.uow.WithTenantTx(ctx, householdID, func(repositories persistence.Repositories) error {
+			var err error
+			deleted, err = repositories.Items.PurgeDeleted(ctx, householdID, deletedBefore)
+
+			return err
+		})
+		if err != nil {
+			service.logger.ErrorContext(ctx, "error purging deleted items", "householdID", householdID, "error", err)
+			errs = append(errs, fmt.Errorf("household %s: %w", householdID, err))
+			continue
+		}
+
+		purged += deleted
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "PurgeDeleted", err)
+		return purged, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return purged, nil
+}
+
 func (service *ItemsService) UpdateCashbackByTag(ctx context.Context, update *domains.ItemCashbackByTagUpdate) (int64, error) {
 	tracer := otel.Tracer("items")
 	ctx, span := tracer.Start(ctx, "items-service")
@@ -385,12 +714,24 @@ func (service *ItemsService) UpdateCashbackByTag(ctx context.Context, update *do
 		return 0, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "UpdateCashbackByTag", err)
+		return 0, err
+	}
+
 	var affected int64
 
 	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
-		var repositoryErr error
-		affected, repositoryErr = repositories.Items.UpdateCashbackByTag(ctx, tagID, update.Cashback)
-		return repositoryErr
+		changes, err := repositories.Items.UpdateCashbackByTag(ctx, householdID, tagID, update.Cashback)
+		if err != nil {
+			return err
+		}
+
+		affected = int64(len(changes))
+		return recordCashbackChanges(ctx, repositories, householdID, changes)
 	})
 	if err != nil {
 		service.logger.ErrorContext(ctx, "error updating cashback by tag", "tagId", update.TagId, "error", err)
@@ -424,13 +765,25 @@ func (service *ItemsService) UpdateCashbackByIds(ctx context.Context, update *do
 		return 0, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "UpdateCashbackByIds", err)
+		return 0, err
+	}
+
 	var affected int64
 	itemIDs := parseUUIDs(update.ItemIds)
 
-	err := service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
-		var repositoryErr error
-		affected, repositoryErr = repositories.Items.UpdateCashbackByIds(ctx, itemIDs, update.Cashback)
-		return repositoryErr
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		changes, err := repositories.Items.UpdateCashbackByIds(ctx, householdID, itemIDs, update.Cashback)
+		if err != nil {
+			return err
+		}
+
+		affected = int64(len(changes))
+		return recordCashbackChanges(ctx, repositories, householdID, changes)
 	})
 	if err != nil {
 		service.logger.ErrorContext(ctx, "error updating cashback by ids", "itemIds", update.ItemIds, "error", err)
@@ -443,6 +796,368 @@ func (service *ItemsService) UpdateCashbackByIds(ctx context.Context, update *do
 	return affected, nil
 }
 
+// AddTags links the tags of change to the selected items outside the trash
+// and returns the number of links added. Every tag must exist and be active.
+func (service *ItemsService) AddTags(ctx context.Context, change *domains.ItemTagsChange) (int64, error) {
+	return service.changeTags(ctx, "AddTags", change, true)
+}
+
+// RemoveTags unlinks the tags of change from the selected items outside the
+// trash and returns the number of links removed. Every tag must exist.
+func (service *ItemsService) RemoveTags(ctx context.Context, change *domains.ItemTagsChange) (int64, error) {
+	return service.changeTags(ctx, "RemoveTags", change, false)
+}
+
+func (service *ItemsService) changeTags(ctx context.Context, operation string, change *domains.ItemTagsChange, add bool) (int64, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-service")
+	traces.RecordServiceSpan(span, operation)
+	defer span.End()
+
+	if change == nil {
+		service.logger.ErrorContext(ctx, "change is nil")
+		err := fmt.Errorf("change is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, operation, err)
+		return 0, err
+	}
+
+	if err := change.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "change validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, operation, err)
+		return 0, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, operation, err)
+		return 0, err
+	}
+
+	var changed int64
+	tagIDs := parseUUIDs(change.TagIds)
+	filter := change.ItemFilter()
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		tags, err := repositories.Tags.GetByIds(ctx, householdID, tagIDs)
+		if err != nil {
+			return err
+		}
+		if len(tags) != len(tagIDs) {
+			return domains.ErrInvalidReference
+		}
+		for _, tag := range tags {
+			if add && !tag.IsActive {
+				return domains.ErrTagInactive
+			}
+		}
+
+		var links []domains.TagToItem
+		if add {
+			links, err = repositories.TagToItems.AddByItemFilter(ctx, householdID, filter, tagIDs)
+		} else {
+			links, err = repositories.TagToItems.RemoveByItemFilter(ctx, householdID, filter, tagIDs)
+		}
+		if err != nil {
+			return err
+		}
+
+		changed = int64(len(links))
+		return recordTagsChanges(ctx, repositories, householdID, links, add)
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error changing item tags", "tagIds", change.TagIds, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, operation, err)
+		return 0, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return changed, nil
+}
+
+// BulkChange applies the operation of change to every item outside the trash
+// that its filter matches, in one transaction. Changed items are audited and
+// price adjustments recorded in the price history, as Update does. A dry run
+// changes nothing and returns the ids of the matching items instead.
+func (service *ItemsService) BulkChange(ctx context.Context, change *domains.ItemBulkChange) (*domains.ItemBulkChangeResult, error) {
+	tracer := otel.Tracer("items")
+	ctx, span := tracer.Start(ctx, "items-service")
+	traces.RecordServiceSpan(span, "BulkChange")
+	defer span.End()
+
+	if change == nil {
+		service.logger.ErrorContext(ctx, "change is nil")
+		err := fmt.Errorf("change is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "BulkChange", err)
+		return nil, err
+	}
+
+	if err := change.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "change validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "BulkChange", err)
+		return nil, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "BulkChange", err)
+		return nil, err
+	}
+
+	result := &domains.ItemBulkChangeResult{ItemIds: []uuid.UUID{}}
+	filter := change.Filter.ItemFilter()
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		items, err := repositories.Items.LockByFilter(ctx, householdID, filter)
+		if err != nil {
+			return err
+		}
+
+		itemIDs := make([]uuid.UUID, 0, len(items))
+		for _, item := range items {
+			itemIDs = append(itemIDs, item.Id)
+		}
+		result.Matched = int64(len(items))
+		if change.DryRun {
+			result.ItemIds = itemIDs
+			return nil
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		tagToItems, err := repositories.TagToItems.GetByItemIds(ctx, householdID, itemIDs)
+		if err != nil {
+			return err
+		}
+		tagIDs := make(map[uuid.UUID][]uuid.UUID, len(items))
+		for _, tagToItem := range tagToItems {
+			tagIDs[tagToItem.ItemId] = append(tagIDs[tagToItem.ItemId], tagToItem.TagId)
+		}
+		before := make(map[uuid.UUID]domains.Item, len(items))
+		for _, item := range items {
+			before[item.Id] = item
+		}
+
+		if change.Operation == domains.ItemBulkDelete {
+			deleted, err := repositories.Items.BulkDelete(ctx, householdID, itemIDs)
+			if err != nil {
+				return err
+			}
+
+			result.Affected = int64(len(deleted))
+			result.ItemIds = append(result.ItemIds, deleted...)
+			for _, itemID := range deleted {
+				snapshot := domains.NewItemAuditSnapshot(before[itemID], tagIDs[itemID])
+				if err = recordAudit(ctx, repositories, householdID, domains.AuditOperationDelete, domains.AuditEntityItem, itemID, snapshot, nil); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		changed, err := repositories.Items.BulkUpdate(ctx, householdID, itemIDs, change)
+		if err != nil {
+			return err
+		}
+
+		result.Affected = int64(len(changed))
+		for _, item := range changed {
+			result.ItemIds = append(result.ItemIds, item.Id)
+			previous := before[item.Id]
+			if !previous.Price.Equal(item.Price) {
+				_, err = repositories.PriceHistories.UpsertToday(ctx, householdID, item.Id, &domains.PriceHistoryUpsert{Value: item.Price})
+				if err != nil {
+					return err
+				}
+			}
+
+			beforeSnapshot := domains.NewItemAuditSnapshot(previous, tagIDs[item.Id])
+			afterSnapshot := domains.NewItemAuditSnapshot(item, tagIDs[item.Id])
+			if err = recordAudit(ctx, repositories, householdID, domains.AuditOperationUpdate, domains.AuditEntityItem, item.Id, beforeSnapshot, afterSnapshot); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error applying a bulk change", "operation", change.Operation, "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, itemsServiceName, "BulkChange", err)
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return result, nil
+}
+
+func createItem(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, create *domains.ItemCreate, tagIds []uuid.UUID) (uuid.UUID, error) {
+	newId, err := repositories.Items.Create(ctx, householdID, create)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if newId == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("failed to create item: repository returned nil uuid")
+	}
+
+	if len(tagIds) > 0 {
+		success, err := repositories.TagToItems.BulkInsert(ctx, householdID, &domains.TagToItemCreate{ItemId: newId, TagIds: tagIds})
+		if err != nil {
+			if details, ok := dh.GetPostgresErrorDetails(err); ok && details.Code == dh.PostgresForeignKeyViolationCode {
+				return newId, domains.ErrInvalidReference
+			}
+			return newId, err
+		}
+		if !success {
+			return newId, fmt.Errorf("failed to create item: tag to item insert affected no rows")
+		}
+	}
+
+	return newId, recordItemChange(ctx, repositories, householdID, newId, nil)
+}
+
+// recordItemChange audits an item created or updated in the transaction of
+// repositories; before is nil for a new item.
+func recordItemChange(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, itemID uuid.UUID, before domains.AuditSnapshot) error {
+	after, err := itemAuditSnapshot(ctx, repositories, householdID, itemID)
+	if err != nil {
+		return err
+	}
+
+	operation := domains.AuditOperationUpdate
+	if before == nil {
+		operation = domains.AuditOperationCreate
+	}
+
+	return recordAudit(ctx, repositories, householdID, operation, domains.AuditEntityItem, itemID, before, after)
+}
+
+// recordCashbackChanges audits every item touched by a bulk cashback update.
+func recordCashbackChanges(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, changes []domains.ItemCashbackChange) error {
+	for _, change := range changes {
+		before := domains.AuditSnapshot{"cashback": change.Before}
+		after := domains.AuditSnapshot{"cashback": change.After}
+		if err := recordAudit(ctx, repositories, householdID, domains.AuditOperationCashbackUpdate, domains.AuditEntityItem, change.ItemId, before, after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordTagsChanges audits the tags of every item in links, which were just
+// added or removed, rebuilding the tags before the change from those after.
+func recordTagsChanges(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, links []domains.TagToItem, added bool) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	changedTags := make(map[uuid.UUID][]uuid.UUID)
+	itemIDs := make([]uuid.UUID, 0)
+	for _, link := range links {
+		if _, ok := changedTags[link.ItemId]; !ok {
+			itemIDs = append(itemIDs, link.ItemId)
+		}
+		changedTags[link.ItemId] = append(changedTags[link.ItemId], link.TagId)
+	}
+
+	currentLinks, err := repositories.TagToItems.GetByItemIds(ctx, householdID, itemIDs)
+	if err != nil {
+		return err
+	}
+	currentTags := make(map[uuid.UUID][]uuid.UUID, len(itemIDs))
+	for _, link := range currentLinks {
+		currentTags[link.ItemId] = append(currentTags[link.ItemId], link.TagId)
+	}
+
+	for _, itemID := range itemIDs {
+		after := currentTags[itemID]
+		var before []uuid.UUID
+		if added {
+			before, _ = dh.Reconcile(changedTags[itemID], after)
+		} else {
+			before = append(append(make([]uuid.UUID, 0), after...), changedTags[itemID]...)
+		}
+
+		if err := recordAudit(ctx, repositories, householdID, domains.AuditOperationTagsUpdate, domains.AuditEntityItem, itemID, domains.NewItemTagsAuditSnapshot(before), domains.NewItemTagsAuditSnapshot(after)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func reconcileItemTags(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, itemID uuid.UUID, tagIds []uuid.UUID) error {
+	tagToItems, err := repositories.TagToItems.GetByItemIds(ctx, householdID, []uuid.UUID{itemID})
+	if err != nil {
+		return err
+	}
+
+	var currentTagIds []uuid.UUID
+	if tagToItems != nil && len(tagToItems) > 0 {
+		for _, tagToItem := range tagToItems {
+			currentTagIds = append(currentTagIds, tagToItem.TagId)
+		}
+	}
+
+	toDelete, toInsert := dh.Reconcile(tagIds, currentTagIds)
+
+	if len(toDelete) > 0 {
+		tagSuccess, err := repositories.TagToItems.BulkDelete(ctx, householdID, &domains.TagToItemDelete{ItemId: itemID, TagIds: toDelete})
+		if err != nil {
+			return err
+		}
+		if !tagSuccess {
+			return fmt.Errorf("failed to update item: tag to item delete affected no rows")
+		}
+	}
+
+	if len(toInsert) > 0 {
+		tagSuccess, err := repositories.TagToItems.BulkInsert(ctx, householdID, &domains.TagToItemCreate{ItemId: itemID, TagIds: toInsert})
+		if err != nil {
+			if details, ok := dh.GetPostgresErrorDetails(err); ok && details.Code == dh.PostgresForeignKeyViolationCode {
+				return domains.ErrInvalidReference
+			}
+			return err
+		}
+		if !tagSuccess {
+			return fmt.Errorf("failed to update item: tag to item insert affected no rows")
+		}
+	}
+
+	return nil
+}
+
+// checkVersion compares the stored version with the one the caller expects;
+// a nil expectation means the write is unconditional.
+func checkVersion(current int32, expected *int32) error {
+	if expected != nil && *expected != current {
+		return domains.ErrPreconditionFailed
+	}
+
+	return nil
+}
+
+// versionConflictOrNotFound explains a conditional write that affected no rows
+// after the row was read in the same transaction: it was changed concurrently.
+func versionConflictOrNotFound(expected *int32) error {
+	if expected != nil {
+		return domains.ErrPreconditionFailed
+	}
+
+	return nil
+}
+
 func parseUUIDs(ids []string) []uuid.UUID {
 	if ids == nil {
 		return nil
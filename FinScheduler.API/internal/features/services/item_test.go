@@ -66,6 +66,30 @@ func TestItemsServiceCreate_ShouldReturnValidationError(t *testing.T) {
 	assert.Equal(t, uuid.Nil, newID)
 }
 
+func TestItemsServiceCreateIdempotent_ShouldReturnErrorOnInvalidInput(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := slog.Default()
+	var uow *persistence.UnitOfWork
+	create := &domains.ItemCreate{Name: "Coffee", Category: "FoodDrinks"}
+	claim := &domains.IdempotencyClaim{Scope: "POST /items", Key: "retry-1", RequestHash: "abc"}
+	invalidClaim := &domains.IdempotencyClaim{Scope: "POST /items", Key: "retry-1"}
+	service := NewItemsService(uow, logger)
+
+	// Act
+	idOnNilCreate, _, errOnNilCreate := service.CreateIdempotent(ctx, nil, claim)
+	idOnNilClaim, _, errOnNilClaim := service.CreateIdempotent(ctx, create, nil)
+	idOnInvalidClaim, _, errOnInvalidClaim := service.CreateIdempotent(ctx, create, invalidClaim)
+
+	// Assert
+	require.EqualError(t, errOnNilCreate, "create is nil")
+	require.EqualError(t, errOnNilClaim, "claim is nil")
+	require.EqualError(t, errOnInvalidClaim, "idempotency request hash is required")
+	assert.Equal(t, uuid.Nil, idOnNilCreate)
+	assert.Equal(t, uuid.Nil, idOnNilClaim)
+	assert.Equal(t, uuid.Nil, idOnInvalidClaim)
+}
+
 func TestItemsServiceUpdate_ShouldReturnErrorOnInvalidInput(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
@@ -78,8 +102,8 @@ func TestItemsServiceUpdate_ShouldReturnErrorOnInvalidInput(t *testing.T) {
 	service := NewItemsService(uow, logger)
 
 	// Act
-	successOnNilID, errOnNilID := service.Update(ctx, nilID, update)
-	successOnNilUpdate, errOnNilUpdate := service.Update(ctx, validID, nilUpdate)
+	successOnNilID, errOnNilID := service.Update(ctx, nilID, update, nil)
+	successOnNilUpdate, errOnNilUpdate := service.Update(ctx, validID, nilUpdate, nil)
 
 	// Assert
 	require.EqualError(t, errOnNilID, "itemID is nil")
@@ -103,13 +127,40 @@ func TestItemsServiceUpdate_ShouldReturnValidationError(t *testing.T) {
 	service := NewItemsService(uow, logger)
 
 	// Act
-	success, err := service.Update(ctx, itemID, update)
+	success, err := service.Update(ctx, itemID, update, nil)
 
 	// Assert
 	require.EqualError(t, err, "tagId is duplicated: "+duplicateTagID)
 	assert.False(t, success)
 }
 
+func TestItemsServicePatch_ShouldReturnErrorOnInvalidInput(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := slog.Default()
+	var uow *persistence.UnitOfWork
+	nilID := uuid.Nil
+	validID := uuid.New()
+	patch := &domains.ItemPatch{}
+	var nilPatch *domains.ItemPatch
+	shortName := "No"
+	invalidPatch := &domains.ItemPatch{Name: &shortName}
+	service := NewItemsService(uow, logger)
+
+	// Act
+	successOnNilID, errOnNilID := service.Patch(ctx, nilID, patch, nil)
+	successOnNilPatch, errOnNilPatch := service.Patch(ctx, validID, nilPatch, nil)
+	successOnInvalidPatch, errOnInvalidPatch := service.Patch(ctx, validID, invalidPatch, nil)
+
+	// Assert
+	require.EqualError(t, errOnNilID, "itemID is nil")
+	require.EqualError(t, errOnNilPatch, "patch is nil")
+	require.EqualError(t, errOnInvalidPatch, "name must be at least 3 characters long")
+	assert.False(t, successOnNilID)
+	assert.False(t, successOnNilPatch)
+	assert.False(t, successOnInvalidPatch)
+}
+
 func TestItemsServiceDelete_ShouldReturnErrorOnNilItemID(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
@@ -119,7 +170,7 @@ func TestItemsServiceDelete_ShouldReturnErrorOnNilItemID(t *testing.T) {
 	service := NewItemsService(uow, logger)
 
 	// Act
-	success, err := service.Delete(ctx, itemID)
+	success, err := service.Delete(ctx, itemID, nil)
 
 	// Assert
 	require.EqualError(t, err, "itemID is nil")
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"finscheduler/internal/features/domains"
 	"finscheduler/internal/metrics"
 	"finscheduler/internal/persistence"
@@ -27,7 +28,7 @@ func NewTagsService(uow *persistence.UnitOfWork, logger *slog.Logger) *TagsServi
 	}
 }
 
-func (service *TagsService) GetListingInfo(ctx context.Context, filter *domains.TagFilter) ([]domains.TagListingDto, int64, error) {
+func (service *TagsService) GetListingInfo(ctx context.Context, filter *domains.TagFilter) ([]domains.TagListingDto, domains.PageInfo, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-service")
 	traces.RecordServiceSpan(span, "GetListingInfo")
@@ -38,14 +39,22 @@ func (service *TagsService) GetListingInfo(ctx context.Context, filter *domains.
 		err := fmt.Errorf("filter is nil")
 		traces.EnrichFailedServiceSpan(span, err)
 		metrics.RecordServiceFailure(ctx, tagsServiceName, "GetListingInfo", err)
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "GetListingInfo", err)
+		return nil, domains.PageInfo{}, err
 	}
 
 	var tags []domains.TagListingDto
-	var count int64
+	var page domains.PageInfo
 
-	err := service.uow.WithoutTx(func(repositories persistence.Repositories) error {
-		rawTags, rawTagsCount, err := repositories.Tags.GetListingInfo(ctx, filter)
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawTags, rawTagsPage, err := repositories.Tags.GetListingInfo(ctx, householdID, filter)
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get tags failed", "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -53,7 +62,7 @@ func (service *TagsService) GetListingInfo(ctx context.Context, filter *domains.
 			return err
 		}
 
-		count = rawTagsCount
+		page = rawTagsPage
 
 		tags = make([]domains.TagListingDto, 0)
 		if rawTags != nil && len(rawTags) > 0 {
@@ -62,14 +71,40 @@ func (service *TagsService) GetListingInfo(ctx context.Context, filter *domains.
 			}
 		}
 
+		if filter.WithStats == nil || !*filter.WithStats || len(tags) == 0 {
+			return nil
+		}
+
+		tagIDs := make([]uuid.UUID, 0, len(tags))
+		for _, tag := range tags {
+			tagIDs = append(tagIDs, tag.Id)
+		}
+		rawStats, err := repositories.Tags.GetStats(ctx, householdID, tagIDs)
+		if err != nil {
+			service.logger.ErrorContext(ctx, "Get tag stats failed", "error", err)
+			traces.EnrichFailedServiceSpan(span, err)
+			metrics.RecordServiceFailure(ctx, tagsServiceName, "GetListingInfo", err)
+			return err
+		}
+
+		statsByTag := make(map[uuid.UUID]domains.TagStats, len(rawStats))
+		for _, stats := range rawStats {
+			statsByTag[stats.TagId] = stats
+		}
+		for i := range tags {
+			if stats, ok := statsByTag[tags[i].Id]; ok {
+				tags[i].Stats = domains.NewTagStatsDto(stats)
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
-		return nil, 0, err
+		return nil, domains.PageInfo{}, err
 	}
 
 	traces.EnrichSuccessServiceSpan(span)
-	return tags, count, err
+	return tags, page, err
 }
 
 func (service *TagsService) GetDetailedInfo(ctx context.Context, tagID uuid.UUID) (*domains.TagDetailedDto, error) {
@@ -86,10 +121,18 @@ func (service *TagsService) GetDetailedInfo(ctx context.Context, tagID uuid.UUID
 		return nil, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "GetDetailedInfo", err)
+		return nil, err
+	}
+
 	var tag *domains.TagDetailedDto
 
-	err := service.uow.WithoutTx(func(repositories persistence.Repositories) error {
-		rawTag, err := repositories.Tags.GetDetailedInfo(ctx, tagID)
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawTag, err := repositories.Tags.GetDetailedInfo(ctx, householdID, tagID)
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get tag by id failed", "tagID", tagID, "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -108,7 +151,56 @@ func (service *TagsService) GetDetailedInfo(ctx context.Context, tagID uuid.UUID
 	return tag, nil
 }
 
-func (service *TagsService) GetLookup(ctx context.Context, filter *domains.TagLookupFilter) ([]domains.Lookup, int64, error) {
+// GetStats returns the usage of a tag, or sql.ErrNoRows when it does not
+// exist.
+func (service *TagsService) GetStats(ctx context.Context, tagID uuid.UUID) (*domains.TagStatsDto, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-service")
+	traces.RecordServiceSpan(span, "GetStats")
+	defer span.End()
+
+	if tagID == uuid.Nil {
+		service.logger.ErrorContext(ctx, "tagID is nil")
+		err := fmt.Errorf("tagID is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "GetStats", err)
+		return nil, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "GetStats", err)
+		return nil, err
+	}
+
+	var stats *domains.TagStatsDto
+
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawStats, err := repositories.Tags.GetStats(ctx, householdID, []uuid.UUID{tagID})
+		if err != nil {
+			service.logger.ErrorContext(ctx, "Get tag stats failed", "tagID", tagID, "error", err)
+			traces.EnrichFailedServiceSpan(span, err)
+			metrics.RecordServiceFailure(ctx, tagsServiceName, "GetStats", err)
+			return err
+		}
+		if len(rawStats) == 0 {
+			return sql.ErrNoRows
+		}
+
+		stats = domains.NewTagStatsDto(rawStats[0])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return stats, nil
+}
+
+func (service *TagsService) GetLookup(ctx context.Context, filter *domains.TagLookupFilter) ([]domains.TagLookupDto, int64, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-service")
 	traces.RecordServiceSpan(span, "GetLookup")
@@ -122,11 +214,19 @@ func (service *TagsService) GetLookup(ctx context.Context, filter *domains.TagLo
 		return nil, 0, err
 	}
 
-	var tags []domains.Lookup
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "GetLookup", err)
+		return nil, 0, err
+	}
+
+	var tags []domains.TagLookupDto
 	var count int64
 
-	err := service.uow.WithoutTx(func(repositories persistence.Repositories) error {
-		rawTags, rawTagsCount, err := repositories.Tags.GetLookup(ctx, filter)
+	err = service.uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		rawTags, rawTagsCount, err := repositories.Tags.GetLookup(ctx, householdID, filter)
 		if err != nil {
 			service.logger.ErrorContext(ctx, "Get tags failed", "error", err)
 			traces.EnrichFailedServiceSpan(span, err)
@@ -161,20 +261,21 @@ func (service *TagsService) Create(ctx context.Context, create *domains.TagCreat
 		return uuid.Nil, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Create", err)
+		return uuid.Nil, err
+	}
+
 	var newId uuid.UUID
 
-	err := service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
 		var err error
-		newId, err = repositories.Tags.Create(ctx, create)
-
-		if err != nil || newId == uuid.Nil {
-			if err == nil {
-				err = fmt.Errorf("failed to create tag: repository returned nil uuid")
-			}
-			return err
-		}
+		newId, err = createTag(ctx, repositories, householdID, create)
 
-		return nil
+		return err
 	})
 
 	if err != nil || newId == uuid.Nil {
@@ -192,7 +293,66 @@ func (service *TagsService) Create(ctx context.Context, create *domains.TagCreat
 	return newId, err
 }
 
-func (service *TagsService) Update(ctx context.Context, tagID uuid.UUID, update *domains.TagUpdate) (bool, error) {
+func (service *TagsService) CreateIdempotent(ctx context.Context, create *domains.TagCreate, claim *domains.IdempotencyClaim) (uuid.UUID, *domains.IdempotencyKey, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-service")
+	traces.RecordServiceSpan(span, "CreateIdempotent")
+	defer span.End()
+
+	if create == nil {
+		service.logger.ErrorContext(ctx, "create is nil")
+		err := fmt.Errorf("create is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+	if claim == nil {
+		service.logger.ErrorContext(ctx, "claim is nil")
+		err := fmt.Errorf("claim is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	if err := claim.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "claim validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	var newId uuid.UUID
+	var replay *domains.IdempotencyKey
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		newId, replay, err = createIdempotently(ctx, repositories, householdScope(householdID, claim), func() (uuid.UUID, error) {
+			return createTag(ctx, repositories, householdID, create)
+		})
+
+		return err
+	})
+
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error creating a tag idempotently", "error", err, "key", claim.Key)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "CreateIdempotent", err)
+		return uuid.Nil, nil, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return newId, replay, nil
+}
+
+func (service *TagsService) Update(ctx context.Context, tagID uuid.UUID, update *domains.TagUpdate, expectedVersion *int32) (bool, error) {
 	tracer := otel.Tracer("tags")
 	ctx, span := tracer.Start(ctx, "tags-service")
 	traces.RecordServiceSpan(span, "Update")
@@ -213,26 +373,49 @@ func (service *TagsService) Update(ctx context.Context, tagID uuid.UUID, update
 		return false, err
 	}
 
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Update", err)
+		return false, err
+	}
+
 	var success bool
 
-	err := service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
-		var err error
-		success, err = repositories.Tags.Update(ctx, tagID, update)
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		currentTag, err := repositories.Tags.GetDetailedInfo(ctx, householdID, tagID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				success = false
+				return nil
+			}
+
+			return err
+		}
+		if err = checkVersion(currentTag.Version, expectedVersion); err != nil {
+			return err
+		}
+		if err = checkTagParent(ctx, repositories, householdID, tagID, currentTag.ParentId, domains.ParseParentId(update.ParentId)); err != nil {
+			return err
+		}
+		before := domains.NewTagAuditSnapshot(*currentTag)
 
+		success, err = repositories.Tags.Update(ctx, householdID, tagID, update, expectedVersion)
 		if err != nil {
 			return err
 		}
 		if !success {
-			return nil
+			return versionConflictOrNotFound(expectedVersion)
 		}
 		if !update.IsActive {
-			_, err = repositories.TagToItems.DeleteByTagId(ctx, tagID)
+			_, err = repositories.TagToItems.DeleteByTagId(ctx, householdID, tagID)
 			if err != nil {
 				return err
 			}
 		}
 
-		return nil
+		return recordTagChange(ctx, repositories, householdID, tagID, before)
 	})
 
 	if err != nil {
@@ -245,3 +428,341 @@ func (service *TagsService) Update(ctx context.Context, tagID uuid.UUID, update
 	traces.EnrichSuccessServiceSpan(span)
 	return success, nil
 }
+
+func (service *TagsService) Patch(ctx context.Context, tagID uuid.UUID, patch *domains.TagPatch, expectedVersion *int32) (bool, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-service")
+	traces.RecordServiceSpan(span, "Patch")
+	defer span.End()
+
+	if tagID == uuid.Nil {
+		service.logger.ErrorContext(ctx, "tagID is nil")
+		err := fmt.Errorf("tagID is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Patch", err)
+		return false, err
+	}
+	if patch == nil {
+		service.logger.ErrorContext(ctx, "patch is nil")
+		err := fmt.Errorf("patch is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Patch", err)
+		return false, err
+	}
+
+	if err := patch.Validate(); err != nil {
+		service.logger.ErrorContext(ctx, "patch validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Patch", err)
+		return false, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Patch", err)
+		return false, err
+	}
+
+	var success bool
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		currentTag, err := repositories.Tags.GetDetailedInfo(ctx, householdID, tagID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				success = false
+				return nil
+			}
+
+			return err
+		}
+		if err = checkVersion(currentTag.Version, expectedVersion); err != nil {
+			return err
+		}
+		if patch.ParentId.Set {
+			if err = checkTagParent(ctx, repositories, householdID, tagID, currentTag.ParentId, domains.ParseParentId(patch.ParentId.Id)); err != nil {
+				return err
+			}
+		}
+		before := domains.NewTagAuditSnapshot(*currentTag)
+
+		success, err = repositories.Tags.Patch(ctx, householdID, tagID, patch, expectedVersion)
+		if err != nil {
+			return err
+		}
+		if !success {
+			return versionConflictOrNotFound(expectedVersion)
+		}
+		if patch.IsActive != nil && !*patch.IsActive {
+			_, err = repositories.TagToItems.DeleteByTagId(ctx, householdID, tagID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return recordTagChange(ctx, repositories, householdID, tagID, before)
+	})
+
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error patching a tag", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Patch", err)
+		return success, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return success, nil
+}
+
+// Delete removes a tag. A tag still linked to items outside the trash is only
+// deleted with force, which unlinks it from them; links to items in the trash
+// go with the tag either way.
+func (service *TagsService) Delete(ctx context.Context, tagID uuid.UUID, force bool, expectedVersion *int32) (bool, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-service")
+	traces.RecordServiceSpan(span, "Delete")
+	defer span.End()
+
+	if tagID == uuid.Nil {
+		service.logger.ErrorContext(ctx, "tagID is nil")
+		err := fmt.Errorf("tagID is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Delete", err)
+		return false, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Delete", err)
+		return false, err
+	}
+
+	var success bool
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		currentTag, err := repositories.Tags.GetDetailedInfo(ctx, householdID, tagID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				success = false
+				return nil
+			}
+
+			return err
+		}
+		if err = checkVersion(currentTag.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if !force {
+			linked, err := repositories.TagToItems.CountByTagId(ctx, householdID, tagID)
+			if err != nil {
+				return err
+			}
+			if linked > 0 {
+				return domains.ErrTagInUse
+			}
+		}
+
+		success, err = deleteTag(ctx, repositories, householdID, tagID, *currentTag, expectedVersion)
+		return err
+	})
+
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error deleting a tag", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "Delete", err)
+		return success, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return success, nil
+}
+
+// MergeInto moves every item link of the source tag to the target, skipping
+// items that already have the target, and deletes the source in the same
+// transaction. It returns false when either tag does not exist.
+func (service *TagsService) MergeInto(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) (bool, error) {
+	tracer := otel.Tracer("tags")
+	ctx, span := tracer.Start(ctx, "tags-service")
+	traces.RecordServiceSpan(span, "MergeInto")
+	defer span.End()
+
+	if sourceID == uuid.Nil || targetID == uuid.Nil {
+		service.logger.ErrorContext(ctx, "sourceID or targetID is nil")
+		err := fmt.Errorf("sourceID or targetID is nil")
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "MergeInto", err)
+		return false, err
+	}
+	if sourceID == targetID {
+		err := domains.ErrTagMergeIntoItself
+		service.logger.ErrorContext(ctx, "merge validation failed", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "MergeInto", err)
+		return false, err
+	}
+
+	householdID, err := householdFromContext(ctx)
+	if err != nil {
+		service.logger.ErrorContext(ctx, "household is unknown", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "MergeInto", err)
+		return false, err
+	}
+
+	var success bool
+
+	err = service.uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		source, err := repositories.Tags.GetDetailedInfo(ctx, householdID, sourceID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				success = false
+				return nil
+			}
+
+			return err
+		}
+		target, err := repositories.Tags.GetDetailedInfo(ctx, householdID, targetID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				success = false
+				return nil
+			}
+
+			return err
+		}
+		if !target.IsActive {
+			return domains.ErrTagInactive
+		}
+
+		moved, err := repositories.TagToItems.CopyToTag(ctx, householdID, sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		service.logger.InfoContext(ctx, "tag links moved", "sourceID", sourceID, "targetID", targetID, "moved", moved)
+
+		success, err = deleteTag(ctx, repositories, householdID, sourceID, *source, nil)
+		return err
+	})
+
+	if err != nil {
+		service.logger.ErrorContext(ctx, "error merging tags", "error", err)
+		traces.EnrichFailedServiceSpan(span, err)
+		metrics.RecordServiceFailure(ctx, tagsServiceName, "MergeInto", err)
+		return success, err
+	}
+
+	traces.EnrichSuccessServiceSpan(span)
+	return success, nil
+}
+
+func createTag(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, create *domains.TagCreate) (uuid.UUID, error) {
+	if err := checkTagParent(ctx, repositories, householdID, uuid.Nil, nil, domains.ParseParentId(create.ParentId)); err != nil {
+		return uuid.Nil, err
+	}
+
+	newId, err := repositories.Tags.Create(ctx, householdID, create)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if newId == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("failed to create tag: repository returned nil uuid")
+	}
+
+	return newId, recordTagChange(ctx, repositories, householdID, newId, nil)
+}
+
+// recordTagChange audits a tag created or updated in the transaction of
+// repositories; before is nil for a new tag.
+func recordTagChange(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, tagID uuid.UUID, before domains.AuditSnapshot) error {
+	after, err := tagAuditSnapshot(ctx, repositories, householdID, tagID)
+	if err != nil {
+		return err
+	}
+
+	operation := domains.AuditOperationUpdate
+	if before == nil {
+		operation = domains.AuditOperationCreate
+	}
+
+	return recordAudit(ctx, repositories, householdID, operation, domains.AuditEntityTag, tagID, before, after)
+}
+
+// checkTagParent makes sure that parentID, when set and different from the
+// current parent, is an existing tag outside the subtree of tagID. tagID is
+// uuid.Nil for a tag yet to be created, which has no subtree.
+func checkTagParent(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, tagID uuid.UUID, currentID *uuid.UUID, parentID *uuid.UUID) error {
+	if parentID == nil || (currentID != nil && *currentID == *parentID) {
+		return nil
+	}
+	if *parentID == tagID {
+		return domains.ErrTagCycle
+	}
+
+	parents, err := repositories.Tags.GetByIds(ctx, householdID, []uuid.UUID{*parentID})
+	if err != nil {
+		return err
+	}
+	if len(parents) == 0 {
+		return domains.ErrInvalidReference
+	}
+	if tagID == uuid.Nil {
+		return nil
+	}
+
+	if err = repositories.Tags.LockHierarchy(ctx, householdID); err != nil {
+		return err
+	}
+	inSubtree, err := repositories.Tags.IsInSubtree(ctx, householdID, tagID, *parentID)
+	if err != nil {
+		return err
+	}
+	if inSubtree {
+		return domains.ErrTagCycle
+	}
+
+	return nil
+}
+
+// deleteTag unlinks a tag from its items, moves its children up to its parent
+// and deletes it in the transaction of repositories, auditing every change;
+// current is the tag as read beforehand.
+func deleteTag(ctx context.Context, repositories persistence.Repositories, householdID uuid.UUID, tagID uuid.UUID, current domains.Tag, expectedVersion *int32) (bool, error) {
+	if _, err := repositories.TagToItems.DeleteByTagId(ctx, householdID, tagID); err != nil {
+		return false, err
+	}
+
+	if err := repositories.Tags.LockHierarchy(ctx, householdID); err != nil {
+		return false, err
+	}
+	childIDs, err := repositories.Tags.MoveChildrenUp(ctx, householdID, tagID)
+	if err != nil {
+		return false, err
+	}
+	children, err := repositories.Tags.GetByIds(ctx, householdID, childIDs)
+	if err != nil {
+		return false, err
+	}
+	for _, child := range children {
+		after := domains.NewTagAuditSnapshot(child)
+		child.ParentId = &tagID
+		before := domains.NewTagAuditSnapshot(child)
+		if err = recordAudit(ctx, repositories, householdID, domains.AuditOperationUpdate, domains.AuditEntityTag, child.Id, before, after); err != nil {
+			return false, err
+		}
+	}
+
+	success, err := repositories.Tags.Delete(ctx, householdID, tagID, expectedVersion)
+	if err != nil {
+		return false, err
+	}
+	if !success {
+		return false, versionConflictOrNotFound(expectedVersion)
+	}
+
+	before := domains.NewTagAuditSnapshot(current)
+	return true, recordAudit(ctx, repositories, householdID, domains.AuditOperationDelete, domains.AuditEntityTag, tagID, before, nil)
+}
@@ -74,8 +74,8 @@ func TestTagsServiceUpdate_ShouldReturnErrorOnInvalidInput(t *testing.T) {
 	service := NewTagsService(uow, logger)
 
 	// Act
-	successOnNilID, errOnNilID := service.Update(ctx, nilID, update)
-	successOnNilUpdate, errOnNilUpdate := service.Update(ctx, validID, nilUpdate)
+	successOnNilID, errOnNilID := service.Update(ctx, nilID, update, nil)
+	successOnNilUpdate, errOnNilUpdate := service.Update(ctx, validID, nilUpdate, nil)
 
 	// Assert
 	require.EqualError(t, errOnNilID, "tagID is nil")
@@ -83,3 +83,49 @@ func TestTagsServiceUpdate_ShouldReturnErrorOnInvalidInput(t *testing.T) {
 	assert.False(t, successOnNilID)
 	assert.False(t, successOnNilUpdate)
 }
+
+func TestTagsServicePatch_ShouldReturnErrorOnInvalidInput(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := slog.Default()
+	var uow *persistence.UnitOfWork
+	nilID := uuid.Nil
+	validID := uuid.New()
+	patch := &domains.TagPatch{}
+	var nilPatch *domains.TagPatch
+	service := NewTagsService(uow, logger)
+
+	// Act
+	successOnNilID, errOnNilID := service.Patch(ctx, nilID, patch, nil)
+	successOnNilPatch, errOnNilPatch := service.Patch(ctx, validID, nilPatch, nil)
+
+	// Assert
+	require.EqualError(t, errOnNilID, "tagID is nil")
+	require.EqualError(t, errOnNilPatch, "patch is nil")
+	assert.False(t, successOnNilID)
+	assert.False(t, successOnNilPatch)
+}
+
+func TestTagsServiceCreateIdempotent_ShouldReturnErrorOnInvalidInput(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := slog.Default()
+	var uow *persistence.UnitOfWork
+	create := &domains.TagCreate{Name: "Groceries", IsActive: true}
+	claim := &domains.IdempotencyClaim{Scope: "POST /tags", Key: "retry-1", RequestHash: "abc"}
+	invalidClaim := &domains.IdempotencyClaim{Scope: "POST /tags", RequestHash: "abc"}
+	service := NewTagsService(uow, logger)
+
+	// Act
+	idOnNilCreate, _, errOnNilCreate := service.CreateIdempotent(ctx, nil, claim)
+	idOnNilClaim, _, errOnNilClaim := service.CreateIdempotent(ctx, create, nil)
+	idOnInvalidClaim, _, errOnInvalidClaim := service.CreateIdempotent(ctx, create, invalidClaim)
+
+	// Assert
+	require.EqualError(t, errOnNilCreate, "create is nil")
+	require.EqualError(t, errOnNilClaim, "claim is nil")
+	require.EqualError(t, errOnInvalidClaim, "idempotency key is required")
+	assert.Equal(t, uuid.Nil, idOnNilCreate)
+	assert.Equal(t, uuid.Nil, idOnNilClaim)
+	assert.Equal(t, uuid.Nil, idOnInvalidClaim)
+}
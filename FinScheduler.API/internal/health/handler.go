@@ -12,9 +12,13 @@ func LiveHandler() http.HandlerFunc {
 	}
 }
 
-func ReadyHandler(db *sqlx.DB) http.HandlerFunc {
+func ReadyHandler(db *sqlx.DB, readiness *Readiness) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
+		if readiness != nil && readiness.IsDraining() {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
 		if db == nil {
 			http.Error(w, "db nil", http.StatusServiceUnavailable)
 			return
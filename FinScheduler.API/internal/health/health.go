@@ -5,7 +5,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func SetupHealthChecks(router *chi.Mux, db *sqlx.DB) {
+func SetupHealthChecks(router *chi.Mux, db *sqlx.DB, readiness *Readiness) {
 	router.Handle("/livez", LiveHandler())
-	router.Handle("/readyz", ReadyHandler(db))
+	router.Handle("/readyz", ReadyHandler(db, readiness))
 }
@@ -0,0 +1,22 @@
+package health
+
+import "sync/atomic"
+
+// Readiness tracks whether the instance should receive new traffic. It is
+// switched off at the start of a graceful shutdown so /readyz fails while
+// in-flight requests drain.
+type Readiness struct {
+	draining atomic.Bool
+}
+
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+func (readiness *Readiness) StartDraining() {
+	readiness.draining.Store(true)
+}
+
+func (readiness *Readiness) IsDraining() bool {
+	return readiness.draining.Load()
+}
@@ -5,11 +5,34 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/subosito/gotenv"
 )
 
+const (
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultReadTimeout       = 15 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 60 * time.Second
+	defaultDrainDelay        = 5 * time.Second
+	defaultShutdownTimeout   = 20 * time.Second
+	defaultMaxBodyBytes      = 1 << 20
+
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+	defaultRateLimitStore  = "memory"
+	defaultRateLimitLimit  = 60
+	defaultRateLimitPeriod = time.Minute
+
+	defaultTrashRetentionDays = 30
+	defaultTrashPurgeInterval = time.Hour
+
+	defaultIdempotencyPurgeInterval = time.Hour
+)
+
 func LoadConfig() (*Config, error) {
 	v := viper.New()
 	if err := loadJSONConfig(v); err != nil {
@@ -57,6 +80,24 @@ func loadDotEnv() error {
 func configureEnvironment(v *viper.Viper) {
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetDefault("server.readHeaderTimeout", defaultReadHeaderTimeout)
+	v.SetDefault("server.readTimeout", defaultReadTimeout)
+	v.SetDefault("server.writeTimeout", defaultWriteTimeout)
+	v.SetDefault("server.idleTimeout", defaultIdleTimeout)
+	v.SetDefault("server.drainDelay", defaultDrainDelay)
+	v.SetDefault("server.shutdownTimeout", defaultShutdownTimeout)
+	v.SetDefault("server.maxBodyBytes", defaultMaxBodyBytes)
+	v.SetDefault("auth.issuer", "fin-scheduler-api")
+	v.SetDefault("auth.accessTokenTTL", defaultAccessTokenTTL)
+	v.SetDefault("auth.refreshTokenTTL", defaultRefreshTokenTTL)
+	v.SetDefault("rateLimit.enabled", true)
+	v.SetDefault("rateLimit.store", defaultRateLimitStore)
+	v.SetDefault("rateLimit.trustForwardedFor", false)
+	v.SetDefault("rateLimit.default.limit", defaultRateLimitLimit)
+	v.SetDefault("rateLimit.default.period", defaultRateLimitPeriod)
+	v.SetDefault("trash.retentionDays", defaultTrashRetentionDays)
+	v.SetDefault("trash.purgeInterval", defaultTrashPurgeInterval)
+	v.SetDefault("idempotency.purgeInterval", defaultIdempotencyPurgeInterval)
 	v.SetDefault("corsSettings.allowedOrigins", []string{"*"})
 	v.SetDefault("corsSettings.allowedMethods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
 	v.SetDefault("corsSettings.allowedHeaders", []string{"*"})
@@ -75,7 +116,27 @@ func configureEnvironment(v *viper.Viper) {
 func bindConfigEnv(v *viper.Viper) {
 	bindEnv(v, "env", "ENV")
 	bindEnv(v, "serverPort", "SERVER_PORT")
+	bindEnv(v, "server.readHeaderTimeout", "SERVER_READ_HEADER_TIMEOUT")
+	bindEnv(v, "server.readTimeout", "SERVER_READ_TIMEOUT")
+	bindEnv(v, "server.writeTimeout", "SERVER_WRITE_TIMEOUT")
+	bindEnv(v, "server.idleTimeout", "SERVER_IDLE_TIMEOUT")
+	bindEnv(v, "server.drainDelay", "SERVER_DRAIN_DELAY")
+	bindEnv(v, "server.shutdownTimeout", "SERVER_SHUTDOWN_TIMEOUT")
+	bindEnv(v, "server.maxBodyBytes", "SERVER_MAX_BODY_BYTES")
 	bindEnv(v, "connectionString", "CONNECTION_STRING")
+	bindEnv(v, "auth.issuer", "AUTH_ISSUER")
+	bindEnv(v, "auth.signingKey", "AUTH_SIGNING_KEY")
+	bindEnv(v, "auth.verificationKeys", "AUTH_VERIFICATION_KEYS")
+	bindEnv(v, "auth.accessTokenTTL", "AUTH_ACCESS_TOKEN_TTL")
+	bindEnv(v, "auth.refreshTokenTTL", "AUTH_REFRESH_TOKEN_TTL")
+	bindEnv(v, "rateLimit.enabled", "RATE_LIMIT_ENABLED")
+	bindEnv(v, "rateLimit.store", "RATE_LIMIT_STORE")
+	bindEnv(v, "rateLimit.trustForwardedFor", "RATE_LIMIT_TRUST_FORWARDED_FOR")
+	bindEnv(v, "rateLimit.default.limit", "RATE_LIMIT_DEFAULT_LIMIT")
+	bindEnv(v, "rateLimit.default.period", "RATE_LIMIT_DEFAULT_PERIOD")
+	bindEnv(v, "trash.retentionDays", "TRASH_RETENTION_DAYS")
+	bindEnv(v, "trash.purgeInterval", "TRASH_PURGE_INTERVAL")
+	bindEnv(v, "idempotency.purgeInterval", "IDEMPOTENCY_PURGE_INTERVAL")
 	bindEnv(v, "corsSettings.allowedOrigins", "CORS_ALLOWED_ORIGINS")
 	bindEnv(v, "corsSettings.allowedMethods", "CORS_ALLOWED_METHODS")
 	bindEnv(v, "corsSettings.allowedHeaders", "CORS_ALLOWED_HEADERS")
@@ -104,7 +165,36 @@ func applyEnvOverrides(v *viper.Viper, cfg *Config) {
 		cfg.ServerPort = serverPort
 	}
 
+	cfg.Server.ReadHeaderTimeout = resolveDuration(v.GetDuration("server.readHeaderTimeout"), defaultReadHeaderTimeout)
+	cfg.Server.ReadTimeout = resolveDuration(v.GetDuration("server.readTimeout"), defaultReadTimeout)
+	cfg.Server.WriteTimeout = resolveDuration(v.GetDuration("server.writeTimeout"), defaultWriteTimeout)
+	cfg.Server.IdleTimeout = resolveDuration(v.GetDuration("server.idleTimeout"), defaultIdleTimeout)
+	cfg.Server.DrainDelay = max(v.GetDuration("server.drainDelay"), 0)
+	cfg.Server.ShutdownTimeout = resolveDuration(v.GetDuration("server.shutdownTimeout"), defaultShutdownTimeout)
+	cfg.Server.MaxBodyBytes = v.GetInt64("server.maxBodyBytes")
+	if cfg.Server.MaxBodyBytes <= 0 {
+		cfg.Server.MaxBodyBytes = defaultMaxBodyBytes
+	}
+
 	cfg.ConnectionString = strings.TrimSpace(v.GetString("connectionString"))
+	cfg.Auth.Issuer = strings.TrimSpace(v.GetString("auth.issuer"))
+	cfg.Auth.SigningKey = strings.TrimSpace(v.GetString("auth.signingKey"))
+	cfg.Auth.VerificationKeys = resolveStringList(v, "auth.verificationKeys", cfg.Auth.VerificationKeys)
+	cfg.Auth.AccessTokenTTL = resolveDuration(v.GetDuration("auth.accessTokenTTL"), defaultAccessTokenTTL)
+	cfg.Auth.RefreshTokenTTL = resolveDuration(v.GetDuration("auth.refreshTokenTTL"), defaultRefreshTokenTTL)
+	cfg.RateLimit.Enabled = v.GetBool("rateLimit.enabled")
+	cfg.RateLimit.Store = strings.ToLower(strings.TrimSpace(v.GetString("rateLimit.store")))
+	cfg.RateLimit.TrustForwardedFor = v.GetBool("rateLimit.trustForwardedFor")
+	cfg.RateLimit.Default = resolveRateLimitRule(RateLimitRule{
+		Limit:  v.GetInt("rateLimit.default.limit"),
+		Period: v.GetDuration("rateLimit.default.period"),
+	}, RateLimitRule{Limit: defaultRateLimitLimit, Period: defaultRateLimitPeriod})
+	for group, rule := range cfg.RateLimit.Groups {
+		cfg.RateLimit.Groups[group] = resolveRateLimitRule(rule, cfg.RateLimit.Default)
+	}
+	cfg.Trash.RetentionDays = max(v.GetInt("trash.retentionDays"), 0)
+	cfg.Trash.PurgeInterval = resolveDuration(v.GetDuration("trash.purgeInterval"), defaultTrashPurgeInterval)
+	cfg.Idempotency.PurgeInterval = resolveDuration(v.GetDuration("idempotency.purgeInterval"), defaultIdempotencyPurgeInterval)
 	cfg.CORSSettings.AllowedOrigins = resolveStringList(v, "corsSettings.allowedOrigins", cfg.CORSSettings.AllowedOrigins)
 	cfg.CORSSettings.AllowedMethods = resolveStringList(v, "corsSettings.allowedMethods", cfg.CORSSettings.AllowedMethods)
 	cfg.CORSSettings.AllowedHeaders = resolveStringList(v, "corsSettings.allowedHeaders", cfg.CORSSettings.AllowedHeaders)
@@ -168,3 +258,23 @@ func resolveSampleRatio(value float64, fallback float64) float64 {
 
 	return 1
 }
+
+// resolveRateLimitRule falls back when the limit or period is not positive,
+// since such a bucket would never refill.
+func resolveRateLimitRule(rule RateLimitRule, fallback RateLimitRule) RateLimitRule {
+	if rule.Limit > 0 && rule.Period > 0 {
+		return rule
+	}
+
+	return fallback
+}
+
+// resolveDuration falls back when a timeout is missing or not positive, since
+// a zero timeout would disable it on http.Server.
+func resolveDuration(value time.Duration, fallback time.Duration) time.Duration {
+	if value > 0 {
+		return value
+	}
+
+	return fallback
+}
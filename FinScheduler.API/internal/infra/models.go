@@ -1,13 +1,81 @@
 package infra
 
+import "time"
+
 type Config struct {
 	Env              string
 	ServerPort       int
+	Server           ServerConfig
 	ConnectionString string
+	Auth             AuthConfig
 	CORSSettings     CORSSettings
+	RateLimit        RateLimitConfig
+	Trash            TrashConfig
+	Idempotency      IdempotencyConfig
 	Observability    ObservabilityConfig
 }
 
+// ServerConfig holds the HTTP server timeouts and the shutdown sequence.
+// DrainDelay is how long /readyz fails before the server stops accepting
+// requests, giving the ingress time to take the pod out of rotation.
+// MaxBodyBytes caps the size of API request bodies.
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	DrainDelay        time.Duration
+	ShutdownTimeout   time.Duration
+	MaxBodyBytes      int64
+}
+
+// AuthConfig configures the JWTs issued by /api/auth. SigningKey signs new
+// tokens; VerificationKeys are retired keys whose tokens are still accepted
+// until they expire, so the signing key can be rotated without logging
+// everyone out.
+type AuthConfig struct {
+	Issuer           string
+	SigningKey       string
+	VerificationKeys []string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+}
+
+// RateLimitConfig configures the token buckets of the API. Store is "memory"
+// for a single replica or "postgres" to share the buckets between replicas.
+// Groups overrides Default per route group: auth, account, items, tags and
+// audit.
+// TrustForwardedFor keys anonymous clients by the last X-Forwarded-For
+// address, the one appended by the proxy; enable it only behind a single
+// proxy that appends to the header.
+type RateLimitConfig struct {
+	Enabled           bool
+	Store             string
+	TrustForwardedFor bool
+	Default           RateLimitRule
+	Groups            map[string]RateLimitRule
+}
+
+// RateLimitRule lets a client make Limit requests at once, refilled evenly
+// over Period.
+type RateLimitRule struct {
+	Limit  int
+	Period time.Duration
+}
+
+// TrashConfig configures how long deleted items can be restored. Every
+// PurgeInterval, items that have been in the trash for more than RetentionDays
+// are deleted for good; a RetentionDays of zero keeps them forever.
+type TrashConfig struct {
+	RetentionDays int
+	PurgeInterval time.Duration
+}
+
+// IdempotencyConfig sets how often expired idempotency keys are deleted.
+type IdempotencyConfig struct {
+	PurgeInterval time.Duration
+}
+
 type CORSSettings struct {
 	AllowedOrigins   []string
 	AllowedMethods   []string
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"finscheduler/internal/infra"
+	"log/slog"
+	"time"
+)
+
+// ExpiredKeysPurger deletes the idempotency keys that expired before a cutoff,
+// such as services.IdempotencyKeysService.
+type ExpiredKeysPurger interface {
+	PurgeExpired(ctx context.Context, expiredBefore time.Time) (int64, error)
+}
+
+// IdempotencyPurge deletes expired idempotency keys, which would otherwise
+// only be reclaimed when the same key is sent again. Every replica may run it.
+type IdempotencyPurge struct {
+	purger   ExpiredKeysPurger
+	interval time.Duration
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+func NewIdempotencyPurge(purger ExpiredKeysPurger, cfg infra.IdempotencyConfig, logger *slog.Logger) *IdempotencyPurge {
+	return &IdempotencyPurge{
+		purger:   purger,
+		interval: cfg.PurgeInterval,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Run purges once, then every interval until ctx is done.
+func (job *IdempotencyPurge) Run(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = job.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce deletes the idempotency keys that have expired and returns how many
+// were deleted.
+func (job *IdempotencyPurge) RunOnce(ctx context.Context) (int64, error) {
+	expiredBefore := job.now().UTC()
+
+	purged, err := job.purger.PurgeExpired(ctx, expiredBefore)
+	if err != nil {
+		job.logger.ErrorContext(ctx, "idempotency key purge failed", "expiredBefore", expiredBefore, "purged", purged, "error", err)
+		return purged, err
+	}
+
+	job.logger.InfoContext(ctx, "idempotency keys purged", "expiredBefore", expiredBefore, "purged", purged)
+	return purged, nil
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"finscheduler/internal/infra"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingKeysPurger struct {
+	calls  []time.Time
+	purged int64
+	err    error
+}
+
+func (purger *recordingKeysPurger) PurgeExpired(_ context.Context, expiredBefore time.Time) (int64, error) {
+	purger.calls = append(purger.calls, expiredBefore)
+	return purger.purged, purger.err
+}
+
+func newTestIdempotencyPurge(purger ExpiredKeysPurger) *IdempotencyPurge {
+	job := NewIdempotencyPurge(purger, infra.IdempotencyConfig{PurgeInterval: time.Hour}, slog.Default())
+	job.now = func() time.Time { return testNow }
+
+	return job
+}
+
+func TestIdempotencyPurge_RunOnce_ShouldPurgeKeysExpiredByNow(t *testing.T) {
+	// Arrange
+	purger := &recordingKeysPurger{purged: 5}
+	job := newTestIdempotencyPurge(purger)
+
+	// Act
+	purged, err := job.RunOnce(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), purged)
+	assert.Equal(t, []time.Time{testNow}, purger.calls)
+}
+
+func TestIdempotencyPurge_RunOnce_ShouldReturnThePurgerError(t *testing.T) {
+	// Arrange
+	purger := &recordingKeysPurger{err: errors.New("database is down")}
+	job := newTestIdempotencyPurge(purger)
+
+	// Act
+	purged, err := job.RunOnce(context.Background())
+
+	// Assert
+	require.EqualError(t, err, "database is down")
+	assert.Zero(t, purged)
+}
+
+func TestIdempotencyPurge_Run_ShouldPurgeAtStartAndStopWithTheContext(t *testing.T) {
+	// Arrange
+	purger := &recordingKeysPurger{}
+	job := newTestIdempotencyPurge(purger)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	job.Run(ctx)
+
+	// Assert
+	assert.Len(t, purger.calls, 1)
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"finscheduler/internal/infra"
+	"log/slog"
+	"time"
+)
+
+// Purger permanently deletes the items moved to the trash before a cutoff,
+// such as services.ItemsService.
+type Purger interface {
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// TrashPurge deletes items for good once they have been in the trash for the
+// configured retention. Every replica may run it: a purge that races another
+// one finds nothing left to delete.
+type TrashPurge struct {
+	purger    Purger
+	retention time.Duration
+	interval  time.Duration
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+func NewTrashPurge(purger Purger, cfg infra.TrashConfig, logger *slog.Logger) *TrashPurge {
+	return &TrashPurge{
+		purger:    purger,
+		retention: time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		interval:  cfg.PurgeInterval,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Run purges once, then every interval until ctx is done. It returns at once
+// when the retention is zero, which keeps deleted items forever.
+func (job *TrashPurge) Run(ctx context.Context) {
+	if job.retention <= 0 {
+		job.logger.InfoContext(ctx, "trash purge is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = job.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce deletes the items that were moved to the trash longer than the
+// retention ago and returns how many were deleted.
+func (job *TrashPurge) RunOnce(ctx context.Context) (int64, error) {
+	deletedBefore := job.now().UTC().Add(-job.retention)
+
+	purged, err := job.purger.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		job.logger.ErrorContext(ctx, "trash purge failed", "deletedBefore", deletedBefore, "purged", purged, "error", err)
+		return purged, err
+	}
+
+	job.logger.InfoContext(ctx, "trash purged", "deletedBefore", deletedBefore, "purged", purged)
+	return purged, nil
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"finscheduler/internal/infra"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+type recordingPurger struct {
+	calls  []time.Time
+	purged int64
+	err    error
+}
+
+func (purger *recordingPurger) PurgeDeleted(_ context.Context, deletedBefore time.Time) (int64, error) {
+	purger.calls = append(purger.calls, deletedBefore)
+	return purger.purged, purger.err
+}
+
+func newTestTrashPurge(purger Purger, retentionDays int) *TrashPurge {
+	job := NewTrashPurge(purger, infra.TrashConfig{RetentionDays: retentionDays, PurgeInterval: time.Hour}, slog.Default())
+	job.now = func() time.Time { return testNow }
+
+	return job
+}
+
+func TestTrashPurge_RunOnce_ShouldPurgeItemsDeletedBeforeTheRetention(t *testing.T) {
+	// Arrange
+	purger := &recordingPurger{purged: 3}
+	job := newTestTrashPurge(purger, 30)
+
+	// Act
+	purged, err := job.RunOnce(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.Equal(t, []time.Time{time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}, purger.calls)
+}
+
+func TestTrashPurge_RunOnce_ShouldReturnThePurgerError(t *testing.T) {
+	// Arrange
+	purger := &recordingPurger{purged: 1, err: errors.New("database is down")}
+	job := newTestTrashPurge(purger, 7)
+
+	// Act
+	purged, err := job.RunOnce(context.Background())
+
+	// Assert
+	require.EqualError(t, err, "database is down")
+	assert.Equal(t, int64(1), purged)
+}
+
+func TestTrashPurge_Run_ShouldNotPurgeWhenRetentionIsZero(t *testing.T) {
+	// Arrange
+	purger := &recordingPurger{}
+	job := newTestTrashPurge(purger, 0)
+
+	// Act
+	job.Run(context.Background())
+
+	// Assert
+	assert.Empty(t, purger.calls)
+}
+
+func TestTrashPurge_Run_ShouldPurgeAtStartAndStopWithTheContext(t *testing.T) {
+	// Arrange
+	purger := &recordingPurger{}
+	job := newTestTrashPurge(purger, 30)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	job.Run(ctx)
+
+	// Assert
+	assert.Len(t, purger.calls, 1)
+}
@@ -0,0 +1,221 @@
+package openapi
+
+import (
+	"encoding/json"
+	"finscheduler/internal/features/domains"
+	featurehttp "finscheduler/internal/features/http"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegisteredRouter() chi.Router {
+	logger := slog.Default()
+	router := chi.NewRouter()
+	featurehttp.AuthRoutes(featurehttp.NewAuthHandler(nil, logger), featurehttp.NoRateLimit, featurehttp.NoValidation)(router)
+	featurehttp.V1Routes(featurehttp.NewItemsHandler(nil, logger), featurehttp.NewTagsHandler(nil, logger), featurehttp.NewHouseholdsHandler(nil, logger), featurehttp.NewApiKeysHandler(nil, logger), featurehttp.NewAuditHandler(nil, logger), featurehttp.NoRateLimit, featurehttp.NoValidation)(router)
+	SetupSpecification(router, NewDocument())
+
+	return router
+}
+
+func registeredOperations(t *testing.T) map[string]bool {
+	t.Helper()
+
+	operations := make(map[string]bool)
+	err := chi.Walk(newRegisteredRouter(), func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		operations[method+" "+route] = true
+		return nil
+	})
+	require.NoError(t, err)
+
+	return operations
+}
+
+func TestNewDocument_ShouldDescribeEveryRegisteredRoute(t *testing.T) {
+	// Arrange
+	document := NewDocument()
+	registered := registeredOperations(t)
+
+	// Act
+	var missing []string
+	for operation := range registered {
+		method, path, _ := strings.Cut(operation, " ")
+		pathItem, ok := document.Paths[path]
+		if !ok || pathItem.Operation(method) == nil {
+			missing = append(missing, operation)
+		}
+	}
+	sort.Strings(missing)
+
+	// Assert
+	require.NotEmpty(t, registered)
+	assert.Empty(t, missing, "routes registered but missing from the OpenAPI document")
+}
+
+func TestNewDocument_ShouldOnlyDescribeRegisteredRoutes(t *testing.T) {
+	// Arrange
+	document := NewDocument()
+	registered := registeredOperations(t)
+
+	// Act
+	var unknown []string
+	for path, pathItem := range document.Paths {
+		for method := range pathItem.Operations() {
+			if !registered[method+" "+path] {
+				unknown = append(unknown, method+" "+path)
+			}
+		}
+	}
+	sort.Strings(unknown)
+
+	// Assert
+	assert.Empty(t, unknown, "operations documented but not registered")
+}
+
+func TestNewDocument_ShouldResolveEveryReference(t *testing.T) {
+	// Arrange
+	document := NewDocument()
+	encoded, marshalErr := json.Marshal(document)
+	var raw any
+	unmarshalErr := json.Unmarshal(encoded, &raw)
+
+	// Act
+	var unresolved []string
+	var visit func(node any)
+	visit = func(node any) {
+		switch value := node.(type) {
+		case map[string]any:
+			if reference, ok := value["$ref"].(string); ok {
+				if name, found := strings.CutPrefix(reference, schemaRefPrefix); found {
+					if _, exists := document.Components.Schemas[name]; !exists {
+						unresolved = append(unresolved, reference)
+					}
+				} else if name, found := strings.CutPrefix(reference, responseRefPrefix); found {
+					if _, exists := document.Components.Responses[name]; !exists {
+						unresolved = append(unresolved, reference)
+					}
+				} else {
+					unresolved = append(unresolved, reference)
+				}
+			}
+			for _, child := range value {
+				visit(child)
+			}
+		case []any:
+			for _, child := range value {
+				visit(child)
+			}
+		}
+	}
+	visit(raw)
+
+	// Assert
+	require.NoError(t, marshalErr)
+	require.NoError(t, unmarshalErr)
+	assert.Empty(t, unresolved)
+}
+
+func TestNewDocument_ShouldMatchDtoFields(t *testing.T) {
+	schemas := NewDocument().Components.Schemas
+
+	tests := []struct {
+		schema string
+		value  any
+	}{
+		{schema: "PriceHistoryPointDto", value: domains.PriceHistoryPointDto{}},
+		{schema: "ItemListingDto", value: domains.ItemListingDto{}},
+		{schema: "ItemDetailedDto", value: domains.ItemDetailedDto{}},
+		{schema: "ItemTrashDto", value: domains.ItemTrashDto{}},
+		{schema: "ItemCreate", value: domains.ItemCreate{}},
+		{schema: "ItemUpdate", value: domains.ItemUpdate{}},
+		{schema: "ItemPatch", value: domains.ItemPatch{}},
+		{schema: "ItemCashbackByTagUpdate", value: domains.ItemCashbackByTagUpdate{}},
+		{schema: "ItemCashbackByIdsUpdate", value: domains.ItemCashbackByIdsUpdate{}},
+		{schema: "ItemSelection", value: domains.ItemSelection{}},
+		{schema: "ItemTagsChange", value: domains.ItemTagsChange{}},
+		{schema: "ItemTagsChangeResult", value: domains.ItemTagsChangeResult{}},
+		{schema: "ItemBulkChange", value: domains.ItemBulkChange{}},
+		{schema: "ItemBulkChangeResult", value: domains.ItemBulkChangeResult{}},
+		{schema: "TagListingDto", value: domains.TagListingDto{}},
+		{schema: "TagDetailedDto", value: domains.TagDetailedDto{}},
+		{schema: "TagLookupDto", value: domains.TagLookupDto{}},
+		{schema: "TagStatsDto", value: domains.TagStatsDto{}},
+		{schema: "TagCreate", value: domains.TagCreate{}},
+		{schema: "TagUpdate", value: domains.TagUpdate{}},
+		{schema: "TagPatch", value: domains.TagPatch{}},
+		{schema: "Credentials", value: domains.Credentials{}},
+		{schema: "TokenRefresh", value: domains.TokenRefresh{}},
+		{schema: "TokenDto", value: domains.TokenDto{}},
+		{schema: "AuditEventDto", value: domains.AuditEventDto{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			// Arrange
+			schema, ok := schemas[tt.schema]
+			require.True(t, ok)
+
+			// Act
+			documented := make([]string, 0, len(schema.Properties))
+			for name := range schema.Properties {
+				documented = append(documented, name)
+			}
+			sort.Strings(documented)
+
+			// Assert
+			assert.Equal(t, jsonFieldNames(tt.value), documented)
+		})
+	}
+}
+
+func TestItemCategories_ShouldAllBeValid(t *testing.T) {
+	for _, category := range itemCategories {
+		assert.True(t, category.IsValid(), string(category))
+	}
+}
+
+func TestHandler_ShouldServeDocumentAsJSON(t *testing.T) {
+	// Arrange
+	router := newRegisteredRouter()
+	request := httptest.NewRequest(http.MethodGet, SpecPath, nil)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	var served Document
+	decodeErr := json.NewDecoder(recorder.Body).Decode(&served)
+
+	// Assert
+	require.NoError(t, decodeErr)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, Version, served.OpenAPI)
+	assert.Contains(t, served.Paths, "/items/{id}")
+}
+
+func jsonFieldNames(value any) []string {
+	valueType := reflect.TypeOf(value)
+	names := make([]string, 0, valueType.NumField())
+	for i := 0; i < valueType.NumField(); i++ {
+		name, _, _ := strings.Cut(valueType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
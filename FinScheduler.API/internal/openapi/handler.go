@@ -0,0 +1,30 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SpecPath is relative to the version prefix, e.g. /api/v1/openapi.json.
+const SpecPath = "/openapi.json"
+
+func SetupSpecification(router chi.Router, document *Document) {
+	router.Get(SpecPath, Handler(document))
+}
+
+// Handler serves the document encoded once at startup.
+func Handler(document *Document) http.HandlerFunc {
+	body, err := json.Marshal(document)
+
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,455 @@
+package openapi
+
+import (
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+)
+
+const (
+	schemaRefPrefix   = "#/components/schemas/"
+	responseRefPrefix = "#/components/responses/"
+)
+
+var itemCategories = []domains.ItemCategory{
+	domains.FoodDrinks,
+	domains.Subscriptions,
+	domains.Health,
+	domains.Beauty,
+	domains.Gifts,
+	domains.Transport,
+	domains.Entertainments,
+	domains.Meds,
+	domains.Travel,
+	domains.Sports,
+	domains.Telecom,
+	domains.Education,
+}
+
+func newSchemas() map[string]*Schema {
+	nameMinLength := 3
+	apiKeyNameMaxLength := 100
+	credentialMinLength := 1
+
+	return map[string]*Schema{
+		"Error": {
+			Type:        "string",
+			Description: "Plain-text error message.",
+		},
+		"Uuid": uuidSchema(),
+		"ItemCategory": {
+			Type: "string",
+			Enum: itemCategoryNames(),
+		},
+		"MoneyObject": object([]string{"amount", "currency"}, map[string]*Schema{
+			"amount":   decimalStringSchema(),
+			"currency": {Type: "string", Description: "ISO 4217 currency code."},
+		}),
+		"Money": {
+			Description: "Exact amount. An object by default; a bare number when the request has moneyFormat=number.",
+			OneOf:       []*Schema{ref("MoneyObject"), numberSchema()},
+		},
+		"PriceHistoryPointDto": object([]string{"point", "value", "absoluteChange", "percentChange"}, map[string]*Schema{
+			"point":          dateTimeSchema(),
+			"value":          ref("Money"),
+			"absoluteChange": {OneOf: []*Schema{ref("Money"), {Type: "null"}}},
+			"percentChange":  nullable(decimalStringSchema()),
+		}),
+		"ItemListingDto": object([]string{"id", "name", "price", "isActive", "updatedAt", "cashback"}, map[string]*Schema{
+			"id":        uuidSchema(),
+			"name":      stringSchema(),
+			"price":     ref("Money"),
+			"isActive":  booleanSchema(),
+			"updatedAt": nullable(dateTimeSchema()),
+			"cashback":  int32Schema(),
+		}),
+		"ItemDetailedDto": object([]string{"name", "price", "description", "isActive", "cashback", "category", "tags", "priceHistory"}, map[string]*Schema{
+			"name":         stringSchema(),
+			"price":        ref("Money"),
+			"description":  stringSchema(),
+			"isActive":     booleanSchema(),
+			"cashback":     int32Schema(),
+			"category":     ref("ItemCategory"),
+			"tags":         arrayOf(ref("TagLookupDto")),
+			"priceHistory": arrayOf(ref("PriceHistoryPointDto")),
+		}),
+		"ItemTrashDto": object([]string{"id", "name", "price", "category", "deletedAt"}, map[string]*Schema{
+			"id":        uuidSchema(),
+			"name":      stringSchema(),
+			"price":     ref("Money"),
+			"category":  ref("ItemCategory"),
+			"deletedAt": dateTimeSchema(),
+		}),
+		"ItemCreate": itemWriteSchema(nameMinLength),
+		"ItemUpdate": itemWriteSchema(nameMinLength),
+		"ItemPatch": object(nil, map[string]*Schema{
+			"name":        {Type: "string", MinLength: &nameMinLength},
+			"price":       decimalInputSchema(),
+			"description": nullable(stringSchema()),
+			"isActive":    booleanSchema(),
+			"cashback":    nonNegativeInt32Schema(),
+			"category":    {Type: "string", Enum: itemCategoryNames()},
+			"tagIds":      uniqueArrayOf(uuidSchema()),
+		}),
+		"ItemCashbackByTagUpdate": object([]string{"cashback", "tagId"}, map[string]*Schema{
+			"cashback": nonNegativeInt32Schema(),
+			"tagId":    uuidSchema(),
+		}),
+		"ItemCashbackByIdsUpdate": object([]string{"cashback", "itemIds"}, map[string]*Schema{
+			"cashback": nonNegativeInt32Schema(),
+			"itemIds":  uniqueArrayOf(uuidSchema()),
+		}),
+		"ItemSelection": object(nil, map[string]*Schema{
+			"all":                booleanSchema(),
+			"ids":                uniqueArrayOf(uuidSchema()),
+			"name":               stringSchema(),
+			"priceFrom":          decimalInputSchema(),
+			"priceTo":            decimalInputSchema(),
+			"description":        stringSchema(),
+			"isActive":           booleanSchema(),
+			"createdFrom":        dateTimeSchema(),
+			"createdTo":          dateTimeSchema(),
+			"updatedFrom":        dateTimeSchema(),
+			"updatedTo":          dateTimeSchema(),
+			"cashbackFrom":       int32Schema(),
+			"cashbackTo":         int32Schema(),
+			"categories":         arrayOf(ref("ItemCategory")),
+			"tagIds":             arrayOf(uuidSchema()),
+			"includeDescendants": booleanSchema(),
+		}),
+		"ItemTagsChange": object([]string{"tagIds"}, map[string]*Schema{
+			"itemIds": uniqueArrayOf(uuidSchema()),
+			"filter":  ref("ItemSelection"),
+			"tagIds":  uniqueArrayOf(uuidSchema()),
+		}),
+		"ItemTagsChangeResult": object([]string{"changed"}, map[string]*Schema{
+			"changed": {Type: "integer", Format: "int64"},
+		}),
+		"ItemBulkOperation": {
+			Type: "string",
+			Enum: []any{
+				string(domains.ItemBulkSetActive),
+				string(domains.ItemBulkSetCategory),
+				string(domains.ItemBulkSetCashback),
+				string(domains.ItemBulkAdjustPrice),
+				string(domains.ItemBulkDelete),
+			},
+		},
+		"ItemBulkChange": object([]string{"filter", "operation"}, map[string]*Schema{
+			"filter":       ref("ItemSelection"),
+			"operation":    ref("ItemBulkOperation"),
+			"isActive":     booleanSchema(),
+			"category":     ref("ItemCategory"),
+			"cashback":     nonNegativeInt32Schema(),
+			"pricePercent": pricePercentSchema(),
+			"dryRun":       booleanSchema(),
+		}),
+		"ItemBulkChangeResult": object([]string{"matched", "affected", "itemIds"}, map[string]*Schema{
+			"matched":  {Type: "integer", Format: "int64"},
+			"affected": {Type: "integer", Format: "int64"},
+			"itemIds":  arrayOf(uuidSchema()),
+		}),
+		"TagListingDto": object([]string{"id", "name", "isActive", "parentId"}, map[string]*Schema{
+			"id":          uuidSchema(),
+			"name":        stringSchema(),
+			"isActive":    booleanSchema(),
+			"parentId":    nullable(uuidSchema()),
+			"color":       nullable(tagColorSchema()),
+			"icon":        nullable(tagIconSchema()),
+			"description": stringSchema(),
+			"stats":       ref("TagStatsDto"),
+		}),
+		"TagStatsDto": object([]string{"itemCount", "activeItemCount", "totalPrice", "averagePrice", "totalCashback", "lastItemUpdatedAt"}, map[string]*Schema{
+			"itemCount":         {Type: "integer", Format: "int64"},
+			"activeItemCount":   {Type: "integer", Format: "int64"},
+			"totalPrice":        ref("Money"),
+			"averagePrice":      {OneOf: []*Schema{ref("Money"), {Type: "null"}}},
+			"totalCashback":     ref("Money"),
+			"lastItemUpdatedAt": nullable(dateTimeSchema()),
+		}),
+		"TagDetailedDto": object([]string{"name", "isActive", "parentId"}, map[string]*Schema{
+			"name":        stringSchema(),
+			"isActive":    booleanSchema(),
+			"parentId":    nullable(uuidSchema()),
+			"color":       nullable(tagColorSchema()),
+			"icon":        nullable(tagIconSchema()),
+			"description": stringSchema(),
+		}),
+		"TagLookupDto": object([]string{"value", "label", "color", "icon", "description"}, map[string]*Schema{
+			"value":       stringSchema(),
+			"label":       stringSchema(),
+			"color":       nullable(tagColorSchema()),
+			"icon":        nullable(tagIconSchema()),
+			"description": stringSchema(),
+		}),
+		"TagCreate": tagWriteSchema(nameMinLength),
+		"TagUpdate": tagWriteSchema(nameMinLength),
+		"TagPatch": object(nil, map[string]*Schema{
+			"name":        {Type: "string", MinLength: &nameMinLength},
+			"isActive":    booleanSchema(),
+			"parentId":    nullable(uuidSchema()),
+			"color":       nullable(tagColorSchema()),
+			"icon":        nullable(tagIconSchema()),
+			"description": nullable(stringSchema()),
+		}),
+		"Credentials": object([]string{"email", "password"}, map[string]*Schema{
+			"email":    {Type: "string", MinLength: &credentialMinLength},
+			"password": {Type: "string", MinLength: &credentialMinLength},
+		}),
+		"TokenRefresh": object([]string{"refreshToken"}, map[string]*Schema{
+			"refreshToken": {Type: "string", MinLength: &credentialMinLength},
+		}),
+		"TokenDto": object([]string{"accessToken", "refreshToken", "tokenType", "expiresIn"}, map[string]*Schema{
+			"accessToken":  stringSchema(),
+			"refreshToken": stringSchema(),
+			"tokenType":    {Type: "string", Enum: []any{"Bearer"}},
+			"expiresIn":    {Type: "integer", Format: "int64", Description: "Access token lifetime in seconds."},
+		}),
+		"Role": {
+			Type: "string",
+			Enum: []any{string(auth.RoleViewer), string(auth.RoleEditor), string(auth.RoleAdmin)},
+		},
+		"HouseholdDto": object([]string{"id", "name", "isPersonal", "role"}, map[string]*Schema{
+			"id":         uuidSchema(),
+			"name":       stringSchema(),
+			"isPersonal": booleanSchema(),
+			"role":       ref("Role"),
+		}),
+		"HouseholdCreate": object([]string{"name"}, map[string]*Schema{
+			"name": {Type: "string", MinLength: &nameMinLength},
+		}),
+		"InvitationCreate": object([]string{"role"}, map[string]*Schema{
+			"role": ref("Role"),
+		}),
+		"InvitationDto": object([]string{"householdId", "role", "token", "link", "expiresAt"}, map[string]*Schema{
+			"householdId": uuidSchema(),
+			"role":        ref("Role"),
+			"token":       stringSchema(),
+			"link":        {Type: "string", Description: "Path that accepts the invitation when POSTed by the invitee."},
+			"expiresAt":   dateTimeSchema(),
+		}),
+		"Scope": {
+			Type: "string",
+			Enum: scopeNames(),
+		},
+		"ApiKeyCreate": object([]string{"name", "scopes"}, map[string]*Schema{
+			"name":      {Type: "string", MinLength: &nameMinLength, MaxLength: &apiKeyNameMaxLength},
+			"scopes":    uniqueArrayOf(ref("Scope")),
+			"expiresAt": nullable(dateTimeSchema()),
+		}),
+		"ApiKeyDto":          apiKeySchema(false),
+		"ApiKeyCreatedDto":   apiKeySchema(true),
+		"ItemListingDtoPage": cursorPaginatedList("ItemListingDto"),
+		"ItemTrashDtoPage":   paginatedList("ItemTrashDto"),
+		"TagListingDtoPage":  cursorPaginatedList("TagListingDto"),
+		"TagLookupDtoPage":   paginatedList("TagLookupDto"),
+		"AuditEntityType": {
+			Type: "string",
+			Enum: []any{string(domains.AuditEntityItem), string(domains.AuditEntityTag)},
+		},
+		"AuditOperation": {
+			Type: "string",
+			Enum: []any{
+				string(domains.AuditOperationCreate),
+				string(domains.AuditOperationUpdate),
+				string(domains.AuditOperationDelete),
+				string(domains.AuditOperationRestore),
+				string(domains.AuditOperationCashbackUpdate),
+				string(domains.AuditOperationTagsUpdate),
+			},
+		},
+		"AuditEventDto": object([]string{"id", "actorId", "actorEmail", "apiKeyId", "operation", "entityType", "entityId", "changes", "traceId", "occurredAt"}, map[string]*Schema{
+			"id":         uuidSchema(),
+			"actorId":    uuidSchema(),
+			"actorEmail": nullable(stringSchema()),
+			"apiKeyId":   {Description: "The API key the change was made with, if any.", OneOf: []*Schema{uuidSchema(), {Type: "null"}}},
+			"operation":  ref("AuditOperation"),
+			"entityType": ref("AuditEntityType"),
+			"entityId":   uuidSchema(),
+			"changes":    {Type: "object", Description: "Maps every changed field to an object with its `before` and `after` values; `before` is null on create and `after` on delete."},
+			"traceId":    nullable(stringSchema()),
+			"occurredAt": dateTimeSchema(),
+		}),
+		"AuditEventDtoPage": paginatedList("AuditEventDto"),
+	}
+}
+
+// apiKeySchema describes an API key; withKey adds the secret that is only
+// returned on creation.
+func apiKeySchema(withKey bool) *Schema {
+	required := []string{"id", "name", "prefix", "scopes", "createdAt", "expiresAt", "lastUsedAt"}
+	properties := map[string]*Schema{
+		"id":         uuidSchema(),
+		"name":       stringSchema(),
+		"prefix":     {Type: "string", Description: "Leading characters of the key, to tell keys apart."},
+		"scopes":     arrayOf(ref("Scope")),
+		"createdAt":  dateTimeSchema(),
+		"expiresAt":  nullable(dateTimeSchema()),
+		"lastUsedAt": nullable(dateTimeSchema()),
+	}
+	if withKey {
+		required = append(required, "key")
+		properties["key"] = &Schema{Type: "string", Description: "The key, sent as `Authorization: ApiKey <key>`. Shown only once."}
+	}
+
+	return object(required, properties)
+}
+
+func scopeNames() []any {
+	names := make([]any, 0, len(auth.Scopes))
+	for _, scope := range auth.Scopes {
+		names = append(names, string(scope))
+	}
+
+	return names
+}
+
+func itemWriteSchema(nameMinLength int) *Schema {
+	return object([]string{"name", "category"}, map[string]*Schema{
+		"name":        {Type: "string", MinLength: &nameMinLength},
+		"price":       decimalInputSchema(),
+		"description": stringSchema(),
+		"isActive":    booleanSchema(),
+		"cashback":    nonNegativeInt32Schema(),
+		"category":    ref("ItemCategory"),
+		"tagIds":      nullable(uniqueArrayOf(uuidSchema())),
+	})
+}
+
+func tagWriteSchema(nameMinLength int) *Schema {
+	return object([]string{"name"}, map[string]*Schema{
+		"name":        {Type: "string", MinLength: &nameMinLength},
+		"isActive":    booleanSchema(),
+		"parentId":    nullable(uuidSchema()),
+		"color":       nullable(tagColorSchema()),
+		"icon":        nullable(tagIconSchema()),
+		"description": stringSchema(),
+	})
+}
+
+func tagColorSchema() *Schema {
+	return &Schema{Type: "string", Pattern: "^#[0-9a-fA-F]{6}$"}
+}
+
+func tagIconSchema() *Schema {
+	maxLength := 64
+	return &Schema{Type: "string", Pattern: "^[a-z0-9]+(-[a-z0-9]+)*$", MaxLength: &maxLength}
+}
+
+func paginatedList(itemSchema string) *Schema {
+	return object([]string{"data", "count"}, map[string]*Schema{
+		"data":  arrayOf(ref(itemSchema)),
+		"count": {Type: "integer", Format: "int64"},
+	})
+}
+
+// cursorPaginatedList is a page of a listing that can skip the count and
+// carries the cursors of the pages around it.
+func cursorPaginatedList(itemSchema string) *Schema {
+	return object([]string{"data"}, map[string]*Schema{
+		"data":     arrayOf(ref(itemSchema)),
+		"count":    {Type: "integer", Format: "int64"},
+		"next":     stringSchema(),
+		"previous": stringSchema(),
+	})
+}
+
+func itemCategoryNames() []any {
+	names := make([]any, 0, len(itemCategories))
+	for _, category := range itemCategories {
+		names = append(names, string(category))
+	}
+
+	return names
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+func object(required []string, properties map[string]*Schema) *Schema {
+	additionalProperties := false
+
+	return &Schema{
+		Type:                 "object",
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: &additionalProperties,
+	}
+}
+
+func arrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+func uniqueArrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items, UniqueItems: true}
+}
+
+// nullable widens a primitive or array schema to also accept null.
+func nullable(schema *Schema) *Schema {
+	widened := *schema
+	widened.Type = append(schema.Types(), "null")
+	if len(schema.Enum) > 0 {
+		widened.Enum = append(append([]any{}, schema.Enum...), nil)
+	}
+
+	return &widened
+}
+
+func stringSchema() *Schema {
+	return &Schema{Type: "string"}
+}
+
+func uuidSchema() *Schema {
+	return &Schema{Type: "string", Format: "uuid"}
+}
+
+func dateTimeSchema() *Schema {
+	return &Schema{Type: "string", Format: "date-time"}
+}
+
+func booleanSchema() *Schema {
+	return &Schema{Type: "boolean"}
+}
+
+func numberSchema() *Schema {
+	return &Schema{Type: "number"}
+}
+
+func int32Schema() *Schema {
+	return &Schema{Type: "integer", Format: "int32"}
+}
+
+func nonNegativeInt32Schema() *Schema {
+	minimum := 0.0
+
+	return &Schema{Type: "integer", Format: "int32", Minimum: &minimum}
+}
+
+// decimalInputSchema accepts amounts either as JSON numbers or numeric strings,
+// matching how decimal.Decimal unmarshals.
+func pricePercentSchema() *Schema {
+	minimum := -100.0
+
+	return &Schema{
+		Type:        []string{"number", "string"},
+		Format:      "decimal",
+		Description: "Percentage to change prices by, as a JSON number or a numeric string; -50 halves them.",
+		Minimum:     &minimum,
+	}
+}
+
+func decimalInputSchema() *Schema {
+	minimum := 0.0
+
+	return &Schema{
+		Type:        []string{"number", "string"},
+		Format:      "decimal",
+		Description: "Non-negative amount as a JSON number or a numeric string.",
+		Minimum:     &minimum,
+	}
+}
+
+func decimalStringSchema() *Schema {
+	return &Schema{Type: "string", Format: "decimal"}
+}
@@ -0,0 +1,806 @@
+package openapi
+
+import (
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"net/http"
+	"strings"
+)
+
+const (
+	jsonContentType       = "application/json"
+	mergePatchContentType = "application/merge-patch+json"
+	textContentType       = "text/plain"
+
+	inQuery  = "query"
+	inPath   = "path"
+	inHeader = "header"
+
+	apiKeysTag    = "api-keys"
+	auditTag      = "audit"
+	authTag       = "auth"
+	householdsTag = "households"
+	itemsTag      = "items"
+	tagsTag       = "tags"
+	specTag       = "specification"
+
+	bearerAuthScheme = "bearerAuth"
+	apiKeyAuthScheme = "apiKeyAuth"
+)
+
+// publicPaths are served without credentials; every other operation requires
+// a bearer access token. Items and tags also accept an API key with the
+// matching scope.
+var publicPaths = map[string]bool{
+	"/auth/login":   true,
+	"/auth/refresh": true,
+	SpecPath:        true,
+}
+
+// NewDocument describes every route of API version v1, registered by
+// featurehttp.AuthRoutes and featurehttp.V1Routes, and the specification
+// endpoint itself. Paths are relative to the version prefix given in Servers.
+func NewDocument() *Document {
+	document := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:   "FinScheduler API",
+			Version: "1.0.0",
+		},
+		Servers: []Server{
+			{URL: "/api/v1", Description: "Current version; also served on the unversioned /api paths."},
+		},
+		Paths: map[string]*PathItem{
+			"/auth/login": {
+				Post: &Operation{
+					OperationID: "login",
+					Summary:     "Exchange an email and password for tokens",
+					Tags:        []string{authTag},
+					RequestBody: jsonBody(jsonContentType, "Credentials"),
+					Responses:   tokenResponses(),
+				},
+			},
+			"/auth/refresh": {
+				Post: &Operation{
+					OperationID: "refreshToken",
+					Summary:     "Exchange a refresh token for new tokens",
+					Tags:        []string{authTag},
+					RequestBody: jsonBody(jsonContentType, "TokenRefresh"),
+					Responses:   tokenResponses(),
+				},
+			},
+			"/api-keys": {
+				Get: &Operation{
+					OperationID: "getApiKeys",
+					Summary:     "List the caller's API keys",
+					Tags:        []string{apiKeysTag},
+					Responses: map[string]*Response{
+						"200": jsonResponse("The keys, the oldest first. The keys themselves are never returned.", arrayOf(ref("ApiKeyDto")), nil),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+				Post: &Operation{
+					OperationID: "createApiKey",
+					Summary:     "Create an API key for automation",
+					Tags:        []string{apiKeysTag},
+					RequestBody: jsonBody(jsonContentType, "ApiKeyCreate"),
+					Responses: map[string]*Response{
+						"201": jsonResponse("The key. It is shown only once.", ref("ApiKeyCreatedDto"), map[string]*Header{
+							"Location":      {Description: "URL of the created resource.", Schema: stringSchema()},
+							"Cache-Control": {Description: "Always no-store.", Schema: stringSchema()},
+						}),
+						"400": responseRef("BadRequest"),
+						"409": errorResponse("The caller already has a key with that name."),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/api-keys/{id}": {
+				Delete: &Operation{
+					OperationID: "deleteApiKey",
+					Summary:     "Revoke an API key",
+					Tags:        []string{apiKeysTag},
+					Parameters:  []*Parameter{{Name: "id", In: inPath, Required: true, Schema: uuidSchema()}},
+					Responses: map[string]*Response{
+						"204": {Description: "Revoked."},
+						"400": responseRef("BadRequest"),
+						"404": responseRef("NotFound"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/households": {
+				Get: &Operation{
+					OperationID: "getHouseholds",
+					Summary:     "List the caller's households and roles",
+					Tags:        []string{householdsTag},
+					Responses: map[string]*Response{
+						"200": jsonResponse("The households, the personal one first.", arrayOf(ref("HouseholdDto")), nil),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+				Post: &Operation{
+					OperationID: "createHousehold",
+					Summary:     "Create a household with the caller as admin",
+					Tags:        []string{householdsTag},
+					RequestBody: jsonBody(jsonContentType, "HouseholdCreate"),
+					Responses: map[string]*Response{
+						"201": {
+							Description: "Created; the body holds the new id.",
+							Headers: map[string]*Header{
+								"Location": {Description: "URL of the created resource.", Schema: stringSchema()},
+							},
+							Content: map[string]*MediaType{jsonContentType: {Schema: ref("Uuid")}},
+						},
+						"400": responseRef("BadRequest"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/households/{householdId}/invitations": {
+				Post: &Operation{
+					OperationID: "createInvitation",
+					Summary:     "Invite someone into a household; admins only",
+					Tags:        []string{householdsTag},
+					Parameters:  []*Parameter{{Name: "householdId", In: inPath, Required: true, Schema: uuidSchema()}},
+					RequestBody: jsonBody(jsonContentType, "InvitationCreate"),
+					Responses: map[string]*Response{
+						"201": jsonResponse("The invitation. The token is shown only once.", ref("InvitationDto"), map[string]*Header{
+							"Cache-Control": {Description: "Always no-store.", Schema: stringSchema()},
+						}),
+						"400": responseRef("BadRequest"),
+						"403": responseRef("Forbidden"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/invitations/{token}/accept": {
+				Post: &Operation{
+					OperationID: "acceptInvitation",
+					Summary:     "Join the household of an invitation",
+					Tags:        []string{householdsTag},
+					Parameters:  []*Parameter{{Name: "token", In: inPath, Required: true, Schema: stringSchema()}},
+					Responses: map[string]*Response{
+						"200": jsonResponse("The joined household.", ref("HouseholdDto"), nil),
+						"404": responseRef("NotFound"),
+						"410": errorResponse("The invitation expired or was already accepted."),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/items": {
+				Get: &Operation{
+					OperationID: "getItems",
+					Summary:     "List items",
+					Tags:        []string{itemsTag},
+					Parameters:  append(itemFilterParameters(), moneyFormatParameter(), ifNoneMatchHeader()),
+					Responses: map[string]*Response{
+						"200": jsonResponse("A page of items.", ref("ItemListingDtoPage"), cacheHeaders()),
+						"304": responseRef("NotModified"),
+						"400": responseRef("BadRequest"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+				Post: &Operation{
+					OperationID: "createItem",
+					Summary:     "Create an item",
+					Tags:        []string{itemsTag},
+					Parameters:  []*Parameter{idempotencyKeyHeader()},
+					RequestBody: jsonBody(jsonContentType, "ItemCreate"),
+					Responses:   createdResponses(),
+				},
+			},
+			"/items/{id}": {
+				Get: &Operation{
+					OperationID: "getItem",
+					Summary:     "Get item details",
+					Tags:        []string{itemsTag},
+					Parameters:  []*Parameter{idPath(), moneyFormatParameter()},
+					Responses: map[string]*Response{
+						"200": jsonResponse("The item.", ref("ItemDetailedDto"), map[string]*Header{"ETag": etagHeader()}),
+						"400": responseRef("BadRequest"),
+						"404": responseRef("NotFound"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+				Put: &Operation{
+					OperationID: "updateItem",
+					Summary:     "Replace an item",
+					Tags:        []string{itemsTag},
+					Parameters:  []*Parameter{idPath(), ifMatchHeader()},
+					RequestBody: jsonBody(jsonContentType, "ItemUpdate"),
+					Responses:   conditionalWriteResponses(),
+				},
+				Patch: &Operation{
+					OperationID: "patchItem",
+					Summary:     "Apply a JSON Merge Patch to an item",
+					Tags:        []string{itemsTag},
+					Parameters:  []*Parameter{idPath(), ifMatchHeader()},
+					RequestBody: jsonBody(mergePatchContentType, "ItemPatch"),
+					Responses:   mergePatchResponses(),
+				},
+				Delete: &Operation{
+					OperationID: "deleteItem",
+					Summary:     "Move an item to the trash",
+					Tags:        []string{itemsTag},
+					Parameters:  []*Parameter{idPath(), ifMatchHeader()},
+					Responses:   conditionalWriteResponses(),
+				},
+			},
+			"/items/trash": {
+				Get: &Operation{
+					OperationID: "getItemTrash",
+					Summary:     "List the items in the trash, the most recently deleted first",
+					Tags:        []string{itemsTag},
+					Parameters:  append(pageParameters(), moneyFormatParameter(), ifNoneMatchHeader()),
+					Responses: map[string]*Response{
+						"200": jsonResponse("A page of deleted items.", ref("ItemTrashDtoPage"), cacheHeaders()),
+						"304": responseRef("NotModified"),
+						"400": responseRef("BadRequest"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/items/{id}/restore": {
+				Post: &Operation{
+					OperationID: "restoreItem",
+					Summary:     "Take an item out of the trash",
+					Tags:        []string{itemsTag},
+					Parameters:  []*Parameter{idPath()},
+					Responses: map[string]*Response{
+						"204": {Description: "Restored."},
+						"400": responseRef("BadRequest"),
+						"404": errorResponse("The item is not in the trash."),
+						"409": errorResponse("Another item already has the name of the restored one."),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/items/cashback/tag": {
+				Patch: &Operation{
+					OperationID: "updateCashbackByTag",
+					Summary:     "Set cashback for every item linked to a tag",
+					Tags:        []string{itemsTag},
+					RequestBody: jsonBody(jsonContentType, "ItemCashbackByTagUpdate"),
+					Responses:   bulkWriteResponses(),
+				},
+			},
+			"/items/cashback/items": {
+				Patch: &Operation{
+					OperationID: "updateCashbackByItems",
+					Summary:     "Set cashback for the selected items",
+					Tags:        []string{itemsTag},
+					RequestBody: jsonBody(jsonContentType, "ItemCashbackByIdsUpdate"),
+					Responses:   bulkWriteResponses(),
+				},
+			},
+			"/items/tags:add": {
+				Post: &Operation{
+					OperationID: "addItemTags",
+					Summary:     "Link tags to the items named by itemIds or matched by filter",
+					Tags:        []string{itemsTag},
+					RequestBody: jsonBody(jsonContentType, "ItemTagsChange"),
+					Responses:   itemTagsChangeResponses("The number of links added.", true),
+				},
+			},
+			"/items/tags:remove": {
+				Post: &Operation{
+					OperationID: "removeItemTags",
+					Summary:     "Unlink tags from the items named by itemIds or matched by filter",
+					Tags:        []string{itemsTag},
+					RequestBody: jsonBody(jsonContentType, "ItemTagsChange"),
+					Responses:   itemTagsChangeResponses("The number of links removed.", false),
+				},
+			},
+			"/items:bulk": {
+				Post: &Operation{
+					OperationID: "bulkChangeItems",
+					Summary:     "Apply one operation to every item matched by filter, or preview the matches with dryRun",
+					Tags:        []string{itemsTag},
+					RequestBody: jsonBody(jsonContentType, "ItemBulkChange"),
+					Responses: map[string]*Response{
+						"200": jsonResponse("The matched and changed items.", ref("ItemBulkChangeResult"), nil),
+						"400": responseRef("BadRequest"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/tags": {
+				Get: &Operation{
+					OperationID: "getTags",
+					Summary:     "List tags",
+					Tags:        []string{tagsTag},
+					Parameters:  append(tagFilterParameters(), moneyFormatParameter(), ifNoneMatchHeader()),
+					Responses: map[string]*Response{
+						"200": jsonResponse("A page of tags.", ref("TagListingDtoPage"), cacheHeaders()),
+						"304": responseRef("NotModified"),
+						"400": responseRef("BadRequest"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+				Post: &Operation{
+					OperationID: "createTag",
+					Summary:     "Create a tag",
+					Tags:        []string{tagsTag},
+					Parameters:  []*Parameter{idempotencyKeyHeader()},
+					RequestBody: jsonBody(jsonContentType, "TagCreate"),
+					Responses:   createdResponses(),
+				},
+			},
+			"/tags/lookup": {
+				Get: &Operation{
+					OperationID: "getTagLookup",
+					Summary:     "List active tags as value/label pairs",
+					Tags:        []string{tagsTag},
+					Parameters:  append(tagLookupFilterParameters(), ifNoneMatchHeader()),
+					Responses: map[string]*Response{
+						"200": jsonResponse("A page of tag lookups.", ref("TagLookupDtoPage"), cacheHeaders()),
+						"304": responseRef("NotModified"),
+						"400": responseRef("BadRequest"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/tags/{id}": {
+				Get: &Operation{
+					OperationID: "getTag",
+					Summary:     "Get tag details",
+					Tags:        []string{tagsTag},
+					Parameters:  []*Parameter{idPath()},
+					Responses: map[string]*Response{
+						"200": jsonResponse("The tag.", ref("TagDetailedDto"), map[string]*Header{"ETag": etagHeader()}),
+						"400": responseRef("BadRequest"),
+						"404": responseRef("NotFound"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+				Put: &Operation{
+					OperationID: "updateTag",
+					Summary:     "Replace a tag",
+					Tags:        []string{tagsTag},
+					Parameters:  []*Parameter{idPath(), ifMatchHeader()},
+					RequestBody: jsonBody(jsonContentType, "TagUpdate"),
+					Responses:   tagWriteResponses(conditionalWriteResponses()),
+				},
+				Patch: &Operation{
+					OperationID: "patchTag",
+					Summary:     "Apply a JSON Merge Patch to a tag",
+					Tags:        []string{tagsTag},
+					Parameters:  []*Parameter{idPath(), ifMatchHeader()},
+					RequestBody: jsonBody(mergePatchContentType, "TagPatch"),
+					Responses:   tagWriteResponses(mergePatchResponses()),
+				},
+				Delete: &Operation{
+					OperationID: "deleteTag",
+					Summary:     "Delete a tag",
+					Tags:        []string{tagsTag},
+					Parameters: []*Parameter{
+						idPath(),
+						queryParameter("force", "Unlink the tag from its items instead of refusing to delete a tag in use.", booleanSchema()),
+						ifMatchHeader(),
+					},
+					Responses: tagDeleteResponses(),
+				},
+			},
+			"/tags/{id}/stats": {
+				Get: &Operation{
+					OperationID: "getTagStats",
+					Summary:     "Get the usage stats of a tag",
+					Tags:        []string{tagsTag},
+					Parameters:  []*Parameter{idPath(), moneyFormatParameter()},
+					Responses: map[string]*Response{
+						"200": jsonResponse("The stats of the tag.", ref("TagStatsDto"), nil),
+						"400": responseRef("BadRequest"),
+						"404": responseRef("NotFound"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/tags/{id}/merge-into/{targetId}": {
+				Post: &Operation{
+					OperationID: "mergeTag",
+					Summary:     "Move the item links of a tag to another tag and delete it",
+					Tags:        []string{tagsTag},
+					Parameters: []*Parameter{
+						idPath(),
+						{Name: "targetId", In: inPath, Required: true, Schema: uuidSchema()},
+					},
+					Responses: map[string]*Response{
+						"204": {Description: "Merged."},
+						"400": responseRef("BadRequest"),
+						"404": responseRef("NotFound"),
+						"409": errorResponse("The target tag is inactive."),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			"/audit": {
+				Get: &Operation{
+					OperationID: "getAuditEvents",
+					Summary:     "List the changes made to items and tags, the latest first",
+					Tags:        []string{auditTag},
+					Parameters:  append(auditFilterParameters(), ifNoneMatchHeader()),
+					Responses: map[string]*Response{
+						"200": jsonResponse("A page of audit events.", ref("AuditEventDtoPage"), cacheHeaders()),
+						"304": responseRef("NotModified"),
+						"400": responseRef("BadRequest"),
+						"500": responseRef("InternalServerError"),
+					},
+				},
+			},
+			SpecPath: {
+				Get: &Operation{
+					OperationID: "getOpenAPIDocument",
+					Summary:     "This OpenAPI document",
+					Tags:        []string{specTag},
+					Responses: map[string]*Response{
+						"200": jsonResponse("The OpenAPI 3.1 document.", &Schema{Type: "object"}, nil),
+					},
+				},
+			},
+		},
+		Components: Components{
+			Schemas:         newSchemas(),
+			Responses:       newResponses(),
+			SecuritySchemes: newSecuritySchemes(),
+		},
+	}
+
+	for path, pathItem := range document.Paths {
+		if path != SpecPath {
+			for _, operation := range pathItem.Operations() {
+				operation.Responses["429"] = responseRef("TooManyRequests")
+			}
+		}
+		if publicPaths[path] {
+			continue
+		}
+		for _, operation := range pathItem.Operations() {
+			operation.Security = []SecurityRequirement{{bearerAuthScheme: {}}}
+			operation.Responses["401"] = responseRef("Unauthorized")
+		}
+		if !isHouseholdScoped(path) {
+			continue
+		}
+		for method, operation := range pathItem.Operations() {
+			if acceptsAPIKeys(path) {
+				operation.Security = append(operation.Security, SecurityRequirement{apiKeyAuthScheme: {string(requiredScope(path, method))}})
+			}
+			operation.Parameters = append(operation.Parameters, householdHeader())
+			operation.Responses["403"] = responseRef("Forbidden")
+		}
+	}
+
+	return document
+}
+
+// isHouseholdScoped reports whether the operations of path act on the
+// household picked by the X-Household-Id header.
+func isHouseholdScoped(path string) bool {
+	return acceptsAPIKeys(path) || strings.HasPrefix(path, "/audit")
+}
+
+// acceptsAPIKeys reports whether API keys may call the operations of path.
+func acceptsAPIKeys(path string) bool {
+	return strings.HasPrefix(path, "/items") || strings.HasPrefix(path, "/tags")
+}
+
+// requiredScope is the scope an API key needs for method on a household
+// scoped path, mirroring auth.RequireScope in featurehttp.V1Routes.
+func requiredScope(path string, method string) auth.Scope {
+	read, write := auth.ScopeItemsRead, auth.ScopeItemsWrite
+	if strings.HasPrefix(path, "/tags") {
+		read, write = auth.ScopeTagsRead, auth.ScopeTagsWrite
+	}
+	if method == http.MethodGet {
+		return read
+	}
+
+	return write
+}
+
+func itemFilterParameters() []*Parameter {
+	return append([]*Parameter{
+		queryParameter("ids", "Restrict to these item ids; repeat the parameter for several values.", arrayOf(uuidSchema())),
+		queryParameter("name", "Case-insensitive substring of the name.", stringSchema()),
+		queryParameter("priceFrom", "Lower price bound, inclusive.", decimalQuerySchema()),
+		queryParameter("priceTo", "Upper price bound, inclusive.", decimalQuerySchema()),
+		queryParameter("description", "Case-insensitive substring of the description.", stringSchema()),
+		queryParameter("isActive", "", booleanSchema()),
+		queryParameter("createdFrom", "RFC 3339 timestamp.", dateTimeSchema()),
+		queryParameter("createdTo", "RFC 3339 timestamp.", dateTimeSchema()),
+		queryParameter("updatedFrom", "RFC 3339 timestamp.", dateTimeSchema()),
+		queryParameter("updatedTo", "RFC 3339 timestamp.", dateTimeSchema()),
+		queryParameter("cashbackFrom", "", int32Schema()),
+		queryParameter("cashbackTo", "", int32Schema()),
+		queryParameter("categories", "Repeat the parameter for several categories.", arrayOf(ref("ItemCategory"))),
+		queryParameter("tagIds", "Items linked to any of these tags.", arrayOf(uuidSchema())),
+		queryParameter("includeDescendants", "Also match items linked to tags nested under tagIds.", booleanSchema()),
+		sortParameter(domains.ItemSortFields),
+	}, cursorPageParameters()...)
+}
+
+func tagFilterParameters() []*Parameter {
+	return append([]*Parameter{
+		queryParameter("ids", "Restrict to these tag ids; repeat the parameter for several values.", arrayOf(uuidSchema())),
+		queryParameter("name", "Case-insensitive substring of the name.", stringSchema()),
+		queryParameter("isActive", "", booleanSchema()),
+		queryParameter("withStats", "Add the usage stats of every tag on the page.", booleanSchema()),
+		sortParameter(domains.TagSortFields),
+	}, cursorPageParameters()...)
+}
+
+func tagLookupFilterParameters() []*Parameter {
+	return append([]*Parameter{
+		queryParameter("name", "Case-insensitive substring of the name.", stringSchema()),
+	}, pageParameters()...)
+}
+
+func auditFilterParameters() []*Parameter {
+	return append([]*Parameter{
+		queryParameter("entityType", "", ref("AuditEntityType")),
+		queryParameter("entityId", "", uuidSchema()),
+		queryParameter("from", "RFC 3339 timestamp, inclusive.", dateTimeSchema()),
+		queryParameter("to", "RFC 3339 timestamp, inclusive.", dateTimeSchema()),
+	}, pageParameters()...)
+}
+
+func pageParameters() []*Parameter {
+	pageMinimum := 0.0
+	pageSizeMinimum := 1.0
+
+	page := queryParameter("page", "Zero-based page index.", &Schema{Type: "integer", Format: "int32", Minimum: &pageMinimum})
+	page.Required = true
+	pageSize := queryParameter("pageSize", "", &Schema{Type: "integer", Format: "int32", Minimum: &pageSizeMinimum})
+	pageSize.Required = true
+
+	return []*Parameter{page, pageSize}
+}
+
+// cursorPageParameters page through a listing either by page index or from
+// the next or previous cursor of an earlier page, in which case page is left out.
+func cursorPageParameters() []*Parameter {
+	parameters := pageParameters()
+	parameters[0].Required = false
+	parameters[0].Description = "Zero-based page index; required unless after or before is given."
+
+	return append(parameters,
+		queryParameter("after", "Cursor of the page to read the next page from.", stringSchema()),
+		queryParameter("before", "Cursor of the page to read the previous page from.", stringSchema()),
+		queryParameter("includeCount", "Count the matching rows; defaults to true.", booleanSchema()),
+	)
+}
+
+// sortParameter lists fields to sort a listing by, each optionally prefixed
+// with a dash for descending order.
+func sortParameter(fields []string) *Parameter {
+	field := "-?(" + strings.Join(fields, "|") + ")"
+
+	return queryParameter(
+		"sort",
+		"Comma-separated fields to sort by, a leading dash for descending, such as -"+fields[1]+","+fields[0]+"; one of "+strings.Join(fields, ", ")+". Cannot be combined with after or before.",
+		&Schema{Type: "string", Pattern: "^" + field + "(," + field + ")*$"},
+	)
+}
+
+func moneyFormatParameter() *Parameter {
+	return queryParameter(
+		"moneyFormat",
+		"object (default) writes amounts as {amount, currency}; number writes bare JSON numbers for legacy clients.",
+		&Schema{Type: "string", Enum: []any{string(domains.MoneyFormatObject), string(domains.MoneyFormatNumber)}},
+	)
+}
+
+func queryParameter(name string, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: inQuery, Description: description, Schema: schema}
+}
+
+func decimalQuerySchema() *Schema {
+	return &Schema{Type: "number", Format: "decimal"}
+}
+
+func idPath() *Parameter {
+	return &Parameter{Name: "id", In: inPath, Required: true, Schema: uuidSchema()}
+}
+
+func householdHeader() *Parameter {
+	return &Parameter{
+		Name:        "X-Household-Id",
+		In:          inHeader,
+		Description: "Household to act on; the caller's personal household when absent.",
+		Schema:      uuidSchema(),
+	}
+}
+
+func ifMatchHeader() *Parameter {
+	return &Parameter{
+		Name:        "If-Match",
+		In:          inHeader,
+		Description: "Strong ETag from the last GET, or * to skip the version check.",
+		Required:    true,
+		Schema:      stringSchema(),
+	}
+}
+
+func ifNoneMatchHeader() *Parameter {
+	return &Parameter{
+		Name:        "If-None-Match",
+		In:          inHeader,
+		Description: "ETag of a cached representation; a match returns 304.",
+		Schema:      stringSchema(),
+	}
+}
+
+func idempotencyKeyHeader() *Parameter {
+	maxLength := 255
+
+	return &Parameter{
+		Name:        "Idempotency-Key",
+		In:          inHeader,
+		Description: "Replays the original response when a request with the same key and body is retried within 24 hours.",
+		Schema:      &Schema{Type: "string", MaxLength: &maxLength},
+	}
+}
+
+func jsonBody(contentType string, schema string) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]*MediaType{contentType: {Schema: ref(schema)}},
+	}
+}
+
+func jsonResponse(description string, schema *Schema, headers map[string]*Header) *Response {
+	return &Response{
+		Description: description,
+		Headers:     headers,
+		Content:     map[string]*MediaType{jsonContentType: {Schema: schema}},
+	}
+}
+
+func responseRef(name string) *Response {
+	return &Response{Ref: responseRefPrefix + name}
+}
+
+func etagHeader() *Header {
+	return &Header{Description: "Strong entity tag of the representation: the row version and a digest of the body.", Schema: stringSchema()}
+}
+
+func cacheHeaders() map[string]*Header {
+	return map[string]*Header{
+		"ETag":          {Description: "Weak entity tag computed from the response body.", Schema: stringSchema()},
+		"Cache-Control": {Schema: stringSchema()},
+	}
+}
+
+func createdResponses() map[string]*Response {
+	return map[string]*Response{
+		"201": {
+			Description: "Created; the body holds the new id.",
+			Headers: map[string]*Header{
+				"Location":            {Description: "URL of the created resource.", Schema: stringSchema()},
+				"Idempotent-Replayed": {Description: "Set to true when the response was replayed.", Schema: stringSchema()},
+			},
+			Content: map[string]*MediaType{jsonContentType: {Schema: ref("Uuid")}},
+		},
+		"400": responseRef("BadRequest"),
+		"422": responseRef("UnprocessableEntity"),
+		"500": responseRef("InternalServerError"),
+	}
+}
+
+func conditionalWriteResponses() map[string]*Response {
+	return map[string]*Response{
+		"204": {Description: "Done."},
+		"400": responseRef("BadRequest"),
+		"404": responseRef("NotFound"),
+		"412": responseRef("PreconditionFailed"),
+		"428": responseRef("PreconditionRequired"),
+		"500": responseRef("InternalServerError"),
+	}
+}
+
+func mergePatchResponses() map[string]*Response {
+	responses := conditionalWriteResponses()
+	responses["415"] = responseRef("UnsupportedMediaType")
+
+	return responses
+}
+
+func tagWriteResponses(responses map[string]*Response) map[string]*Response {
+	responses["409"] = errorResponse("The parent would nest the tag under itself or its descendants.")
+
+	return responses
+}
+
+func tagDeleteResponses() map[string]*Response {
+	responses := conditionalWriteResponses()
+	responses["409"] = errorResponse("The tag is linked to items and force is not set.")
+
+	return responses
+}
+
+func tokenResponses() map[string]*Response {
+	return map[string]*Response{
+		"200": jsonResponse("A new access and refresh token.", ref("TokenDto"), map[string]*Header{
+			"Cache-Control": {Description: "Always no-store.", Schema: stringSchema()},
+		}),
+		"400": responseRef("BadRequest"),
+		"401": responseRef("Unauthorized"),
+		"500": responseRef("InternalServerError"),
+	}
+}
+
+func bulkWriteResponses() map[string]*Response {
+	return map[string]*Response{
+		"204": {Description: "Done."},
+		"400": responseRef("BadRequest"),
+		"500": responseRef("InternalServerError"),
+	}
+}
+
+func itemTagsChangeResponses(description string, adds bool) map[string]*Response {
+	responses := map[string]*Response{
+		"200": jsonResponse(description, ref("ItemTagsChangeResult"), nil),
+		"400": responseRef("BadRequest"),
+		"500": responseRef("InternalServerError"),
+	}
+	if adds {
+		responses["409"] = errorResponse("A tag to add is inactive.")
+	}
+
+	return responses
+}
+
+func newResponses() map[string]*Response {
+	return map[string]*Response{
+		"NotModified": {Description: "The cached representation is still current."},
+		"BadRequest":  errorResponse("The request is malformed or fails validation."),
+		"Unauthorized": {
+			Description: "Credentials are missing, wrong or expired.",
+			Headers: map[string]*Header{
+				"WWW-Authenticate": {Description: "The authentication scheme to use.", Schema: stringSchema()},
+			},
+			Content: map[string]*MediaType{textContentType: {Schema: ref("Error")}},
+		},
+		"Forbidden":            errorResponse("The caller is not a member of the household, their role does not allow the operation or their API key lacks the scope."),
+		"NotFound":             errorResponse("The resource does not exist."),
+		"PreconditionFailed":   errorResponse("If-Match does not match the current version."),
+		"PreconditionRequired": errorResponse("If-Match is missing."),
+		"UnsupportedMediaType": errorResponse("The request body has an unsupported content type."),
+		"UnprocessableEntity":  errorResponse("The Idempotency-Key was already used with a different body."),
+		"InternalServerError":  errorResponse("Unexpected server error."),
+		"TooManyRequests": {
+			Description: "The client used up its rate limit for the route group.",
+			Headers: map[string]*Header{
+				"RateLimit-Limit":     {Description: "Requests allowed per period.", Schema: int32Schema()},
+				"RateLimit-Remaining": {Description: "Requests left in the current period.", Schema: int32Schema()},
+				"RateLimit-Reset":     {Description: "Seconds until the bucket is full again.", Schema: int32Schema()},
+				"Retry-After":         {Description: "Seconds to wait before the next request.", Schema: int32Schema()},
+			},
+			Content: map[string]*MediaType{textContentType: {Schema: ref("Error")}},
+		},
+	}
+}
+
+func newSecuritySchemes() map[string]*SecurityScheme {
+	return map[string]*SecurityScheme{
+		bearerAuthScheme: {
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+			Description:  "Access token from /auth/login or /auth/refresh.",
+		},
+		apiKeyAuthScheme: {
+			Type:        "http",
+			Scheme:      "ApiKey",
+			Description: "Key from POST /api-keys, sent as `Authorization: ApiKey <key>`. Accepted on items and tags only; the requirement lists the scope the key needs.",
+		},
+	}
+}
+
+func errorResponse(description string) *Response {
+	return &Response{
+		Description: description,
+		Content:     map[string]*MediaType{textContentType: {Schema: ref("Error")}},
+	}
+}
@@ -0,0 +1,489 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	errUnsupportedMediaType = errors.New("unsupported content type")
+	errRequestTooLarge      = errors.New("request body too large")
+)
+
+// Validator checks incoming requests against the operations of a Document
+// before they reach a handler. It is meant to be used by the router of an API
+// version, so paths are matched relative to the version prefix. Requests for
+// paths or methods the document does not describe are passed through so the
+// router still answers 404 or 405. Request bodies are limited to maxBodyBytes.
+type Validator struct {
+	document     *Document
+	routes       []route
+	maxBodyBytes int64
+}
+
+type route struct {
+	segments []string
+	pathItem *PathItem
+}
+
+func NewValidator(document *Document, maxBodyBytes int64) *Validator {
+	routes := make([]route, 0, len(document.Paths))
+	for path, pathItem := range document.Paths {
+		routes = append(routes, route{segments: splitPath(path), pathItem: pathItem})
+	}
+
+	return &Validator{document: document, routes: routes, maxBodyBytes: maxBodyBytes}
+}
+
+// Middleware answers 400 Bad Request with every violation found, 413 Content
+// Too Large when the body exceeds the limit, or 415 Unsupported Media Type
+// when the body is not in a documented content type. The body is read, so
+// mount it after authentication and rate limiting.
+func (validator *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, validator.maxBodyBytes)
+
+		operation, pathParams := validator.findOperation(r)
+		if operation == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := validator.ValidateRequest(r, operation, pathParams); err != nil {
+			statusCode := http.StatusBadRequest
+			switch {
+			case errors.Is(err, errRequestTooLarge):
+				statusCode = http.StatusRequestEntityTooLarge
+			case errors.Is(err, errUnsupportedMediaType):
+				statusCode = http.StatusUnsupportedMediaType
+			}
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ValidateRequest checks the path, query, header and body of r against
+// operation. The body is buffered and restored so the handler can read it.
+// Missing required headers are left to the handler, which answers with a more
+// specific status such as 428 Precondition Required.
+func (validator *Validator) ValidateRequest(r *http.Request, operation *Operation, pathParams map[string]string) error {
+	var errs []error
+	query := r.URL.Query()
+
+	declared := make(map[string]bool)
+	for _, parameter := range operation.Parameters {
+		switch parameter.In {
+		case inPath:
+			errs = append(errs, validator.validateParameter(parameter, []string{pathParams[parameter.Name]})...)
+		case inQuery:
+			declared[parameter.Name] = true
+			values, ok := query[parameter.Name]
+			if !ok {
+				if parameter.Required {
+					errs = append(errs, fmt.Errorf("query parameter %q is required", parameter.Name))
+				}
+				continue
+			}
+			errs = append(errs, validator.validateParameter(parameter, values)...)
+		case inHeader:
+			values := r.Header.Values(parameter.Name)
+			if len(values) == 0 {
+				continue
+			}
+			errs = append(errs, validator.validateParameter(parameter, values)...)
+		}
+	}
+
+	unknown := make([]string, 0)
+	for name := range query {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("query parameter %q is not supported", name))
+	}
+
+	if operation.RequestBody != nil {
+		if err := validator.validateBody(r, operation.RequestBody); err != nil {
+			if errors.Is(err, errRequestTooLarge) || errors.Is(err, errUnsupportedMediaType) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (validator *Validator) findOperation(r *http.Request) (*Operation, map[string]string) {
+	segments := splitPath(routePath(r))
+
+	var best *route
+	bestStatic := -1
+	for i := range validator.routes {
+		candidate := &validator.routes[i]
+		static, ok := matchSegments(candidate.segments, segments)
+		if ok && static > bestStatic {
+			best = candidate
+			bestStatic = static
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	operation := best.pathItem.Operation(r.Method)
+	if operation == nil {
+		return nil, nil
+	}
+
+	pathParams := make(map[string]string)
+	for i, segment := range best.segments {
+		if name, ok := templateName(segment); ok {
+			pathParams[name] = segments[i]
+		}
+	}
+
+	return operation, pathParams
+}
+
+// matchSegments reports whether path fits template and how many template
+// segments matched literally, so /api/tags/lookup wins over /api/tags/{id}.
+func matchSegments(template []string, path []string) (int, bool) {
+	if len(template) != len(path) {
+		return 0, false
+	}
+
+	static := 0
+	for i, segment := range template {
+		if _, ok := templateName(segment); ok {
+			if path[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if segment != path[i] {
+			return 0, false
+		}
+		static++
+	}
+
+	return static, true
+}
+
+func templateName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+
+	return "", false
+}
+
+// routePath returns the part of the path left to route inside a mounted
+// router, or the full path outside of chi.
+func routePath(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		return rctx.RoutePath
+	}
+
+	return r.URL.Path
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func (validator *Validator) validateParameter(parameter *Parameter, values []string) []error {
+	location := fmt.Sprintf("%s parameter %q", parameter.In, parameter.Name)
+	schema := validator.resolve(parameter.Schema)
+
+	if slices.Contains(schema.Types(), "array") {
+		var errs []error
+		for _, value := range values {
+			errs = append(errs, validator.validateText(location, validator.resolve(schema.Items), value)...)
+		}
+		return errs
+	}
+
+	if len(values) > 1 {
+		return []error{fmt.Errorf("%s must not be repeated", location)}
+	}
+
+	return validator.validateText(location, schema, values[0])
+}
+
+// validateText checks a path, query or header value, which always arrives as
+// text, against a primitive schema.
+func (validator *Validator) validateText(location string, schema *Schema, text string) []error {
+	types := schema.Types()
+
+	var value any
+	switch {
+	case slices.Contains(types, "integer"):
+		if _, err := strconv.ParseInt(text, 10, integerBits(schema)); err != nil {
+			return []error{fmt.Errorf("%s must be an integer", location)}
+		}
+		value = json.Number(text)
+	case slices.Contains(types, "number"):
+		if _, err := decimal.NewFromString(text); err != nil {
+			return []error{fmt.Errorf("%s must be a number", location)}
+		}
+		value = json.Number(text)
+	case slices.Contains(types, "boolean"):
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return []error{fmt.Errorf("%s must be a boolean", location)}
+		}
+		value = parsed
+	default:
+		value = text
+	}
+
+	return validator.validateValue(location, schema, value)
+}
+
+func (validator *Validator) validateBody(r *http.Request, requestBody *RequestBody) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return fmt.Errorf("%w: the limit is %d bytes", errRequestTooLarge, maxBytesErr.Limit)
+		}
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if requestBody.Required {
+			return errors.New("request body is required")
+		}
+		return nil
+	}
+
+	mediaType, _, parseErr := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	content, ok := requestBody.Content[mediaType]
+	if parseErr != nil || !ok {
+		supported := make([]string, 0, len(requestBody.Content))
+		for contentType := range requestBody.Content {
+			supported = append(supported, contentType)
+		}
+		slices.Sort(supported)
+		return fmt.Errorf("%w: Content-Type must be %s", errUnsupportedMediaType, strings.Join(supported, " or "))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("request body is not valid JSON: %w", err)
+	}
+	if decoder.More() {
+		return errors.New("request body must contain a single JSON value")
+	}
+
+	return errors.Join(validator.validateValue("body", content.Schema, value)...)
+}
+
+// validateValue checks a decoded JSON value. Numbers must be decoded as
+// json.Number so integers and decimals keep their exact text.
+func (validator *Validator) validateValue(location string, schema *Schema, value any) []error {
+	schema = validator.resolve(schema)
+	types := schema.Types()
+
+	kind := jsonType(value)
+	if len(types) > 0 && !slices.Contains(types, kind) && !(kind == "integer" && slices.Contains(types, "number")) {
+		return []error{fmt.Errorf("%s must be of type %s", location, strings.Join(types, " or "))}
+	}
+	if value == nil {
+		return nil
+	}
+
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, value) {
+		return []error{fmt.Errorf("%s must be one of %s", location, enumNames(schema.Enum))}
+	}
+
+	switch typed := value.(type) {
+	case string:
+		return validator.validateString(location, schema, typed)
+	case json.Number:
+		return validator.validateNumber(location, schema, typed)
+	case []any:
+		return validator.validateArray(location, schema, typed)
+	case map[string]any:
+		return validator.validateObject(location, schema, typed)
+	}
+
+	return nil
+}
+
+func (validator *Validator) validateString(location string, schema *Schema, value string) []error {
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		return []error{fmt.Errorf("%s must be at least %d characters long", location, *schema.MinLength)}
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return []error{fmt.Errorf("%s must be at most %d characters long", location, *schema.MaxLength)}
+	}
+	if schema.Pattern != "" {
+		if matched, err := regexp.MatchString(schema.Pattern, value); err != nil || !matched {
+			return []error{fmt.Errorf("%s must match %s", location, schema.Pattern)}
+		}
+	}
+
+	switch schema.Format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			return []error{fmt.Errorf("%s must be a UUID", location)}
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return []error{fmt.Errorf("%s must be an RFC 3339 timestamp", location)}
+		}
+	case "decimal":
+		return validator.validateNumber(location, schema, json.Number(value))
+	}
+
+	return nil
+}
+
+func (validator *Validator) validateNumber(location string, schema *Schema, value json.Number) []error {
+	number, err := decimal.NewFromString(value.String())
+	if err != nil {
+		return []error{fmt.Errorf("%s must be a number", location)}
+	}
+
+	if slices.Contains(schema.Types(), "integer") {
+		if _, err := strconv.ParseInt(value.String(), 10, integerBits(schema)); err != nil {
+			return []error{fmt.Errorf("%s must be a %d-bit integer", location, integerBits(schema))}
+		}
+	}
+	if schema.Minimum != nil && number.LessThan(decimal.NewFromFloat(*schema.Minimum)) {
+		return []error{fmt.Errorf("%s must be greater than or equal to %s", location, strconv.FormatFloat(*schema.Minimum, 'f', -1, 64))}
+	}
+
+	return nil
+}
+
+func (validator *Validator) validateArray(location string, schema *Schema, values []any) []error {
+	var errs []error
+	seen := make(map[string]bool, len(values))
+	for i, element := range values {
+		elementLocation := fmt.Sprintf("%s[%d]", location, i)
+		if schema.Items != nil {
+			errs = append(errs, validator.validateValue(elementLocation, schema.Items, element)...)
+		}
+
+		if schema.UniqueItems {
+			key, _ := json.Marshal(element)
+			if seen[string(key)] {
+				errs = append(errs, fmt.Errorf("%s is a duplicate", elementLocation))
+			}
+			seen[string(key)] = true
+		}
+	}
+
+	return errs
+}
+
+func (validator *Validator) validateObject(location string, schema *Schema, members map[string]any) []error {
+	var errs []error
+	for _, name := range schema.Required {
+		if _, ok := members[name]; !ok {
+			errs = append(errs, fmt.Errorf("%s.%s is required", location, name))
+		}
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				errs = append(errs, fmt.Errorf("%s.%s is not a known field", location, name))
+			}
+			continue
+		}
+		errs = append(errs, validator.validateValue(location+"."+name, property, members[name])...)
+	}
+
+	return errs
+}
+
+// resolve follows component references until it reaches an inline schema.
+func (validator *Validator) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = validator.document.Components.Schemas[strings.TrimPrefix(schema.Ref, schemaRefPrefix)]
+	}
+	if schema == nil {
+		return &Schema{}
+	}
+
+	return schema
+}
+
+func jsonType(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := typed.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func integerBits(schema *Schema) int {
+	if schema.Format == "int32" {
+		return 32
+	}
+
+	return 64
+}
+
+func enumNames(values []any) string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			names = append(names, "null")
+			continue
+		}
+		names = append(names, fmt.Sprint(value))
+	}
+
+	return strings.Join(names, ", ")
+}
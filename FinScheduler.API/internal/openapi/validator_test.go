@@ -0,0 +1,458 @@
+package openapi
+
+import (
+	featurehttp "finscheduler/internal/features/http"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMaxBodyBytes = 1 << 10
+
+const validItemBody = `{"name":"Coffee","price":15.5,"category":"FoodDrinks","tagIds":["8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f"]}`
+
+type recordingHandler struct {
+	called bool
+	body   string
+}
+
+func (handler *recordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler.called = true
+	body, _ := io.ReadAll(r.Body)
+	handler.body = string(body)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func serveValidated(request *http.Request) (*httptest.ResponseRecorder, *recordingHandler) {
+	next := &recordingHandler{}
+	recorder := httptest.NewRecorder()
+	NewValidator(NewDocument(), testMaxBodyBytes).Middleware(next).ServeHTTP(recorder, request)
+
+	return recorder, next
+}
+
+func newRequest(method string, target string, contentType string, body string) *http.Request {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	return request
+}
+
+func TestValidator_ShouldPassValidRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *http.Request
+	}{
+		{
+			name:    "item listing with filters",
+			request: newRequest(http.MethodGet, "/items?page=0&pageSize=10&isActive=true&priceFrom=1.5&categories=FoodDrinks&categories=Travel&createdFrom=2024-01-01T00:00:00Z", "", ""),
+		},
+		{
+			name:    "item listing with tag descendants",
+			request: newRequest(http.MethodGet, "/items?page=0&pageSize=10&tagIds=8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f&includeDescendants=true", "", ""),
+		},
+		{
+			name:    "item listing by cursor without count",
+			request: newRequest(http.MethodGet, "/items?after=eyJpIjoiIn0&pageSize=10&includeCount=false", "", ""),
+		},
+		{
+			name:    "item listing sorted",
+			request: newRequest(http.MethodGet, "/items?page=0&pageSize=10&sort=price,-updatedAt,name", "", ""),
+		},
+		{
+			name:    "tag listing with stats",
+			request: newRequest(http.MethodGet, "/tags?page=0&pageSize=10&withStats=true&moneyFormat=number", "", ""),
+		},
+		{
+			name:    "item trash with money format",
+			request: newRequest(http.MethodGet, "/items/trash?page=0&pageSize=10&moneyFormat=number", "", ""),
+		},
+		{
+			name:    "tag creation with appearance",
+			request: newRequest(http.MethodPost, "/tags", jsonContentType, `{"name":"Power","color":"#1E88E5","icon":"flash-on","description":"Electricity bills"}`),
+		},
+		{
+			name:    "tag merge patch moving the tag to the top level",
+			request: newRequest(http.MethodPatch, "/tags/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", mergePatchContentType, `{"parentId":null}`),
+		},
+		{
+			name:    "tag lookup",
+			request: newRequest(http.MethodGet, "/tags/lookup?page=0&pageSize=10&name=foo", "", ""),
+		},
+		{
+			name:    "item creation",
+			request: newRequest(http.MethodPost, "/items", jsonContentType, validItemBody),
+		},
+		{
+			name:    "item creation with price as string",
+			request: newRequest(http.MethodPost, "/items", "application/json; charset=utf-8", `{"name":"Coffee","price":"15.50","category":"FoodDrinks"}`),
+		},
+		{
+			name:    "item merge patch clearing the description",
+			request: newRequest(http.MethodPatch, "/items/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", mergePatchContentType, `{"name":"Coffee","description":null}`),
+		},
+		{
+			name:    "cashback by tag",
+			request: newRequest(http.MethodPatch, "/items/cashback/tag", jsonContentType, `{"cashback":5,"tagId":"8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f"}`),
+		},
+		{
+			name:    "item tags add by filter",
+			request: newRequest(http.MethodPost, "/items/tags:add", jsonContentType, `{"filter":{"categories":["FoodDrinks"]},"tagIds":["8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f"]}`),
+		},
+		{
+			name:    "item bulk price adjustment",
+			request: newRequest(http.MethodPost, "/items:bulk", jsonContentType, `{"filter":{"priceFrom":"10"},"operation":"adjustPrice","pricePercent":-12.5,"dryRun":true}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			body := ""
+			if tt.request.Body != nil {
+				raw, err := io.ReadAll(tt.request.Body)
+				require.NoError(t, err)
+				body = string(raw)
+				tt.request.Body = io.NopCloser(strings.NewReader(body))
+			}
+
+			// Act
+			recorder, next := serveValidated(tt.request)
+
+			// Assert
+			assert.Equal(t, http.StatusNoContent, recorder.Code, recorder.Body.String())
+			assert.True(t, next.called)
+			assert.Equal(t, body, next.body)
+		})
+	}
+}
+
+func TestValidator_ShouldRejectInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        *http.Request
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "unknown query parameter",
+			request:        newRequest(http.MethodGet, "/items?page=0&pageSize=10&isactive=true", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "isactive" is not supported`,
+		},
+		{
+			name:           "missing required query parameter",
+			request:        newRequest(http.MethodGet, "/tags?page=0", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "pageSize" is required`,
+		},
+		{
+			name:           "query parameter of the wrong type",
+			request:        newRequest(http.MethodGet, "/items?page=first&pageSize=10", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "page" must be an integer`,
+		},
+		{
+			name:           "repeated scalar query parameter",
+			request:        newRequest(http.MethodGet, "/tags?page=0&page=1&pageSize=10", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "page" must not be repeated`,
+		},
+		{
+			name:           "query parameter below minimum",
+			request:        newRequest(http.MethodGet, "/tags/lookup?page=0&pageSize=0", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "pageSize" must be greater than or equal to 1`,
+		},
+		{
+			name:           "item merge patch with a null required member",
+			request:        newRequest(http.MethodPatch, "/items/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", mergePatchContentType, `{"name":null}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.name must be of type string",
+		},
+		{
+			name:           "tag merge patch with a null required member",
+			request:        newRequest(http.MethodPatch, "/tags/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", mergePatchContentType, `{"isActive":null}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.isActive must be of type boolean",
+		},
+		{
+			name:           "unknown enum value in query",
+			request:        newRequest(http.MethodGet, "/items?page=0&pageSize=10&categories=Groceries", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "categories" must be one of`,
+		},
+		{
+			name:           "unknown sort field",
+			request:        newRequest(http.MethodGet, "/tags?page=0&pageSize=10&sort=-color", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "sort" must match`,
+		},
+		{
+			name:           "unknown money format",
+			request:        newRequest(http.MethodGet, "/items/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f?moneyFormat=float", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `query parameter "moneyFormat" must be one of object, number`,
+		},
+		{
+			name:           "invalid path parameter",
+			request:        newRequest(http.MethodGet, "/tags/not-a-uuid", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `path parameter "id" must be a UUID`,
+		},
+		{
+			name:           "unknown body field",
+			request:        newRequest(http.MethodPost, "/tags", jsonContentType, `{"name":"Groceries","isactive":true}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.isactive is not a known field",
+		},
+		{
+			name:           "missing required body field",
+			request:        newRequest(http.MethodPut, "/items/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", jsonContentType, `{"name":"Coffee"}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.category is required",
+		},
+		{
+			name:           "body field of the wrong type",
+			request:        newRequest(http.MethodPost, "/items", jsonContentType, `{"name":"Coffee","price":true,"category":"FoodDrinks"}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.price must be of type number or string",
+		},
+		{
+			name:           "non-numeric price string",
+			request:        newRequest(http.MethodPost, "/items", jsonContentType, `{"name":"Coffee","price":"cheap","category":"FoodDrinks"}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.price must be a number",
+		},
+		{
+			name:           "negative cashback",
+			request:        newRequest(http.MethodPatch, "/items/cashback/items", jsonContentType, `{"cashback":-1,"itemIds":[]}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.cashback must be greater than or equal to 0",
+		},
+		{
+			name:           "fractional integer",
+			request:        newRequest(http.MethodPost, "/items", jsonContentType, `{"name":"Coffee","cashback":1.5,"category":"FoodDrinks"}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.cashback must be of type integer",
+		},
+		{
+			name:           "duplicate array element",
+			request:        newRequest(http.MethodPost, "/items", jsonContentType, `{"name":"Coffee","category":"FoodDrinks","tagIds":["8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f","8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f"]}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.tagIds[1] is a duplicate",
+		},
+		{
+			name:           "invalid array element",
+			request:        newRequest(http.MethodPatch, "/items/cashback/items", jsonContentType, `{"cashback":1,"itemIds":["bad-uuid"]}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.itemIds[0] must be a UUID",
+		},
+		{
+			name:           "tag colour not hex",
+			request:        newRequest(http.MethodPost, "/tags", jsonContentType, `{"name":"Groceries","color":"green"}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.color must match ^#[0-9a-fA-F]{6}$",
+		},
+		{
+			name:           "name too short",
+			request:        newRequest(http.MethodPatch, "/tags/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", mergePatchContentType, `{"name":"ab"}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "body.name must be at least 3 characters long",
+		},
+		{
+			name:           "malformed body",
+			request:        newRequest(http.MethodPost, "/tags", jsonContentType, `{"name":`),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "request body is not valid JSON",
+		},
+		{
+			name:           "missing body",
+			request:        newRequest(http.MethodPost, "/tags", "", ""),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "request body is required",
+		},
+		{
+			name:           "undocumented content type",
+			request:        newRequest(http.MethodPatch, "/tags/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", jsonContentType, `{"name":"Groceries"}`),
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedError:  "Content-Type must be application/merge-patch+json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			recorder, next := serveValidated(tt.request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), tt.expectedError)
+			assert.False(t, next.called)
+		})
+	}
+}
+
+func TestValidator_ShouldReportEveryViolation(t *testing.T) {
+	// Arrange
+	request := newRequest(http.MethodPost, "/items", jsonContentType, `{"nmae":"Coffee","price":-1}`)
+
+	// Act
+	recorder, _ := serveValidated(request)
+	body := recorder.Body.String()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, body, "body.name is required")
+	assert.Contains(t, body, "body.category is required")
+	assert.Contains(t, body, "body.nmae is not a known field")
+	assert.Contains(t, body, "body.price must be greater than or equal to 0")
+}
+
+func TestValidator_ShouldLeaveMissingIfMatchToHandler(t *testing.T) {
+	// Arrange
+	request := newRequest(http.MethodDelete, "/items/8d7f3a7e-4c1b-4f55-9a0e-3b5b2c1d0e9f", "", "")
+
+	// Act
+	recorder, next := serveValidated(request)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.True(t, next.called)
+}
+
+func TestValidator_ShouldRejectOversizedIdempotencyKey(t *testing.T) {
+	// Arrange
+	request := newRequest(http.MethodPost, "/items", jsonContentType, validItemBody)
+	request.Header.Set("Idempotency-Key", strings.Repeat("k", 256))
+
+	// Act
+	recorder, next := serveValidated(request)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `header parameter "Idempotency-Key" must be at most 255 characters long`)
+	assert.False(t, next.called)
+}
+
+func TestValidator_ShouldRejectOversizedBody(t *testing.T) {
+	// Arrange
+	body := `{"name":"` + strings.Repeat("a", testMaxBodyBytes) + `"}`
+	request := newRequest(http.MethodPost, "/tags", jsonContentType, body)
+
+	// Act
+	recorder, next := serveValidated(request)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "request body too large: the limit is 1024 bytes")
+	assert.False(t, next.called)
+}
+
+func TestValidator_ShouldRunAfterAuthenticationAndRateLimiting(t *testing.T) {
+	reject := func(statusCode int) func(http.Handler) http.Handler {
+		return func(http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(statusCode)
+			})
+		}
+	}
+	pass := func(next http.Handler) http.Handler { return next }
+
+	tests := []struct {
+		name           string
+		authenticate   func(http.Handler) http.Handler
+		rateLimit      featurehttp.RateLimit
+		expectedStatus int
+	}{
+		{name: "anonymous", authenticate: reject(http.StatusUnauthorized), rateLimit: featurehttp.NoRateLimit, expectedStatus: http.StatusUnauthorized},
+		{name: "throttled", authenticate: pass, rateLimit: func(string) func(http.Handler) http.Handler { return reject(http.StatusTooManyRequests) }, expectedStatus: http.StatusTooManyRequests},
+		{name: "admitted", authenticate: pass, rateLimit: featurehttp.NoRateLimit, expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			logger := slog.Default()
+			validator := NewValidator(NewDocument(), testMaxBodyBytes)
+			router := chi.NewRouter()
+			featurehttp.RegisterVersions(router, featurehttp.APIVersion{
+				Name:   "v1",
+				Routes: featurehttp.Protected(tt.authenticate, featurehttp.V1Routes(featurehttp.NewItemsHandler(nil, logger), featurehttp.NewTagsHandler(nil, logger), featurehttp.NewHouseholdsHandler(nil, logger), featurehttp.NewApiKeysHandler(nil, logger), featurehttp.NewAuditHandler(nil, logger), tt.rateLimit, validator.Middleware)),
+			})
+			body := `{"name":"` + strings.Repeat("a", testMaxBodyBytes) + `"}`
+			request := newRequest(http.MethodPost, "/api/v1/items", jsonContentType, body)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestValidator_ShouldLimitBodiesOfUndocumentedRoutes(t *testing.T) {
+	// Arrange
+	next := &recordingHandler{}
+	request := newRequest(http.MethodPost, "/unknown", jsonContentType, strings.Repeat("a", 2*testMaxBodyBytes))
+
+	// Act
+	NewValidator(NewDocument(), testMaxBodyBytes).Middleware(next).ServeHTTP(httptest.NewRecorder(), request)
+
+	// Assert
+	assert.True(t, next.called)
+	assert.Len(t, next.body, testMaxBodyBytes)
+}
+
+func TestValidator_ShouldPassUndocumentedRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *http.Request
+	}{
+		{name: "health check", request: newRequest(http.MethodGet, "/livez?verbose=1", "", "")},
+		{name: "undocumented method", request: newRequest(http.MethodDelete, "/tags/lookup", "", "")},
+		{name: "unknown path", request: newRequest(http.MethodGet, "/unknown", "", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			recorder, next := serveValidated(tt.request)
+
+			// Assert
+			assert.Equal(t, http.StatusNoContent, recorder.Code)
+			assert.True(t, next.called)
+		})
+	}
+}
+
+func TestValidator_ShouldMatchPathsRelativeToMountedVersion(t *testing.T) {
+	// Arrange
+	next := &recordingHandler{}
+	versionRouter := chi.NewRouter()
+	versionRouter.Use(NewValidator(NewDocument(), testMaxBodyBytes).Middleware)
+	versionRouter.Handle("/*", next)
+	router := chi.NewRouter()
+	router.Mount("/api/v1", versionRouter)
+	request := newRequest(http.MethodGet, "/api/v1/tags?page=0&pageSize=10&isactive=true", "", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `query parameter "isactive" is not supported`)
+	assert.False(t, next.called)
+}
@@ -14,6 +14,26 @@ func NewRepositoryFactory(db repositories.DBTX, logger *slog.Logger) *Repository
 	return &RepositoryFactory{db: db, logger: logger}
 }
 
+func (factory *RepositoryFactory) ApiKeys() *repositories.ApiKeysRepository {
+	return repositories.NewApiKeysRepository(factory.db, factory.logger)
+}
+
+func (factory *RepositoryFactory) AuditEvents() *repositories.AuditEventsRepository {
+	return repositories.NewAuditEventsRepository(factory.db, factory.logger)
+}
+
+func (factory *RepositoryFactory) Households() *repositories.HouseholdsRepository {
+	return repositories.NewHouseholdsRepository(factory.db, factory.logger)
+}
+
+func (factory *RepositoryFactory) IdempotencyKeys() *repositories.IdempotencyKeysRepository {
+	return repositories.NewIdempotencyKeysRepository(factory.db, factory.logger)
+}
+
+func (factory *RepositoryFactory) Invitations() *repositories.InvitationsRepository {
+	return repositories.NewInvitationsRepository(factory.db, factory.logger)
+}
+
 func (factory *RepositoryFactory) Items() *repositories.ItemsRepository {
 	return repositories.NewItemsRepository(factory.db, factory.logger)
 }
@@ -29,3 +49,7 @@ func (factory *RepositoryFactory) Tags() *repositories.TagsRepository {
 func (factory *RepositoryFactory) TagToItems() *repositories.TagToItemsRepository {
 	return repositories.NewTagToItemsRepository(factory.db, factory.logger)
 }
+
+func (factory *RepositoryFactory) Users() *repositories.UsersRepository {
+	return repositories.NewUsersRepository(factory.db, factory.logger)
+}
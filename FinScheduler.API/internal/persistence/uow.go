@@ -2,9 +2,12 @@ package persistence
 
 import (
 	"context"
+	"finscheduler/internal/auth"
 	"finscheduler/internal/features/repositories"
+	"fmt"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -18,17 +21,51 @@ func NewUnitOfWork(db *sqlx.DB, logger *slog.Logger) *UnitOfWork {
 }
 
 type Repositories struct {
-	Items          *repositories.ItemsRepository
-	PriceHistories *repositories.PriceHistoriesRepository
-	Tags           *repositories.TagsRepository
-	TagToItems     *repositories.TagToItemsRepository
+	ApiKeys         *repositories.ApiKeysRepository
+	AuditEvents     *repositories.AuditEventsRepository
+	Households      *repositories.HouseholdsRepository
+	IdempotencyKeys *repositories.IdempotencyKeysRepository
+	Invitations     *repositories.InvitationsRepository
+	Items           *repositories.ItemsRepository
+	PriceHistories  *repositories.PriceHistoriesRepository
+	Tags            *repositories.TagsRepository
+	TagToItems      *repositories.TagToItemsRepository
+	Users           *repositories.UsersRepository
 }
 
-func (uow *UnitOfWork) WithoutTx(fn func(Repositories) error) error {
+// WithoutTx runs fn on the pool when fn does not need its statements to be
+// atomic. Requests acting on a household still get a transaction, because the
+// tenant setting of the row-level security policies only lasts for one.
+func (uow *UnitOfWork) WithoutTx(ctx context.Context, fn func(Repositories) error) error {
+	if _, ok := tenantFromContext(ctx); ok {
+		return uow.WithTx(ctx, fn)
+	}
+
 	return fn(uow.buildRepositories(uow.db))
 }
 
+// WithTx runs fn in a transaction that is committed when fn succeeds. When
+// ctx carries a household membership, the transaction is bound to that
+// household as the tenant of the row-level security policies.
 func (uow *UnitOfWork) WithTx(ctx context.Context, fn func(Repositories) error) error {
+	tenantID, _ := tenantFromContext(ctx)
+
+	return uow.withTx(ctx, tenantID, fn)
+}
+
+// WithTenantTx runs fn in a transaction bound to tenantID, whatever household
+// ctx carries. Background jobs use it to visit every household in turn, as the
+// row-level security policies hide the rows of all others.
+func (uow *UnitOfWork) WithTenantTx(ctx context.Context, tenantID uuid.UUID, fn func(Repositories) error) error {
+	if tenantID == uuid.Nil {
+		return fmt.Errorf("tenantID should not be nil")
+	}
+
+	return uow.withTx(ctx, tenantID, fn)
+}
+
+// withTx binds the transaction to tenantID unless it is uuid.Nil.
+func (uow *UnitOfWork) withTx(ctx context.Context, tenantID uuid.UUID, fn func(Repositories) error) error {
 	tx, err := uow.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
@@ -41,6 +78,12 @@ func (uow *UnitOfWork) WithTx(ctx context.Context, fn func(Repositories) error)
 		}
 	}()
 
+	if tenantID != uuid.Nil {
+		if err := setTenant(ctx, tx, tenantID); err != nil {
+			return err
+		}
+	}
+
 	if err := fn(uow.buildRepositories(tx)); err != nil {
 		return err
 	}
@@ -57,9 +100,35 @@ func (uow *UnitOfWork) buildRepositories(db repositories.DBTX) Repositories {
 	factory := NewRepositoryFactory(db, uow.logger)
 
 	return Repositories{
-		Items:          factory.Items(),
-		PriceHistories: factory.PriceHistories(),
-		Tags:           factory.Tags(),
-		TagToItems:     factory.TagToItems(),
+		ApiKeys:         factory.ApiKeys(),
+		AuditEvents:     factory.AuditEvents(),
+		Households:      factory.Households(),
+		IdempotencyKeys: factory.IdempotencyKeys(),
+		Invitations:     factory.Invitations(),
+		Items:           factory.Items(),
+		PriceHistories:  factory.PriceHistories(),
+		Tags:            factory.Tags(),
+		TagToItems:      factory.TagToItems(),
+		Users:           factory.Users(),
+	}
+}
+
+// tenantFromContext returns the household resolved for the request, which is
+// the tenant of the items, tags, tag_to_item and price_history tables.
+func tenantFromContext(ctx context.Context) (uuid.UUID, bool) {
+	membership, ok := auth.MembershipFromContext(ctx)
+	if !ok || membership.HouseholdID == uuid.Nil {
+		return uuid.Nil, false
 	}
+
+	return membership.HouseholdID, true
+}
+
+// setTenant is SET LOCAL app.tenant_id in the form that takes a bind
+// parameter; the setting is dropped when the transaction ends.
+func setTenant(ctx context.Context, tx *sqlx.Tx, tenantID uuid.UUID) error {
+	query := tx.Rebind("SELECT set_config('app.tenant_id', ?, true)")
+	_, err := tx.ExecContext(ctx, query, tenantID.String())
+
+	return err
 }
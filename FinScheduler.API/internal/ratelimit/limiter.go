@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"finscheduler/internal/auth"
+	"finscheduler/internal/infra"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	limitHeader      = "RateLimit-Limit"
+	remainingHeader  = "RateLimit-Remaining"
+	resetHeader      = "RateLimit-Reset"
+	retryAfterHeader = "Retry-After"
+
+	// pruneInterval is how often idle buckets are forgotten.
+	pruneInterval = time.Minute
+)
+
+// Limiter applies the rules of infra.RateLimitConfig to route groups.
+type Limiter struct {
+	store             Store
+	enabled           bool
+	trustForwardedFor bool
+	defaultRule       Rule
+	rules             map[string]Rule
+	longestPeriod     time.Duration
+	logger            *slog.Logger
+	now               func() time.Time
+
+	pruneMu  sync.Mutex
+	prunedAt time.Time
+}
+
+func NewLimiter(cfg infra.RateLimitConfig, store Store, logger *slog.Logger) *Limiter {
+	limiter := &Limiter{
+		store:             store,
+		enabled:           cfg.Enabled,
+		trustForwardedFor: cfg.TrustForwardedFor,
+		defaultRule:       Rule(cfg.Default),
+		rules:             make(map[string]Rule, len(cfg.Groups)),
+		longestPeriod:     cfg.Default.Period,
+		logger:            logger,
+		now:               time.Now,
+	}
+	for group, rule := range cfg.Groups {
+		limiter.rules[strings.ToLower(group)] = Rule(rule)
+		limiter.longestPeriod = max(limiter.longestPeriod, rule.Period)
+	}
+
+	return limiter
+}
+
+// Middleware limits the requests of each client to the routes of group. A
+// client is the API key or the user of the request, or its address when the
+// route is anonymous. Every response carries the RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers of the client's bucket;
+// refused requests get 429 with Retry-After. When the store fails the request
+// is let through, since a limiter outage should not take the API down.
+func (limiter *Limiter) Middleware(group string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !limiter.enabled {
+			return next
+		}
+
+		rule := limiter.rule(group)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			now := limiter.now()
+			limiter.prune(r, now)
+
+			result, err := limiter.store.Take(ctx, group+":"+limiter.client(r), rule, now)
+			if err != nil {
+				limiter.logger.ErrorContext(ctx, "Rate limit store failed", "group", group, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(limitHeader, strconv.Itoa(rule.Limit))
+			w.Header().Set(remainingHeader, strconv.Itoa(result.Remaining))
+			w.Header().Set(resetHeader, strconv.Itoa(ceilSeconds(result.Reset)))
+			if !result.Allowed {
+				limiter.logger.WarnContext(ctx, "Rate limit exceeded", "group", group, "method", r.Method, "path", r.URL.Path)
+				w.Header().Set(retryAfterHeader, strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (limiter *Limiter) rule(group string) Rule {
+	if rule, ok := limiter.rules[strings.ToLower(group)]; ok {
+		return rule
+	}
+
+	return limiter.defaultRule
+}
+
+// client identifies the caller: an API key has its own bucket, separate from
+// the interactive sessions of its user.
+func (limiter *Limiter) client(r *http.Request) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		if principal.ViaAPIKey() {
+			return "api-key:" + principal.APIKeyID.String()
+		}
+
+		return "user:" + principal.UserID.String()
+	}
+
+	return "ip:" + limiter.clientAddress(r)
+}
+
+// clientAddress is the address of the caller. Behind a trusted proxy it is the
+// last X-Forwarded-For entry, the one the proxy appended; the entries before
+// it come from the client and could be anything. A last entry that is not an
+// IP address falls back to the address of the connection.
+func (limiter *Limiter) clientAddress(r *http.Request) string {
+	if limiter.trustForwardedFor {
+		forwardedFor := r.Header.Values("X-Forwarded-For")
+		if len(forwardedFor) > 0 {
+			entries := strings.Split(forwardedFor[len(forwardedFor)-1], ",")
+			if address := net.ParseIP(strings.TrimSpace(entries[len(entries)-1])); address != nil {
+				return address.String()
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// prune forgets idle buckets at most once per pruneInterval. A bucket idle
+// for the longest period of any rule is full whatever its rule.
+func (limiter *Limiter) prune(r *http.Request, now time.Time) {
+	limiter.pruneMu.Lock()
+	due := now.Sub(limiter.prunedAt) >= pruneInterval
+	if due {
+		limiter.prunedAt = now
+	}
+	limiter.pruneMu.Unlock()
+
+	if !due {
+		return
+	}
+	if err := limiter.store.Prune(r.Context(), now.Add(-limiter.longestPeriod)); err != nil {
+		limiter.logger.ErrorContext(r.Context(), "Rate limit pruning failed", "error", err)
+	}
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/infra"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Rule, time.Time) (Result, error) {
+	return Result{}, errors.New("store is down")
+}
+
+func (failingStore) Prune(context.Context, time.Time) error {
+	return nil
+}
+
+func newTestLimiter(cfg infra.RateLimitConfig, store Store) *Limiter {
+	limiter := NewLimiter(cfg, store, slog.Default())
+	limiter.now = func() time.Time { return testNow }
+
+	return limiter
+}
+
+func testRateLimitConfig() infra.RateLimitConfig {
+	return infra.RateLimitConfig{
+		Enabled: true,
+		Default: infra.RateLimitRule{Limit: 2, Period: time.Minute},
+		Groups:  map[string]infra.RateLimitRule{"auth": {Limit: 1, Period: time.Minute}},
+	}
+}
+
+func serve(handler http.Handler, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+var noContent = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+})
+
+func TestLimiter_Middleware_ShouldAnswerTooManyRequestsOnceTheBucketIsEmpty(t *testing.T) {
+	// Arrange
+	handler := newTestLimiter(testRateLimitConfig(), NewMemoryStore()).Middleware("items")(noContent)
+
+	// Act
+	first := serve(handler, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+	second := serve(handler, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+	third := serve(handler, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", first.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, http.StatusNoContent, second.Code)
+	assert.Equal(t, http.StatusTooManyRequests, third.Code)
+	assert.Equal(t, "0", third.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", third.Header().Get("Retry-After"))
+}
+
+func TestLimiter_Middleware_ShouldUseTheRuleOfTheGroup(t *testing.T) {
+	// Arrange
+	limiter := newTestLimiter(testRateLimitConfig(), NewMemoryStore())
+	handler := limiter.Middleware("auth")(noContent)
+
+	// Act
+	first := serve(handler, httptest.NewRequest(http.MethodPost, "/api/auth/login", nil))
+	second := serve(handler, httptest.NewRequest(http.MethodPost, "/api/auth/login", nil))
+
+	// Assert
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+}
+
+func TestLimiter_Middleware_ShouldKeepGroupsApart(t *testing.T) {
+	// Arrange
+	limiter := newTestLimiter(testRateLimitConfig(), NewMemoryStore())
+	_ = serve(limiter.Middleware("auth")(noContent), httptest.NewRequest(http.MethodPost, "/api/auth/login", nil))
+
+	// Act
+	recorder := serve(limiter.Middleware("items")(noContent), httptest.NewRequest(http.MethodGet, "/api/items", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestLimiter_Client(t *testing.T) {
+	userID := uuid.New()
+	apiKeyID := uuid.New()
+
+	tests := []struct {
+		name              string
+		principal         *auth.Principal
+		remoteAddr        string
+		forwardedFor      string
+		trustForwardedFor bool
+		want              string
+	}{
+		{name: "user", principal: &auth.Principal{UserID: userID}, want: "user:" + userID.String()},
+		{name: "api key", principal: &auth.Principal{UserID: userID, APIKeyID: apiKeyID}, want: "api-key:" + apiKeyID.String()},
+		{name: "anonymous", remoteAddr: "192.0.2.1:1234", want: "ip:192.0.2.1"},
+		{name: "untrusted forwarded for", remoteAddr: "192.0.2.1:1234", forwardedFor: "198.51.100.7", want: "ip:192.0.2.1"},
+		{name: "trusted forwarded for", remoteAddr: "10.0.0.5:1234", forwardedFor: "198.51.100.7", trustForwardedFor: true, want: "ip:198.51.100.7"},
+		{name: "trusted forwarded for ignores spoofed entries", remoteAddr: "10.0.0.5:1234", forwardedFor: "203.0.113.99, 198.51.100.7", trustForwardedFor: true, want: "ip:198.51.100.7"},
+		{name: "trusted forwarded for that is not an address", remoteAddr: "192.0.2.1:1234", forwardedFor: "198.51.100.7, not-an-ip", trustForwardedFor: true, want: "ip:192.0.2.1"},
+		{name: "trusted but missing forwarded for", remoteAddr: "192.0.2.1:1234", trustForwardedFor: true, want: "ip:192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := testRateLimitConfig()
+			cfg.TrustForwardedFor = tt.trustForwardedFor
+			limiter := newTestLimiter(cfg, NewMemoryStore())
+			request := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+			request.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				request.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			if tt.principal != nil {
+				request = request.WithContext(auth.WithPrincipal(request.Context(), *tt.principal))
+			}
+
+			// Act
+			got := limiter.client(request)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLimiter_Middleware_ShouldKeepBucketWhenLeftmostForwardedForChanges(t *testing.T) {
+	// Arrange
+	cfg := testRateLimitConfig()
+	cfg.TrustForwardedFor = true
+	limiter := newTestLimiter(cfg, NewMemoryStore())
+	handler := limiter.Middleware("auth")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	send := func(spoofed string) int {
+		request := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+		request.RemoteAddr = "10.0.0.5:1234"
+		request.Header.Set("X-Forwarded-For", spoofed+", 198.51.100.7")
+		return serve(handler, request).Code
+	}
+
+	// Act
+	first := send("203.0.113.1")
+	second := send("203.0.113.2")
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, first)
+	assert.Equal(t, http.StatusTooManyRequests, second)
+}
+
+func TestLimiter_Middleware_ShouldLetRequestsThrough(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   infra.RateLimitConfig
+		store Store
+	}{
+		{name: "disabled", cfg: infra.RateLimitConfig{Default: infra.RateLimitRule{Limit: 1, Period: time.Minute}}, store: NewMemoryStore()},
+		{name: "store failure", cfg: testRateLimitConfig(), store: failingStore{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler := newTestLimiter(tt.cfg, tt.store).Middleware("items")(noContent)
+
+			// Act
+			first := serve(handler, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+			second := serve(handler, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+			third := serve(handler, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+
+			// Assert
+			assert.Equal(t, http.StatusNoContent, first.Code)
+			assert.Equal(t, http.StatusNoContent, second.Code)
+			assert.Equal(t, http.StatusNoContent, third.Code)
+			assert.Empty(t, third.Header().Get("RateLimit-Limit"))
+		})
+	}
+}
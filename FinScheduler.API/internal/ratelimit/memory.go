@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryStore keeps the buckets in the process. Every replica counts on its
+// own, so it only enforces the configured limits with a single replica.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (store *MemoryStore) Take(_ context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	current, ok := store.buckets[key]
+	if !ok {
+		current = &bucket{tokens: float64(rule.Limit), updatedAt: now}
+		store.buckets[key] = current
+	}
+
+	current.tokens = refill(current.tokens, current.updatedAt, rule, now)
+	current.updatedAt = now
+
+	allowed := current.tokens >= 1
+	if allowed {
+		current.tokens--
+	}
+
+	return newResult(allowed, current.tokens, rule), nil
+}
+
+func (store *MemoryStore) Prune(_ context.Context, idleSince time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for key, current := range store.buckets {
+		if current.updatedAt.Before(idleSince) {
+			delete(store.buckets, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func TestMemoryStore_Take_ShouldRefuseOnceTheBucketIsEmpty(t *testing.T) {
+	// Arrange
+	store := NewMemoryStore()
+	rule := Rule{Limit: 2, Period: time.Minute}
+
+	// Act
+	first, firstErr := store.Take(context.Background(), "client", rule, testNow)
+	second, secondErr := store.Take(context.Background(), "client", rule, testNow)
+	third, thirdErr := store.Take(context.Background(), "client", rule, testNow)
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, thirdErr)
+	assert.Equal(t, Result{Allowed: true, Remaining: 1, Reset: 30 * time.Second}, first)
+	assert.Equal(t, Result{Allowed: true, Remaining: 0, Reset: time.Minute}, second)
+	assert.Equal(t, Result{Allowed: false, Remaining: 0, Reset: time.Minute, RetryAfter: 30 * time.Second}, third)
+}
+
+func TestMemoryStore_Take_ShouldRefillOverThePeriod(t *testing.T) {
+	tests := []struct {
+		name          string
+		elapsed       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{name: "not a whole token yet", elapsed: 29 * time.Second, wantAllowed: false},
+		{name: "one token", elapsed: 30 * time.Second, wantAllowed: true, wantRemaining: 0},
+		{name: "never above the limit", elapsed: time.Hour, wantAllowed: true, wantRemaining: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			store := NewMemoryStore()
+			rule := Rule{Limit: 2, Period: time.Minute}
+			_, _ = store.Take(context.Background(), "client", rule, testNow)
+			_, _ = store.Take(context.Background(), "client", rule, testNow)
+
+			// Act
+			result, err := store.Take(context.Background(), "client", rule, testNow.Add(tt.elapsed))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			assert.Equal(t, tt.wantRemaining, result.Remaining)
+		})
+	}
+}
+
+func TestMemoryStore_Take_ShouldKeepClientsApart(t *testing.T) {
+	// Arrange
+	store := NewMemoryStore()
+	rule := Rule{Limit: 1, Period: time.Minute}
+	_, _ = store.Take(context.Background(), "first", rule, testNow)
+
+	// Act
+	result, err := store.Take(context.Background(), "second", rule, testNow)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryStore_Prune_ShouldForgetIdleBuckets(t *testing.T) {
+	// Arrange
+	store := NewMemoryStore()
+	rule := Rule{Limit: 1, Period: time.Minute}
+	_, _ = store.Take(context.Background(), "idle", rule, testNow)
+	_, _ = store.Take(context.Background(), "busy", rule, testNow.Add(time.Minute))
+
+	// Act
+	err := store.Prune(context.Background(), testNow.Add(30*time.Second))
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, store.buckets, "idle")
+	assert.Contains(t, store.buckets, "busy")
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"finscheduler/internal/metrics"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	databaseDriver        = "postgresql"
+	rateLimitBucketsTable = "rate_limit_buckets"
+)
+
+// refilledTokens is refill in SQL. In ON CONFLICT DO UPDATE, b is the stored
+// bucket and EXCLUDED the proposed row, whose tokens are Limit - 1 and whose
+// updated_at is now.
+const refilledTokens = `LEAST(EXCLUDED.tokens + 1, b.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - b.updated_at)::float8, 0) * ?)`
+
+// PostgresStore keeps the buckets in the rate_limit_buckets table so that
+// every replica draws from the same bucket.
+type PostgresStore struct {
+	db *sqlx.DB
+}
+
+func NewPostgresStore(db *sqlx.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take refills and takes from the bucket in one statement. The row lock of
+// the upsert serialises concurrent requests of a client, and every
+// expression of SET sees the bucket as it was before the request.
+func (store *PostgresStore) Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	query := `INSERT INTO public.rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+			  VALUES (?, ?, true, ?)
+			  ON CONFLICT (key) DO UPDATE
+			  SET tokens = CASE WHEN ` + refilledTokens + ` >= 1 THEN ` + refilledTokens + ` - 1 ELSE ` + refilledTokens + ` END,
+			      allowed = ` + refilledTokens + ` >= 1,
+			      updated_at = EXCLUDED.updated_at
+			  RETURNING tokens, allowed`
+	query = store.db.Rebind(query)
+
+	rate := rule.rate()
+	var bucket struct {
+		Tokens  float64 `db:"tokens"`
+		Allowed bool    `db:"allowed"`
+	}
+
+	start := time.Now()
+	err := store.db.GetContext(ctx, &bucket, query, key, float64(rule.Limit-1), now.UTC(), rate, rate, rate, rate)
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, rateLimitBucketsTable, err == nil, metrics.DatabaseOperationInsert)
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, rateLimitBucketsTable, err == nil, metrics.DatabaseOperationInsert)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(bucket.Allowed, bucket.Tokens, rule), nil
+}
+
+func (store *PostgresStore) Prune(ctx context.Context, idleSince time.Time) error {
+	query := store.db.Rebind("DELETE FROM public.rate_limit_buckets WHERE updated_at < ?")
+
+	start := time.Now()
+	_, err := store.db.ExecContext(ctx, query, idleSince.UTC())
+	metrics.RecordDatabaseDuration(ctx, start, databaseDriver, rateLimitBucketsTable, err == nil, metrics.DatabaseOperationDelete)
+	metrics.RecordDatabaseRequest(ctx, databaseDriver, rateLimitBucketsTable, err == nil, metrics.DatabaseOperationDelete)
+
+	return err
+}
@@ -0,0 +1,83 @@
+// Package ratelimit throttles clients with token buckets. Each client gets a
+// bucket per route group that holds up to Rule.Limit tokens and refills
+// evenly over Rule.Period; a request takes one token and is refused with 429
+// when none is left.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Rule is the size and refill period of a bucket.
+type Rule struct {
+	Limit  int
+	Period time.Duration
+}
+
+// rate is the number of tokens added per second.
+func (rule Rule) rate() float64 {
+	return float64(rule.Limit) / rule.Period.Seconds()
+}
+
+// Result is the state of a bucket after a request tried to take a token.
+type Result struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left.
+	Remaining int
+	// Reset is how long the bucket takes to fill up again.
+	Reset time.Duration
+	// RetryAfter is how long a refused client has to wait for a token.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. Take must refill and take from the bucket of key
+// atomically, since concurrent requests of a client race for its tokens.
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+	// Prune forgets buckets untouched since before idleSince. Such buckets
+	// are full, so forgetting them does not change any limit.
+	Prune(ctx context.Context, idleSince time.Time) error
+}
+
+// NewStore returns the store named by infra.RateLimitConfig.Store.
+func NewStore(name string, db *sqlx.DB) (Store, error) {
+	switch name {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "postgres":
+		return NewPostgresStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", name)
+	}
+}
+
+// refill returns the tokens of a bucket that held tokens at updatedAt.
+func refill(tokens float64, updatedAt time.Time, rule Rule, now time.Time) float64 {
+	elapsed := max(now.Sub(updatedAt).Seconds(), 0)
+
+	return math.Min(float64(rule.Limit), tokens+elapsed*rule.rate())
+}
+
+// newResult describes a bucket left with tokens after a request that was
+// allowed or not.
+func newResult(allowed bool, tokens float64, rule Rule) Result {
+	result := Result{
+		Allowed:   allowed,
+		Remaining: max(int(math.Floor(tokens)), 0),
+		Reset:     secondsToDuration((float64(rule.Limit) - tokens) / rule.rate()),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rule.rate())
+	}
+
+	return result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(max(seconds, 0) * float64(time.Second))
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
-const PostgresForeignKeyViolationCode = "23503"
+const (
+	PostgresForeignKeyViolationCode = "23503"
+	PostgresUniqueViolationCode     = "23505"
+)
 
 type PostgresErrorDetails struct {
 	Code           string
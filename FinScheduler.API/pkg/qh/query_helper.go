@@ -35,6 +35,20 @@ func ParseUUIDs(queryParams url.Values, key string) ([]*uuid.UUID, error) {
 	return res, nil
 }
 
+func ParseUUID(queryParams url.Values, key string) (*uuid.UUID, error) {
+	param, exists := parseSingleParam(queryParams, key)
+	if !exists {
+		return nil, nil
+	}
+
+	res, err := uuid.Parse(param)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query parameter %q value %q: %w", key, param, err)
+	}
+
+	return &res, nil
+}
+
 func ParseString(queryParams url.Values, key string) *string {
 	param := queryParams.Get(key)
 	if param == "" {
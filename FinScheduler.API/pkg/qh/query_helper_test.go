@@ -78,6 +78,52 @@ func TestParseUUIDs_ShouldReturnErrorWhenValueIsInvalid(t *testing.T) {
 	assert.Contains(t, err.Error(), `invalid query parameter "ids" value "broken-uuid"`)
 }
 
+func TestParseUUID_ShouldReturnNilWhenValueIsMissing(t *testing.T) {
+	// Arrange
+	queryParams := url.Values{}
+	key := "entityId"
+
+	// Act
+	result, err := qh.ParseUUID(queryParams, key)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestParseUUID_ShouldReturnParsedUUIDWhenValueIsValid(t *testing.T) {
+	// Arrange
+	key := "entityId"
+	expectedValue := uuid.New()
+	queryParams := url.Values{
+		key: []string{expectedValue.String()},
+	}
+
+	// Act
+	result, err := qh.ParseUUID(queryParams, key)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, expectedValue, *result)
+}
+
+func TestParseUUID_ShouldReturnErrorWhenValueIsInvalid(t *testing.T) {
+	// Arrange
+	key := "entityId"
+	queryParams := url.Values{
+		key: []string{"not-uuid"},
+	}
+
+	// Act
+	result, err := qh.ParseUUID(queryParams, key)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), `invalid query parameter "entityId" value "not-uuid"`)
+}
+
 func TestParseString_ShouldReturnNilWhenValueIsMissing(t *testing.T) {
 	// Arrange
 	queryParams := url.Values{}
@@ -0,0 +1,191 @@
+//go:build integration
+// +build integration
+
+package featurehttp_test
+
+import (
+	"encoding/json"
+	"finscheduler/internal/features/domains"
+	"finscheduler/tests/internal/testsupport"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveWithKey sends a request authenticated with an API key.
+func (app *authTestApplication) serveWithKey(key string, method string, target string, body string) *httptest.ResponseRecorder {
+	request := newJSONRequest(method, target, body)
+	request.Header.Set("Authorization", "ApiKey "+key)
+
+	return app.serve(request)
+}
+
+func createApiKey(t *testing.T, app *authTestApplication, accessToken string, body string) domains.ApiKeyCreatedDto {
+	t.Helper()
+
+	recorder := app.serveAs(accessToken, http.MethodPost, "/api/v1/api-keys", body)
+	require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	var created domains.ApiKeyCreatedDto
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&created))
+
+	return created
+}
+
+func Test_ApiKeysHandler_Create_ShouldReturnTheKeyOnce(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	accessToken := app.registerAndLogin(t, testUserEmail)
+
+	// Act
+	created := createApiKey(t, app, accessToken, `{"name":"cashback","scopes":["items:read","items:write"]}`)
+	listing := app.serveAs(accessToken, http.MethodGet, "/api/v1/api-keys", "")
+
+	// Assert
+	assert.Regexp(t, `^fsk_`, created.Key)
+	assert.Equal(t, created.Key[:len(created.Prefix)], created.Prefix)
+	assert.Equal(t, http.StatusOK, listing.Code)
+	assert.NotContains(t, listing.Body.String(), created.Key)
+	assert.Contains(t, listing.Body.String(), created.Prefix)
+
+	var storedHash string
+	require.NoError(t, testDB.Get(&storedHash, "SELECT key_hash FROM api_keys WHERE id = $1", created.Id))
+	assert.NotEqual(t, created.Key, storedHash)
+}
+
+func Test_ApiKeysHandler_Create_ShouldRejectDuplicateNames(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	accessToken := app.registerAndLogin(t, testUserEmail)
+	createApiKey(t, app, accessToken, `{"name":"cashback","scopes":["items:read"]}`)
+
+	// Act
+	recorder := app.serveAs(accessToken, http.MethodPost, "/api/v1/api-keys", `{"name":"cashback","scopes":["tags:write"]}`)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func Test_Routes_ShouldAuthorizeApiKeysByScope(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	accessToken := app.registerAndLogin(t, testUserEmail)
+	readOnly := createApiKey(t, app, accessToken, `{"name":"reporting","scopes":["items:read"]}`)
+	writer := createApiKey(t, app, accessToken, `{"name":"cashback","scopes":["items:write"]}`)
+	cashback := fmt.Sprintf(`{"cashback":5,"tagId":%q}`, "0190a8e4-0000-7000-8000-0000000000ff")
+
+	// Act
+	readItems := app.serveWithKey(readOnly.Key, http.MethodGet, "/api/v1/items?page=0&pageSize=20", "")
+	readTags := app.serveWithKey(readOnly.Key, http.MethodGet, "/api/v1/tags?page=0&pageSize=20", "")
+	readOnlyCashback := app.serveWithKey(readOnly.Key, http.MethodPatch, "/api/v1/items/cashback/tag", cashback)
+	writerCashback := app.serveWithKey(writer.Key, http.MethodPatch, "/api/v1/items/cashback/tag", cashback)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, readItems.Code)
+	assert.Equal(t, http.StatusForbidden, readTags.Code)
+	assert.Equal(t, http.StatusForbidden, readOnlyCashback.Code)
+	assert.NotEqual(t, http.StatusForbidden, writerCashback.Code)
+	assert.NotEqual(t, http.StatusUnauthorized, writerCashback.Code)
+}
+
+func Test_Routes_ShouldRecordApiKeyUse(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	accessToken := app.registerAndLogin(t, testUserEmail)
+	created := createApiKey(t, app, accessToken, `{"name":"reporting","scopes":["items:read"]}`)
+	require.Nil(t, created.LastUsedAt)
+
+	// Act
+	recorder := app.serveWithKey(created.Key, http.MethodGet, "/api/v1/items?page=0&pageSize=20", "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var keys []domains.ApiKeyDto
+	require.NoError(t, json.NewDecoder(app.serveAs(accessToken, http.MethodGet, "/api/v1/api-keys", "").Body).Decode(&keys))
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+}
+
+func Test_Routes_ShouldRejectUnusableApiKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		revoke func(t *testing.T, app *authTestApplication, accessToken string, key domains.ApiKeyCreatedDto)
+	}{
+		{
+			name: "expired",
+			revoke: func(t *testing.T, _ *authTestApplication, _ string, key domains.ApiKeyCreatedDto) {
+				_, err := testDB.Exec("UPDATE api_keys SET expires_at = now() - interval '1 minute' WHERE id = $1", key.Id)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "deleted",
+			revoke: func(t *testing.T, app *authTestApplication, accessToken string, key domains.ApiKeyCreatedDto) {
+				recorder := app.serveAs(accessToken, http.MethodDelete, "/api/v1/api-keys/"+key.Id.String(), "")
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Cleanup(func() {
+				testsupport.Truncate(t, testDB)
+			})
+
+			app := newAuthTestApplication(t)
+			accessToken := app.registerAndLogin(t, testUserEmail)
+			created := createApiKey(t, app, accessToken, `{"name":"reporting","scopes":["items:read"]}`)
+			tt.revoke(t, app, accessToken, created)
+
+			// Act
+			recorder := app.serveWithKey(created.Key, http.MethodGet, "/api/v1/items?page=0&pageSize=20", "")
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+			assert.Contains(t, recorder.Header().Values("WWW-Authenticate"), `ApiKey realm="finscheduler", error="invalid_token"`)
+		})
+	}
+}
+
+func Test_Routes_ShouldNotLetApiKeysManageKeysOrHouseholdsOrReadAudit(t *testing.T) {
+	for _, target := range []string{"/api/v1/api-keys", "/api/v1/households", "/api/v1/audit?page=0&pageSize=20"} {
+		t.Run(target, func(t *testing.T) {
+			// Arrange
+			t.Cleanup(func() {
+				testsupport.Truncate(t, testDB)
+			})
+
+			app := newAuthTestApplication(t)
+			accessToken := app.registerAndLogin(t, testUserEmail)
+			created := createApiKey(t, app, accessToken, `{"name":"everything","scopes":["items:read","items:write","tags:read","tags:write"]}`)
+
+			// Act
+			recorder := app.serveWithKey(created.Key, http.MethodGet, target, "")
+
+			// Assert
+			assert.Equal(t, http.StatusForbidden, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+//go:build integration
+// +build integration
+
+package featurehttp_test
+
+import (
+	"encoding/json"
+	"finscheduler/internal/features/domains"
+	"finscheduler/tests/internal/testsupport"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AuditHandler_GetListing_ShouldReturnPaginatedEvents(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	tagID, createErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Groceries", IsActive: true})
+	request := newJSONRequest(http.MethodGet, "/api/audit?entityType=tag&entityId="+tagID.String()+"&page=0&pageSize=20", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	var actualResponse domains.PaginatedList[domains.AuditEventDto]
+	decodeErr := json.NewDecoder(response.Body).Decode(&actualResponse)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, decodeErr)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	require.Len(t, actualResponse.Data, 1)
+	assert.Equal(t, int64(1), actualResponse.Count)
+	event := actualResponse.Data[0]
+	assert.Equal(t, domains.AuditOperationCreate, event.Operation)
+	assert.Equal(t, tagID, event.EntityId)
+	assert.Equal(t, testsupport.OwnerID, event.ActorId)
+	require.NotNil(t, event.ActorEmail)
+	assert.JSONEq(t, `{"name":{"before":null,"after":"Groceries"},"isActive":{"before":null,"after":true}}`, string(event.Changes))
+}
+
+func Test_AuditHandler_GetListing_ShouldReturnBadRequestOnUnknownEntityType(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	request := newJSONRequest(http.MethodGet, "/api/audit?entityType=household&page=0&pageSize=20", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Contains(t, recorder.Body.String(), "entityType must be one of")
+}
+
+func Test_Routes_ShouldRecordTheApiKeyOfAChange(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	accessToken := app.registerAndLogin(t, testUserEmail)
+	created := createApiKey(t, app, accessToken, `{"name":"importer","scopes":["items:write"]}`)
+
+	// Act
+	createItem := app.serveWithKey(created.Key, http.MethodPost, "/api/v1/items", `{"name":"Coffee","category":"FoodDrinks"}`)
+	listing := app.serveAs(accessToken, http.MethodGet, "/api/v1/audit?entityType=item&page=0&pageSize=20", "")
+
+	var events domains.PaginatedList[domains.AuditEventDto]
+	decodeErr := json.NewDecoder(listing.Body).Decode(&events)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, createItem.Code, createItem.Body.String())
+	require.Equal(t, http.StatusOK, listing.Code, listing.Body.String())
+	require.NoError(t, decodeErr)
+	require.Len(t, events.Data, 1)
+	require.NotNil(t, events.Data[0].ApiKeyId)
+	assert.Equal(t, created.Id, *events.Data[0].ApiKeyId)
+	require.NotNil(t, events.Data[0].ActorEmail)
+	assert.Equal(t, testUserEmail, *events.Data[0].ActorEmail)
+}
@@ -0,0 +1,191 @@
+//go:build integration
+// +build integration
+
+package featurehttp_test
+
+import (
+	"encoding/json"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	featurehttp "finscheduler/internal/features/http"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/infra"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserEmail    = "owner@example.com"
+	testUserPassword = "correct horse battery"
+)
+
+type authTestApplication struct {
+	router      http.Handler
+	authService *services.AuthService
+}
+
+func newAuthTestApplication(t *testing.T) *authTestApplication {
+	t.Helper()
+
+	tokens, err := auth.NewTokenIssuer(infra.AuthConfig{
+		Issuer:          "fin-scheduler-api",
+		SigningKey:      "0123456789abcdef0123456789abcdef",
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	authService := services.NewAuthService(uow, tokens, testLogger)
+	itemsHandler := featurehttp.NewItemsHandler(services.NewItemsService(uow, testLogger), testLogger)
+	tagsHandler := featurehttp.NewTagsHandler(services.NewTagsService(uow, testLogger), testLogger)
+	householdsHandler := featurehttp.NewHouseholdsHandler(services.NewHouseholdsService(uow, testLogger), testLogger)
+	apiKeysService := services.NewApiKeysService(uow, testLogger)
+	apiKeysHandler := featurehttp.NewApiKeysHandler(apiKeysService, testLogger)
+	auditHandler := featurehttp.NewAuditHandler(services.NewAuditService(uow, testLogger), testLogger)
+	authenticator := auth.NewAuthenticator(tokens, testLogger).AcceptAPIKeys(apiKeysService)
+	router := chi.NewRouter()
+
+	featurehttp.RegisterVersions(router, featurehttp.APIVersion{
+		Name: "v1",
+		Routes: func(r chi.Router) {
+			featurehttp.AuthRoutes(featurehttp.NewAuthHandler(authService, testLogger), featurehttp.NoRateLimit, featurehttp.NoValidation)(r)
+			featurehttp.Protected(authenticator.Middleware, featurehttp.V1Routes(itemsHandler, tagsHandler, householdsHandler, apiKeysHandler, auditHandler, featurehttp.NoRateLimit, featurehttp.NoValidation))(r)
+		},
+	})
+
+	return &authTestApplication{router: router, authService: authService}
+}
+
+func (app *authTestApplication) serve(request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (app *authTestApplication) login(t *testing.T, email string, password string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body := fmt.Sprintf(`{"email":%q,"password":%q}`, email, password)
+	return app.serve(newJSONRequest(http.MethodPost, "/api/auth/login", body))
+}
+
+func decodeTokens(t *testing.T, recorder *httptest.ResponseRecorder) domains.TokenDto {
+	t.Helper()
+
+	var tokens domains.TokenDto
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&tokens))
+
+	return tokens
+}
+
+func Test_AuthHandler_Login_ShouldIssueTokensThatUnlockTheApi(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	_, registerErr := app.authService.Register(testContext, &domains.UserCreate{Email: testUserEmail, Password: testUserPassword})
+	require.NoError(t, registerErr)
+
+	// Act
+	loginResponse := app.login(t, "Owner@Example.com", testUserPassword)
+	tokens := decodeTokens(t, loginResponse)
+
+	request := newJSONRequest(http.MethodGet, "/api/v1/tags?page=0&pageSize=20", "")
+	request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	tagsResponse := app.serve(request)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, loginResponse.Code)
+	assert.Equal(t, "no-store", loginResponse.Header().Get("Cache-Control"))
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, int64(900), tokens.ExpiresIn)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, http.StatusOK, tagsResponse.Code)
+}
+
+func Test_AuthHandler_Login_ShouldReturnUnauthorizedOnWrongCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		password string
+	}{
+		{name: "wrong password", email: testUserEmail, password: "incorrect horse battery"},
+		{name: "unknown email", email: "nobody@example.com", password: testUserPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Cleanup(func() {
+				testsupport.Truncate(t, testDB)
+			})
+
+			app := newAuthTestApplication(t)
+			_, registerErr := app.authService.Register(testContext, &domains.UserCreate{Email: testUserEmail, Password: testUserPassword})
+			require.NoError(t, registerErr)
+
+			// Act
+			recorder := app.login(t, tt.email, tt.password)
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), domains.ErrInvalidCredentials.Error())
+		})
+	}
+}
+
+func Test_AuthHandler_Refresh_ShouldRotateTokens(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	_, registerErr := app.authService.Register(testContext, &domains.UserCreate{Email: testUserEmail, Password: testUserPassword})
+	require.NoError(t, registerErr)
+	issued := decodeTokens(t, app.login(t, testUserEmail, testUserPassword))
+
+	// Act
+	refreshResponse := app.serve(newJSONRequest(http.MethodPost, "/api/auth/refresh", fmt.Sprintf(`{"refreshToken":%q}`, issued.RefreshToken)))
+	accessAsRefresh := app.serve(newJSONRequest(http.MethodPost, "/api/auth/refresh", fmt.Sprintf(`{"refreshToken":%q}`, issued.AccessToken)))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, refreshResponse.Code)
+	refreshed := decodeTokens(t, refreshResponse)
+	assert.NotEqual(t, issued.AccessToken, refreshed.AccessToken)
+	assert.Equal(t, http.StatusUnauthorized, accessAsRefresh.Code)
+}
+
+func Test_Routes_ShouldRequireBearerTokenForItemsAndTags(t *testing.T) {
+	for _, target := range []string{"/api/v1/items?page=0&pageSize=20", "/api/tags?page=0&pageSize=20"} {
+		t.Run(target, func(t *testing.T) {
+			// Arrange
+			app := newAuthTestApplication(t)
+			request := newJSONRequest(http.MethodGet, target, "")
+			request.Header.Set("Authorization", "Bearer not-a-token")
+
+			// Act
+			anonymous := app.serve(newJSONRequest(http.MethodGet, target, ""))
+			invalid := app.serve(request)
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, anonymous.Code)
+			assert.Equal(t, `Bearer realm="finscheduler"`, anonymous.Header().Get("WWW-Authenticate"))
+			assert.Equal(t, http.StatusUnauthorized, invalid.Code)
+			assert.Equal(t, `Bearer realm="finscheduler", error="invalid_token"`, invalid.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
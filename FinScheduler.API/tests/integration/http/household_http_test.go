@@ -0,0 +1,278 @@
+//go:build integration
+// +build integration
+
+package featurehttp_test
+
+import (
+	"encoding/json"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/tests/internal/testsupport"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const otherUserEmail = "other@example.com"
+
+// serveAs sends an unconditional request as the holder of accessToken.
+func (app *authTestApplication) serveAs(accessToken string, method string, target string, body string) *httptest.ResponseRecorder {
+	return app.serveIn(accessToken, uuid.Nil, method, target, body)
+}
+
+// serveIn is serveAs acting on householdID instead of the personal household.
+func (app *authTestApplication) serveIn(accessToken string, householdID uuid.UUID, method string, target string, body string) *httptest.ResponseRecorder {
+	request := newJSONRequest(method, target, body)
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+	request.Header.Set("If-Match", "*")
+	if householdID != uuid.Nil {
+		request.Header.Set("X-Household-Id", householdID.String())
+	}
+
+	return app.serve(request)
+}
+
+func (app *authTestApplication) personalHousehold(t *testing.T, accessToken string) uuid.UUID {
+	t.Helper()
+
+	recorder := app.serveAs(accessToken, http.MethodGet, "/api/v1/households", "")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var households []domains.HouseholdDto
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&households))
+	require.NotEmpty(t, households)
+	require.True(t, households[0].IsPersonal)
+
+	return households[0].Id
+}
+
+func (app *authTestApplication) invite(t *testing.T, accessToken string, householdID uuid.UUID, role auth.Role) domains.InvitationDto {
+	t.Helper()
+
+	target := fmt.Sprintf("/api/v1/households/%s/invitations", householdID)
+	recorder := app.serveAs(accessToken, http.MethodPost, target, fmt.Sprintf(`{"role":%q}`, role))
+	require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+	var invitation domains.InvitationDto
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&invitation))
+
+	return invitation
+}
+
+func (app *authTestApplication) registerAndLogin(t *testing.T, email string) string {
+	t.Helper()
+
+	_, err := app.authService.Register(testContext, &domains.UserCreate{Email: email, Password: testUserPassword})
+	require.NoError(t, err)
+
+	return decodeTokens(t, app.login(t, email, testUserPassword)).AccessToken
+}
+
+func decodeCreatedID(t *testing.T, recorder *httptest.ResponseRecorder) uuid.UUID {
+	t.Helper()
+
+	require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+	var id uuid.UUID
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&id))
+
+	return id
+}
+
+func Test_Items_ShouldOnlyBeVisibleInTheirHousehold(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	otherToken := app.registerAndLogin(t, otherUserEmail)
+	itemID := decodeCreatedID(t, app.serveAs(ownerToken, http.MethodPost, "/api/v1/items", `{"name":"Coffee","price":15.5,"category":"FoodDrinks"}`))
+	target := "/api/v1/items/" + itemID.String()
+
+	// Act
+	otherGet := app.serveAs(otherToken, http.MethodGet, target, "")
+	otherList := app.serveAs(otherToken, http.MethodGet, "/api/v1/items?page=0&pageSize=20", "")
+	otherPut := app.serveAs(otherToken, http.MethodPut, target, `{"name":"Tea","price":1,"category":"FoodDrinks"}`)
+	otherDelete := app.serveAs(otherToken, http.MethodDelete, target, "")
+	ownerGet := app.serveAs(ownerToken, http.MethodGet, target, "")
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, otherGet.Code)
+	assert.Equal(t, http.StatusOK, otherList.Code)
+	var list domains.PaginatedList[domains.ItemListingDto]
+	require.NoError(t, json.NewDecoder(otherList.Body).Decode(&list))
+	assert.Zero(t, list.Count)
+	assert.Empty(t, list.Data)
+	assert.Equal(t, http.StatusNotFound, otherPut.Code)
+	assert.Equal(t, http.StatusNotFound, otherDelete.Code)
+	assert.Equal(t, http.StatusOK, ownerGet.Code)
+	assert.Contains(t, ownerGet.Body.String(), `"name":"Coffee"`)
+}
+
+func Test_Tags_ShouldAllowTheSameNameInDifferentHouseholds(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	otherToken := app.registerAndLogin(t, otherUserEmail)
+	body := `{"name":"Groceries","isActive":true}`
+
+	// Act
+	ownerTagID := decodeCreatedID(t, app.serveAs(ownerToken, http.MethodPost, "/api/v1/tags", body))
+	otherTagID := decodeCreatedID(t, app.serveAs(otherToken, http.MethodPost, "/api/v1/tags", body))
+	otherGet := app.serveAs(otherToken, http.MethodGet, "/api/v1/tags/"+ownerTagID.String(), "")
+
+	// Assert
+	assert.NotEqual(t, ownerTagID, otherTagID)
+	assert.Equal(t, http.StatusNotFound, otherGet.Code)
+}
+
+func Test_Items_ShouldRejectTagsOfAnotherHousehold(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	otherToken := app.registerAndLogin(t, otherUserEmail)
+	ownerTagID := decodeCreatedID(t, app.serveAs(ownerToken, http.MethodPost, "/api/v1/tags", `{"name":"Groceries","isActive":true}`))
+	body := fmt.Sprintf(`{"name":"Coffee","price":15.5,"category":"FoodDrinks","tagIds":[%q]}`, ownerTagID)
+
+	// Act
+	recorder := app.serveAs(otherToken, http.MethodPost, "/api/v1/items", body)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), domains.ErrInvalidReference.Error())
+}
+
+func Test_Invitations_ShouldGrantTheInvitedRole(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	viewerToken := app.registerAndLogin(t, otherUserEmail)
+	householdID := app.personalHousehold(t, ownerToken)
+	itemID := decodeCreatedID(t, app.serveAs(ownerToken, http.MethodPost, "/api/v1/items", `{"name":"Coffee","price":15.5,"category":"FoodDrinks"}`))
+	invitation := app.invite(t, ownerToken, householdID, auth.RoleViewer)
+	target := "/api/v1/items/" + itemID.String()
+
+	// Act
+	accept := app.serveAs(viewerToken, http.MethodPost, invitation.Link, "")
+	viewerGet := app.serveIn(viewerToken, householdID, http.MethodGet, target, "")
+	viewerPost := app.serveIn(viewerToken, householdID, http.MethodPost, "/api/v1/items", `{"name":"Tea","price":1,"category":"FoodDrinks"}`)
+	viewerPut := app.serveIn(viewerToken, householdID, http.MethodPut, target, `{"name":"Tea","price":1,"category":"FoodDrinks"}`)
+	viewerDelete := app.serveIn(viewerToken, householdID, http.MethodDelete, target, "")
+	viewerInvite := app.serveAs(viewerToken, http.MethodPost, fmt.Sprintf("/api/v1/households/%s/invitations", householdID), `{"role":"admin"}`)
+
+	// Assert
+	assert.Equal(t, fmt.Sprintf("/api/v1/invitations/%s/accept", invitation.Token), invitation.Link)
+	require.Equal(t, http.StatusOK, accept.Code, accept.Body.String())
+	var joined domains.HouseholdDto
+	require.NoError(t, json.NewDecoder(accept.Body).Decode(&joined))
+	assert.Equal(t, householdID, joined.Id)
+	assert.Equal(t, auth.RoleViewer, joined.Role)
+	assert.Equal(t, http.StatusOK, viewerGet.Code)
+	assert.Contains(t, viewerGet.Body.String(), `"name":"Coffee"`)
+	assert.Equal(t, http.StatusForbidden, viewerPost.Code)
+	assert.Equal(t, http.StatusForbidden, viewerPut.Code)
+	assert.Equal(t, http.StatusForbidden, viewerDelete.Code)
+	assert.Equal(t, http.StatusForbidden, viewerInvite.Code)
+}
+
+func Test_Invitations_ShouldLetEditorsWriteToTheSharedHousehold(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	editorToken := app.registerAndLogin(t, otherUserEmail)
+	householdID := decodeCreatedID(t, app.serveAs(ownerToken, http.MethodPost, "/api/v1/households", `{"name":"Flat share"}`))
+	invitation := app.invite(t, ownerToken, householdID, auth.RoleEditor)
+	accept := app.serveAs(editorToken, http.MethodPost, invitation.Link, "")
+	require.Equal(t, http.StatusOK, accept.Code, accept.Body.String())
+
+	// Act
+	itemID := decodeCreatedID(t, app.serveIn(editorToken, householdID, http.MethodPost, "/api/v1/items", `{"name":"Rent","price":900,"category":"FoodDrinks"}`))
+	ownerGet := app.serveIn(ownerToken, householdID, http.MethodGet, "/api/v1/items/"+itemID.String(), "")
+	ownerPersonalGet := app.serveAs(ownerToken, http.MethodGet, "/api/v1/items/"+itemID.String(), "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, ownerGet.Code)
+	assert.Equal(t, http.StatusNotFound, ownerPersonalGet.Code)
+}
+
+func Test_Invitations_ShouldOnlyBeAcceptedOnce(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	otherToken := app.registerAndLogin(t, otherUserEmail)
+	invitation := app.invite(t, ownerToken, app.personalHousehold(t, ownerToken), auth.RoleEditor)
+
+	// Act
+	first := app.serveAs(otherToken, http.MethodPost, invitation.Link, "")
+	second := app.serveAs(otherToken, http.MethodPost, invitation.Link, "")
+	unknown := app.serveAs(otherToken, http.MethodPost, "/api/v1/invitations/unknown/accept", "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusGone, second.Code)
+	assert.Equal(t, http.StatusNotFound, unknown.Code)
+}
+
+func Test_Invitations_ShouldRejectExpiredTokens(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	otherToken := app.registerAndLogin(t, otherUserEmail)
+	invitation := app.invite(t, ownerToken, app.personalHousehold(t, ownerToken), auth.RoleViewer)
+	_, err := testDB.Exec("UPDATE invitations SET expires_at = now() - interval '1 minute'")
+	require.NoError(t, err)
+
+	// Act
+	recorder := app.serveAs(otherToken, http.MethodPost, invitation.Link, "")
+
+	// Assert
+	assert.Equal(t, http.StatusGone, recorder.Code)
+}
+
+func Test_Households_ShouldRejectNonMembers(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newAuthTestApplication(t)
+	ownerToken := app.registerAndLogin(t, testUserEmail)
+	otherToken := app.registerAndLogin(t, otherUserEmail)
+	householdID := app.personalHousehold(t, ownerToken)
+
+	// Act
+	list := app.serveIn(otherToken, householdID, http.MethodGet, "/api/v1/items?page=0&pageSize=20", "")
+	invite := app.serveAs(otherToken, http.MethodPost, fmt.Sprintf("/api/v1/households/%s/invitations", householdID), `{"role":"viewer"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, list.Code)
+	assert.Equal(t, http.StatusForbidden, invite.Code)
+}
@@ -9,6 +9,7 @@ import (
 	"finscheduler/tests/internal/testsupport"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -56,6 +57,84 @@ func Test_ItemsHandler_GetListingInfo_ShouldReturnPaginatedItems(t *testing.T) {
 	assert.Equal(t, expectedName, actualResponse.Data[0].Name)
 }
 
+func Test_ItemsHandler_GetListingInfo_ShouldReturnNotModifiedForMatchingETag(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	target := "/api/items?page=0&pageSize=20"
+	create := &domains.ItemCreate{
+		Name:     "Milk",
+		Price:    decimal.NewFromFloat(12.50),
+		Category: "FoodDrinks",
+	}
+
+	_, createErr := app.itemsService.Create(ctx, create)
+	firstRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(firstRecorder, newJSONRequest(http.MethodGet, target, ""))
+	etag := firstRecorder.Header().Get("ETag")
+	request := newJSONRequest(http.MethodGet, target, "")
+	request.Header.Set("If-None-Match", etag)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	require.NoError(t, createErr)
+	assert.Equal(t, http.StatusOK, firstRecorder.Code)
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.Equal(t, http.StatusNotModified, response.StatusCode)
+	assert.Equal(t, etag, response.Header.Get("ETag"))
+	assert.Empty(t, recorder.Body.String())
+}
+
+func Test_ItemsHandler_GetListingInfo_ShouldReturnFreshBodyAfterChange(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	target := "/api/items?page=0&pageSize=20"
+	create := &domains.ItemCreate{
+		Name:     "Milk",
+		Price:    decimal.NewFromFloat(12.50),
+		Category: "FoodDrinks",
+	}
+
+	_, createErr := app.itemsService.Create(ctx, create)
+	firstRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(firstRecorder, newJSONRequest(http.MethodGet, target, ""))
+	etag := firstRecorder.Header().Get("ETag")
+	_, secondCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Bread",
+		Price:    decimal.NewFromFloat(3.00),
+		Category: "FoodDrinks",
+	})
+	request := newJSONRequest(http.MethodGet, target, "")
+	request.Header.Set("If-None-Match", etag)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, secondCreateErr)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.NotEqual(t, etag, response.Header.Get("ETag"))
+	assert.Equal(t, "private, no-cache", response.Header.Get("Cache-Control"))
+}
+
 func Test_ItemsHandler_GetDetailedInfo_ShouldReturnItem(t *testing.T) {
 	// Arrange
 	t.Cleanup(func() {
@@ -70,7 +149,7 @@ func Test_ItemsHandler_GetDetailedInfo_ShouldReturnItem(t *testing.T) {
 	olderPriceHistoryValue := decimal.RequireFromString("11.00")
 	newerPriceHistoryDate := "2026-01-15"
 	newerPriceHistoryValue := decimal.RequireFromString("13.75")
-	insertHistoryQuery := `INSERT INTO price_history (id, item_id, recorded_at, value) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)`
+	insertHistoryQuery := `INSERT INTO price_history (id, item_id, recorded_at, value, household_id) VALUES ($1, $2, $3, $4, $9), ($5, $6, $7, $8, $9)`
 	create := &domains.ItemCreate{
 		Name:     expectedName,
 		Price:    decimal.NewFromFloat(12.50),
@@ -82,6 +161,7 @@ func Test_ItemsHandler_GetDetailedInfo_ShouldReturnItem(t *testing.T) {
 		insertHistoryQuery,
 		uuid.New(), itemID, olderPriceHistoryDate, olderPriceHistoryValue,
 		uuid.New(), itemID, newerPriceHistoryDate, newerPriceHistoryValue,
+		testsupport.HouseholdID,
 	)
 	target := "/api/items/" + itemID.String()
 	request := newJSONRequest(method, target, "")
@@ -100,22 +180,86 @@ func Test_ItemsHandler_GetDetailedInfo_ShouldReturnItem(t *testing.T) {
 	require.NoError(t, insertHistoryErr)
 	require.NoError(t, decodeErr)
 	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Regexp(t, `^"1-[0-9a-f]{16}"$`, response.Header.Get("ETag"))
+	assert.Equal(t, "Authorization, X-Household-Id", response.Header.Get("Vary"))
 	assert.Equal(t, expectedName, actualResponse.Name)
-	assert.Equal(t, 12.5, actualResponse.Price)
+	assert.True(t, decimal.RequireFromString("12.50").Equal(actualResponse.Price.Amount))
+	assert.Equal(t, domains.DefaultCurrency, actualResponse.Price.Currency)
 	assert.Equal(t, domains.ItemCategory("FoodDrinks"), actualResponse.Category)
 	require.Len(t, actualResponse.PriceHistory, 2)
 	assert.Equal(t, newerPriceHistoryDate, actualResponse.PriceHistory[0].Point.UTC().Format("2006-01-02"))
-	assert.True(t, newerPriceHistoryValue.Equal(actualResponse.PriceHistory[0].Value))
+	assert.True(t, newerPriceHistoryValue.Equal(actualResponse.PriceHistory[0].Value.Amount))
 	require.NotNil(t, actualResponse.PriceHistory[0].AbsoluteChange)
 	require.NotNil(t, actualResponse.PriceHistory[0].PercentChange)
-	assert.True(t, decimal.RequireFromString("2.75").Equal(*actualResponse.PriceHistory[0].AbsoluteChange))
+	assert.True(t, decimal.RequireFromString("2.75").Equal(actualResponse.PriceHistory[0].AbsoluteChange.Amount))
 	assert.True(t, decimal.RequireFromString("25").Equal(*actualResponse.PriceHistory[0].PercentChange))
 	assert.Equal(t, olderPriceHistoryDate, actualResponse.PriceHistory[1].Point.UTC().Format("2006-01-02"))
-	assert.True(t, olderPriceHistoryValue.Equal(actualResponse.PriceHistory[1].Value))
+	assert.True(t, olderPriceHistoryValue.Equal(actualResponse.PriceHistory[1].Value.Amount))
 	assert.Nil(t, actualResponse.PriceHistory[1].AbsoluteChange)
 	assert.Nil(t, actualResponse.PriceHistory[1].PercentChange)
 }
 
+func Test_ItemsHandler_GetListingInfo_ShouldWriteMoneyInRequestedFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedPrice string
+	}{
+		{name: "object by default", query: "", expectedPrice: `{"amount":"1234567.07","currency":"RUB"}`},
+		{name: "number on request", query: "&moneyFormat=number", expectedPrice: `1234567.07`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Cleanup(func() {
+				testsupport.Truncate(t, testDB)
+			})
+
+			app := newTestApplication()
+			create := &domains.ItemCreate{
+				Name:     "Laptop",
+				Price:    decimal.RequireFromString("1234567.07"),
+				Category: "Education",
+			}
+
+			_, createErr := app.itemsService.Create(testContext, create)
+			request := newJSONRequest(http.MethodGet, "/api/items?page=0&pageSize=20"+tt.query, "")
+
+			// Act
+			recorder := httptest.NewRecorder()
+			app.router.ServeHTTP(recorder, request)
+			response := recorder.Result()
+			defer response.Body.Close()
+
+			var actualResponse domains.PaginatedList[map[string]json.RawMessage]
+			decodeErr := json.NewDecoder(response.Body).Decode(&actualResponse)
+
+			// Assert
+			require.NoError(t, createErr)
+			require.NoError(t, decodeErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			require.Len(t, actualResponse.Data, 1)
+			assert.JSONEq(t, tt.expectedPrice, string(actualResponse.Data[0]["price"]))
+		})
+	}
+}
+
+func Test_ItemsHandler_GetDetailedInfo_ShouldReturnBadRequestOnUnknownMoneyFormat(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	target := "/api/items/" + uuid.New().String() + "?moneyFormat=float"
+	request := newJSONRequest(http.MethodGet, target, "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `invalid query parameter "moneyFormat"`)
+}
+
 func Test_ItemsHandler_GetDetailedInfo_ShouldReturnBadRequestOnInvalidID(t *testing.T) {
 	// Arrange
 	app := newTestApplication()
@@ -226,6 +370,90 @@ func Test_ItemsHandler_Create_ShouldReturnCreatedWithLocationAndBody(t *testing.
 	assert.Equal(t, locationPrefix+actualID.String(), actualLocation)
 }
 
+func Test_ItemsHandler_Create_ShouldReplayResponseForRepeatedIdempotencyKey(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	target := "/api/items"
+	idempotencyKey := "create-coffee-1"
+	countQuery := "SELECT COUNT(*) FROM items WHERE name = $1"
+	firstRequest := newJSONRequest(http.MethodPost, target, `{"name":"Coffee","price":15.5,"category":"FoodDrinks"}`)
+	firstRequest.Header.Set("Idempotency-Key", idempotencyKey)
+	retryRequest := newJSONRequest(http.MethodPost, target, `{ "category": "FoodDrinks", "price": 15.5, "name": "Coffee" }`)
+	retryRequest.Header.Set("Idempotency-Key", idempotencyKey)
+
+	// Act
+	firstRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(firstRecorder, firstRequest)
+	retryRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(retryRecorder, retryRequest)
+
+	var count int
+	countErr := testDB.Get(&count, countQuery, "Coffee")
+
+	// Assert
+	require.NoError(t, countErr)
+	assert.Equal(t, http.StatusCreated, firstRecorder.Code)
+	assert.Equal(t, http.StatusCreated, retryRecorder.Code)
+	assert.Equal(t, firstRecorder.Body.String(), retryRecorder.Body.String())
+	assert.Equal(t, firstRecorder.Header().Get("Location"), retryRecorder.Header().Get("Location"))
+	assert.Empty(t, firstRecorder.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, "true", retryRecorder.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, count)
+}
+
+func Test_ItemsHandler_Create_ShouldReturnUnprocessableEntityWhenIdempotencyKeyIsReused(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	target := "/api/items"
+	idempotencyKey := "create-coffee-1"
+	firstRequest := newJSONRequest(http.MethodPost, target, `{"name":"Coffee","price":15.5,"category":"FoodDrinks"}`)
+	firstRequest.Header.Set("Idempotency-Key", idempotencyKey)
+	reusedRequest := newJSONRequest(http.MethodPost, target, `{"name":"Tea","price":7,"category":"FoodDrinks"}`)
+	reusedRequest.Header.Set("Idempotency-Key", idempotencyKey)
+
+	// Act
+	firstRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(firstRecorder, firstRequest)
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, reusedRequest)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, firstRecorder.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+	assert.Contains(t, recorder.Body.String(), domains.ErrIdempotencyKeyReused.Error())
+}
+
+func Test_ItemsHandler_Create_ShouldReturnBadRequestOnUnknownField(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	method := http.MethodPost
+	target := "/api/items"
+	requestBody := `{"name":"Coffee","isactive":true,"category":"FoodDrinks"}`
+	expectedBodyFragment := `unknown field "isactive"`
+	request := newJSONRequest(method, target, requestBody)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	actualBody := recorder.Body.String()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Contains(t, actualBody, expectedBodyFragment)
+}
+
 func Test_ItemsHandler_Create_ShouldReturnBadRequestOnMalformedJSON(t *testing.T) {
 	// Arrange
 	app := newTestApplication()
@@ -313,6 +541,7 @@ func Test_ItemsHandler_Update_ShouldReturnNoContent(t *testing.T) {
 	target := "/api/items/" + itemID.String()
 	requestBody := `{"name":"` + updatedName + `","price":12.5,"category":"FoodDrinks"}`
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -334,6 +563,7 @@ func Test_ItemsHandler_Update_ShouldReturnBadRequestOnMalformedJSON(t *testing.T
 	requestBody := `{"name":`
 	expectedBodyFragment := "unexpected EOF"
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -369,6 +599,7 @@ func Test_ItemsHandler_Update_ShouldReturnBadRequestOnInvalidReference(t *testin
 	expectedBodyFragment := domains.ErrInvalidReference.Error()
 	requestBody := `{"name":"Coffee updated","price":12.5,"category":"FoodDrinks","tagIds":["` + invalidTagID.String() + `"]}`
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -392,6 +623,7 @@ func Test_ItemsHandler_Update_ShouldReturnInternalServerErrorOnServiceFailure(t
 	target := "/api/items/" + itemID.String()
 	requestBody := `{"name":"Coffee updated","price":12.5,"category":"FoodDrinks"}`
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -412,6 +644,7 @@ func Test_ItemsHandler_Update_ShouldReturnNotFoundForMissingItem(t *testing.T) {
 	expectedBodyFragment := "item not found"
 	requestBody := `{"name":"Missing","price":12.5,"category":"FoodDrinks"}`
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -425,7 +658,7 @@ func Test_ItemsHandler_Update_ShouldReturnNotFoundForMissingItem(t *testing.T) {
 	assert.Contains(t, actualBody, expectedBodyFragment)
 }
 
-func Test_ItemsHandler_UpdateCashbackByTag_ShouldReturnNoContentAndUpdateTaggedItems(t *testing.T) {
+func Test_ItemsHandler_Update_ShouldAcceptMatchingETagAndBumpVersion(t *testing.T) {
 	// Arrange
 	t.Cleanup(func() {
 		testsupport.Truncate(t, testDB)
@@ -433,56 +666,36 @@ func Test_ItemsHandler_UpdateCashbackByTag_ShouldReturnNoContentAndUpdateTaggedI
 
 	app := newTestApplication()
 	ctx := testContext
-	method := http.MethodPatch
-	tagCreate := &domains.TagCreate{Name: "Groceries"}
-
-	tagID, tagCreateErr := app.tagsService.Create(ctx, tagCreate)
-	firstTaggedID, firstTaggedCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
-		Name:     "Milk",
-		Category: "FoodDrinks",
-		TagIds:   []string{tagID.String()},
-	})
-	secondTaggedID, secondTaggedCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
-		Name:     "Bread",
+	create := &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
 		Category: "FoodDrinks",
-		TagIds:   []string{tagID.String()},
-	})
-	untouchedID, untouchedCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
-		Name:     "Taxi",
-		Category: "Transport",
-	})
-	target := "/api/items/cashback/tag"
-	requestBody := `{"cashback":7,"tagId":"` + tagID.String() + `"}`
-	request := newJSONRequest(method, target, requestBody)
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	target := "/api/items/" + itemID.String()
+	getRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(getRecorder, newJSONRequest(http.MethodGet, target, ""))
+	etag := getRecorder.Header().Get("ETag")
+	request := newJSONRequest(http.MethodPut, target, `{"name":"Latte","price":12.5,"category":"FoodDrinks"}`)
+	request.Header.Set("If-Match", etag)
 
 	// Act
 	recorder := httptest.NewRecorder()
 	app.router.ServeHTTP(recorder, request)
 	response := recorder.Result()
 	defer response.Body.Close()
-
-	firstTaggedItem, firstTaggedGetErr := app.itemsService.GetDetailedInfo(ctx, firstTaggedID)
-	secondTaggedItem, secondTaggedGetErr := app.itemsService.GetDetailedInfo(ctx, secondTaggedID)
-	untouchedItem, untouchedGetErr := app.itemsService.GetDetailedInfo(ctx, untouchedID)
+	item, getErr := app.itemsService.GetDetailedInfo(ctx, itemID)
 
 	// Assert
-	require.NoError(t, tagCreateErr)
-	require.NoError(t, firstTaggedCreateErr)
-	require.NoError(t, secondTaggedCreateErr)
-	require.NoError(t, untouchedCreateErr)
-	require.NoError(t, firstTaggedGetErr)
-	require.NoError(t, secondTaggedGetErr)
-	require.NoError(t, untouchedGetErr)
+	require.NoError(t, createErr)
+	require.NoError(t, getErr)
+	assert.Regexp(t, `^"1-[0-9a-f]{16}"$`, etag)
 	assert.Equal(t, http.StatusNoContent, response.StatusCode)
-	require.NotNil(t, firstTaggedItem)
-	require.NotNil(t, secondTaggedItem)
-	require.NotNil(t, untouchedItem)
-	assert.Equal(t, int32(7), firstTaggedItem.Cashback)
-	assert.Equal(t, int32(7), secondTaggedItem.Cashback)
-	assert.Equal(t, int32(0), untouchedItem.Cashback)
+	assert.Equal(t, int32(2), item.Version)
 }
 
-func Test_ItemsHandler_UpdateCashbackByItems_ShouldReturnNoContentAndUpdateSelectedItems(t *testing.T) {
+func Test_ItemsHandler_GetDetailedInfo_ShouldTagEachMoneyFormatDifferently(t *testing.T) {
 	// Arrange
 	t.Cleanup(func() {
 		testsupport.Truncate(t, testDB)
@@ -490,79 +703,91 @@ func Test_ItemsHandler_UpdateCashbackByItems_ShouldReturnNoContentAndUpdateSelec
 
 	app := newTestApplication()
 	ctx := testContext
-	method := http.MethodPatch
-
-	firstItemID, firstCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
-		Name:     "Milk",
+	create := &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
 		Category: "FoodDrinks",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	target := "/api/items/" + itemID.String()
+
+	// Act
+	objectRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(objectRecorder, newJSONRequest(http.MethodGet, target, ""))
+	numberRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(numberRecorder, newJSONRequest(http.MethodGet, target+"?moneyFormat=number", ""))
+	request := newMergePatchRequest(target, `{"isActive":false}`)
+	request.Header.Set("If-Match", numberRecorder.Header().Get("ETag"))
+	patchRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(patchRecorder, request)
+
+	// Assert
+	require.NoError(t, createErr)
+	assert.Equal(t, http.StatusOK, objectRecorder.Code)
+	assert.Equal(t, http.StatusOK, numberRecorder.Code)
+	assert.NotEqual(t, objectRecorder.Header().Get("ETag"), numberRecorder.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNoContent, patchRecorder.Code)
+}
+
+func Test_ItemsHandler_Update_ShouldReturnPreconditionFailedOnStaleETag(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
 	})
-	secondItemID, secondCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
-		Name:     "Taxi",
-		Category: "Transport",
-	})
-	thirdItemID, thirdCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
-		Name:     "Gym",
-		Category: "Sports",
-	})
-	target := "/api/items/cashback/items"
-	requestBody := `{"cashback":11,"itemIds":["` + firstItemID.String() + `","` + thirdItemID.String() + `"]}`
-	request := newJSONRequest(method, target, requestBody)
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+	}
+
+	concurrentName := "Espresso"
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	_, concurrentErr := app.itemsService.Patch(ctx, itemID, &domains.ItemPatch{Name: &concurrentName}, nil)
+	target := "/api/items/" + itemID.String()
+	request := newJSONRequest(http.MethodPut, target, `{"name":"Latte","price":12.5,"category":"FoodDrinks"}`)
+	request.Header.Set("If-Match", `"1"`)
 
 	// Act
 	recorder := httptest.NewRecorder()
 	app.router.ServeHTTP(recorder, request)
 	response := recorder.Result()
 	defer response.Body.Close()
-
-	firstItem, firstGetErr := app.itemsService.GetDetailedInfo(ctx, firstItemID)
-	secondItem, secondGetErr := app.itemsService.GetDetailedInfo(ctx, secondItemID)
-	thirdItem, thirdGetErr := app.itemsService.GetDetailedInfo(ctx, thirdItemID)
+	item, getErr := app.itemsService.GetDetailedInfo(ctx, itemID)
 
 	// Assert
-	require.NoError(t, firstCreateErr)
-	require.NoError(t, secondCreateErr)
-	require.NoError(t, thirdCreateErr)
-	require.NoError(t, firstGetErr)
-	require.NoError(t, secondGetErr)
-	require.NoError(t, thirdGetErr)
-	assert.Equal(t, http.StatusNoContent, response.StatusCode)
-	require.NotNil(t, firstItem)
-	require.NotNil(t, secondItem)
-	require.NotNil(t, thirdItem)
-	assert.Equal(t, int32(11), firstItem.Cashback)
-	assert.Equal(t, int32(0), secondItem.Cashback)
-	assert.Equal(t, int32(11), thirdItem.Cashback)
+	require.NoError(t, createErr)
+	require.NoError(t, concurrentErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusPreconditionFailed, response.StatusCode)
+	assert.Equal(t, concurrentName, item.Name)
 }
 
-func Test_ItemsHandler_UpdateCashbackByItems_ShouldReturnBadRequestOnInvalidPayload(t *testing.T) {
+func Test_ItemsHandler_Update_ShouldReturnPreconditionRequiredWithoutIfMatch(t *testing.T) {
 	// Arrange
 	app := newTestApplication()
-	method := http.MethodPatch
-	target := "/api/items/cashback/items"
-	expectedBodyFragment := "itemId is invalid: bad-uuid"
-	requestBody := `{"cashback":11,"itemIds":["bad-uuid"]}`
-	request := newJSONRequest(method, target, requestBody)
+	target := "/api/items/" + uuid.New().String()
+	request := newJSONRequest(http.MethodPut, target, `{"name":"Latte","price":12.5,"category":"FoodDrinks"}`)
 
 	// Act
 	recorder := httptest.NewRecorder()
 	app.router.ServeHTTP(recorder, request)
 	response := recorder.Result()
 	defer response.Body.Close()
-	actualBody := recorder.Body.String()
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-	assert.Contains(t, actualBody, expectedBodyFragment)
+	assert.Equal(t, http.StatusPreconditionRequired, response.StatusCode)
 }
 
-func Test_ItemsHandler_UpdateCashbackByTag_ShouldReturnInternalServerErrorOnServiceFailure(t *testing.T) {
+func Test_ItemsHandler_Delete_ShouldReturnPreconditionFailedOnWeakETag(t *testing.T) {
 	// Arrange
-	closedDB := newClosedDB(t)
-	app := newTestApplicationWithDB(closedDB)
-	method := http.MethodPatch
-	target := "/api/items/cashback/tag"
-	requestBody := `{"cashback":11,"tagId":"` + uuid.New().String() + `"}`
-	request := newJSONRequest(method, target, requestBody)
+	app := newTestApplication()
+	target := "/api/items/" + uuid.New().String()
+	request := newJSONRequest(http.MethodDelete, target, "")
+	request.Header.Set("If-Match", `W/"1"`)
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -571,10 +796,10 @@ func Test_ItemsHandler_UpdateCashbackByTag_ShouldReturnInternalServerErrorOnServ
 	defer response.Body.Close()
 
 	// Assert
-	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, http.StatusPreconditionFailed, response.StatusCode)
 }
 
-func Test_ItemsHandler_Delete_ShouldReturnNoContent(t *testing.T) {
+func Test_ItemsHandler_Patch_ShouldReturnNoContentAndUpdateOnlySuppliedFields(t *testing.T) {
 	// Arrange
 	t.Cleanup(func() {
 		testsupport.Truncate(t, testDB)
@@ -582,38 +807,361 @@ func Test_ItemsHandler_Delete_ShouldReturnNoContent(t *testing.T) {
 
 	app := newTestApplication()
 	ctx := testContext
-	method := http.MethodDelete
 	itemName := "Coffee"
 	create := &domains.ItemCreate{
 		Name:     itemName,
 		Price:    decimal.NewFromFloat(10.00),
+		IsActive: true,
 		Category: "FoodDrinks",
 	}
 
 	itemID, createErr := app.itemsService.Create(ctx, create)
 	target := "/api/items/" + itemID.String()
-	request := newJSONRequest(method, target, "")
+	request := newMergePatchRequest(target, `{"isActive":false}`)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
 	app.router.ServeHTTP(recorder, request)
 	response := recorder.Result()
 	defer response.Body.Close()
+	item, getErr := app.itemsService.GetDetailedInfo(ctx, itemID)
 
 	// Assert
 	require.NoError(t, createErr)
+	require.NoError(t, getErr)
 	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Equal(t, itemName, item.Name)
+	assert.False(t, item.IsActive)
 }
 
-func Test_ItemsHandler_Delete_ShouldReturnBadRequestOnInvalidID(t *testing.T) {
+func Test_ItemsHandler_Patch_ShouldClearDescriptionOnNull(t *testing.T) {
 	// Arrange
-	app := newTestApplication()
-	method := http.MethodDelete
-	target := "/api/items/bad-id"
-	expectedBodyFragment := "invalid UUID length"
-	request := newJSONRequest(method, target, "")
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
 
-	// Act
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.ItemCreate{
+		Name:        "Coffee",
+		Price:       decimal.NewFromFloat(10.00),
+		Description: "Morning drink",
+		IsActive:    true,
+		Category:    "FoodDrinks",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	target := "/api/items/" + itemID.String()
+	request := newMergePatchRequest(target, `{"description":null}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	item, getErr := app.itemsService.GetDetailedInfo(ctx, itemID)
+	var description *string
+	queryErr := testDB.Get(&description, "SELECT description FROM public.items WHERE id = $1", itemID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, getErr)
+	require.NoError(t, queryErr)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Empty(t, item.Description)
+	assert.Equal(t, "Coffee", item.Name)
+	assert.Nil(t, description)
+}
+
+func Test_ItemsHandler_Patch_ShouldReturnBadRequestOnNullRequiredMember(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		IsActive: true,
+		Category: "FoodDrinks",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	target := "/api/items/" + itemID.String()
+	request := newMergePatchRequest(target, `{"price":null}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	item, getErr := app.itemsService.GetDetailedInfo(ctx, itemID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.True(t, item.Price.Amount.Equal(decimal.NewFromFloat(10.00)))
+}
+
+func Test_ItemsHandler_Patch_ShouldReturnUnsupportedMediaTypeOnPlainJSON(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	target := "/api/items/" + uuid.NewString()
+	request := newJSONRequest(http.MethodPatch, target, `{"isActive":false}`)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusUnsupportedMediaType, response.StatusCode)
+}
+
+func Test_ItemsHandler_Patch_ShouldReturnBadRequestOnValidationError(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	target := "/api/items/" + uuid.NewString()
+	request := newMergePatchRequest(target, `{"cashback":-1}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func Test_ItemsHandler_Patch_ShouldReturnNotFoundForMissingItem(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	target := "/api/items/" + uuid.NewString()
+	request := newMergePatchRequest(target, `{"isActive":true}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func Test_ItemsHandler_UpdateCashbackByTag_ShouldReturnNoContentAndUpdateTaggedItems(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	method := http.MethodPatch
+	tagCreate := &domains.TagCreate{Name: "Groceries"}
+
+	tagID, tagCreateErr := app.tagsService.Create(ctx, tagCreate)
+	firstTaggedID, firstTaggedCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Milk",
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	})
+	secondTaggedID, secondTaggedCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Bread",
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	})
+	untouchedID, untouchedCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Taxi",
+		Category: "Transport",
+	})
+	target := "/api/items/cashback/tag"
+	requestBody := `{"cashback":7,"tagId":"` + tagID.String() + `"}`
+	request := newJSONRequest(method, target, requestBody)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	firstTaggedItem, firstTaggedGetErr := app.itemsService.GetDetailedInfo(ctx, firstTaggedID)
+	secondTaggedItem, secondTaggedGetErr := app.itemsService.GetDetailedInfo(ctx, secondTaggedID)
+	untouchedItem, untouchedGetErr := app.itemsService.GetDetailedInfo(ctx, untouchedID)
+
+	// Assert
+	require.NoError(t, tagCreateErr)
+	require.NoError(t, firstTaggedCreateErr)
+	require.NoError(t, secondTaggedCreateErr)
+	require.NoError(t, untouchedCreateErr)
+	require.NoError(t, firstTaggedGetErr)
+	require.NoError(t, secondTaggedGetErr)
+	require.NoError(t, untouchedGetErr)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	require.NotNil(t, firstTaggedItem)
+	require.NotNil(t, secondTaggedItem)
+	require.NotNil(t, untouchedItem)
+	assert.Equal(t, int32(7), firstTaggedItem.Cashback)
+	assert.Equal(t, int32(7), secondTaggedItem.Cashback)
+	assert.Equal(t, int32(0), untouchedItem.Cashback)
+}
+
+func Test_ItemsHandler_UpdateCashbackByItems_ShouldReturnNoContentAndUpdateSelectedItems(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	method := http.MethodPatch
+
+	firstItemID, firstCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Milk",
+		Category: "FoodDrinks",
+	})
+	secondItemID, secondCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Taxi",
+		Category: "Transport",
+	})
+	thirdItemID, thirdCreateErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Gym",
+		Category: "Sports",
+	})
+	target := "/api/items/cashback/items"
+	requestBody := `{"cashback":11,"itemIds":["` + firstItemID.String() + `","` + thirdItemID.String() + `"]}`
+	request := newJSONRequest(method, target, requestBody)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	firstItem, firstGetErr := app.itemsService.GetDetailedInfo(ctx, firstItemID)
+	secondItem, secondGetErr := app.itemsService.GetDetailedInfo(ctx, secondItemID)
+	thirdItem, thirdGetErr := app.itemsService.GetDetailedInfo(ctx, thirdItemID)
+
+	// Assert
+	require.NoError(t, firstCreateErr)
+	require.NoError(t, secondCreateErr)
+	require.NoError(t, thirdCreateErr)
+	require.NoError(t, firstGetErr)
+	require.NoError(t, secondGetErr)
+	require.NoError(t, thirdGetErr)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	require.NotNil(t, firstItem)
+	require.NotNil(t, secondItem)
+	require.NotNil(t, thirdItem)
+	assert.Equal(t, int32(11), firstItem.Cashback)
+	assert.Equal(t, int32(0), secondItem.Cashback)
+	assert.Equal(t, int32(11), thirdItem.Cashback)
+}
+
+func Test_ItemsHandler_UpdateCashbackByItems_ShouldReturnBadRequestOnInvalidPayload(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	method := http.MethodPatch
+	target := "/api/items/cashback/items"
+	expectedBodyFragment := "itemId is invalid: bad-uuid"
+	requestBody := `{"cashback":11,"itemIds":["bad-uuid"]}`
+	request := newJSONRequest(method, target, requestBody)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	actualBody := recorder.Body.String()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Contains(t, actualBody, expectedBodyFragment)
+}
+
+func Test_ItemsHandler_UpdateCashbackByTag_ShouldReturnInternalServerErrorOnServiceFailure(t *testing.T) {
+	// Arrange
+	closedDB := newClosedDB(t)
+	app := newTestApplicationWithDB(closedDB)
+	method := http.MethodPatch
+	target := "/api/items/cashback/tag"
+	requestBody := `{"cashback":11,"tagId":"` + uuid.New().String() + `"}`
+	request := newJSONRequest(method, target, requestBody)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+}
+
+func Test_ItemsHandler_Delete_ShouldReturnNoContent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	method := http.MethodDelete
+	itemName := "Coffee"
+	create := &domains.ItemCreate{
+		Name:     itemName,
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	target := "/api/items/" + itemID.String()
+	request := newJSONRequest(method, target, "")
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	require.NoError(t, createErr)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+}
+
+func Test_ItemsHandler_Delete_ShouldReturnBadRequestOnInvalidID(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	method := http.MethodDelete
+	target := "/api/items/bad-id"
+	expectedBodyFragment := "invalid UUID length"
+	request := newJSONRequest(method, target, "")
+	request.Header.Set("If-Match", "*")
+
+	// Act
 	recorder := httptest.NewRecorder()
 	app.router.ServeHTTP(recorder, request)
 	response := recorder.Result()
@@ -633,6 +1181,7 @@ func Test_ItemsHandler_Delete_ShouldReturnInternalServerErrorOnServiceFailure(t
 	itemID := uuid.New()
 	target := "/api/items/" + itemID.String()
 	request := newJSONRequest(method, target, "")
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -652,6 +1201,7 @@ func Test_ItemsHandler_Delete_ShouldReturnNotFoundForMissingItem(t *testing.T) {
 	target := "/api/items/" + missingID.String()
 	expectedBodyFragment := "item not found"
 	request := newJSONRequest(method, target, "")
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -664,3 +1214,280 @@ func Test_ItemsHandler_Delete_ShouldReturnNotFoundForMissingItem(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, response.StatusCode)
 	assert.Contains(t, actualBody, expectedBodyFragment)
 }
+
+func Test_ItemsHandler_GetTrash_ShouldListDeletedItems(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	_, deleteErr := app.itemsService.Delete(ctx, itemID, nil)
+	request := newJSONRequest(http.MethodGet, "/api/items/trash?page=0&pageSize=20", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	var actualBody domains.PaginatedList[domains.ItemTrashDto]
+	decodeErr := json.NewDecoder(recorder.Body).Decode(&actualBody)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, decodeErr)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	require.Len(t, actualBody.Data, 1)
+	assert.Equal(t, itemID, actualBody.Data[0].Id)
+	assert.Equal(t, "Coffee", actualBody.Data[0].Name)
+}
+
+func Test_ItemsHandler_GetTrash_ShouldWriteMoneyInRequestedFormat(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.ItemCreate{
+		Name:     "Laptop",
+		Price:    decimal.RequireFromString("1234567.07"),
+		Category: "Education",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	_, deleteErr := app.itemsService.Delete(ctx, itemID, nil)
+	request := newJSONRequest(http.MethodGet, "/api/items/trash?page=0&pageSize=20&moneyFormat=number", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	var actualBody domains.PaginatedList[map[string]json.RawMessage]
+	decodeErr := json.NewDecoder(response.Body).Decode(&actualBody)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, decodeErr)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	require.Len(t, actualBody.Data, 1)
+	assert.Equal(t, `1234567.07`, string(actualBody.Data[0]["price"]))
+}
+
+func Test_ItemsHandler_Restore_ShouldReturnNoContentAndThenNotFound(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	_, deleteErr := app.itemsService.Delete(ctx, itemID, nil)
+	target := "/api/items/" + itemID.String() + "/restore"
+
+	// Act
+	first := httptest.NewRecorder()
+	app.router.ServeHTTP(first, newJSONRequest(http.MethodPost, target, ""))
+	second := httptest.NewRecorder()
+	app.router.ServeHTTP(second, newJSONRequest(http.MethodPost, target, ""))
+	_, getErr := app.itemsService.GetDetailedInfo(ctx, itemID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, http.StatusNotFound, second.Code)
+}
+
+func Test_ItemsHandler_Restore_ShouldReturnConflictWhenNameWasReused(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+	}
+
+	itemID, createErr := app.itemsService.Create(ctx, create)
+	_, deleteErr := app.itemsService.Delete(ctx, itemID, nil)
+	_, recreateErr := app.itemsService.Create(ctx, create)
+	request := newJSONRequest(http.MethodPost, "/api/items/"+itemID.String()+"/restore", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, recreateErr)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+}
+
+func Test_ItemsHandler_AddTags_ShouldReturnChangedCount(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	tagID, tagErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Groceries", IsActive: true})
+	_, createErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+	})
+	body := `{"filter":{"categories":["FoodDrinks"]},"tagIds":["` + tagID.String() + `"]}`
+	request := newJSONRequest(http.MethodPost, "/api/items/tags:add", body)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	var actualBody domains.ItemTagsChangeResult
+	decodeErr := json.NewDecoder(recorder.Body).Decode(&actualBody)
+
+	// Assert
+	require.NoError(t, tagErr)
+	require.NoError(t, createErr)
+	require.NoError(t, decodeErr)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int64(1), actualBody.Changed)
+}
+
+func Test_ItemsHandler_RemoveTags_ShouldReturnBadRequestForIdsCombinedWithFilter(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	body := `{"itemIds":["` + uuid.NewString() + `"],"filter":{},"tagIds":["` + uuid.NewString() + `"]}`
+	request := newJSONRequest(http.MethodPost, "/api/items/tags:remove", body)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Contains(t, recorder.Body.String(), "itemIds and filter cannot be combined")
+}
+
+func Test_ItemsHandler_BulkChange_ShouldReturnCountsAndIds(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	itemID, createErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+	})
+	body := `{"filter":{"categories":["FoodDrinks"]},"operation":"setCashback","cashback":7}`
+	request := newJSONRequest(http.MethodPost, "/api/items:bulk", body)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	var actualBody domains.ItemBulkChangeResult
+	decodeErr := json.NewDecoder(recorder.Body).Decode(&actualBody)
+	item, getErr := app.itemsService.GetDetailedInfo(ctx, itemID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, decodeErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int64(1), actualBody.Matched)
+	assert.Equal(t, int64(1), actualBody.Affected)
+	assert.Equal(t, []uuid.UUID{itemID}, actualBody.ItemIds)
+	assert.Equal(t, int32(7), item.Cashback)
+}
+
+func Test_ItemsHandler_BulkChange_ShouldReturnBadRequestWhenValueIsMissing(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	request := newJSONRequest(http.MethodPost, "/api/items:bulk", `{"filter":{"all":true},"operation":"setCategory"}`)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Contains(t, recorder.Body.String(), "category is required by setCategory")
+}
+
+func Test_ItemsHandler_BulkChange_ShouldRejectEmptySelectionsAndKeepItems(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	_, createErr := app.itemsService.Create(testContext, &domains.ItemCreate{Name: "Coffee", Price: decimal.NewFromFloat(5), Category: "FoodDrinks"})
+
+	// Act
+	emptyIdsRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(emptyIdsRecorder, newJSONRequest(http.MethodPost, "/api/items:bulk", `{"filter":{"ids":[]},"operation":"delete"}`))
+	noCriteriaRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(noCriteriaRecorder, newJSONRequest(http.MethodPost, "/api/items:bulk", `{"filter":{},"operation":"delete"}`))
+	var remaining int
+	countErr := testDB.Get(&remaining, "SELECT COUNT(*) FROM items WHERE deleted_at IS NULL")
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, countErr)
+	assert.Equal(t, http.StatusBadRequest, emptyIdsRecorder.Code)
+	assert.Contains(t, emptyIdsRecorder.Body.String(), "ids are empty")
+	assert.Equal(t, http.StatusBadRequest, noCriteriaRecorder.Code)
+	assert.Contains(t, noCriteriaRecorder.Body.String(), "filter needs at least one criterion")
+	assert.Equal(t, 1, remaining)
+}
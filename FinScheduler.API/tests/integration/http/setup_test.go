@@ -5,6 +5,7 @@ package featurehttp_test
 
 import (
 	"context"
+	"finscheduler/internal/auth"
 	featurehttp "finscheduler/internal/features/http"
 	"finscheduler/internal/features/services"
 	"finscheduler/internal/persistence"
@@ -26,9 +27,12 @@ var testLogger *slog.Logger
 var testContext context.Context
 
 type testApplication struct {
-	router       http.Handler
-	itemsService *services.ItemsService
-	tagsService  *services.TagsService
+	router            http.Handler
+	itemsService      *services.ItemsService
+	tagsService       *services.TagsService
+	householdsService *services.HouseholdsService
+	apiKeysService    *services.ApiKeysService
+	auditService      *services.AuditService
 }
 
 const closedDBDriverName = "pgx"
@@ -62,24 +66,39 @@ func newTestApplicationWithDB(db *sqlx.DB) *testApplication {
 	uow := persistence.NewUnitOfWork(db, testLogger)
 	itemsService := services.NewItemsService(uow, testLogger)
 	tagsService := services.NewTagsService(uow, testLogger)
+	householdsService := services.NewHouseholdsService(uow, testLogger)
+	apiKeysService := services.NewApiKeysService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
 	itemsHandler := featurehttp.NewItemsHandler(itemsService, testLogger)
 	tagsHandler := featurehttp.NewTagsHandler(tagsService, testLogger)
+	householdsHandler := featurehttp.NewHouseholdsHandler(householdsService, testLogger)
+	apiKeysHandler := featurehttp.NewApiKeysHandler(apiKeysService, testLogger)
+	auditHandler := featurehttp.NewAuditHandler(auditService, testLogger)
 	router := chi.NewRouter()
+	router.Use(authenticateAsOwner)
 
-	router.Route("/api/items", func(route chi.Router) {
-		itemsHandler.RegisterEndpoints(route)
-	})
-	router.Route("/api/tags", func(route chi.Router) {
-		tagsHandler.RegisterEndpoints(route)
-	})
+	featurehttp.RegisterRoutes(router, itemsHandler, tagsHandler, householdsHandler, apiKeysHandler, auditHandler, featurehttp.NoRateLimit)
 
 	return &testApplication{
-		router:       router,
-		itemsService: itemsService,
-		tagsService:  tagsService,
+		router:            router,
+		itemsService:      itemsService,
+		tagsService:       tagsService,
+		householdsService: householdsService,
+		apiKeysService:    apiKeysService,
+		auditService:      auditService,
 	}
 }
 
+// authenticateAsOwner stands in for the bearer token middleware so that the
+// handlers run as testsupport.OwnerID. The membership middleware then picks
+// the owner's personal household, testsupport.HouseholdID.
+func authenticateAsOwner(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.Principal{UserID: testsupport.OwnerID}
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
 func newClosedDB(t testing.TB) *sqlx.DB {
 	t.Helper()
 
@@ -110,3 +129,10 @@ func newJSONRequest(method string, target string, body string) *http.Request {
 
 	return request
 }
+
+func newMergePatchRequest(target string, body string) *http.Request {
+	request := httptest.NewRequest(http.MethodPatch, target, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/merge-patch+json")
+
+	return request
+}
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -119,10 +120,49 @@ func Test_TagsHandler_GetDetailedInfo_ShouldReturnTag(t *testing.T) {
 	require.NoError(t, createErr)
 	require.NoError(t, decodeErr)
 	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Regexp(t, `^"1-[0-9a-f]{16}"$`, response.Header.Get("ETag"))
 	assert.Equal(t, expectedName, actualResponse.Name)
 	assert.Equal(t, expectedIsActive, actualResponse.IsActive)
 }
 
+func Test_TagsHandler_GetStats_ShouldReturnStatsOrNotFound(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	tagID, tagErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Food", IsActive: true})
+	_, itemErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.50),
+		Category: "FoodDrinks",
+		IsActive: true,
+		TagIds:   []string{tagID.String()},
+	})
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, newJSONRequest(http.MethodGet, "/api/tags/"+tagID.String()+"/stats?moneyFormat=number", ""))
+	missingRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(missingRecorder, newJSONRequest(http.MethodGet, "/api/tags/"+uuid.NewString()+"/stats", ""))
+
+	var actualResponse map[string]any
+	decodeErr := json.NewDecoder(recorder.Body).Decode(&actualResponse)
+
+	// Assert
+	require.NoError(t, tagErr)
+	require.NoError(t, itemErr)
+	require.NoError(t, decodeErr)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, float64(1), actualResponse["itemCount"])
+	assert.Equal(t, float64(1), actualResponse["activeItemCount"])
+	assert.Equal(t, 10.5, actualResponse["totalPrice"])
+	assert.Equal(t, 10.5, actualResponse["averagePrice"])
+	assert.Equal(t, http.StatusNotFound, missingRecorder.Code)
+}
+
 func Test_TagsHandler_GetDetailedInfo_ShouldReturnBadRequestOnInvalidID(t *testing.T) {
 	// Arrange
 	app := newTestApplication()
@@ -208,7 +248,7 @@ func Test_TagsHandler_GetLookup_ShouldReturnOnlyActiveTags(t *testing.T) {
 	response := recorder.Result()
 	defer response.Body.Close()
 
-	var actualResponse domains.PaginatedList[domains.Lookup]
+	var actualResponse domains.PaginatedList[domains.TagLookupDto]
 	decodeErr := json.NewDecoder(response.Body).Decode(&actualResponse)
 
 	// Assert
@@ -221,6 +261,39 @@ func Test_TagsHandler_GetLookup_ShouldReturnOnlyActiveTags(t *testing.T) {
 	assert.Equal(t, activeName, actualResponse.Data[0].Label)
 }
 
+func Test_TagsHandler_GetLookup_ShouldSetCacheHeadersAndHonourIfNoneMatch(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	target := "/api/tags/lookup?page=0&pageSize=20"
+
+	_, createErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Groceries", IsActive: true})
+	firstRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(firstRecorder, newJSONRequest(http.MethodGet, target, ""))
+	etag := firstRecorder.Header().Get("ETag")
+	request := newJSONRequest(http.MethodGet, target, "")
+	request.Header.Set("If-None-Match", `"unrelated", `+etag)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	require.NoError(t, createErr)
+	assert.Equal(t, http.StatusOK, firstRecorder.Code)
+	assert.Equal(t, "private, max-age=60, must-revalidate", firstRecorder.Header().Get("Cache-Control"))
+	assert.Contains(t, firstRecorder.Header().Values("Vary"), "Authorization, X-Household-Id")
+	assert.Equal(t, http.StatusNotModified, response.StatusCode)
+	assert.Equal(t, etag, response.Header.Get("ETag"))
+	assert.Contains(t, response.Header.Values("Vary"), "Authorization, X-Household-Id")
+}
+
 func Test_TagsHandler_GetLookup_ShouldReturnBadRequestOnInvalidQuery(t *testing.T) {
 	// Arrange
 	app := newTestApplication()
@@ -290,6 +363,59 @@ func Test_TagsHandler_Create_ShouldReturnCreatedWithLocationAndBody(t *testing.T
 	assert.Equal(t, locationPrefix+actualID.String(), actualLocation)
 }
 
+func Test_TagsHandler_Create_ShouldReplayResponseForRepeatedIdempotencyKey(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	target := "/api/tags"
+	requestBody := `{"name":"Groceries","isActive":true}`
+	firstRequest := newJSONRequest(http.MethodPost, target, requestBody)
+	firstRequest.Header.Set("Idempotency-Key", "create-groceries")
+	retryRequest := newJSONRequest(http.MethodPost, target, requestBody)
+	retryRequest.Header.Set("Idempotency-Key", "create-groceries")
+
+	// Act
+	firstRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(firstRecorder, firstRequest)
+	retryRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(retryRecorder, retryRequest)
+
+	var count int
+	countErr := testDB.Get(&count, "SELECT COUNT(*) FROM tags")
+
+	// Assert
+	require.NoError(t, countErr)
+	assert.Equal(t, http.StatusCreated, firstRecorder.Code)
+	assert.Equal(t, http.StatusCreated, retryRecorder.Code)
+	assert.Equal(t, firstRecorder.Body.String(), retryRecorder.Body.String())
+	assert.Equal(t, "true", retryRecorder.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, count)
+}
+
+func Test_TagsHandler_Create_ShouldReturnBadRequestOnUnknownField(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	method := http.MethodPost
+	target := "/api/tags"
+	requestBody := `{"name":"Groceries","isactive":true}`
+	expectedBodyFragment := `unknown field "isactive"`
+	request := newJSONRequest(method, target, requestBody)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	actualBody := recorder.Body.String()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Contains(t, actualBody, expectedBodyFragment)
+}
+
 func Test_TagsHandler_Create_ShouldReturnBadRequestOnMalformedJSON(t *testing.T) {
 	// Arrange
 	app := newTestApplication()
@@ -368,6 +494,7 @@ func Test_TagsHandler_Update_ShouldReturnNoContent(t *testing.T) {
 	target := "/api/tags/" + tagID.String()
 	requestBody := `{"name":"` + updatedName + `","isActive":true}`
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -389,6 +516,7 @@ func Test_TagsHandler_Update_ShouldReturnBadRequestOnMalformedJSON(t *testing.T)
 	requestBody := `{"name":`
 	expectedBodyFragment := "unexpected EOF"
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -410,6 +538,7 @@ func Test_TagsHandler_Update_ShouldReturnBadRequestOnInvalidID(t *testing.T) {
 	requestBody := `{"name":"Supermarket","isActive":true}`
 	expectedBodyFragment := "invalid UUID length"
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -432,6 +561,7 @@ func Test_TagsHandler_Update_ShouldReturnInternalServerErrorOnServiceFailure(t *
 	target := "/api/tags/" + tagID.String()
 	requestBody := `{"name":"Supermarket","isActive":true}`
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -452,6 +582,7 @@ func Test_TagsHandler_Update_ShouldReturnNotFoundForMissingTag(t *testing.T) {
 	requestBody := `{"name":"Missing","isActive":true}`
 	expectedBodyFragment := "tag not found"
 	request := newJSONRequest(method, target, requestBody)
+	request.Header.Set("If-Match", "*")
 
 	// Act
 	recorder := httptest.NewRecorder()
@@ -464,3 +595,264 @@ func Test_TagsHandler_Update_ShouldReturnNotFoundForMissingTag(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, response.StatusCode)
 	assert.Contains(t, actualBody, expectedBodyFragment)
 }
+
+func Test_TagsHandler_Patch_ShouldReturnNoContent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	originalName := "Groceries"
+	create := &domains.TagCreate{Name: originalName, IsActive: true}
+
+	tagID, createErr := app.tagsService.Create(ctx, create)
+	target := "/api/tags/" + tagID.String()
+	request := newMergePatchRequest(target, `{"isActive":false}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	tag, getErr := app.tagsService.GetDetailedInfo(ctx, tagID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Equal(t, originalName, tag.Name)
+	assert.False(t, tag.IsActive)
+}
+
+func Test_TagsHandler_Patch_ShouldClearDescriptionOnNull(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.TagCreate{Name: "Utilities", IsActive: true, Description: "Electricity bills"}
+
+	tagID, createErr := app.tagsService.Create(ctx, create)
+	target := "/api/tags/" + tagID.String()
+	request := newMergePatchRequest(target, `{"description":null}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	tag, getErr := app.tagsService.GetDetailedInfo(ctx, tagID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Empty(t, tag.Description)
+	assert.Equal(t, "Utilities", tag.Name)
+}
+
+func Test_TagsHandler_Patch_ShouldReturnBadRequestOnNullRequiredMember(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	create := &domains.TagCreate{Name: "Groceries", IsActive: true}
+
+	tagID, createErr := app.tagsService.Create(ctx, create)
+	target := "/api/tags/" + tagID.String()
+	request := newMergePatchRequest(target, `{"name":null}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	tag, getErr := app.tagsService.GetDetailedInfo(ctx, tagID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, "Groceries", tag.Name)
+}
+
+func Test_TagsHandler_Patch_ShouldReturnConflictForCycleAndBadRequestForUnknownParent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	homeID, homeErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Home", IsActive: true})
+	homeParent := homeID.String()
+	utilitiesID, utilitiesErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Utilities", IsActive: true, ParentId: &homeParent})
+	target := "/api/tags/" + homeID.String()
+	cycle := newMergePatchRequest(target, `{"parentId":"`+utilitiesID.String()+`"}`)
+	cycle.Header.Set("If-Match", "*")
+	unknown := newMergePatchRequest(target, `{"parentId":"`+uuid.NewString()+`"}`)
+	unknown.Header.Set("If-Match", "*")
+
+	// Act
+	cycleRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(cycleRecorder, cycle)
+	unknownRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(unknownRecorder, unknown)
+
+	// Assert
+	require.NoError(t, homeErr)
+	require.NoError(t, utilitiesErr)
+	assert.Equal(t, http.StatusConflict, cycleRecorder.Code)
+	assert.Equal(t, http.StatusBadRequest, unknownRecorder.Code)
+}
+
+func Test_TagsHandler_Patch_ShouldReturnPreconditionFailedOnStaleETag(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	tagID, createErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Groceries", IsActive: true})
+	_, concurrentErr := app.tagsService.Update(ctx, tagID, &domains.TagUpdate{Name: "Food", IsActive: true}, nil)
+	target := "/api/tags/" + tagID.String()
+	request := newMergePatchRequest(target, `{"name":"Supermarket"}`)
+	request.Header.Set("If-Match", `"1"`)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+	tag, getErr := app.tagsService.GetDetailedInfo(ctx, tagID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, concurrentErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, http.StatusPreconditionFailed, response.StatusCode)
+	assert.Equal(t, "Food", tag.Name)
+}
+
+func Test_TagsHandler_Patch_ShouldReturnNotFoundForMissingTag(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	target := "/api/tags/" + uuid.NewString()
+	request := newMergePatchRequest(target, `{"name":"Supermarket"}`)
+	request.Header.Set("If-Match", "*")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func Test_TagsHandler_Delete_ShouldReturnPreconditionRequiredWithoutIfMatch(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	request := newJSONRequest(http.MethodDelete, "/api/tags/"+uuid.NewString(), "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionRequired, response.StatusCode)
+}
+
+func Test_TagsHandler_Delete_ShouldReturnConflictForTagInUseUnlessForced(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	tagID, tagErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Food", IsActive: true})
+	_, itemErr := app.itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	})
+	target := "/api/tags/" + tagID.String()
+	refused := newJSONRequest(http.MethodDelete, target, "")
+	refused.Header.Set("If-Match", "*")
+	forced := newJSONRequest(http.MethodDelete, target+"?force=true", "")
+	forced.Header.Set("If-Match", "*")
+
+	// Act
+	refusedRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(refusedRecorder, refused)
+	forcedRecorder := httptest.NewRecorder()
+	app.router.ServeHTTP(forcedRecorder, forced)
+
+	// Assert
+	require.NoError(t, tagErr)
+	require.NoError(t, itemErr)
+	assert.Equal(t, http.StatusConflict, refusedRecorder.Code)
+	assert.Contains(t, refusedRecorder.Body.String(), "force=true")
+	assert.Equal(t, http.StatusNoContent, forcedRecorder.Code)
+}
+
+func Test_TagsHandler_MergeInto_ShouldReturnNoContent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	app := newTestApplication()
+	ctx := testContext
+	sourceID, sourceErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Foods", IsActive: true})
+	targetID, targetErr := app.tagsService.Create(ctx, &domains.TagCreate{Name: "Food", IsActive: true})
+	target := "/api/tags/" + sourceID.String() + "/merge-into/" + targetID.String()
+
+	// Act
+	first := httptest.NewRecorder()
+	app.router.ServeHTTP(first, newJSONRequest(http.MethodPost, target, ""))
+	second := httptest.NewRecorder()
+	app.router.ServeHTTP(second, newJSONRequest(http.MethodPost, target, ""))
+
+	// Assert
+	require.NoError(t, sourceErr)
+	require.NoError(t, targetErr)
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, http.StatusNotFound, second.Code)
+}
+
+func Test_TagsHandler_MergeInto_ShouldReturnBadRequestForSameTag(t *testing.T) {
+	// Arrange
+	app := newTestApplication()
+	tagID := uuid.NewString()
+	request := newJSONRequest(http.MethodPost, "/api/tags/"+tagID+"/merge-into/"+tagID, "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
@@ -0,0 +1,148 @@
+//go:build integration
+// +build integration
+
+package featurehttp_test
+
+import (
+	"encoding/json"
+	"finscheduler/internal/features/domains"
+	featurehttp "finscheduler/internal/features/http"
+	"finscheduler/tests/internal/testsupport"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVersionedRouter(deprecation time.Time, sunset time.Time) http.Handler {
+	app := newTestApplication()
+	itemsHandler := featurehttp.NewItemsHandler(app.itemsService, testLogger)
+	tagsHandler := featurehttp.NewTagsHandler(app.tagsService, testLogger)
+	householdsHandler := featurehttp.NewHouseholdsHandler(app.householdsService, testLogger)
+	apiKeysHandler := featurehttp.NewApiKeysHandler(app.apiKeysService, testLogger)
+	auditHandler := featurehttp.NewAuditHandler(app.auditService, testLogger)
+	router := chi.NewRouter()
+	router.Use(authenticateAsOwner)
+
+	featurehttp.RegisterVersions(router,
+		featurehttp.APIVersion{
+			Name:        "v1",
+			Deprecation: deprecation,
+			Sunset:      sunset,
+			Routes:      featurehttp.V1Routes(itemsHandler, tagsHandler, householdsHandler, apiKeysHandler, auditHandler, featurehttp.NoRateLimit, featurehttp.NoValidation),
+		},
+		featurehttp.APIVersion{
+			Name: "v2",
+			Routes: func(r chi.Router) {
+				r.Get("/tags", func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				})
+			},
+		},
+	)
+
+	return router
+}
+
+func Test_Routes_ShouldServeVersionedAndUnversionedPaths(t *testing.T) {
+	for _, target := range []string{"/api/v1/tags?page=0&pageSize=20", "/api/tags?page=0&pageSize=20"} {
+		t.Run(target, func(t *testing.T) {
+			// Arrange
+			t.Cleanup(func() {
+				testsupport.Truncate(t, testDB)
+			})
+
+			app := newTestApplication()
+			_, createErr := app.tagsService.Create(testContext, &domains.TagCreate{Name: "Groceries"})
+			request := newJSONRequest(http.MethodGet, target, "")
+
+			// Act
+			recorder := httptest.NewRecorder()
+			app.router.ServeHTTP(recorder, request)
+			response := recorder.Result()
+			defer response.Body.Close()
+
+			var actualResponse domains.PaginatedList[domains.TagListingDto]
+			decodeErr := json.NewDecoder(response.Body).Decode(&actualResponse)
+
+			// Assert
+			require.NoError(t, createErr)
+			require.NoError(t, decodeErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, "v1", response.Header.Get("API-Version"))
+			assert.Empty(t, response.Header.Get("Deprecation"))
+			assert.Equal(t, int64(1), actualResponse.Count)
+		})
+	}
+}
+
+func Test_Routes_ShouldAnnounceDeprecationAndSunset(t *testing.T) {
+	// Arrange
+	deprecation := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+	router := newVersionedRouter(deprecation, sunset)
+	request := newJSONRequest(http.MethodGet, "/api/v1/tags?page=0&pageSize=20", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "@1767225600", response.Header.Get("Deprecation"))
+	assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", response.Header.Get("Sunset"))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, response.Header.Get("Link"))
+}
+
+func Test_Routes_ShouldServeSuccessorVersionSideBySide(t *testing.T) {
+	// Arrange
+	router := newVersionedRouter(time.Time{}, time.Time{})
+	request := newJSONRequest(http.MethodGet, "/api/v2/tags", "")
+
+	// Act
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+	assert.Equal(t, "v2", recorder.Header().Get("API-Version"))
+	assert.Empty(t, recorder.Header().Get("Deprecation"))
+}
+
+func Test_Routes_ShouldNegotiateVersionOnUnversionedPaths(t *testing.T) {
+	tests := []struct {
+		name            string
+		accept          string
+		expectedStatus  int
+		expectedVersion string
+	}{
+		{name: "default version", accept: "application/json", expectedStatus: http.StatusOK, expectedVersion: "v1"},
+		{name: "explicit v1", accept: "application/vnd.finscheduler.v1+json", expectedStatus: http.StatusOK, expectedVersion: "v1"},
+		{name: "explicit v2", accept: "application/vnd.finscheduler.v2+json, application/json;q=0.5", expectedStatus: http.StatusTeapot, expectedVersion: "v2"},
+		{name: "unknown version", accept: "application/vnd.finscheduler.v9+json", expectedStatus: http.StatusNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := newVersionedRouter(time.Time{}, time.Time{})
+			request := newJSONRequest(http.MethodGet, "/api/tags?page=0&pageSize=20", "")
+			request.Header.Set("Accept", tt.accept)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedVersion, recorder.Header().Get("API-Version"))
+			assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+		})
+	}
+}
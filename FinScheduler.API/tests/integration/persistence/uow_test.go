@@ -52,12 +52,12 @@ func TestUnitOfWorkWithTx_ShouldCommitChangesWhenCallbackSucceeds(t *testing.T)
 	err := uow.WithTx(ctx, func(repositories persistence.Repositories) error {
 		var createErr error
 
-		createdItemID, createErr = repositories.Items.Create(ctx, itemCreate)
+		createdItemID, createErr = repositories.Items.Create(ctx, testsupport.HouseholdID, itemCreate)
 		if createErr != nil {
 			return createErr
 		}
 
-		createdTagID, createErr = repositories.Tags.Create(ctx, tagCreate)
+		createdTagID, createErr = repositories.Tags.Create(ctx, testsupport.HouseholdID, tagCreate)
 		if createErr != nil {
 			return createErr
 		}
@@ -67,7 +67,7 @@ func TestUnitOfWorkWithTx_ShouldCommitChangesWhenCallbackSucceeds(t *testing.T)
 			TagIds: []uuid.UUID{createdTagID},
 		}
 
-		_, createErr = repositories.TagToItems.BulkInsert(ctx, linkCreate)
+		_, createErr = repositories.TagToItems.BulkInsert(ctx, testsupport.HouseholdID, linkCreate)
 		if createErr != nil {
 			return createErr
 		}
@@ -129,12 +129,12 @@ func TestUnitOfWorkWithTx_ShouldRollbackChangesWhenCallbackReturnsError(t *testi
 	err := uow.WithTx(ctx, func(repositories persistence.Repositories) error {
 		var createErr error
 
-		createdItemID, createErr = repositories.Items.Create(ctx, itemCreate)
+		createdItemID, createErr = repositories.Items.Create(ctx, testsupport.HouseholdID, itemCreate)
 		if createErr != nil {
 			return createErr
 		}
 
-		createdTagID, createErr = repositories.Tags.Create(ctx, tagCreate)
+		createdTagID, createErr = repositories.Tags.Create(ctx, testsupport.HouseholdID, tagCreate)
 		if createErr != nil {
 			return createErr
 		}
@@ -144,7 +144,7 @@ func TestUnitOfWorkWithTx_ShouldRollbackChangesWhenCallbackReturnsError(t *testi
 			TagIds: []uuid.UUID{createdTagID},
 		}
 
-		_, createErr = repositories.TagToItems.BulkInsert(ctx, linkCreate)
+		_, createErr = repositories.TagToItems.BulkInsert(ctx, testsupport.HouseholdID, linkCreate)
 		if createErr != nil {
 			return createErr
 		}
@@ -0,0 +1,94 @@
+//go:build integration
+// +build integration
+
+package repositories_test
+
+import (
+	"database/sql"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/repositories"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHouseholdsRepositoryGetPersonal_ShouldReturnSeededHousehold(t *testing.T) {
+	// Arrange
+	ctx := testContext
+	repo := repositories.NewHouseholdsRepository(testDB, testLogger)
+
+	// Act
+	membership, err := repo.GetPersonal(ctx, testsupport.OwnerID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, testsupport.HouseholdID, membership.Id)
+	assert.True(t, membership.IsPersonal)
+	assert.Equal(t, auth.RoleAdmin, membership.Role)
+}
+
+func TestHouseholdsRepositoryCreate_ShouldNotCreateASecondPersonalHousehold(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewHouseholdsRepository(testDB, testLogger)
+
+	// Act
+	sharedID, sharedErr := repo.Create(ctx, testsupport.OwnerID, "Flat share", false)
+	_, personalErr := repo.Create(ctx, testsupport.OwnerID, "Personal", true)
+
+	// Assert
+	require.NoError(t, sharedErr)
+	assert.NotEqual(t, testsupport.HouseholdID, sharedID)
+	assert.ErrorIs(t, personalErr, sql.ErrNoRows)
+}
+
+func TestHouseholdsRepositoryAddMember_ShouldKeepTheExistingRole(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewHouseholdsRepository(testDB, testLogger)
+	userID := testsupport.CreateUser(t, testDB, "member@finscheduler.test")
+
+	// Act
+	added, addErr := repo.AddMember(ctx, testsupport.HouseholdID, userID, auth.RoleViewer)
+	addedAgain, addAgainErr := repo.AddMember(ctx, testsupport.HouseholdID, userID, auth.RoleAdmin)
+	membership, getErr := repo.GetMembership(ctx, userID, testsupport.HouseholdID)
+	memberships, listErr := repo.GetByUserId(ctx, userID)
+
+	// Assert
+	require.NoError(t, addErr)
+	require.NoError(t, addAgainErr)
+	require.NoError(t, getErr)
+	require.NoError(t, listErr)
+	assert.True(t, added)
+	assert.False(t, addedAgain)
+	assert.Equal(t, auth.RoleViewer, membership.Role)
+	require.Len(t, memberships, 1)
+	assert.Equal(t, testsupport.HouseholdID, memberships[0].Id)
+}
+
+func TestHouseholdsRepositoryGetMembership_ShouldReturnNoRowsForNonMembers(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewHouseholdsRepository(testDB, testLogger)
+	userID := testsupport.CreateUser(t, testDB, "stranger@finscheduler.test")
+
+	// Act
+	_, err := repo.GetMembership(ctx, userID, testsupport.HouseholdID)
+
+	// Assert
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
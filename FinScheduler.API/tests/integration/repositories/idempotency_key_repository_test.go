@@ -0,0 +1,43 @@
+//go:build integration
+// +build integration
+
+package repositories_test
+
+import (
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/repositories"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyKeysRepositoryDeleteExpired_ShouldDeleteOnlyExpiredKeys(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewIdempotencyKeysRepository(testDB, testLogger)
+	expired := &domains.IdempotencyClaim{Scope: "POST /api/v1/items", Key: "expired", RequestHash: "hash"}
+	live := &domains.IdempotencyClaim{Scope: "POST /api/v1/items", Key: "live", RequestHash: "hash"}
+
+	_, expiredErr := repo.Claim(ctx, expired, -time.Minute)
+	_, liveErr := repo.Claim(ctx, live, time.Hour)
+
+	// Act
+	deleted, err := repo.DeleteExpired(ctx, time.Now())
+	var keys []string
+	queryErr := testDB.Select(&keys, "SELECT key FROM public.idempotency_keys")
+
+	// Assert
+	require.NoError(t, expiredErr)
+	require.NoError(t, liveErr)
+	require.NoError(t, err)
+	require.NoError(t, queryErr)
+	assert.Equal(t, int64(1), deleted)
+	assert.Equal(t, []string{"live"}, keys)
+}
@@ -34,8 +34,8 @@ func Test_ItemsRepository_CreateAndGetDetailedInfo_ShouldNotErr(t *testing.T) {
 	}
 
 	// Act
-	id, createErr := repo.Create(ctx, create)
-	item, getErr := repo.GetDetailedInfo(ctx, id)
+	id, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
+	item, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, id)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -66,9 +66,9 @@ func Test_ItemsRepository_GetListingInfo_ShouldFilterAndReturnCount(t *testing.T
 	secondPrice := decimal.NewFromFloat(20.00)
 	thirdPrice := decimal.NewFromFloat(30.00)
 	fourthPrice := decimal.NewFromFloat(40.00)
-	tagInsertQuery := "INSERT INTO tags (id, name, is_active) VALUES ($1, $2, $3), ($4, $5, $6)"
-	tagInsertArgs := []any{targetTagID, "Target", true, otherTagID, "Other", true}
-	linkInsertQuery := "INSERT INTO tag_to_item (item_id, tag_id) VALUES ($1, $2), ($3, $4), ($5, $6), ($7, $8)"
+	tagInsertQuery := "INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)"
+	tagInsertArgs := []any{targetTagID, "Target", true, otherTagID, "Other", true, testsupport.HouseholdID}
+	linkInsertQuery := "INSERT INTO tag_to_item (item_id, tag_id, household_id) VALUES ($1, $2, $9), ($3, $4, $9), ($5, $6, $9), ($7, $8, $9)"
 	filterName := "Coffee"
 	page := int32(0)
 	pageSize := int32(1)
@@ -81,16 +81,17 @@ func Test_ItemsRepository_GetListingInfo_ShouldFilterAndReturnCount(t *testing.T
 	thirdCreate := &domains.ItemCreate{Name: thirdName, Price: thirdPrice, Category: giftCategory}
 	fourthCreate := &domains.ItemCreate{Name: fourthName, Price: fourthPrice, Category: foodCategory}
 
-	firstID, firstCreateErr := repo.Create(ctx, firstCreate)
-	secondID, secondCreateErr := repo.Create(ctx, secondCreate)
-	thirdID, thirdCreateErr := repo.Create(ctx, thirdCreate)
-	fourthID, fourthCreateErr := repo.Create(ctx, fourthCreate)
+	firstID, firstCreateErr := repo.Create(ctx, testsupport.HouseholdID, firstCreate)
+	secondID, secondCreateErr := repo.Create(ctx, testsupport.HouseholdID, secondCreate)
+	thirdID, thirdCreateErr := repo.Create(ctx, testsupport.HouseholdID, thirdCreate)
+	fourthID, fourthCreateErr := repo.Create(ctx, testsupport.HouseholdID, fourthCreate)
 	_, tagInsertErr := testDB.Exec(tagInsertQuery, tagInsertArgs...)
 	linkInsertArgs := []any{
 		firstID, targetTagID,
 		secondID, targetTagID,
 		thirdID, targetTagID,
 		fourthID, otherTagID,
+		testsupport.HouseholdID,
 	}
 	_, linkInsertErr := testDB.Exec(linkInsertQuery, linkInsertArgs...)
 
@@ -105,7 +106,7 @@ func Test_ItemsRepository_GetListingInfo_ShouldFilterAndReturnCount(t *testing.T
 	expectedNames := []string{firstName, secondName}
 
 	// Act
-	items, count, getErr := repo.GetListingInfo(ctx, filter)
+	items, pageInfo, getErr := repo.GetListingInfo(ctx, testsupport.HouseholdID, filter)
 
 	// Assert
 	require.NoError(t, firstCreateErr)
@@ -116,10 +117,48 @@ func Test_ItemsRepository_GetListingInfo_ShouldFilterAndReturnCount(t *testing.T
 	require.NoError(t, linkInsertErr)
 	require.NoError(t, getErr)
 	require.Len(t, items, 1)
-	assert.Equal(t, int64(2), count)
+	assert.Equal(t, int64(2), *pageInfo.Count)
 	assert.Contains(t, expectedNames, items[0].Name)
 }
 
+func Test_ItemsRepository_GetListingInfo_ShouldSortBySelectedFieldsWithoutCursors(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewItemsRepository(testDB, testLogger)
+	creates := []*domains.ItemCreate{
+		{Name: "Bread", Price: decimal.NewFromFloat(20), Cashback: 5, Category: "FoodDrinks"},
+		{Name: "Apples", Price: decimal.NewFromFloat(20), Cashback: 1, Category: "FoodDrinks"},
+		{Name: "Milk", Price: decimal.NewFromFloat(10), Cashback: 5, Category: "FoodDrinks"},
+	}
+	for _, create := range creates {
+		_, err := repo.Create(ctx, testsupport.HouseholdID, create)
+		require.NoError(t, err)
+	}
+	page := int32(0)
+	pageSize := int32(2)
+	filter := &domains.ItemFilter{
+		Page:     &page,
+		PageSize: &pageSize,
+		Sort:     []domains.SortField{{Name: "price", Descending: true}, {Name: "name"}},
+	}
+
+	// Act
+	items, pageInfo, err := repo.GetListingInfo(ctx, testsupport.HouseholdID, filter)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Apples", items[0].Name)
+	assert.Equal(t, "Bread", items[1].Name)
+	assert.Equal(t, int64(3), *pageInfo.Count)
+	assert.Nil(t, pageInfo.Next)
+	assert.Nil(t, pageInfo.Previous)
+}
+
 func Test_ItemsRepository_GetDetailedInfo_ShouldReturnErrorOnNilID(t *testing.T) {
 	// Arrange
 	ctx := testContext
@@ -127,7 +166,7 @@ func Test_ItemsRepository_GetDetailedInfo_ShouldReturnErrorOnNilID(t *testing.T)
 	itemID := uuid.Nil
 
 	// Act
-	item, err := repo.GetDetailedInfo(ctx, itemID)
+	item, err := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, itemID)
 
 	// Assert
 	require.EqualError(t, err, "id should not be nil")
@@ -147,12 +186,12 @@ func Test_ItemsRepository_GetListingInfo_ShouldReturnErrorWhenDatabaseIsClosed(t
 	}
 
 	// Act
-	items, count, err := repo.GetListingInfo(ctx, filter)
+	items, pageInfo, err := repo.GetListingInfo(ctx, testsupport.HouseholdID, filter)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, items)
-	assert.Zero(t, count)
+	assert.Nil(t, pageInfo.Count)
 }
 
 func Test_ItemsRepository_Create_ShouldReturnErrorOnDuplicateName(t *testing.T) {
@@ -172,10 +211,10 @@ func Test_ItemsRepository_Create_ShouldReturnErrorOnDuplicateName(t *testing.T)
 		Category: itemCategory,
 	}
 
-	firstID, firstCreateErr := repo.Create(ctx, create)
+	firstID, firstCreateErr := repo.Create(ctx, testsupport.HouseholdID, create)
 
 	// Act
-	secondID, secondCreateErr := repo.Create(ctx, create)
+	secondID, secondCreateErr := repo.Create(ctx, testsupport.HouseholdID, create)
 
 	// Assert
 	require.NoError(t, firstCreateErr)
@@ -211,9 +250,9 @@ func Test_ItemsRepository_UpdateAndGetDetailedInfo_ShouldNotErr(t *testing.T) {
 	}
 
 	// Act
-	id, createErr := repo.Create(ctx, create)
-	ok, updateErr := repo.Update(ctx, id, update)
-	item, getErr := repo.GetDetailedInfo(ctx, id)
+	id, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
+	ok, updateErr := repo.Update(ctx, testsupport.HouseholdID, id, update, nil)
+	item, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, id)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -225,6 +264,49 @@ func Test_ItemsRepository_UpdateAndGetDetailedInfo_ShouldNotErr(t *testing.T) {
 	assert.True(t, updatedPrice.Equal(item.Price))
 }
 
+func Test_ItemsRepository_Update_ShouldReturnFalseOnVersionMismatch(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "items")
+	})
+
+	ctx := testContext
+	repo := repositories.NewItemsRepository(testDB, testLogger)
+	itemCategory := "FoodDrinks"
+	staleVersion := int32(1)
+	create := &domains.ItemCreate{
+		Name:     "Old",
+		Price:    decimal.NewFromFloat(10.00),
+		Category: itemCategory,
+	}
+	firstUpdate := &domains.ItemUpdate{
+		Name:     "First",
+		Price:    decimal.NewFromFloat(11.00),
+		Category: itemCategory,
+	}
+	secondUpdate := &domains.ItemUpdate{
+		Name:     "Second",
+		Price:    decimal.NewFromFloat(12.00),
+		Category: itemCategory,
+	}
+
+	// Act
+	id, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
+	firstOk, firstErr := repo.Update(ctx, testsupport.HouseholdID, id, firstUpdate, &staleVersion)
+	secondOk, secondErr := repo.Update(ctx, testsupport.HouseholdID, id, secondUpdate, &staleVersion)
+	item, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, id)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, getErr)
+	assert.True(t, firstOk)
+	assert.False(t, secondOk)
+	assert.Equal(t, "First", item.Name)
+	assert.Equal(t, int32(2), item.Version)
+}
+
 func Test_ItemsRepository_Update_ShouldReturnFalseWhenItemDoesNotExist(t *testing.T) {
 	// Arrange
 	ctx := testContext
@@ -241,7 +323,7 @@ func Test_ItemsRepository_Update_ShouldReturnFalseWhenItemDoesNotExist(t *testin
 	}
 
 	// Act
-	ok, err := repo.Update(ctx, itemID, update)
+	ok, err := repo.Update(ctx, testsupport.HouseholdID, itemID, update, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -264,7 +346,7 @@ func Test_ItemsRepository_Update_ShouldReturnErrorWhenDatabaseIsClosed(t *testin
 	}
 
 	// Act
-	ok, err := repo.Update(ctx, itemID, update)
+	ok, err := repo.Update(ctx, testsupport.HouseholdID, itemID, update, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -290,9 +372,9 @@ func Test_ItemsRepository_DeleteAndGetDetailedInfo_ShouldErr(t *testing.T) {
 	}
 
 	// Act
-	id, createErr := repo.Create(ctx, create)
-	ok, deleteErr := repo.Delete(ctx, id)
-	item, getErr := repo.GetDetailedInfo(ctx, id)
+	id, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
+	ok, deleteErr := repo.Delete(ctx, testsupport.HouseholdID, id, nil)
+	item, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, id)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -309,7 +391,7 @@ func Test_ItemsRepository_Delete_ShouldReturnFalseWhenItemDoesNotExist(t *testin
 	itemID := uuid.New()
 
 	// Act
-	ok, err := repo.Delete(ctx, itemID)
+	ok, err := repo.Delete(ctx, testsupport.HouseholdID, itemID, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -324,7 +406,7 @@ func Test_ItemsRepository_Delete_ShouldReturnErrorWhenDatabaseIsClosed(t *testin
 	itemID := uuid.New()
 
 	// Act
-	ok, err := repo.Delete(ctx, itemID)
+	ok, err := repo.Delete(ctx, testsupport.HouseholdID, itemID, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -338,3 +420,141 @@ func uuidPointer(value uuid.UUID) *uuid.UUID {
 func itemCategoryPointer(value domains.ItemCategory) *domains.ItemCategory {
 	return &value
 }
+
+func Test_ItemsRepository_LockByFilter_ShouldReturnMatchingItemsOutsideTheTrash(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "items")
+	})
+
+	ctx := testContext
+	repo := repositories.NewItemsRepository(testDB, testLogger)
+	bookID, bookErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Book", Price: decimal.NewFromFloat(10), Category: "Entertainments"})
+	trashedID, trashedErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Old book", Price: decimal.NewFromFloat(5), Category: "Entertainments"})
+	_, coffeeErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Coffee", Price: decimal.NewFromFloat(3), Category: "FoodDrinks"})
+	_, deleteErr := repo.Delete(ctx, testsupport.HouseholdID, trashedID, nil)
+	category := domains.ItemCategory("Entertainments")
+
+	// Act
+	items, err := repo.LockByFilter(ctx, testsupport.HouseholdID, &domains.ItemFilter{Categories: []*domains.ItemCategory{&category}})
+
+	// Assert
+	require.NoError(t, bookErr)
+	require.NoError(t, trashedErr)
+	require.NoError(t, coffeeErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, bookID, items[0].Id)
+	assert.Equal(t, "Book", items[0].Name)
+	assert.True(t, decimal.NewFromFloat(10).Equal(items[0].Price))
+}
+
+func Test_ItemsRepository_BulkUpdate_ShouldAdjustPricesAndSkipUnchangedItems(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "items")
+	})
+
+	ctx := testContext
+	repo := repositories.NewItemsRepository(testDB, testLogger)
+	bookID, bookErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Book", Price: decimal.NewFromFloat(10.05), Category: "Entertainments"})
+	freeID, freeErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Leaflet", Price: decimal.Zero, Category: "Entertainments"})
+	percent := decimal.NewFromInt(-10)
+	change := &domains.ItemBulkChange{Operation: domains.ItemBulkAdjustPrice, PricePercent: &percent}
+
+	// Act
+	changed, err := repo.BulkUpdate(ctx, testsupport.HouseholdID, []uuid.UUID{bookID, freeID}, change)
+	book, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, bookID)
+
+	// Assert
+	require.NoError(t, bookErr)
+	require.NoError(t, freeErr)
+	require.NoError(t, err)
+	require.NoError(t, getErr)
+	require.Len(t, changed, 1)
+	assert.Equal(t, bookID, changed[0].Id)
+	assert.Equal(t, "9.05", changed[0].Price.StringFixed(2))
+	assert.Equal(t, "9.05", book.Price.StringFixed(2))
+	assert.Equal(t, int32(2), book.Version)
+}
+
+func Test_ItemsRepository_BulkUpdate_ShouldAdjustPricesByFractionalPercent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "items")
+	})
+
+	ctx := testContext
+	repo := repositories.NewItemsRepository(testDB, testLogger)
+	bookID, bookErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Book", Price: decimal.NewFromFloat(10), Category: "Entertainments"})
+	percent := decimal.RequireFromString("-12.5")
+	change := &domains.ItemBulkChange{Operation: domains.ItemBulkAdjustPrice, PricePercent: &percent}
+
+	// Act
+	changed, err := repo.BulkUpdate(ctx, testsupport.HouseholdID, []uuid.UUID{bookID}, change)
+
+	// Assert
+	require.NoError(t, bookErr)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, "8.75", changed[0].Price.StringFixed(2))
+}
+
+func Test_ItemsRepository_BulkDelete_ShouldReturnItemsMovedToTheTrash(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "items")
+	})
+
+	ctx := testContext
+	repo := repositories.NewItemsRepository(testDB, testLogger)
+	bookID, bookErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Book", Price: decimal.NewFromFloat(10), Category: "Entertainments"})
+	trashedID, trashedErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Old book", Price: decimal.NewFromFloat(5), Category: "Entertainments"})
+	_, deleteErr := repo.Delete(ctx, testsupport.HouseholdID, trashedID, nil)
+
+	// Act
+	deleted, err := repo.BulkDelete(ctx, testsupport.HouseholdID, []uuid.UUID{bookID, trashedID})
+	_, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, bookID)
+
+	// Assert
+	require.NoError(t, bookErr)
+	require.NoError(t, trashedErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{bookID}, deleted)
+	assert.Error(t, getErr)
+}
+
+func Test_ItemsRepository_UpdateCashback_ShouldBumpVersion(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewItemsRepository(testDB, testLogger)
+	tagID := uuid.New()
+	itemID, createErr := repo.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{Name: "Groceries", Price: decimal.NewFromFloat(50), Category: "FoodDrinks"})
+	_, tagErr := testDB.Exec("INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, 'Monthly', true, $2)", tagID, testsupport.HouseholdID)
+	_, linkErr := testDB.Exec("INSERT INTO tag_to_item (item_id, tag_id, household_id) VALUES ($1, $2, $3)", itemID, tagID, testsupport.HouseholdID)
+
+	// Act
+	_, byTagErr := repo.UpdateCashbackByTag(ctx, testsupport.HouseholdID, tagID, 3)
+	afterTag, afterTagErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, itemID)
+	_, byIdsErr := repo.UpdateCashbackByIds(ctx, testsupport.HouseholdID, []uuid.UUID{itemID}, 5)
+	afterIds, afterIdsErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, itemID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, tagErr)
+	require.NoError(t, linkErr)
+	require.NoError(t, byTagErr)
+	require.NoError(t, afterTagErr)
+	require.NoError(t, byIdsErr)
+	require.NoError(t, afterIdsErr)
+	assert.Equal(t, int32(3), afterTag.Cashback)
+	assert.Equal(t, int32(2), afterTag.Version)
+	assert.Equal(t, int32(5), afterIds.Cashback)
+	assert.Equal(t, int32(3), afterIds.Version)
+}
@@ -23,7 +23,7 @@ func TestPriceHistoriesRepositoryGetByItemID_ShouldReturnErrorOnNilItemID(t *tes
 	itemID := uuid.Nil
 
 	// Act
-	priceHistories, err := repo.GetByItemID(ctx, itemID)
+	priceHistories, err := repo.GetByItemID(ctx, testsupport.HouseholdID, itemID)
 
 	// Assert
 	require.EqualError(t, err, "itemID should not be nil")
@@ -43,19 +43,20 @@ func TestPriceHistoriesRepositoryGetByItemID_ShouldReturnRecordsOrderedByRecorde
 	secondHistoryID := uuid.New()
 	olderDate := "2026-01-10"
 	newerDate := "2026-01-15"
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Coffee", "FoodDrinks"}
-	historyInsertQuery := `INSERT INTO price_history (id, item_id, recorded_at, value) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)`
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Coffee", "FoodDrinks", testsupport.HouseholdID}
+	historyInsertQuery := `INSERT INTO price_history (id, item_id, recorded_at, value, household_id) VALUES ($1, $2, $3, $4, $9), ($5, $6, $7, $8, $9)`
 	historyInsertArgs := []any{
 		firstHistoryID, itemID, olderDate, decimal.RequireFromString("12.50"),
 		secondHistoryID, itemID, newerDate, decimal.RequireFromString("15.00"),
+		testsupport.HouseholdID,
 	}
 
 	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
 	_, historyInsertErr := testDB.Exec(historyInsertQuery, historyInsertArgs...)
 
 	// Act
-	priceHistories, getErr := repo.GetByItemID(ctx, itemID)
+	priceHistories, getErr := repo.GetByItemID(ctx, testsupport.HouseholdID, itemID)
 
 	// Assert
 	require.NoError(t, itemInsertErr)
@@ -76,7 +77,7 @@ func TestPriceHistoriesRepositoryGetByItemID_ShouldReturnErrorWhenDatabaseIsClos
 	itemID := uuid.New()
 
 	// Act
-	priceHistories, err := repo.GetByItemID(ctx, itemID)
+	priceHistories, err := repo.GetByItemID(ctx, testsupport.HouseholdID, itemID)
 
 	// Assert
 	require.Error(t, err)
@@ -94,10 +95,10 @@ func TestPriceHistoriesRepositoryUpsertToday_ShouldInsertNewRecordWhenTodayDoesN
 	itemID := uuid.New()
 	olderHistoryID := uuid.New()
 	todayUTC := time.Now().UTC().Format("2006-01-02")
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Milk", "FoodDrinks"}
-	historyInsertQuery := `INSERT INTO price_history (id, item_id, recorded_at, value) VALUES ($1, $2, $3, $4)`
-	historyInsertArgs := []any{olderHistoryID, itemID, "2026-01-10", decimal.RequireFromString("8.00")}
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Milk", "FoodDrinks", testsupport.HouseholdID}
+	historyInsertQuery := `INSERT INTO price_history (id, item_id, recorded_at, value, household_id) VALUES ($1, $2, $3, $4, $5)`
+	historyInsertArgs := []any{olderHistoryID, itemID, "2026-01-10", decimal.RequireFromString("8.00"), testsupport.HouseholdID}
 	upsert := &domains.PriceHistoryUpsert{
 		Value: decimal.RequireFromString("9.50"),
 	}
@@ -106,8 +107,8 @@ func TestPriceHistoriesRepositoryUpsertToday_ShouldInsertNewRecordWhenTodayDoesN
 	_, historyInsertErr := testDB.Exec(historyInsertQuery, historyInsertArgs...)
 
 	// Act
-	priceHistory, upsertErr := repo.UpsertToday(ctx, itemID, upsert)
-	priceHistories, getErr := repo.GetByItemID(ctx, itemID)
+	priceHistory, upsertErr := repo.UpsertToday(ctx, testsupport.HouseholdID, itemID, upsert)
+	priceHistories, getErr := repo.GetByItemID(ctx, testsupport.HouseholdID, itemID)
 
 	// Assert
 	require.NoError(t, itemInsertErr)
@@ -136,10 +137,10 @@ func TestPriceHistoriesRepositoryUpsertToday_ShouldUpdateExistingRecordForToday(
 	itemID := uuid.New()
 	existingHistoryID := uuid.New()
 	todayUTC := time.Now().UTC().Format("2006-01-02")
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Bread", "FoodDrinks"}
-	historyInsertQuery := `INSERT INTO price_history (id, item_id, recorded_at, value) VALUES ($1, $2, $3, $4)`
-	historyInsertArgs := []any{existingHistoryID, itemID, todayUTC, decimal.RequireFromString("11.00")}
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Bread", "FoodDrinks", testsupport.HouseholdID}
+	historyInsertQuery := `INSERT INTO price_history (id, item_id, recorded_at, value, household_id) VALUES ($1, $2, $3, $4, $5)`
+	historyInsertArgs := []any{existingHistoryID, itemID, todayUTC, decimal.RequireFromString("11.00"), testsupport.HouseholdID}
 	upsert := &domains.PriceHistoryUpsert{
 		Value: decimal.RequireFromString("13.25"),
 	}
@@ -149,10 +150,10 @@ func TestPriceHistoriesRepositoryUpsertToday_ShouldUpdateExistingRecordForToday(
 	_, historyInsertErr := testDB.Exec(historyInsertQuery, historyInsertArgs...)
 
 	// Act
-	priceHistory, upsertErr := repo.UpsertToday(ctx, itemID, upsert)
+	priceHistory, upsertErr := repo.UpsertToday(ctx, testsupport.HouseholdID, itemID, upsert)
 	var actualCount int
 	countErr := testDB.Get(&actualCount, countQuery, itemID)
-	priceHistories, getErr := repo.GetByItemID(ctx, itemID)
+	priceHistories, getErr := repo.GetByItemID(ctx, testsupport.HouseholdID, itemID)
 
 	// Assert
 	require.NoError(t, itemInsertErr)
@@ -181,7 +182,7 @@ func TestPriceHistoriesRepositoryUpsertToday_ShouldReturnErrorOnNilItemID(t *tes
 	}
 
 	// Act
-	priceHistory, err := repo.UpsertToday(ctx, itemID, upsert)
+	priceHistory, err := repo.UpsertToday(ctx, testsupport.HouseholdID, itemID, upsert)
 
 	// Assert
 	require.EqualError(t, err, "itemID should not be nil")
@@ -195,7 +196,7 @@ func TestPriceHistoriesRepositoryUpsertToday_ShouldReturnErrorOnNilUpsert(t *tes
 	itemID := uuid.New()
 
 	// Act
-	priceHistory, err := repo.UpsertToday(ctx, itemID, nil)
+	priceHistory, err := repo.UpsertToday(ctx, testsupport.HouseholdID, itemID, nil)
 
 	// Assert
 	require.EqualError(t, err, "upsert should not be nil")
@@ -213,7 +214,7 @@ func TestPriceHistoriesRepositoryUpsertToday_ShouldReturnErrorWhenDatabaseIsClos
 	}
 
 	// Act
-	priceHistory, err := repo.UpsertToday(ctx, itemID, upsert)
+	priceHistory, err := repo.UpsertToday(ctx, testsupport.HouseholdID, itemID, upsert)
 
 	// Assert
 	require.Error(t, err)
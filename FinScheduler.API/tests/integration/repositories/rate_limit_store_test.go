@@ -0,0 +1,94 @@
+//go:build integration
+// +build integration
+
+package repositories_test
+
+import (
+	"finscheduler/internal/ratelimit"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStoreTake_ShouldRefuseOnceTheBucketIsEmpty(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "rate_limit_buckets")
+	})
+
+	ctx := testContext
+	store := ratelimit.NewPostgresStore(testDB)
+	rule := ratelimit.Rule{Limit: 2, Period: time.Minute}
+	now := time.Now().UTC()
+
+	// Act
+	first, firstErr := store.Take(ctx, "items:user:a", rule, now)
+	second, secondErr := store.Take(ctx, "items:user:a", rule, now)
+	third, thirdErr := store.Take(ctx, "items:user:a", rule, now)
+	other, otherErr := store.Take(ctx, "items:user:b", rule, now)
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, thirdErr)
+	require.NoError(t, otherErr)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+	assert.False(t, third.Allowed)
+	assert.InDelta(t, 30*time.Second, third.RetryAfter, float64(time.Millisecond))
+	assert.True(t, other.Allowed)
+}
+
+func TestPostgresStoreTake_ShouldRefillOverThePeriod(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "rate_limit_buckets")
+	})
+
+	ctx := testContext
+	store := ratelimit.NewPostgresStore(testDB)
+	rule := ratelimit.Rule{Limit: 1, Period: time.Minute}
+	now := time.Now().UTC()
+	_, err := store.Take(ctx, "auth:ip:10.0.0.1", rule, now)
+	require.NoError(t, err)
+
+	// Act
+	early, earlyErr := store.Take(ctx, "auth:ip:10.0.0.1", rule, now.Add(30*time.Second))
+	late, lateErr := store.Take(ctx, "auth:ip:10.0.0.1", rule, now.Add(time.Minute))
+
+	// Assert
+	require.NoError(t, earlyErr)
+	require.NoError(t, lateErr)
+	assert.False(t, early.Allowed)
+	assert.True(t, late.Allowed)
+}
+
+func TestPostgresStorePrune_ShouldForgetIdleBuckets(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "rate_limit_buckets")
+	})
+
+	ctx := testContext
+	store := ratelimit.NewPostgresStore(testDB)
+	rule := ratelimit.Rule{Limit: 1, Period: time.Minute}
+	now := time.Now().UTC()
+	_, err := store.Take(ctx, "tags:user:a", rule, now.Add(-time.Hour))
+	require.NoError(t, err)
+	_, err = store.Take(ctx, "tags:user:b", rule, now)
+	require.NoError(t, err)
+
+	// Act
+	pruneErr := store.Prune(ctx, now.Add(-time.Minute))
+
+	// Assert
+	require.NoError(t, pruneErr)
+	var keys []string
+	require.NoError(t, testDB.SelectContext(ctx, &keys, "SELECT key FROM rate_limit_buckets"))
+	assert.Equal(t, []string{"tags:user:b"}, keys)
+}
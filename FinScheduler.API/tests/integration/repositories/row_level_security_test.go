@@ -0,0 +1,186 @@
+//go:build integration
+// +build integration
+
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/repositories"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// otherHouseholdItem arranges an item and a tag in the personal household of
+// a second user, bypassing row-level security, and returns the household id,
+// item id and tag id.
+func otherHouseholdItem(t *testing.T) (uuid.UUID, uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	ctx := context.Background()
+	userID := testsupport.CreateUser(t, testDB, "other@finscheduler.test")
+	households := repositories.NewHouseholdsRepository(testDB, testLogger)
+	householdID, err := households.Create(ctx, userID, "Personal", true)
+	require.NoError(t, err)
+
+	itemID, err := repositories.NewItemsRepository(testDB, testLogger).Create(ctx, householdID, &domains.ItemCreate{
+		Name:     "Other Item",
+		Price:    decimal.NewFromInt(10),
+		Category: "FoodDrinks",
+	})
+	require.NoError(t, err)
+
+	tagID, err := repositories.NewTagsRepository(testDB, testLogger).Create(ctx, householdID, &domains.TagCreate{Name: "Other Tag", IsActive: true})
+	require.NoError(t, err)
+
+	return householdID, itemID, tagID
+}
+
+func firstPage() *domains.ItemFilter {
+	page := int32(0)
+	pageSize := int32(20)
+
+	return &domains.ItemFilter{Page: &page, PageSize: &pageSize}
+}
+
+func TestRowLevelSecurity_ShouldHideRowsOfOtherHouseholds(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	otherHouseholdID, otherItemID, otherTagID := otherHouseholdItem(t)
+	ctx := testsupport.WithMember(context.Background(), testsupport.OwnerID, testsupport.HouseholdID, auth.RoleAdmin)
+	uow := persistence.NewUnitOfWork(testApplicationDB, testLogger)
+
+	var itemErr, tagErr error
+	var items []domains.Item
+	var deleted bool
+	var updated []domains.ItemCashbackChange
+
+	// Act
+	// The repositories are asked for the other household explicitly, as a
+	// query missing its household filter would.
+	err := uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		_, itemErr = repositories.Items.GetDetailedInfo(ctx, otherHouseholdID, otherItemID)
+		_, tagErr = repositories.Tags.GetDetailedInfo(ctx, otherHouseholdID, otherTagID)
+		if items, _, err = repositories.Items.GetListingInfo(ctx, otherHouseholdID, firstPage()); err != nil {
+			return err
+		}
+		if updated, err = repositories.Items.UpdateCashbackByIds(ctx, otherHouseholdID, []uuid.UUID{otherItemID}, 5); err != nil {
+			return err
+		}
+		deleted, err = repositories.Items.Delete(ctx, otherHouseholdID, otherItemID, nil)
+
+		return err
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ErrorIs(t, itemErr, sql.ErrNoRows)
+	assert.ErrorIs(t, tagErr, sql.ErrNoRows)
+	assert.Empty(t, items)
+	assert.Empty(t, updated)
+	assert.False(t, deleted)
+
+	var cashback int32
+	require.NoError(t, testDB.Get(&cashback, "SELECT cashback FROM items WHERE id = $1", otherItemID))
+	assert.Zero(t, cashback)
+}
+
+func TestRowLevelSecurity_ShouldRejectWritesIntoOtherHouseholds(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	otherHouseholdID, _, _ := otherHouseholdItem(t)
+	ctx := testsupport.WithMember(context.Background(), testsupport.OwnerID, testsupport.HouseholdID, auth.RoleAdmin)
+	uow := persistence.NewUnitOfWork(testApplicationDB, testLogger)
+
+	// Act
+	err := uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		_, err := repositories.Tags.Create(ctx, otherHouseholdID, &domains.TagCreate{Name: "Planted Tag", IsActive: true})
+
+		return err
+	})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "row-level security")
+
+	var count int
+	require.NoError(t, testDB.Get(&count, "SELECT COUNT(*) FROM tags WHERE name = 'Planted Tag'"))
+	assert.Zero(t, count)
+}
+
+func TestRowLevelSecurity_ShouldAllowRowsOfTheTenant(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testsupport.WithMember(context.Background(), testsupport.OwnerID, testsupport.HouseholdID, auth.RoleEditor)
+	uow := persistence.NewUnitOfWork(testApplicationDB, testLogger)
+
+	var item *domains.Item
+
+	// Act
+	err := uow.WithTx(ctx, func(repositories persistence.Repositories) error {
+		itemID, err := repositories.Items.Create(ctx, testsupport.HouseholdID, &domains.ItemCreate{
+			Name:     "Own Item",
+			Price:    decimal.NewFromInt(10),
+			Category: "FoodDrinks",
+		})
+		if err != nil {
+			return err
+		}
+
+		item, err = repositories.Items.GetDetailedInfo(ctx, testsupport.HouseholdID, itemID)
+		return err
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Own Item", item.Name)
+}
+
+func TestRowLevelSecurity_ShouldHideEveryRowWithoutTenant(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	_, err := repositories.NewItemsRepository(testDB, testLogger).Create(context.Background(), testsupport.HouseholdID, &domains.ItemCreate{
+		Name:     "Own Item",
+		Price:    decimal.NewFromInt(10),
+		Category: "FoodDrinks",
+	})
+	require.NoError(t, err)
+
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: testsupport.OwnerID})
+	uow := persistence.NewUnitOfWork(testApplicationDB, testLogger)
+
+	var pageInfo domains.PageInfo
+
+	// Act
+	err = uow.WithoutTx(ctx, func(repositories persistence.Repositories) error {
+		var err error
+		_, pageInfo, err = repositories.Items.GetListingInfo(ctx, testsupport.HouseholdID, firstPage())
+
+		return err
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Zero(t, *pageInfo.Count)
+}
@@ -16,6 +16,7 @@ import (
 )
 
 var testDB *sqlx.DB
+var testApplicationDB *sqlx.DB
 var testLogger *slog.Logger
 var testContext context.Context
 
@@ -30,6 +31,7 @@ func TestMain(m *testing.M) {
 	}
 
 	testDB = env.DB
+	testApplicationDB = env.ApplicationDB
 	testLogger = env.Logger
 	testContext = env.Context
 
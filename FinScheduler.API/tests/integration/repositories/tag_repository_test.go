@@ -31,9 +31,9 @@ func TestTagsRepositoryCreateAndGetByIDs_ShouldNotErr(t *testing.T) {
 	}
 
 	// Act
-	tagID, createErr := repo.Create(ctx, create)
+	tagID, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
 	ids := []uuid.UUID{tagID}
-	tags, getErr := repo.GetByIds(ctx, ids)
+	tags, getErr := repo.GetByIds(ctx, testsupport.HouseholdID, ids)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -59,10 +59,10 @@ func TestTagsRepositoryGetDetailedInfo_ShouldReturnCreatedTag(t *testing.T) {
 		IsActive: tagIsActive,
 	}
 
-	tagID, createErr := repo.Create(ctx, create)
+	tagID, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
 
 	// Act
-	tag, getErr := repo.GetDetailedInfo(ctx, tagID)
+	tag, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, tagID)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -95,10 +95,10 @@ func TestTagsRepositoryGetListingInfo_ShouldFilterAndReturnCount(t *testing.T) {
 	thirdCreate := &domains.TagCreate{Name: thirdName, IsActive: inactiveValue}
 	fourthCreate := &domains.TagCreate{Name: fourthName, IsActive: activeValue}
 
-	_, firstCreateErr := repo.Create(ctx, firstCreate)
-	_, secondCreateErr := repo.Create(ctx, secondCreate)
-	_, thirdCreateErr := repo.Create(ctx, thirdCreate)
-	_, fourthCreateErr := repo.Create(ctx, fourthCreate)
+	_, firstCreateErr := repo.Create(ctx, testsupport.HouseholdID, firstCreate)
+	_, secondCreateErr := repo.Create(ctx, testsupport.HouseholdID, secondCreate)
+	_, thirdCreateErr := repo.Create(ctx, testsupport.HouseholdID, thirdCreate)
+	_, fourthCreateErr := repo.Create(ctx, testsupport.HouseholdID, fourthCreate)
 
 	filter := &domains.TagFilter{
 		Name:     &filterName,
@@ -110,7 +110,7 @@ func TestTagsRepositoryGetListingInfo_ShouldFilterAndReturnCount(t *testing.T) {
 	expectedNames := []string{firstName, secondName}
 
 	// Act
-	tags, count, getErr := repo.GetListingInfo(ctx, filter)
+	tags, pageInfo, getErr := repo.GetListingInfo(ctx, testsupport.HouseholdID, filter)
 
 	// Assert
 	require.NoError(t, firstCreateErr)
@@ -119,7 +119,7 @@ func TestTagsRepositoryGetListingInfo_ShouldFilterAndReturnCount(t *testing.T) {
 	require.NoError(t, fourthCreateErr)
 	require.NoError(t, getErr)
 	require.Len(t, tags, 1)
-	assert.Equal(t, int64(2), count)
+	assert.Equal(t, int64(2), *pageInfo.Count)
 	assert.Contains(t, expectedNames, tags[0].Name)
 	assert.True(t, tags[0].IsActive)
 }
@@ -138,12 +138,12 @@ func TestTagsRepositoryGetByIDs_ShouldReturnOnlyRequestedTags(t *testing.T) {
 	firstCreate := &domains.TagCreate{Name: firstName, IsActive: true}
 	secondCreate := &domains.TagCreate{Name: secondName, IsActive: true}
 
-	firstID, firstCreateErr := repo.Create(ctx, firstCreate)
-	secondID, secondCreateErr := repo.Create(ctx, secondCreate)
+	firstID, firstCreateErr := repo.Create(ctx, testsupport.HouseholdID, firstCreate)
+	secondID, secondCreateErr := repo.Create(ctx, testsupport.HouseholdID, secondCreate)
 	ids := []uuid.UUID{firstID}
 
 	// Act
-	tags, getErr := repo.GetByIds(ctx, ids)
+	tags, getErr := repo.GetByIds(ctx, testsupport.HouseholdID, ids)
 
 	// Assert
 	require.NoError(t, firstCreateErr)
@@ -161,7 +161,7 @@ func TestTagsRepositoryGetByIDs_ShouldReturnErrorOnNilIDs(t *testing.T) {
 	var ids []uuid.UUID
 
 	// Act
-	tags, err := repo.GetByIds(ctx, ids)
+	tags, err := repo.GetByIds(ctx, testsupport.HouseholdID, ids)
 
 	// Assert
 	require.EqualError(t, err, "ids should not be nil")
@@ -181,12 +181,12 @@ func TestTagsRepositoryGetListingInfo_ShouldReturnErrorWhenDatabaseIsClosed(t *t
 	}
 
 	// Act
-	tags, count, err := repo.GetListingInfo(ctx, filter)
+	tags, pageInfo, err := repo.GetListingInfo(ctx, testsupport.HouseholdID, filter)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, tags)
-	assert.Zero(t, count)
+	assert.Nil(t, pageInfo.Count)
 }
 
 func TestTagsRepositoryGetByIDs_ShouldReturnEmptySliceOnEmptyIDs(t *testing.T) {
@@ -196,7 +196,7 @@ func TestTagsRepositoryGetByIDs_ShouldReturnEmptySliceOnEmptyIDs(t *testing.T) {
 	ids := make([]uuid.UUID, 0)
 
 	// Act
-	tags, err := repo.GetByIds(ctx, ids)
+	tags, err := repo.GetByIds(ctx, testsupport.HouseholdID, ids)
 
 	// Assert
 	require.NoError(t, err)
@@ -212,7 +212,7 @@ func TestTagsRepositoryGetByIDs_ShouldReturnErrorWhenDatabaseIsClosed(t *testing
 	ids := []uuid.UUID{uuid.New()}
 
 	// Act
-	tags, err := repo.GetByIds(ctx, ids)
+	tags, err := repo.GetByIds(ctx, testsupport.HouseholdID, ids)
 
 	// Assert
 	require.Error(t, err)
@@ -242,10 +242,10 @@ func TestTagsRepositoryGetLookup_ShouldReturnActiveMatchesOrderedAndCount(t *tes
 	thirdCreate := &domains.TagCreate{Name: thirdName, IsActive: inactiveValue}
 	fourthCreate := &domains.TagCreate{Name: fourthName, IsActive: activeValue}
 
-	_, firstCreateErr := repo.Create(ctx, firstCreate)
-	_, secondCreateErr := repo.Create(ctx, secondCreate)
-	_, thirdCreateErr := repo.Create(ctx, thirdCreate)
-	_, fourthCreateErr := repo.Create(ctx, fourthCreate)
+	_, firstCreateErr := repo.Create(ctx, testsupport.HouseholdID, firstCreate)
+	_, secondCreateErr := repo.Create(ctx, testsupport.HouseholdID, secondCreate)
+	_, thirdCreateErr := repo.Create(ctx, testsupport.HouseholdID, thirdCreate)
+	_, fourthCreateErr := repo.Create(ctx, testsupport.HouseholdID, fourthCreate)
 
 	filter := &domains.TagLookupFilter{
 		Name:     &filterName,
@@ -256,7 +256,7 @@ func TestTagsRepositoryGetLookup_ShouldReturnActiveMatchesOrderedAndCount(t *tes
 	expectedLabels := []string{firstName, secondName}
 
 	// Act
-	lookups, count, getErr := repo.GetLookup(ctx, filter)
+	lookups, count, getErr := repo.GetLookup(ctx, testsupport.HouseholdID, filter)
 
 	// Assert
 	require.NoError(t, firstCreateErr)
@@ -283,7 +283,7 @@ func TestTagsRepositoryGetLookup_ShouldReturnErrorWhenDatabaseIsClosed(t *testin
 	}
 
 	// Act
-	lookups, count, err := repo.GetLookup(ctx, filter)
+	lookups, count, err := repo.GetLookup(ctx, testsupport.HouseholdID, filter)
 
 	// Assert
 	require.Error(t, err)
@@ -306,10 +306,10 @@ func TestTagsRepositoryCreate_ShouldReturnErrorOnDuplicateName(t *testing.T) {
 		IsActive: tagIsActive,
 	}
 
-	firstID, firstCreateErr := repo.Create(ctx, create)
+	firstID, firstCreateErr := repo.Create(ctx, testsupport.HouseholdID, create)
 
 	// Act
-	secondID, secondCreateErr := repo.Create(ctx, create)
+	secondID, secondCreateErr := repo.Create(ctx, testsupport.HouseholdID, create)
 
 	// Assert
 	require.NoError(t, firstCreateErr)
@@ -342,10 +342,10 @@ func TestTagsRepositoryUpdateAndGetByIDs_ShouldNotErr(t *testing.T) {
 	}
 
 	// Act
-	tagID, createErr := repo.Create(ctx, create)
-	ok, updateErr := repo.Update(ctx, tagID, update)
+	tagID, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
+	ok, updateErr := repo.Update(ctx, testsupport.HouseholdID, tagID, update, nil)
 	ids := []uuid.UUID{tagID}
-	tags, getErr := repo.GetByIds(ctx, ids)
+	tags, getErr := repo.GetByIds(ctx, testsupport.HouseholdID, ids)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -371,13 +371,40 @@ func TestTagsRepositoryUpdate_ShouldReturnFalseWhenTagDoesNotExist(t *testing.T)
 	}
 
 	// Act
-	ok, err := repo.Update(ctx, tagID, update)
+	ok, err := repo.Update(ctx, testsupport.HouseholdID, tagID, update, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.False(t, ok)
 }
 
+func TestTagsRepositoryDelete_ShouldRespectExpectedVersion(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagsRepository(testDB, testLogger)
+	staleVersion := int32(2)
+	currentVersion := int32(1)
+	tagID, createErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Paper", IsActive: true})
+
+	// Act
+	staleOk, staleErr := repo.Delete(ctx, testsupport.HouseholdID, tagID, &staleVersion)
+	ok, deleteErr := repo.Delete(ctx, testsupport.HouseholdID, tagID, &currentVersion)
+	againOk, againErr := repo.Delete(ctx, testsupport.HouseholdID, tagID, nil)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, staleErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, againErr)
+	assert.False(t, staleOk)
+	assert.True(t, ok)
+	assert.False(t, againOk)
+}
+
 func TestTagsRepositoryUpdate_ShouldReturnErrorWhenDatabaseIsClosed(t *testing.T) {
 	// Arrange
 	ctx := testContext
@@ -392,9 +419,159 @@ func TestTagsRepositoryUpdate_ShouldReturnErrorWhenDatabaseIsClosed(t *testing.T
 	}
 
 	// Act
-	ok, err := repo.Update(ctx, tagID, update)
+	ok, err := repo.Update(ctx, testsupport.HouseholdID, tagID, update, nil)
 
 	// Assert
 	require.Error(t, err)
 	assert.False(t, ok)
 }
+
+func TestTagsRepositoryGetLookup_ShouldLabelNestedTagsWithTheirPath(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "tags")
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagsRepository(testDB, testLogger)
+	page := int32(0)
+	pageSize := int32(20)
+	filterName := "Power"
+
+	homeID, homeErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Home", IsActive: true})
+	homeParent := homeID.String()
+	utilitiesID, utilitiesErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Utilities", IsActive: true, ParentId: &homeParent})
+	utilitiesParent := utilitiesID.String()
+	_, powerErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Power", IsActive: true, ParentId: &utilitiesParent})
+
+	// Act
+	all, allCount, allErr := repo.GetLookup(ctx, testsupport.HouseholdID, &domains.TagLookupFilter{Page: &page, PageSize: &pageSize})
+	matched, matchedCount, matchedErr := repo.GetLookup(ctx, testsupport.HouseholdID, &domains.TagLookupFilter{Name: &filterName, Page: &page, PageSize: &pageSize})
+
+	// Assert
+	require.NoError(t, homeErr)
+	require.NoError(t, utilitiesErr)
+	require.NoError(t, powerErr)
+	require.NoError(t, allErr)
+	require.NoError(t, matchedErr)
+	require.Len(t, all, 3)
+	assert.Equal(t, int64(3), allCount)
+	assert.Equal(t, "Home", all[0].Label)
+	assert.Equal(t, "Home / Utilities", all[1].Label)
+	assert.Equal(t, "Home / Utilities / Power", all[2].Label)
+	require.Len(t, matched, 1)
+	assert.Equal(t, int64(1), matchedCount)
+	assert.Equal(t, "Home / Utilities / Power", matched[0].Label)
+}
+
+func TestTagsRepositoryIsInSubtree_ShouldFollowTheHierarchy(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "tags")
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagsRepository(testDB, testLogger)
+	rootID, rootErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Home", IsActive: true})
+	rootParent := rootID.String()
+	childID, childErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Utilities", IsActive: true, ParentId: &rootParent})
+	childParent := childID.String()
+	grandchildID, grandchildErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Power", IsActive: true, ParentId: &childParent})
+	otherID, otherErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Travel", IsActive: true})
+
+	// Act
+	self, selfErr := repo.IsInSubtree(ctx, testsupport.HouseholdID, rootID, rootID)
+	descendant, descendantErr := repo.IsInSubtree(ctx, testsupport.HouseholdID, rootID, grandchildID)
+	ancestor, ancestorErr := repo.IsInSubtree(ctx, testsupport.HouseholdID, grandchildID, rootID)
+	unrelated, unrelatedErr := repo.IsInSubtree(ctx, testsupport.HouseholdID, rootID, otherID)
+
+	// Assert
+	require.NoError(t, rootErr)
+	require.NoError(t, childErr)
+	require.NoError(t, grandchildErr)
+	require.NoError(t, otherErr)
+	require.NoError(t, selfErr)
+	require.NoError(t, descendantErr)
+	require.NoError(t, ancestorErr)
+	require.NoError(t, unrelatedErr)
+	assert.True(t, self)
+	assert.True(t, descendant)
+	assert.False(t, ancestor)
+	assert.False(t, unrelated)
+}
+
+func TestTagsRepositoryMoveChildrenUp_ShouldHandChildrenToTheGrandparent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "tags")
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagsRepository(testDB, testLogger)
+	rootID, rootErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Home", IsActive: true})
+	rootParent := rootID.String()
+	middleID, middleErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Utilities", IsActive: true, ParentId: &rootParent})
+	middleParent := middleID.String()
+	leafID, leafErr := repo.Create(ctx, testsupport.HouseholdID, &domains.TagCreate{Name: "Power", IsActive: true, ParentId: &middleParent})
+
+	// Act
+	moved, moveErr := repo.MoveChildrenUp(ctx, testsupport.HouseholdID, middleID)
+	leaf, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, leafID)
+
+	// Assert
+	require.NoError(t, rootErr)
+	require.NoError(t, middleErr)
+	require.NoError(t, leafErr)
+	require.NoError(t, moveErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, []uuid.UUID{leafID}, moved)
+	assert.Equal(t, &rootID, leaf.ParentId)
+	assert.Equal(t, int32(2), leaf.Version)
+}
+
+func TestTagsRepositoryCreateAndPatch_ShouldKeepAppearance(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB, "tags")
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagsRepository(testDB, testLogger)
+	page := int32(0)
+	pageSize := int32(20)
+	color := "#1e88e5"
+	icon := "flash-on"
+	patchedColor := "#43a047"
+	create := &domains.TagCreate{Name: "Power", IsActive: true, Color: &color, Icon: &icon, Description: "Electricity bills"}
+
+	// Act
+	tagID, createErr := repo.Create(ctx, testsupport.HouseholdID, create)
+	created, getErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, tagID)
+	lookups, _, lookupErr := repo.GetLookup(ctx, testsupport.HouseholdID, &domains.TagLookupFilter{Page: &page, PageSize: &pageSize})
+	patched, patchErr := repo.Patch(ctx, testsupport.HouseholdID, tagID, &domains.TagPatch{Color: domains.Nullable[string]{Set: true, Value: &patchedColor}}, nil)
+	afterPatch, afterPatchErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, tagID)
+	cleared, clearErr := repo.Patch(ctx, testsupport.HouseholdID, tagID, &domains.TagPatch{Icon: domains.Nullable[string]{Set: true}}, nil)
+	afterClear, afterClearErr := repo.GetDetailedInfo(ctx, testsupport.HouseholdID, tagID)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, getErr)
+	require.NoError(t, lookupErr)
+	require.NoError(t, patchErr)
+	require.NoError(t, afterPatchErr)
+	assert.Equal(t, &color, created.Color)
+	assert.Equal(t, &icon, created.Icon)
+	assert.Equal(t, "Electricity bills", created.Description)
+	require.Len(t, lookups, 1)
+	assert.Equal(t, &color, lookups[0].Color)
+	assert.Equal(t, &icon, lookups[0].Icon)
+	assert.Equal(t, "Electricity bills", lookups[0].Description)
+	assert.True(t, patched)
+	assert.Equal(t, &patchedColor, afterPatch.Color)
+	assert.Equal(t, &icon, afterPatch.Icon)
+	require.NoError(t, clearErr)
+	require.NoError(t, afterClearErr)
+	assert.True(t, cleared)
+	assert.Equal(t, &patchedColor, afterClear.Color)
+	assert.Nil(t, afterClear.Icon)
+}
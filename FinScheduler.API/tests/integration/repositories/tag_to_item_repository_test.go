@@ -21,7 +21,7 @@ func TestTagToItemsRepositoryGetByItemIDs_ShouldReturnErrorOnNilItemIDs(t *testi
 	var itemIDs []uuid.UUID
 
 	// Act
-	tagToItems, err := repo.GetByItemIds(ctx, itemIDs)
+	tagToItems, err := repo.GetByItemIds(ctx, testsupport.HouseholdID, itemIDs)
 
 	// Assert
 	require.EqualError(t, err, "itemId should not be nil")
@@ -35,7 +35,7 @@ func TestTagToItemsRepositoryGetByItemIDs_ShouldReturnEmptySliceOnEmptyItemIDs(t
 	itemIDs := make([]uuid.UUID, 0)
 
 	// Act
-	tagToItems, err := repo.GetByItemIds(ctx, itemIDs)
+	tagToItems, err := repo.GetByItemIds(ctx, testsupport.HouseholdID, itemIDs)
 
 	// Assert
 	require.NoError(t, err)
@@ -51,7 +51,7 @@ func TestTagToItemsRepositoryGetByItemIDs_ShouldReturnErrorWhenDatabaseIsClosed(
 	itemIDs := []uuid.UUID{uuid.New()}
 
 	// Act
-	tagToItems, err := repo.GetByItemIds(ctx, itemIDs)
+	tagToItems, err := repo.GetByItemIds(ctx, testsupport.HouseholdID, itemIDs)
 
 	// Assert
 	require.Error(t, err)
@@ -69,10 +69,10 @@ func TestTagToItemsRepositoryBulkInsertAndGetByItemIDs_ShouldNotErr(t *testing.T
 	itemID := uuid.New()
 	firstTagID := uuid.New()
 	secondTagID := uuid.New()
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Apple", "FoodDrinks"}
-	tagInsertQuery := `INSERT INTO tags (id, name, is_active) VALUES ($1, $2, $3), ($4, $5, $6)`
-	tagInsertArgs := []any{firstTagID, "Fruit", true, secondTagID, "Food", true}
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Apple", "FoodDrinks", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	tagInsertArgs := []any{firstTagID, "Fruit", true, secondTagID, "Food", true, testsupport.HouseholdID}
 	create := &domains.TagToItemCreate{
 		ItemId: itemID,
 		TagIds: []uuid.UUID{firstTagID, secondTagID},
@@ -83,8 +83,8 @@ func TestTagToItemsRepositoryBulkInsertAndGetByItemIDs_ShouldNotErr(t *testing.T
 	_, tagInsertErr := testDB.Exec(tagInsertQuery, tagInsertArgs...)
 
 	// Act
-	ok, insertErr := repo.BulkInsert(ctx, create)
-	tagToItems, getErr := repo.GetByItemIds(ctx, itemIDs)
+	ok, insertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, create)
+	tagToItems, getErr := repo.GetByItemIds(ctx, testsupport.HouseholdID, itemIDs)
 
 	// Assert
 	require.NoError(t, itemInsertErr)
@@ -117,19 +117,21 @@ func TestTagToItemsRepositoryGetByItemIDs_ShouldReturnOnlyRequestedItemLinks(t *
 	firstRequestedTagID := uuid.New()
 	secondRequestedTagID := uuid.New()
 	otherTagID := uuid.New()
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3), ($4, $5, $6)`
-	itemInsertArgs := []any{requestedItemID, "Apple", "FoodDrinks", otherItemID, "Book", "Entertainments"}
-	tagInsertQuery := `INSERT INTO tags (id, name, is_active) VALUES ($1, $2, $3), ($4, $5, $6), ($7, $8, $9)`
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	itemInsertArgs := []any{requestedItemID, "Apple", "FoodDrinks", otherItemID, "Book", "Entertainments", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $10), ($4, $5, $6, $10), ($7, $8, $9, $10)`
 	tagInsertArgs := []any{
 		firstRequestedTagID, "Fruit", true,
 		secondRequestedTagID, "Fresh", true,
 		otherTagID, "Gift", true,
+		testsupport.HouseholdID,
 	}
-	linkInsertQuery := `INSERT INTO tag_to_item (item_id, tag_id) VALUES ($1, $2), ($3, $4), ($5, $6)`
+	linkInsertQuery := `INSERT INTO tag_to_item (item_id, tag_id, household_id) VALUES ($1, $2, $7), ($3, $4, $7), ($5, $6, $7)`
 	linkInsertArgs := []any{
 		requestedItemID, firstRequestedTagID,
 		requestedItemID, secondRequestedTagID,
 		otherItemID, otherTagID,
+		testsupport.HouseholdID,
 	}
 	itemIDs := []uuid.UUID{requestedItemID}
 
@@ -138,7 +140,7 @@ func TestTagToItemsRepositoryGetByItemIDs_ShouldReturnOnlyRequestedItemLinks(t *
 	_, linkInsertErr := testDB.Exec(linkInsertQuery, linkInsertArgs...)
 
 	// Act
-	tagToItems, getErr := repo.GetByItemIds(ctx, itemIDs)
+	tagToItems, getErr := repo.GetByItemIds(ctx, testsupport.HouseholdID, itemIDs)
 
 	// Assert
 	require.NoError(t, itemInsertErr)
@@ -168,8 +170,8 @@ func TestTagToItemsRepositoryBulkInsert_ShouldReturnErrorOnInvalidReference(t *t
 	repo := repositories.NewTagToItemsRepository(testDB, testLogger)
 	itemID := uuid.New()
 	missingTagID := uuid.New()
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Apple", "FoodDrinks"}
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Apple", "FoodDrinks", testsupport.HouseholdID}
 	countQuery := `SELECT COUNT(*) FROM tag_to_item WHERE item_id = $1`
 	create := &domains.TagToItemCreate{
 		ItemId: itemID,
@@ -179,7 +181,7 @@ func TestTagToItemsRepositoryBulkInsert_ShouldReturnErrorOnInvalidReference(t *t
 	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
 
 	// Act
-	ok, insertErr := repo.BulkInsert(ctx, create)
+	ok, insertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, create)
 	var actualCount int
 	countErr := testDB.Get(&actualCount, countQuery, itemID)
 
@@ -200,8 +202,8 @@ func TestTagToItemsRepositoryBulkInsert_ShouldReturnErrorOnEmptyTagIDs(t *testin
 	ctx := testContext
 	repo := repositories.NewTagToItemsRepository(testDB, testLogger)
 	itemID := uuid.New()
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Apple", "FoodDrinks"}
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Apple", "FoodDrinks", testsupport.HouseholdID}
 	create := &domains.TagToItemCreate{
 		ItemId: itemID,
 		TagIds: []uuid.UUID{},
@@ -210,7 +212,7 @@ func TestTagToItemsRepositoryBulkInsert_ShouldReturnErrorOnEmptyTagIDs(t *testin
 	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
 
 	// Act
-	ok, err := repo.BulkInsert(ctx, create)
+	ok, err := repo.BulkInsert(ctx, testsupport.HouseholdID, create)
 
 	// Assert
 	require.NoError(t, itemInsertErr)
@@ -229,10 +231,10 @@ func TestTagToItemsRepositoryBulkDelete_ShouldRemoveOnlySelectedTags(t *testing.
 	itemID := uuid.New()
 	firstTagID := uuid.New()
 	secondTagID := uuid.New()
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Book", "Entertainments"}
-	tagInsertQuery := `INSERT INTO tags (id, name, is_active) VALUES ($1, $2, $3), ($4, $5, $6)`
-	tagInsertArgs := []any{firstTagID, "Paper", true, secondTagID, "Gift", true}
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Book", "Entertainments", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	tagInsertArgs := []any{firstTagID, "Paper", true, secondTagID, "Gift", true, testsupport.HouseholdID}
 	create := &domains.TagToItemCreate{
 		ItemId: itemID,
 		TagIds: []uuid.UUID{firstTagID, secondTagID},
@@ -245,11 +247,11 @@ func TestTagToItemsRepositoryBulkDelete_ShouldRemoveOnlySelectedTags(t *testing.
 
 	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
 	_, tagInsertErr := testDB.Exec(tagInsertQuery, tagInsertArgs...)
-	_, insertErr := repo.BulkInsert(ctx, create)
+	_, insertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, create)
 
 	// Act
-	ok, deleteErr := repo.BulkDelete(ctx, deleteInput)
-	tagToItems, getErr := repo.GetByItemIds(ctx, itemIDs)
+	ok, deleteErr := repo.BulkDelete(ctx, testsupport.HouseholdID, deleteInput)
+	tagToItems, getErr := repo.GetByItemIds(ctx, testsupport.HouseholdID, itemIDs)
 
 	// Assert
 	require.NoError(t, itemInsertErr)
@@ -274,12 +276,12 @@ func TestTagToItemsRepositoryBulkDelete_ShouldReturnFalseWhenNothingDeleted(t *t
 	itemID := uuid.New()
 	existingTagID := uuid.New()
 	missingTagID := uuid.New()
-	itemInsertQuery := `INSERT INTO items (id, name, category) VALUES ($1, $2, $3)`
-	itemInsertArgs := []any{itemID, "Milk", "FoodDrinks"}
-	tagInsertQuery := `INSERT INTO tags (id, name, is_active) VALUES ($1, $2, $3), ($4, $5, $6)`
-	tagInsertArgs := []any{existingTagID, "Dairy", true, missingTagID, "Unused", true}
-	linkInsertQuery := `INSERT INTO tag_to_item (item_id, tag_id) VALUES ($1, $2)`
-	linkInsertArgs := []any{itemID, existingTagID}
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	itemInsertArgs := []any{itemID, "Milk", "FoodDrinks", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	tagInsertArgs := []any{existingTagID, "Dairy", true, missingTagID, "Unused", true, testsupport.HouseholdID}
+	linkInsertQuery := `INSERT INTO tag_to_item (item_id, tag_id, household_id) VALUES ($1, $2, $3)`
+	linkInsertArgs := []any{itemID, existingTagID, testsupport.HouseholdID}
 	deleteInput := &domains.TagToItemDelete{
 		ItemId: itemID,
 		TagIds: []uuid.UUID{missingTagID},
@@ -291,7 +293,7 @@ func TestTagToItemsRepositoryBulkDelete_ShouldReturnFalseWhenNothingDeleted(t *t
 	_, linkInsertErr := testDB.Exec(linkInsertQuery, linkInsertArgs...)
 
 	// Act
-	ok, deleteErr := repo.BulkDelete(ctx, deleteInput)
+	ok, deleteErr := repo.BulkDelete(ctx, testsupport.HouseholdID, deleteInput)
 	var actualCount int
 	countErr := testDB.Get(&actualCount, countQuery, itemID)
 
@@ -316,7 +318,7 @@ func TestTagToItemsRepositoryBulkDelete_ShouldReturnFalseOnEmptyTagIDs(t *testin
 	}
 
 	// Act
-	ok, err := repo.BulkDelete(ctx, deleteInput)
+	ok, err := repo.BulkDelete(ctx, testsupport.HouseholdID, deleteInput)
 
 	// Assert
 	require.NoError(t, err)
@@ -333,9 +335,171 @@ func TestTagToItemsRepositoryBulkDelete_ShouldReturnErrorOnNilItemID(t *testing.
 	}
 
 	// Act
-	ok, err := repo.BulkDelete(ctx, deleteInput)
+	ok, err := repo.BulkDelete(ctx, testsupport.HouseholdID, deleteInput)
 
 	// Assert
 	require.EqualError(t, err, "itemId should not be nil")
 	assert.False(t, ok)
 }
+
+func TestTagToItemsRepositoryCopyToTag_ShouldSkipItemsThatAlreadyHaveTarget(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagToItemsRepository(testDB, testLogger)
+	firstItemID := uuid.New()
+	secondItemID := uuid.New()
+	sourceTagID := uuid.New()
+	targetTagID := uuid.New()
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	itemInsertArgs := []any{firstItemID, "Book", "Entertainments", secondItemID, "Pen", "Entertainments", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	tagInsertArgs := []any{sourceTagID, "Paper", true, targetTagID, "Stationery", true, testsupport.HouseholdID}
+
+	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
+	_, tagInsertErr := testDB.Exec(tagInsertQuery, tagInsertArgs...)
+	_, firstInsertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, &domains.TagToItemCreate{ItemId: firstItemID, TagIds: []uuid.UUID{sourceTagID, targetTagID}})
+	_, secondInsertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, &domains.TagToItemCreate{ItemId: secondItemID, TagIds: []uuid.UUID{sourceTagID}})
+
+	// Act
+	copied, copyErr := repo.CopyToTag(ctx, testsupport.HouseholdID, sourceTagID, targetTagID)
+	sourceCount, sourceCountErr := repo.CountByTagId(ctx, testsupport.HouseholdID, sourceTagID)
+	targetCount, targetCountErr := repo.CountByTagId(ctx, testsupport.HouseholdID, targetTagID)
+
+	// Assert
+	require.NoError(t, itemInsertErr)
+	require.NoError(t, tagInsertErr)
+	require.NoError(t, firstInsertErr)
+	require.NoError(t, secondInsertErr)
+	require.NoError(t, copyErr)
+	require.NoError(t, sourceCountErr)
+	require.NoError(t, targetCountErr)
+	assert.Equal(t, int64(1), copied)
+	assert.Equal(t, int64(2), sourceCount)
+	assert.Equal(t, int64(2), targetCount)
+}
+
+func TestTagToItemsRepositoryCountByTagId_ShouldIgnoreItemsInTheTrash(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagToItemsRepository(testDB, testLogger)
+	itemID := uuid.New()
+	tagID := uuid.New()
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id, deleted_at) VALUES ($1, $2, $3, $4, now())`
+	itemInsertArgs := []any{itemID, "Book", "Entertainments", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $4)`
+	tagInsertArgs := []any{tagID, "Paper", true, testsupport.HouseholdID}
+
+	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
+	_, tagInsertErr := testDB.Exec(tagInsertQuery, tagInsertArgs...)
+	_, insertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, &domains.TagToItemCreate{ItemId: itemID, TagIds: []uuid.UUID{tagID}})
+
+	// Act
+	count, countErr := repo.CountByTagId(ctx, testsupport.HouseholdID, tagID)
+
+	// Assert
+	require.NoError(t, itemInsertErr)
+	require.NoError(t, tagInsertErr)
+	require.NoError(t, insertErr)
+	require.NoError(t, countErr)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestTagToItemsRepositoryAddByItemFilter_ShouldLinkMatchingItemsOutsideTheTrash(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagToItemsRepository(testDB, testLogger)
+	bookID := uuid.New()
+	penID := uuid.New()
+	trashedID := uuid.New()
+	coffeeID := uuid.New()
+	tagID := uuid.New()
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	itemInsertArgs := []any{bookID, "Book", "Entertainments", coffeeID, "Coffee", "FoodDrinks", testsupport.HouseholdID}
+	trashedInsertQuery := `INSERT INTO items (id, name, category, household_id, deleted_at) VALUES ($1, $2, $3, $4, now())`
+	trashedInsertArgs := []any{trashedID, "Old book", "Entertainments", testsupport.HouseholdID}
+	penInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $4)`
+	penInsertArgs := []any{penID, "Pen", "Entertainments", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $4)`
+	tagInsertArgs := []any{tagID, "Paper", true, testsupport.HouseholdID}
+	category := domains.ItemCategory("Entertainments")
+
+	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
+	_, trashedInsertErr := testDB.Exec(trashedInsertQuery, trashedInsertArgs...)
+	_, penInsertErr := testDB.Exec(penInsertQuery, penInsertArgs...)
+	_, tagInsertErr := testDB.Exec(tagInsertQuery, tagInsertArgs...)
+	_, linkErr := repo.BulkInsert(ctx, testsupport.HouseholdID, &domains.TagToItemCreate{ItemId: penID, TagIds: []uuid.UUID{tagID}})
+	filter := &domains.ItemFilter{Categories: []*domains.ItemCategory{&category}}
+
+	// Act
+	added, addErr := repo.AddByItemFilter(ctx, testsupport.HouseholdID, filter, []uuid.UUID{tagID})
+	var versions []int32
+	versionsErr := testDB.Select(&versions, "SELECT version FROM items WHERE id IN ($1, $2) ORDER BY name", bookID, penID)
+
+	// Assert
+	require.NoError(t, itemInsertErr)
+	require.NoError(t, trashedInsertErr)
+	require.NoError(t, penInsertErr)
+	require.NoError(t, tagInsertErr)
+	require.NoError(t, linkErr)
+	require.NoError(t, addErr)
+	require.NoError(t, versionsErr)
+	assert.Equal(t, []domains.TagToItem{{TagId: tagID, ItemId: bookID}}, added)
+	assert.Equal(t, []int32{2, 1}, versions)
+}
+
+func TestTagToItemsRepositoryRemoveByItemFilter_ShouldUnlinkOnlyGivenTags(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	repo := repositories.NewTagToItemsRepository(testDB, testLogger)
+	firstItemID := uuid.New()
+	secondItemID := uuid.New()
+	removedTagID := uuid.New()
+	keptTagID := uuid.New()
+	itemInsertQuery := `INSERT INTO items (id, name, category, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	itemInsertArgs := []any{firstItemID, "Book", "Entertainments", secondItemID, "Pen", "Entertainments", testsupport.HouseholdID}
+	tagInsertQuery := `INSERT INTO tags (id, name, is_active, household_id) VALUES ($1, $2, $3, $7), ($4, $5, $6, $7)`
+	tagInsertArgs := []any{removedTagID, "Paper", true, keptTagID, "Stationery", true, testsupport.HouseholdID}
+
+	_, itemInsertErr := testDB.Exec(itemInsertQuery, itemInsertArgs...)
+	_, tagInsertErr := testDB.Exec(tagInsertQuery, tagInsertArgs...)
+	_, firstInsertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, &domains.TagToItemCreate{ItemId: firstItemID, TagIds: []uuid.UUID{removedTagID, keptTagID}})
+	_, secondInsertErr := repo.BulkInsert(ctx, testsupport.HouseholdID, &domains.TagToItemCreate{ItemId: secondItemID, TagIds: []uuid.UUID{removedTagID}})
+	filter := &domains.ItemFilter{Ids: []*uuid.UUID{&firstItemID}}
+
+	// Act
+	removed, removeErr := repo.RemoveByItemFilter(ctx, testsupport.HouseholdID, filter, []uuid.UUID{removedTagID})
+	removedCount, removedCountErr := repo.CountByTagId(ctx, testsupport.HouseholdID, removedTagID)
+	keptCount, keptCountErr := repo.CountByTagId(ctx, testsupport.HouseholdID, keptTagID)
+	var versions []int32
+	versionsErr := testDB.Select(&versions, "SELECT version FROM items WHERE id IN ($1, $2) ORDER BY name", firstItemID, secondItemID)
+
+	// Assert
+	require.NoError(t, itemInsertErr)
+	require.NoError(t, tagInsertErr)
+	require.NoError(t, firstInsertErr)
+	require.NoError(t, secondInsertErr)
+	require.NoError(t, removeErr)
+	require.NoError(t, removedCountErr)
+	require.NoError(t, keptCountErr)
+	require.NoError(t, versionsErr)
+	assert.Equal(t, []domains.TagToItem{{TagId: removedTagID, ItemId: firstItemID}}, removed)
+	assert.Equal(t, []int32{2, 1}, versions)
+	assert.Equal(t, int64(1), removedCount)
+	assert.Equal(t, int64(1), keptCount)
+}
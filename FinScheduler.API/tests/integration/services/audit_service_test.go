@@ -0,0 +1,137 @@
+//go:build integration
+// +build integration
+
+package services_test
+
+import (
+	"encoding/json"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func auditFilterFor(entityID uuid.UUID) *domains.AuditFilter {
+	page := int32(0)
+	pageSize := int32(20)
+
+	return &domains.AuditFilter{EntityId: &entityID, Page: &page, PageSize: &pageSize}
+}
+
+func decodeChanges(t *testing.T, event domains.AuditEventDto) map[string]domains.AuditChange {
+	t.Helper()
+
+	var changes map[string]domains.AuditChange
+	require.NoError(t, json.Unmarshal(event.Changes, &changes))
+
+	return changes
+}
+
+func Test_AuditService_ShouldRecordItemCreateUpdateAndDelete(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+
+	itemID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Coffee", Price: decimal.RequireFromString("10.50"), Category: "FoodDrinks", Cashback: 5})
+	require.NoError(t, err)
+
+	// Act
+	_, updateErr := itemsService.Update(ctx, itemID, &domains.ItemUpdate{Name: "Coffee", Price: decimal.RequireFromString("10.50"), Category: "FoodDrinks", Cashback: 7}, nil)
+	_, deleteErr := itemsService.Delete(ctx, itemID, nil)
+	events, count, listErr := auditService.GetListing(ctx, auditFilterFor(itemID))
+
+	// Assert
+	require.NoError(t, updateErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, listErr)
+	require.Len(t, events, 3)
+	assert.Equal(t, int64(3), count)
+
+	deleted, updated, created := events[0], events[1], events[2]
+	assert.Equal(t, domains.AuditOperationDelete, deleted.Operation)
+	assert.Equal(t, domains.AuditOperationUpdate, updated.Operation)
+	assert.Equal(t, domains.AuditOperationCreate, created.Operation)
+	for _, event := range events {
+		assert.Equal(t, testsupport.OwnerID, event.ActorId)
+		assert.Equal(t, domains.AuditEntityItem, event.EntityType)
+		assert.Nil(t, event.ApiKeyId)
+	}
+
+	assert.Equal(t, map[string]domains.AuditChange{"cashback": {Before: float64(5), After: float64(7)}}, decodeChanges(t, updated))
+	assert.Equal(t, "Coffee", decodeChanges(t, created)["name"].After)
+	assert.Nil(t, decodeChanges(t, created)["name"].Before)
+	assert.Equal(t, "Coffee", decodeChanges(t, deleted)["name"].Before)
+	assert.Nil(t, decodeChanges(t, deleted)["name"].After)
+}
+
+func Test_AuditService_ShouldRecordEveryItemOfBulkCashbackUpdate(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+
+	firstID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Coffee", Category: "FoodDrinks", Cashback: 1})
+	require.NoError(t, err)
+	secondID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Tea", Category: "FoodDrinks", Cashback: 2})
+	require.NoError(t, err)
+
+	// Act
+	affected, updateErr := itemsService.UpdateCashbackByIds(ctx, &domains.ItemCashbackByIdsUpdate{Cashback: 10, ItemIds: []string{firstID.String(), secondID.String()}})
+	firstEvents, _, firstErr := auditService.GetListing(ctx, auditFilterFor(firstID))
+	secondEvents, _, secondErr := auditService.GetListing(ctx, auditFilterFor(secondID))
+
+	// Assert
+	require.NoError(t, updateErr)
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, int64(2), affected)
+	require.Len(t, firstEvents, 2)
+	require.Len(t, secondEvents, 2)
+	assert.Equal(t, domains.AuditOperationCashbackUpdate, firstEvents[0].Operation)
+	assert.Equal(t, map[string]domains.AuditChange{"cashback": {Before: float64(1), After: float64(10)}}, decodeChanges(t, firstEvents[0]))
+	assert.Equal(t, map[string]domains.AuditChange{"cashback": {Before: float64(2), After: float64(10)}}, decodeChanges(t, secondEvents[0]))
+}
+
+func Test_AuditService_ShouldNotRecordUpdateThatChangesNothing(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+
+	tagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Groceries", IsActive: true})
+	require.NoError(t, err)
+
+	// Act
+	_, updateErr := tagsService.Update(ctx, tagID, &domains.TagUpdate{Name: "Groceries", IsActive: true}, nil)
+	events, count, listErr := auditService.GetListing(ctx, auditFilterFor(tagID))
+
+	// Assert
+	require.NoError(t, updateErr)
+	require.NoError(t, listErr)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, domains.AuditOperationCreate, events[0].Operation)
+	assert.Equal(t, domains.AuditEntityTag, events[0].EntityType)
+}
@@ -0,0 +1,132 @@
+//go:build integration
+// +build integration
+
+package services_test
+
+import (
+	"database/sql"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ItemsService_BulkChange_DryRunShouldListMatchesWithoutChangingThem(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	coffeeID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Coffee", Price: decimal.NewFromFloat(5), Category: "FoodDrinks", IsActive: true})
+	require.NoError(t, err)
+	_, err = itemsService.Create(ctx, &domains.ItemCreate{Name: "Book", Price: decimal.NewFromFloat(20), Category: "Education", IsActive: true})
+	require.NoError(t, err)
+	isActive := false
+
+	// Act
+	result, bulkErr := itemsService.BulkChange(ctx, &domains.ItemBulkChange{
+		Filter:    &domains.ItemSelection{Categories: []domains.ItemCategory{domains.FoodDrinks}},
+		Operation: domains.ItemBulkSetActive,
+		IsActive:  &isActive,
+		DryRun:    true,
+	})
+	coffee, getErr := itemsService.GetDetailedInfo(ctx, coffeeID)
+
+	// Assert
+	require.NoError(t, bulkErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, int64(1), result.Matched)
+	assert.Equal(t, int64(0), result.Affected)
+	assert.Equal(t, []uuid.UUID{coffeeID}, result.ItemIds)
+	assert.True(t, coffee.IsActive)
+}
+
+func Test_ItemsService_BulkChange_AdjustPriceShouldWritePriceHistoryAndAudit(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+	coffeeID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Coffee", Price: decimal.NewFromFloat(5), Category: "FoodDrinks"})
+	require.NoError(t, err)
+	freeID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Water", Price: decimal.Zero, Category: "FoodDrinks"})
+	require.NoError(t, err)
+	percent := decimal.NewFromInt(20)
+
+	// Act
+	result, bulkErr := itemsService.BulkChange(ctx, &domains.ItemBulkChange{
+		Filter:       &domains.ItemSelection{All: true},
+		Operation:    domains.ItemBulkAdjustPrice,
+		PricePercent: &percent,
+	})
+	var history []decimal.Decimal
+	historyErr := testDB.Select(&history, "SELECT value FROM price_history WHERE item_id = $1", coffeeID)
+	coffeeEvents, _, coffeeEventsErr := auditService.GetListing(ctx, auditFilterFor(coffeeID))
+	freeEvents, _, freeEventsErr := auditService.GetListing(ctx, auditFilterFor(freeID))
+
+	// Assert
+	require.NoError(t, bulkErr)
+	require.NoError(t, historyErr)
+	require.NoError(t, coffeeEventsErr)
+	require.NoError(t, freeEventsErr)
+	assert.Equal(t, int64(2), result.Matched)
+	assert.Equal(t, int64(1), result.Affected)
+	assert.Equal(t, []uuid.UUID{coffeeID}, result.ItemIds)
+	require.Len(t, history, 1)
+	assert.Equal(t, "6.00", history[0].StringFixed(2))
+	require.Len(t, coffeeEvents, 2)
+	assert.Equal(t, domains.AuditOperationUpdate, coffeeEvents[0].Operation)
+	changes := decodeChanges(t, coffeeEvents[0])
+	require.Len(t, changes, 1)
+	assert.Equal(t, "5", changes["price"].Before)
+	assert.Equal(t, "6", changes["price"].After)
+	assert.Len(t, freeEvents, 1)
+}
+
+func Test_ItemsService_BulkChange_DeleteShouldMoveMatchesToTrash(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	coffeeID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Coffee", Price: decimal.NewFromFloat(5), Category: "FoodDrinks"})
+	require.NoError(t, err)
+	bookID, err := itemsService.Create(ctx, &domains.ItemCreate{Name: "Book", Price: decimal.NewFromFloat(20), Category: "Education"})
+	require.NoError(t, err)
+	name := "Coff"
+
+	// Act
+	result, bulkErr := itemsService.BulkChange(ctx, &domains.ItemBulkChange{
+		Filter:    &domains.ItemSelection{Name: &name},
+		Operation: domains.ItemBulkDelete,
+	})
+	_, coffeeErr := itemsService.GetDetailedInfo(ctx, coffeeID)
+	_, bookErr := itemsService.GetDetailedInfo(ctx, bookID)
+	trash, _, trashErr := itemsService.GetTrash(ctx, trashFilter())
+
+	// Assert
+	require.NoError(t, bulkErr)
+	require.NoError(t, trashErr)
+	require.ErrorIs(t, coffeeErr, sql.ErrNoRows)
+	require.NoError(t, bookErr)
+	assert.Equal(t, int64(1), result.Affected)
+	require.Len(t, trash, 1)
+	assert.Equal(t, coffeeID, trash[0].Id)
+}
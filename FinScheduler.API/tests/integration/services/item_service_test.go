@@ -44,13 +44,13 @@ func Test_ItemsService_Flow_CreateAndGetListingInfo_ShouldNotErr(t *testing.T) {
 		PageSize: &pageSize,
 	}
 
-	items, count, getErr := service.GetListingInfo(ctx, &filter)
+	items, pageInfo, getErr := service.GetListingInfo(ctx, &filter)
 
 	// Assert
 	require.NoError(t, createErr)
 	require.NoError(t, getErr)
 	require.Len(t, items, 1)
-	assert.Equal(t, int64(1), count)
+	assert.Equal(t, int64(1), *pageInfo.Count)
 	assert.Equal(t, expectedName, items[0].Name)
 }
 
@@ -104,7 +104,7 @@ func Test_ItemsService_GetDetailedInfo_ShouldReturnPriceHistoryOrderedByDateDesc
 	itemName := "Milk"
 	olderDate := "2026-01-10"
 	newerDate := "2026-01-15"
-	insertHistoryQuery := `INSERT INTO price_history (id, item_id, recorded_at, value) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)`
+	insertHistoryQuery := `INSERT INTO price_history (id, item_id, recorded_at, value, household_id) VALUES ($1, $2, $3, $4, $9), ($5, $6, $7, $8, $9)`
 	create := &domains.ItemCreate{
 		Name:     itemName,
 		Price:    decimal.RequireFromString("10.00"),
@@ -116,6 +116,7 @@ func Test_ItemsService_GetDetailedInfo_ShouldReturnPriceHistoryOrderedByDateDesc
 		insertHistoryQuery,
 		uuid.New(), itemID, olderDate, decimal.RequireFromString("9.50"),
 		uuid.New(), itemID, newerDate, decimal.RequireFromString("11.25"),
+		testsupport.HouseholdID,
 	)
 
 	// Act
@@ -128,13 +129,13 @@ func Test_ItemsService_GetDetailedInfo_ShouldReturnPriceHistoryOrderedByDateDesc
 	require.NotNil(t, item)
 	require.Len(t, item.PriceHistory, 2)
 	assert.Equal(t, newerDate, item.PriceHistory[0].Point.UTC().Format("2006-01-02"))
-	assert.True(t, decimal.RequireFromString("11.25").Equal(item.PriceHistory[0].Value))
+	assert.True(t, decimal.RequireFromString("11.25").Equal(item.PriceHistory[0].Value.Amount))
 	require.NotNil(t, item.PriceHistory[0].AbsoluteChange)
 	require.NotNil(t, item.PriceHistory[0].PercentChange)
-	assert.True(t, decimal.RequireFromString("1.75").Equal(*item.PriceHistory[0].AbsoluteChange))
+	assert.True(t, decimal.RequireFromString("1.75").Equal(item.PriceHistory[0].AbsoluteChange.Amount))
 	assert.True(t, decimal.RequireFromString("18.42105263157895").Equal(*item.PriceHistory[0].PercentChange))
 	assert.Equal(t, olderDate, item.PriceHistory[1].Point.UTC().Format("2006-01-02"))
-	assert.True(t, decimal.RequireFromString("9.50").Equal(item.PriceHistory[1].Value))
+	assert.True(t, decimal.RequireFromString("9.50").Equal(item.PriceHistory[1].Value.Amount))
 	assert.Nil(t, item.PriceHistory[1].AbsoluteChange)
 	assert.Nil(t, item.PriceHistory[1].PercentChange)
 }
@@ -168,14 +169,14 @@ func Test_ItemsService_UpdateAndGetListingInfo_ShouldNotErr(t *testing.T) {
 
 	// Act
 	id, createErr := service.Create(ctx, create)
-	ok, updateErr := service.Update(ctx, id, update)
+	ok, updateErr := service.Update(ctx, id, update, nil)
 	filter := domains.ItemFilter{
 		Ids:      []*uuid.UUID{&id},
 		Page:     &page,
 		PageSize: &pageSize,
 	}
 
-	items, count, getErr := service.GetListingInfo(ctx, &filter)
+	items, pageInfo, getErr := service.GetListingInfo(ctx, &filter)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -183,9 +184,9 @@ func Test_ItemsService_UpdateAndGetListingInfo_ShouldNotErr(t *testing.T) {
 	require.NoError(t, getErr)
 	require.True(t, ok)
 	require.Len(t, items, 1)
-	assert.Equal(t, int64(1), count)
+	assert.Equal(t, int64(1), *pageInfo.Count)
 	assert.Equal(t, updatedName, items[0].Name)
-	assert.Equal(t, updatedPrice, items[0].Price)
+	assert.True(t, decimal.NewFromFloat(updatedPrice).Equal(items[0].Price.Amount))
 }
 
 func Test_ItemsService_Update_ShouldReconcileTagLinks(t *testing.T) {
@@ -223,7 +224,7 @@ func Test_ItemsService_Update_ShouldReconcileTagLinks(t *testing.T) {
 	query := "SELECT tag_id FROM tag_to_item WHERE item_id = $1 ORDER BY tag_id"
 
 	// Act
-	ok, updateErr := itemsService.Update(ctx, itemID, update)
+	ok, updateErr := itemsService.Update(ctx, itemID, update, nil)
 	var actualTagIDs []uuid.UUID
 	item, getErr := itemsService.GetDetailedInfo(ctx, itemID)
 	selectErr := testDB.Select(&actualTagIDs, query, itemID)
@@ -244,6 +245,122 @@ func Test_ItemsService_Update_ShouldReconcileTagLinks(t *testing.T) {
 	assert.Equal(t, []uuid.UUID{secondTagID}, actualTagIDs)
 }
 
+func Test_ItemsService_Patch_ShouldUpdateOnlySuppliedFieldsAndKeepTags(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	itemName := "Patched item"
+	itemDescription := "Original description"
+	itemPrice := decimal.RequireFromString("10.00")
+	tagCreate := &domains.TagCreate{Name: "Kept Tag"}
+
+	tagID, tagCreateErr := tagsService.Create(ctx, tagCreate)
+
+	itemCreate := &domains.ItemCreate{
+		Name:        itemName,
+		Price:       itemPrice,
+		Description: itemDescription,
+		IsActive:    true,
+		Cashback:    3,
+		Category:    "FoodDrinks",
+		TagIds:      []string{tagID.String()},
+	}
+	itemID, itemCreateErr := itemsService.Create(ctx, itemCreate)
+
+	isActive := false
+	patch := &domains.ItemPatch{IsActive: &isActive}
+	query := "SELECT tag_id FROM tag_to_item WHERE item_id = $1"
+
+	// Act
+	ok, patchErr := itemsService.Patch(ctx, itemID, patch, nil)
+	var actualTagIDs []uuid.UUID
+	item, getErr := itemsService.GetDetailedInfo(ctx, itemID)
+	selectErr := testDB.Select(&actualTagIDs, query, itemID)
+
+	// Assert
+	require.NoError(t, tagCreateErr)
+	require.NoError(t, itemCreateErr)
+	require.NoError(t, patchErr)
+	require.NoError(t, getErr)
+	require.NoError(t, selectErr)
+	require.True(t, ok)
+	require.NotNil(t, item)
+	assert.False(t, item.IsActive)
+	assert.Equal(t, itemName, item.Name)
+	assert.Equal(t, itemDescription, item.Description)
+	assert.Equal(t, int32(3), item.Cashback)
+	assert.True(t, itemPrice.Equal(item.Price.Amount))
+	assert.Equal(t, []uuid.UUID{tagID}, actualTagIDs)
+}
+
+func Test_ItemsService_Patch_ShouldReconcileTagLinksWhenPresent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	firstTagCreate := &domains.TagCreate{Name: "Old Tag"}
+	secondTagCreate := &domains.TagCreate{Name: "New Tag"}
+
+	firstTagID, firstTagCreateErr := tagsService.Create(ctx, firstTagCreate)
+	secondTagID, secondTagCreateErr := tagsService.Create(ctx, secondTagCreate)
+
+	itemCreate := &domains.ItemCreate{
+		Name:     "Tagged item",
+		Category: "FoodDrinks",
+		TagIds:   []string{firstTagID.String()},
+	}
+	itemID, itemCreateErr := itemsService.Create(ctx, itemCreate)
+
+	tagIDs := []string{secondTagID.String()}
+	patch := &domains.ItemPatch{TagIds: &tagIDs}
+	query := "SELECT tag_id FROM tag_to_item WHERE item_id = $1"
+
+	// Act
+	ok, patchErr := itemsService.Patch(ctx, itemID, patch, nil)
+	var actualTagIDs []uuid.UUID
+	selectErr := testDB.Select(&actualTagIDs, query, itemID)
+
+	// Assert
+	require.NoError(t, firstTagCreateErr)
+	require.NoError(t, secondTagCreateErr)
+	require.NoError(t, itemCreateErr)
+	require.NoError(t, patchErr)
+	require.NoError(t, selectErr)
+	require.True(t, ok)
+	assert.Equal(t, []uuid.UUID{secondTagID}, actualTagIDs)
+}
+
+func Test_ItemsService_PatchMissing_ShouldReturnFalseWithoutErr(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	service := services.NewItemsService(uow, testLogger)
+	isActive := true
+	patch := &domains.ItemPatch{IsActive: &isActive}
+
+	// Act
+	ok, err := service.Patch(ctx, uuid.New(), patch, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
 func Test_ItemsService_Update_ShouldUpsertPriceHistoryWhenPriceChanged(t *testing.T) {
 	// Arrange
 	t.Cleanup(func() {
@@ -269,7 +386,7 @@ func Test_ItemsService_Update_ShouldUpsertPriceHistoryWhenPriceChanged(t *testin
 	itemID, itemCreateErr := itemsService.Create(ctx, create)
 
 	// Act
-	ok, updateErr := itemsService.Update(ctx, itemID, update)
+	ok, updateErr := itemsService.Update(ctx, itemID, update, nil)
 	item, getErr := itemsService.GetDetailedInfo(ctx, itemID)
 	var actualCount int
 	countErr := testDB.Get(&actualCount, countQuery, itemID)
@@ -284,7 +401,7 @@ func Test_ItemsService_Update_ShouldUpsertPriceHistoryWhenPriceChanged(t *testin
 	require.Len(t, item.PriceHistory, 1)
 	assert.Equal(t, 1, actualCount)
 	assert.Equal(t, todayUTC, item.PriceHistory[0].Point.UTC().Format("2006-01-02"))
-	assert.True(t, decimal.RequireFromString("12.50").Equal(item.PriceHistory[0].Value))
+	assert.True(t, decimal.RequireFromString("12.50").Equal(item.PriceHistory[0].Value.Amount))
 	assert.Nil(t, item.PriceHistory[0].AbsoluteChange)
 	assert.Nil(t, item.PriceHistory[0].PercentChange)
 }
@@ -313,7 +430,7 @@ func Test_ItemsService_Update_ShouldNotUpsertPriceHistoryWhenPriceIsUnchanged(t
 	itemID, itemCreateErr := itemsService.Create(ctx, create)
 
 	// Act
-	ok, updateErr := itemsService.Update(ctx, itemID, update)
+	ok, updateErr := itemsService.Update(ctx, itemID, update, nil)
 	item, getErr := itemsService.GetDetailedInfo(ctx, itemID)
 	var actualCount int
 	countErr := testDB.Get(&actualCount, countQuery, itemID)
@@ -350,14 +467,14 @@ func Test_ItemsService_DeleteAndGetListingInfo_ShouldErr(t *testing.T) {
 
 	// Act
 	id, createErr := service.Create(ctx, create)
-	ok, deleteErr := service.Delete(ctx, id)
+	ok, deleteErr := service.Delete(ctx, id, nil)
 	filter := domains.ItemFilter{
 		Ids:      []*uuid.UUID{&id},
 		Page:     &page,
 		PageSize: &pageSize,
 	}
 
-	items, count, getErr := service.GetListingInfo(ctx, &filter)
+	items, pageInfo, getErr := service.GetListingInfo(ctx, &filter)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -365,7 +482,39 @@ func Test_ItemsService_DeleteAndGetListingInfo_ShouldErr(t *testing.T) {
 	require.NoError(t, getErr)
 	require.True(t, ok)
 	assert.Empty(t, items)
-	assert.Equal(t, int64(0), count)
+	assert.Equal(t, int64(0), *pageInfo.Count)
+}
+
+func Test_ItemsService_Delete_ShouldReturnPreconditionFailedOnStaleVersion(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	service := services.NewItemsService(uow, testLogger)
+	staleVersion := int32(1)
+	isActive := true
+	create := &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+	}
+
+	// Act
+	id, createErr := service.Create(ctx, create)
+	_, patchErr := service.Patch(ctx, id, &domains.ItemPatch{IsActive: &isActive}, &staleVersion)
+	ok, deleteErr := service.Delete(ctx, id, &staleVersion)
+	item, getErr := service.GetDetailedInfo(ctx, id)
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, patchErr)
+	require.NoError(t, getErr)
+	require.ErrorIs(t, deleteErr, domains.ErrPreconditionFailed)
+	assert.False(t, ok)
+	assert.Equal(t, int32(2), item.Version)
 }
 
 func Test_ItemsService_UpdateMissing_ShouldReturnFalseWithoutErr(t *testing.T) {
@@ -381,7 +530,7 @@ func Test_ItemsService_UpdateMissing_ShouldReturnFalseWithoutErr(t *testing.T) {
 	}
 
 	// Act
-	ok, err := service.Update(ctx, missingID, update)
+	ok, err := service.Update(ctx, missingID, update, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -396,7 +545,7 @@ func Test_ItemsService_DeleteMissing_ShouldReturnFalseWithoutErr(t *testing.T) {
 	missingID := uuid.New()
 
 	// Act
-	ok, err := service.Delete(ctx, missingID)
+	ok, err := service.Delete(ctx, missingID, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -435,3 +584,76 @@ func Test_ItemsService_Create_ShouldRollbackItemWhenTagInsertFails(t *testing.T)
 	assert.NotEqual(t, uuid.Nil, id)
 	assert.Equal(t, expectedCount, count)
 }
+
+func Test_ItemsService_CreateIdempotent_ShouldRollbackClaimWhenCreateFails(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	service := services.NewItemsService(uow, testLogger)
+	countQuery := "SELECT COUNT(*) FROM idempotency_keys WHERE key = $1"
+	claim := &domains.IdempotencyClaim{Scope: "POST /items", Key: "rollback-1", RequestHash: "hash"}
+	create := &domains.ItemCreate{
+		Name:     "Rollback",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+		TagIds:   []string{uuid.New().String()},
+	}
+
+	// Act
+	_, replay, createErr := service.CreateIdempotent(ctx, create, claim)
+	var count int
+	countErr := testDB.Get(&count, countQuery, claim.Key)
+
+	// Assert
+	require.ErrorIs(t, createErr, domains.ErrInvalidReference)
+	require.NoError(t, countErr)
+	assert.Nil(t, replay)
+	assert.Equal(t, 0, count)
+}
+
+func Test_ItemsService_GetListingInfo_ShouldPageForwardAndBackByCursor(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	service := services.NewItemsService(uow, testLogger)
+	for _, name := range []string{"Oldest", "Middle", "Newest"} {
+		_, err := service.Create(ctx, &domains.ItemCreate{Name: name, Category: "FoodDrinks"})
+		require.NoError(t, err)
+	}
+
+	page := int32(0)
+	pageSize := int32(2)
+	includeCount := false
+
+	// Act
+	first, firstPage, firstErr := service.GetListingInfo(ctx, &domains.ItemFilter{Page: &page, PageSize: &pageSize, IncludeCount: &includeCount})
+	require.NoError(t, firstErr)
+	require.NotNil(t, firstPage.Next)
+	second, secondPage, secondErr := service.GetListingInfo(ctx, &domains.ItemFilter{PageSize: &pageSize, After: firstPage.Next})
+	require.NoError(t, secondErr)
+	require.NotNil(t, secondPage.Previous)
+	back, backPage, backErr := service.GetListingInfo(ctx, &domains.ItemFilter{PageSize: &pageSize, Before: secondPage.Previous})
+
+	// Assert
+	require.NoError(t, backErr)
+	require.Len(t, first, 2)
+	assert.Equal(t, "Newest", first[0].Name)
+	assert.Equal(t, "Middle", first[1].Name)
+	assert.Nil(t, firstPage.Count)
+	assert.Nil(t, firstPage.Previous)
+	require.Len(t, second, 1)
+	assert.Equal(t, "Oldest", second[0].Name)
+	assert.Equal(t, int64(3), *secondPage.Count)
+	assert.Nil(t, secondPage.Next)
+	assert.Equal(t, first, back)
+	assert.Nil(t, backPage.Previous)
+	assert.NotNil(t, backPage.Next)
+}
@@ -0,0 +1,167 @@
+//go:build integration
+// +build integration
+
+package services_test
+
+import (
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ItemsService_AddTags_ShouldLinkTagToFilteredItemsAndRecordAudit(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+	existingTagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Breakfast", IsActive: true})
+	require.NoError(t, err)
+	tagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Groceries", IsActive: true})
+	require.NoError(t, err)
+	coffeeID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(5),
+		Category: "FoodDrinks",
+		TagIds:   []string{existingTagID.String()},
+	})
+	require.NoError(t, err)
+	teaID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Tea",
+		Price:    decimal.NewFromFloat(3),
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	})
+	require.NoError(t, err)
+	bookID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Book",
+		Price:    decimal.NewFromFloat(20),
+		Category: "Education",
+	})
+	require.NoError(t, err)
+
+	// Act
+	changed, addErr := itemsService.AddTags(ctx, &domains.ItemTagsChange{
+		Filter: &domains.ItemSelection{Categories: []domains.ItemCategory{domains.FoodDrinks}},
+		TagIds: []string{tagID.String()},
+	})
+	coffee, coffeeErr := itemsService.GetDetailedInfo(ctx, coffeeID)
+	book, bookErr := itemsService.GetDetailedInfo(ctx, bookID)
+	coffeeEvents, _, coffeeEventsErr := auditService.GetListing(ctx, auditFilterFor(coffeeID))
+	teaEvents, _, teaEventsErr := auditService.GetListing(ctx, auditFilterFor(teaID))
+
+	// Assert
+	require.NoError(t, addErr)
+	require.NoError(t, coffeeErr)
+	require.NoError(t, bookErr)
+	require.NoError(t, coffeeEventsErr)
+	require.NoError(t, teaEventsErr)
+	assert.Equal(t, int64(1), changed)
+	assert.ElementsMatch(t, []string{existingTagID.String(), tagID.String()}, tagIdsOf(coffee))
+	assert.Empty(t, tagIdsOf(book))
+	require.Len(t, coffeeEvents, 2)
+	assert.Equal(t, domains.AuditOperationTagsUpdate, coffeeEvents[0].Operation)
+	changes := decodeChanges(t, coffeeEvents[0])
+	require.Contains(t, changes, "tagIds")
+	assert.ElementsMatch(t, []any{existingTagID.String()}, changes["tagIds"].Before)
+	assert.ElementsMatch(t, []any{existingTagID.String(), tagID.String()}, changes["tagIds"].After)
+	require.Len(t, teaEvents, 1)
+	assert.Equal(t, domains.AuditOperationCreate, teaEvents[0].Operation)
+}
+
+func Test_ItemsService_RemoveTags_ShouldUnlinkTagsFromGivenItems(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	firstTagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Breakfast", IsActive: true})
+	require.NoError(t, err)
+	secondTagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Groceries", IsActive: true})
+	require.NoError(t, err)
+	tagIds := []string{firstTagID.String(), secondTagID.String()}
+	coffeeID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(5),
+		Category: "FoodDrinks",
+		TagIds:   tagIds,
+	})
+	require.NoError(t, err)
+	teaID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Tea",
+		Price:    decimal.NewFromFloat(3),
+		Category: "FoodDrinks",
+		TagIds:   tagIds,
+	})
+	require.NoError(t, err)
+
+	// Act
+	changed, removeErr := itemsService.RemoveTags(ctx, &domains.ItemTagsChange{
+		ItemIds: []string{coffeeID.String(), uuid.Must(uuid.NewV7()).String()},
+		TagIds:  []string{firstTagID.String()},
+	})
+	coffee, coffeeErr := itemsService.GetDetailedInfo(ctx, coffeeID)
+	tea, teaErr := itemsService.GetDetailedInfo(ctx, teaID)
+
+	// Assert
+	require.NoError(t, removeErr)
+	require.NoError(t, coffeeErr)
+	require.NoError(t, teaErr)
+	assert.Equal(t, int64(1), changed)
+	assert.Equal(t, []string{secondTagID.String()}, tagIdsOf(coffee))
+	assert.ElementsMatch(t, tagIds, tagIdsOf(tea))
+}
+
+func Test_ItemsService_AddTags_ShouldRejectUnknownOrInactiveTags(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	inactiveID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Archive", IsActive: false})
+	require.NoError(t, err)
+	itemID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Coffee",
+		Price:    decimal.NewFromFloat(5),
+		Category: "FoodDrinks",
+	})
+	require.NoError(t, err)
+
+	// Act
+	_, unknownErr := itemsService.AddTags(ctx, &domains.ItemTagsChange{
+		ItemIds: []string{itemID.String()},
+		TagIds:  []string{uuid.Must(uuid.NewV7()).String()},
+	})
+	_, inactiveErr := itemsService.AddTags(ctx, &domains.ItemTagsChange{
+		ItemIds: []string{itemID.String()},
+		TagIds:  []string{inactiveID.String()},
+	})
+	item, getErr := itemsService.GetDetailedInfo(ctx, itemID)
+
+	// Assert
+	require.ErrorIs(t, unknownErr, domains.ErrInvalidReference)
+	require.ErrorIs(t, inactiveErr, domains.ErrTagInactive)
+	require.NoError(t, getErr)
+	assert.Empty(t, tagIdsOf(item))
+}
@@ -0,0 +1,218 @@
+//go:build integration
+// +build integration
+
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"finscheduler/internal/auth"
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trashFilter() *domains.ItemTrashFilter {
+	page := int32(0)
+	pageSize := int32(20)
+	return &domains.ItemTrashFilter{Page: &page, PageSize: &pageSize}
+}
+
+func Test_ItemsService_Delete_ShouldMoveItemToTrash(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	id, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+	})
+	require.NoError(t, err)
+
+	// Act
+	ok, deleteErr := itemsService.Delete(ctx, id, nil)
+	_, getErr := itemsService.GetDetailedInfo(ctx, id)
+	trash, count, trashErr := itemsService.GetTrash(ctx, trashFilter())
+
+	// Assert
+	require.NoError(t, deleteErr)
+	require.NoError(t, trashErr)
+	require.True(t, ok)
+	require.ErrorIs(t, getErr, sql.ErrNoRows)
+	require.Len(t, trash, 1)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, id, trash[0].Id)
+	assert.Equal(t, "Orange", trash[0].Name)
+	assert.False(t, trash[0].DeletedAt.IsZero())
+}
+
+func Test_ItemsService_Restore_ShouldBringBackItemWithTagsAndPriceHistory(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	tagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Fruit"})
+	require.NoError(t, err)
+	id, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	})
+	require.NoError(t, err)
+	before, err := itemsService.GetDetailedInfo(ctx, id)
+	require.NoError(t, err)
+	_, err = itemsService.Delete(ctx, id, nil)
+	require.NoError(t, err)
+
+	// Act
+	restored, restoreErr := itemsService.Restore(ctx, id)
+	restoredAgain, restoreAgainErr := itemsService.Restore(ctx, id)
+	after, getErr := itemsService.GetDetailedInfo(ctx, id)
+	trash, count, trashErr := itemsService.GetTrash(ctx, trashFilter())
+
+	// Assert
+	require.NoError(t, restoreErr)
+	require.NoError(t, restoreAgainErr)
+	require.NoError(t, getErr)
+	require.NoError(t, trashErr)
+	assert.True(t, restored)
+	assert.False(t, restoredAgain)
+	assert.Equal(t, before.Tags, after.Tags)
+	assert.Equal(t, before.PriceHistory, after.PriceHistory)
+	assert.Empty(t, trash)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_ItemsService_Restore_ShouldReturnNameTakenWhenNameWasReused(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	create := &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+	}
+	id, err := itemsService.Create(ctx, create)
+	require.NoError(t, err)
+	_, err = itemsService.Delete(ctx, id, nil)
+	require.NoError(t, err)
+
+	// Act
+	_, createErr := itemsService.Create(ctx, create)
+	restored, restoreErr := itemsService.Restore(ctx, id)
+	trash, _, trashErr := itemsService.GetTrash(ctx, trashFilter())
+
+	// Assert
+	require.NoError(t, createErr)
+	require.NoError(t, trashErr)
+	require.ErrorIs(t, restoreErr, domains.ErrItemNameTaken)
+	assert.False(t, restored)
+	require.Len(t, trash, 1)
+	assert.Equal(t, id, trash[0].Id)
+}
+
+func Test_ItemsService_Restore_ShouldRecordAuditEvent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+	id, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+	})
+	require.NoError(t, err)
+	_, err = itemsService.Delete(ctx, id, nil)
+	require.NoError(t, err)
+
+	// Act
+	_, restoreErr := itemsService.Restore(ctx, id)
+	events, _, getErr := auditService.GetListing(ctx, auditFilterFor(id))
+
+	// Assert
+	require.NoError(t, restoreErr)
+	require.NoError(t, getErr)
+	require.Len(t, events, 3)
+	assert.Equal(t, domains.AuditOperationRestore, events[0].Operation)
+	changes := decodeChanges(t, events[0])
+	require.Contains(t, changes, "deletedAt")
+	assert.NotNil(t, changes["deletedAt"].Before)
+	assert.Nil(t, changes["deletedAt"].After)
+}
+
+func Test_ItemsService_PurgeDeleted_ShouldDeleteOldTrashOfEveryHousehold(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	otherUserID := testsupport.CreateUser(t, testDB, "other@example.com")
+	otherHouseholdID := uuid.Must(uuid.NewV7())
+	_, err := testDB.Exec("INSERT INTO households (id, owner_id, name, is_personal) VALUES ($1, $2, 'Other', true)", otherHouseholdID, otherUserID)
+	require.NoError(t, err)
+	otherContext := testsupport.WithMember(testContext, otherUserID, otherHouseholdID, auth.RoleAdmin)
+
+	newItem := func(ctx context.Context, name string) uuid.UUID {
+		t.Helper()
+		id, err := itemsService.Create(ctx, &domains.ItemCreate{
+			Name:     name,
+			Price:    decimal.NewFromFloat(1),
+			Category: "FoodDrinks",
+		})
+		require.NoError(t, err)
+		return id
+	}
+	active := newItem(testContext, "Active")
+	expired := newItem(testContext, "Expired")
+	recent := newItem(testContext, "Recent")
+	otherExpired := newItem(otherContext, "Other expired")
+
+	longAgo := time.Now().Add(-48 * time.Hour).UTC()
+	_, err = testDB.Exec("UPDATE items SET deleted_at = $1 WHERE id IN ($2, $3)", longAgo, expired, otherExpired)
+	require.NoError(t, err)
+	_, err = itemsService.Delete(testContext, recent, nil)
+	require.NoError(t, err)
+
+	// Act
+	purged, purgeErr := itemsService.PurgeDeleted(testContext, time.Now().Add(-24*time.Hour))
+	var remaining []uuid.UUID
+	selectErr := testDB.Select(&remaining, "SELECT id FROM items ORDER BY id")
+
+	// Assert
+	require.NoError(t, purgeErr)
+	require.NoError(t, selectErr)
+	assert.Equal(t, int64(2), purged)
+	assert.ElementsMatch(t, []uuid.UUID{active, recent}, remaining)
+}
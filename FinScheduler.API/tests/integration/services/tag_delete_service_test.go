@@ -0,0 +1,191 @@
+//go:build integration
+// +build integration
+
+package services_test
+
+import (
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tagIdsOf(item *domains.ItemDetailedDto) []string {
+	ids := make([]string, 0, len(item.Tags))
+	for _, tag := range item.Tags {
+		ids = append(ids, tag.Value)
+	}
+
+	return ids
+}
+
+func Test_TagsService_Delete_ShouldRefuseTagInUseWithoutForce(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Fruit", IsActive: true})
+	require.NoError(t, err)
+	_, err = itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	})
+	require.NoError(t, err)
+
+	// Act
+	ok, deleteErr := tagsService.Delete(ctx, tagID, false, nil)
+	_, getErr := tagsService.GetDetailedInfo(ctx, tagID)
+
+	// Assert
+	require.ErrorIs(t, deleteErr, domains.ErrTagInUse)
+	require.NoError(t, getErr)
+	assert.False(t, ok)
+}
+
+func Test_TagsService_Delete_ShouldUnlinkItemsWithForce(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+	tagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Fruit", IsActive: true})
+	require.NoError(t, err)
+	itemID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	})
+	require.NoError(t, err)
+
+	// Act
+	ok, deleteErr := tagsService.Delete(ctx, tagID, true, nil)
+	_, getErr := tagsService.GetDetailedInfo(ctx, tagID)
+	item, itemErr := itemsService.GetDetailedInfo(ctx, itemID)
+	events, _, auditErr := auditService.GetListing(ctx, auditFilterFor(tagID))
+
+	// Assert
+	require.NoError(t, deleteErr)
+	require.Error(t, getErr)
+	require.NoError(t, itemErr)
+	require.NoError(t, auditErr)
+	assert.True(t, ok)
+	assert.Empty(t, item.Tags)
+	require.Len(t, events, 2)
+	assert.Equal(t, domains.AuditOperationDelete, events[0].Operation)
+}
+
+func Test_TagsService_Delete_ShouldReturnPreconditionFailedOnStaleVersion(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	staleVersion := int32(2)
+	tagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Fruit", IsActive: true})
+	require.NoError(t, err)
+
+	// Act
+	ok, deleteErr := tagsService.Delete(ctx, tagID, false, &staleVersion)
+	missingOk, missingErr := tagsService.Delete(ctx, uuid.New(), false, nil)
+
+	// Assert
+	require.ErrorIs(t, deleteErr, domains.ErrPreconditionFailed)
+	require.NoError(t, missingErr)
+	assert.False(t, ok)
+	assert.False(t, missingOk)
+}
+
+func Test_TagsService_MergeInto_ShouldMoveLinksAndDeleteSource(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	sourceID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Fruits", IsActive: true})
+	require.NoError(t, err)
+	targetID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Fruit", IsActive: true})
+	require.NoError(t, err)
+	bothID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Orange",
+		Price:    decimal.NewFromFloat(15.50),
+		Category: "FoodDrinks",
+		TagIds:   []string{sourceID.String(), targetID.String()},
+	})
+	require.NoError(t, err)
+	sourceOnlyID, err := itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Apple",
+		Price:    decimal.NewFromFloat(5),
+		Category: "FoodDrinks",
+		TagIds:   []string{sourceID.String()},
+	})
+	require.NoError(t, err)
+
+	// Act
+	ok, mergeErr := tagsService.MergeInto(ctx, sourceID, targetID)
+	_, sourceErr := tagsService.GetDetailedInfo(ctx, sourceID)
+	both, bothErr := itemsService.GetDetailedInfo(ctx, bothID)
+	sourceOnly, sourceOnlyErr := itemsService.GetDetailedInfo(ctx, sourceOnlyID)
+
+	// Assert
+	require.NoError(t, mergeErr)
+	require.Error(t, sourceErr)
+	require.NoError(t, bothErr)
+	require.NoError(t, sourceOnlyErr)
+	assert.True(t, ok)
+	assert.Equal(t, []string{targetID.String()}, tagIdsOf(both))
+	assert.Equal(t, []string{targetID.String()}, tagIdsOf(sourceOnly))
+}
+
+func Test_TagsService_MergeInto_ShouldRejectInvalidTargets(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	sourceID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Fruits", IsActive: true})
+	require.NoError(t, err)
+	inactiveID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Archive", IsActive: false})
+	require.NoError(t, err)
+
+	// Act
+	_, selfErr := tagsService.MergeInto(ctx, sourceID, sourceID)
+	_, inactiveErr := tagsService.MergeInto(ctx, sourceID, inactiveID)
+	missingOk, missingErr := tagsService.MergeInto(ctx, sourceID, uuid.New())
+	_, sourceErr := tagsService.GetDetailedInfo(ctx, sourceID)
+
+	// Assert
+	require.ErrorIs(t, selfErr, domains.ErrTagMergeIntoItself)
+	require.ErrorIs(t, inactiveErr, domains.ErrTagInactive)
+	require.NoError(t, missingErr)
+	require.NoError(t, sourceErr)
+	assert.False(t, missingOk)
+}
@@ -0,0 +1,151 @@
+//go:build integration
+// +build integration
+
+package services_test
+
+import (
+	"finscheduler/internal/features/domains"
+	"finscheduler/internal/features/services"
+	"finscheduler/internal/persistence"
+	"finscheduler/tests/internal/testsupport"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TagsService_Patch_ShouldRejectParentInsideTheSubtree(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	homeID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Home", IsActive: true})
+	require.NoError(t, err)
+	homeParent := homeID.String()
+	utilitiesID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Utilities", IsActive: true, ParentId: &homeParent})
+	require.NoError(t, err)
+	utilitiesParent := utilitiesID.String()
+	unknownParent := uuid.NewString()
+
+	// Act
+	_, selfErr := tagsService.Patch(ctx, homeID, &domains.TagPatch{ParentId: domains.TagParent{Set: true, Id: &homeParent}}, nil)
+	_, cycleErr := tagsService.Patch(ctx, homeID, &domains.TagPatch{ParentId: domains.TagParent{Set: true, Id: &utilitiesParent}}, nil)
+	_, unknownErr := tagsService.Update(ctx, homeID, &domains.TagUpdate{Name: "Home", IsActive: true, ParentId: &unknownParent}, nil)
+	topLevel, topLevelErr := tagsService.Patch(ctx, utilitiesID, &domains.TagPatch{ParentId: domains.TagParent{Set: true}}, nil)
+	utilities, getErr := tagsService.GetDetailedInfo(ctx, utilitiesID)
+
+	// Assert
+	require.ErrorIs(t, selfErr, domains.ErrTagCycle)
+	require.ErrorIs(t, cycleErr, domains.ErrTagCycle)
+	require.ErrorIs(t, unknownErr, domains.ErrInvalidReference)
+	require.NoError(t, topLevelErr)
+	require.NoError(t, getErr)
+	assert.True(t, topLevel)
+	assert.Nil(t, utilities.ParentId)
+}
+
+func Test_TagsService_Create_ShouldRejectUnknownParent(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	unknownParent := uuid.NewString()
+
+	// Act
+	id, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Power", IsActive: true, ParentId: &unknownParent})
+
+	// Assert
+	require.ErrorIs(t, err, domains.ErrInvalidReference)
+	assert.Equal(t, uuid.Nil, id)
+}
+
+func Test_TagsService_Delete_ShouldMoveChildrenUpAndAuditThem(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	auditService := services.NewAuditService(uow, testLogger)
+	homeID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Home", IsActive: true})
+	require.NoError(t, err)
+	homeParent := homeID.String()
+	utilitiesID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Utilities", IsActive: true, ParentId: &homeParent})
+	require.NoError(t, err)
+	utilitiesParent := utilitiesID.String()
+	powerID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Power", IsActive: true, ParentId: &utilitiesParent})
+	require.NoError(t, err)
+
+	// Act
+	ok, deleteErr := tagsService.Delete(ctx, utilitiesID, false, nil)
+	power, getErr := tagsService.GetDetailedInfo(ctx, powerID)
+	events, _, auditErr := auditService.GetListing(ctx, auditFilterFor(powerID))
+
+	// Assert
+	require.NoError(t, deleteErr)
+	require.NoError(t, getErr)
+	require.NoError(t, auditErr)
+	assert.True(t, ok)
+	assert.Equal(t, &homeID, power.ParentId)
+	require.Len(t, events, 2)
+	assert.Equal(t, domains.AuditOperationUpdate, events[0].Operation)
+	changes := decodeChanges(t, events[0])
+	require.Contains(t, changes, "parentId")
+	assert.Equal(t, utilitiesID.String(), changes["parentId"].Before)
+	assert.Equal(t, homeID.String(), changes["parentId"].After)
+}
+
+func Test_ItemsService_GetListingInfo_ShouldMatchDescendantTagsOnlyWhenAsked(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	homeID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Home", IsActive: true})
+	require.NoError(t, err)
+	homeParent := homeID.String()
+	powerID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Power", IsActive: true, ParentId: &homeParent})
+	require.NoError(t, err)
+	_, err = itemsService.Create(ctx, &domains.ItemCreate{
+		Name:     "Electricity",
+		Price:    decimal.NewFromFloat(40),
+		Category: "FoodDrinks",
+		TagIds:   []string{powerID.String()},
+	})
+	require.NoError(t, err)
+
+	page := int32(0)
+	pageSize := int32(20)
+	includeDescendants := true
+	direct := domains.ItemFilter{TagIds: []*uuid.UUID{&homeID}, Page: &page, PageSize: &pageSize}
+	nested := domains.ItemFilter{TagIds: []*uuid.UUID{&homeID}, IncludeDescendants: &includeDescendants, Page: &page, PageSize: &pageSize}
+
+	// Act
+	directItems, directPage, directErr := itemsService.GetListingInfo(ctx, &direct)
+	nestedItems, nestedPage, nestedErr := itemsService.GetListingInfo(ctx, &nested)
+
+	// Assert
+	require.NoError(t, directErr)
+	require.NoError(t, nestedErr)
+	assert.Empty(t, directItems)
+	assert.Equal(t, int64(0), *directPage.Count)
+	require.Len(t, nestedItems, 1)
+	assert.Equal(t, int64(1), *nestedPage.Count)
+	assert.Equal(t, "Electricity", nestedItems[0].Name)
+}
@@ -4,6 +4,7 @@
 package services_test
 
 import (
+	"database/sql"
 	"finscheduler/internal/features/domains"
 	"finscheduler/internal/features/services"
 	"finscheduler/internal/persistence"
@@ -11,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -43,13 +45,13 @@ func TestTagsServiceCreateAndGetListingInfo_ShouldNotErr(t *testing.T) {
 		PageSize: &pageSize,
 	}
 
-	tags, count, getErr := service.GetListingInfo(ctx, filter)
+	tags, pageInfo, getErr := service.GetListingInfo(ctx, filter)
 
 	// Assert
 	require.NoError(t, createErr)
 	require.NoError(t, getErr)
 	require.Len(t, tags, 1)
-	assert.Equal(t, int64(1), count)
+	assert.Equal(t, int64(1), *pageInfo.Count)
 	assert.Equal(t, tagName, tags[0].Name)
 	assert.Equal(t, tagIsActive, tags[0].IsActive)
 }
@@ -155,7 +157,7 @@ func TestTagsServiceUpdateAndGetListingInfo_ShouldNotErr(t *testing.T) {
 
 	// Act
 	tagID, createErr := service.Create(ctx, create)
-	ok, updateErr := service.Update(ctx, tagID, update)
+	ok, updateErr := service.Update(ctx, tagID, update, nil)
 	tagIDPointer := &tagID
 	filter := &domains.TagFilter{
 		Ids:      []*uuid.UUID{tagIDPointer},
@@ -163,7 +165,7 @@ func TestTagsServiceUpdateAndGetListingInfo_ShouldNotErr(t *testing.T) {
 		PageSize: &pageSize,
 	}
 
-	tags, count, getErr := service.GetListingInfo(ctx, filter)
+	tags, pageInfo, getErr := service.GetListingInfo(ctx, filter)
 
 	// Assert
 	require.NoError(t, createErr)
@@ -171,7 +173,7 @@ func TestTagsServiceUpdateAndGetListingInfo_ShouldNotErr(t *testing.T) {
 	require.NoError(t, getErr)
 	require.True(t, ok)
 	require.Len(t, tags, 1)
-	assert.Equal(t, int64(1), count)
+	assert.Equal(t, int64(1), *pageInfo.Count)
 	assert.Equal(t, updatedName, tags[0].Name)
 	assert.Equal(t, updatedIsActive, tags[0].IsActive)
 }
@@ -211,7 +213,7 @@ func TestTagsServiceUpdate_ShouldRemoveItemLinksWhenTagBecomesInactive(t *testin
 	}
 
 	// Act
-	ok, updateErr := tagsService.Update(ctx, tagID, update)
+	ok, updateErr := tagsService.Update(ctx, tagID, update, nil)
 	item, getItemErr := itemsService.GetDetailedInfo(ctx, itemID)
 
 	var actualLinkCount int
@@ -229,6 +231,51 @@ func TestTagsServiceUpdate_ShouldRemoveItemLinksWhenTagBecomesInactive(t *testin
 	assert.Zero(t, actualLinkCount)
 }
 
+func TestTagsServicePatch_ShouldKeepNameAndRemoveItemLinksWhenTagBecomesInactive(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagName := "Groceries"
+	countQuery := `SELECT COUNT(*) FROM tag_to_item WHERE tag_id = $1`
+
+	tagID, tagCreateErr := tagsService.Create(ctx, &domains.TagCreate{Name: tagName, IsActive: true})
+
+	itemCreate := &domains.ItemCreate{
+		Name:     "Milk",
+		Category: "FoodDrinks",
+		TagIds:   []string{tagID.String()},
+	}
+	_, itemCreateErr := itemsService.Create(ctx, itemCreate)
+
+	isActive := false
+	patch := &domains.TagPatch{IsActive: &isActive}
+
+	// Act
+	ok, patchErr := tagsService.Patch(ctx, tagID, patch, nil)
+	tag, getErr := tagsService.GetDetailedInfo(ctx, tagID)
+
+	var actualLinkCount int
+	countErr := testDB.Get(&actualLinkCount, countQuery, tagID)
+
+	// Assert
+	require.NoError(t, tagCreateErr)
+	require.NoError(t, itemCreateErr)
+	require.NoError(t, patchErr)
+	require.NoError(t, getErr)
+	require.NoError(t, countErr)
+	require.True(t, ok)
+	require.NotNil(t, tag)
+	assert.Equal(t, tagName, tag.Name)
+	assert.False(t, tag.IsActive)
+	assert.Zero(t, actualLinkCount)
+}
+
 func TestTagsServiceUpdateMissing_ShouldReturnFalseWithoutErr(t *testing.T) {
 	// Arrange
 	ctx := testContext
@@ -244,9 +291,73 @@ func TestTagsServiceUpdateMissing_ShouldReturnFalseWithoutErr(t *testing.T) {
 	}
 
 	// Act
-	ok, err := service.Update(ctx, missingID, update)
+	ok, err := service.Update(ctx, missingID, update, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.False(t, ok)
 }
+
+func TestTagsServiceGetStats_ShouldSumUpLinkedItemsOutsideTheTrash(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() {
+		testsupport.Truncate(t, testDB)
+	})
+
+	ctx := testContext
+	uow := persistence.NewUnitOfWork(testDB, testLogger)
+	tagsService := services.NewTagsService(uow, testLogger)
+	itemsService := services.NewItemsService(uow, testLogger)
+	tagID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Utilities", IsActive: true})
+	require.NoError(t, err)
+	unusedID, err := tagsService.Create(ctx, &domains.TagCreate{Name: "Unused", IsActive: true})
+	require.NoError(t, err)
+	newItem := func(name string, price float64, cashback int32, isActive bool) uuid.UUID {
+		t.Helper()
+		id, err := itemsService.Create(ctx, &domains.ItemCreate{
+			Name:     name,
+			Price:    decimal.NewFromFloat(price),
+			Category: "FoodDrinks",
+			IsActive: isActive,
+			Cashback: cashback,
+			TagIds:   []string{tagID.String()},
+		})
+		require.NoError(t, err)
+		return id
+	}
+	newItem("Power", 40, 5, true)
+	newItem("Water", 20, 0, false)
+	trashedID := newItem("Gas", 100, 10, true)
+	_, err = itemsService.Delete(ctx, trashedID, nil)
+	require.NoError(t, err)
+
+	page := int32(0)
+	pageSize := int32(20)
+	withStats := true
+
+	// Act
+	stats, statsErr := tagsService.GetStats(ctx, tagID)
+	unused, unusedErr := tagsService.GetStats(ctx, unusedID)
+	_, missingErr := tagsService.GetStats(ctx, uuid.New())
+	tags, _, listErr := tagsService.GetListingInfo(ctx, &domains.TagFilter{WithStats: &withStats, Page: &page, PageSize: &pageSize})
+
+	// Assert
+	require.NoError(t, statsErr)
+	require.NoError(t, unusedErr)
+	require.ErrorIs(t, missingErr, sql.ErrNoRows)
+	require.NoError(t, listErr)
+	assert.Equal(t, int64(2), stats.ItemCount)
+	assert.Equal(t, int64(1), stats.ActiveItemCount)
+	assert.True(t, decimal.NewFromFloat(60).Equal(stats.TotalPrice.Amount))
+	require.NotNil(t, stats.AveragePrice)
+	assert.True(t, decimal.NewFromFloat(30).Equal(stats.AveragePrice.Amount))
+	assert.True(t, decimal.NewFromFloat(2).Equal(stats.TotalCashback.Amount))
+	assert.NotNil(t, stats.LastItemUpdatedAt)
+	assert.Equal(t, int64(0), unused.ItemCount)
+	assert.Nil(t, unused.AveragePrice)
+	assert.Nil(t, unused.LastItemUpdatedAt)
+	require.Len(t, tags, 2)
+	for _, tag := range tags {
+		require.NotNil(t, tag.Stats)
+	}
+}
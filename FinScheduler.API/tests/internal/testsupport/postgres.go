@@ -13,9 +13,11 @@ import (
 	"testing"
 	"time"
 
+	"finscheduler/internal/auth"
 	"finscheduler/internal/infra"
 	"finscheduler/internal/metrics"
 
+	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	"github.com/testcontainers/testcontainers-go"
@@ -23,17 +25,36 @@ import (
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
+// OwnerID is the account the integration tests act as and HouseholdID its
+// personal household, where it is an admin. Both are seeded with the schema
+// and again after every Truncate, and Environment.Context carries them as the
+// authenticated principal and its membership.
+var (
+	OwnerID     = uuid.MustParse("0190a8e4-0000-7000-8000-000000000001")
+	HouseholdID = uuid.MustParse("0190a8e4-0000-7000-8000-000000000002")
+)
+
+const ownerEmail = "owner@finscheduler.test"
+
+// applicationRole is an ordinary role, unlike the superuser of the container,
+// so the row-level security policies apply to it as they do to the API.
+const applicationRole = "finscheduler_app"
+
+// Environment.DB connects as the superuser of the container, which bypasses
+// row-level security so that tests can arrange rows of any household.
+// Environment.ApplicationDB connects as applicationRole.
 type Environment struct {
-	Context context.Context
-	DB      *sqlx.DB
-	Logger  *slog.Logger
+	Context       context.Context
+	DB            *sqlx.DB
+	ApplicationDB *sqlx.DB
+	Logger        *slog.Logger
 
 	container     testcontainers.Container
 	meterProvider *sdkmetric.MeterProvider
 }
 
 func NewPostgresEnvironment(ctx context.Context) (*Environment, error) {
-	container, db, err := setupPostgresContainer(ctx)
+	container, db, host, err := setupPostgresContainer(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +75,7 @@ func NewPostgresEnvironment(ctx context.Context) (*Environment, error) {
 
 	stdoutHandler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})
 	env := &Environment{
-		Context:       ctx,
+		Context:       WithMember(ctx, OwnerID, HouseholdID, auth.RoleAdmin),
 		DB:            db,
 		Logger:        slog.New(stdoutHandler),
 		container:     container,
@@ -65,6 +86,16 @@ func NewPostgresEnvironment(ctx context.Context) (*Environment, error) {
 		_ = env.Close()
 		return nil, err
 	}
+	if err := seedOwner(env.DB); err != nil {
+		_ = env.Close()
+		return nil, err
+	}
+
+	env.ApplicationDB, err = openApplicationDB(ctx, env.DB, host)
+	if err != nil {
+		_ = env.Close()
+		return nil, err
+	}
 
 	return env, nil
 }
@@ -76,8 +107,13 @@ func (env *Environment) Close() error {
 			log.Printf("failed to shutdown meter: %v", err)
 		}
 	}
+	if env.ApplicationDB != nil {
+		closeErr = env.ApplicationDB.Close()
+	}
 	if env.DB != nil {
-		closeErr = env.DB.Close()
+		if err := env.DB.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
 	}
 	if env.container != nil {
 		if err := env.container.Terminate(env.Context); err != nil && closeErr == nil {
@@ -92,16 +128,53 @@ func Truncate(t testing.TB, db *sqlx.DB, tables ...string) {
 	t.Helper()
 
 	if len(tables) == 0 {
-		tables = []string{"items", "tags", "tag_to_item"}
+		tables = []string{"items", "tags", "tag_to_item", "idempotency_keys", "audit_events", "api_keys", "invitations", "household_members", "households", "users"}
 	}
 
 	query := fmt.Sprintf("TRUNCATE %s CASCADE", strings.Join(tables, ", "))
 	if _, err := db.Exec(query); err != nil {
 		t.Fatalf("failed to truncate tables: %v", err)
 	}
+	if err := seedOwner(db); err != nil {
+		t.Fatalf("failed to seed owner: %v", err)
+	}
+}
+
+// WithMember authenticates ctx as userID acting on householdID with role.
+func WithMember(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, role auth.Role) context.Context {
+	ctx = auth.WithPrincipal(ctx, auth.Principal{UserID: userID})
+	return auth.WithMembership(ctx, auth.Membership{HouseholdID: householdID, Role: role})
 }
 
-func setupPostgresContainer(ctx context.Context) (testcontainers.Container, *sqlx.DB, error) {
+// CreateUser inserts an account that cannot log in and returns its id, for
+// tests that need a second user.
+func CreateUser(t testing.TB, db *sqlx.DB, email string) uuid.UUID {
+	t.Helper()
+
+	userID, err := uuid.NewV7()
+	if err != nil {
+		t.Fatalf("failed to generate user id: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO users (id, email, password_hash) VALUES ($1, $2, '!')", userID, email); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	return userID
+}
+
+func seedOwner(db *sqlx.DB) error {
+	if _, err := db.Exec("INSERT INTO users (id, email, password_hash) VALUES ($1, $2, '!') ON CONFLICT (id) DO NOTHING", OwnerID, ownerEmail); err != nil {
+		return err
+	}
+	if _, err := db.Exec("INSERT INTO households (id, owner_id, name, is_personal) VALUES ($1, $2, 'Personal', true) ON CONFLICT (id) DO NOTHING", HouseholdID, OwnerID); err != nil {
+		return err
+	}
+
+	_, err := db.Exec("INSERT INTO household_members (household_id, user_id, role) VALUES ($1, $2, 'admin') ON CONFLICT (household_id, user_id) DO NOTHING", HouseholdID, OwnerID)
+	return err
+}
+
+func setupPostgresContainer(ctx context.Context) (testcontainers.Container, *sqlx.DB, string, error) {
 	req := testcontainers.ContainerRequest{
 		Image:        "postgres:18",
 		ExposedPorts: []string{"5432/tcp"},
@@ -121,35 +194,62 @@ func setupPostgresContainer(ctx context.Context) (testcontainers.Container, *sql
 		Started:          true,
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	host, err := container.Host(ctx)
 	if err != nil {
 		_ = container.Terminate(ctx)
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	port, err := container.MappedPort(ctx, "5432")
 	if err != nil {
 		_ = container.Terminate(ctx)
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
-	dsn := fmt.Sprintf("postgres://test:secret@%s:%s/testdb?sslmode=disable", host, port.Port())
+	address := fmt.Sprintf("%s:%s", host, port.Port())
+	dsn := fmt.Sprintf("postgres://test:secret@%s/testdb?sslmode=disable", address)
 	db, err := sqlx.Open("pgx", dsn)
 	if err != nil {
 		_ = container.Terminate(ctx)
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	if err := waitForDatabase(ctx, db); err != nil {
 		_ = db.Close()
 		_ = container.Terminate(ctx)
-		return nil, nil, err
+		return nil, nil, "", err
+	}
+
+	return container, db, address, nil
+}
+
+// openApplicationDB creates applicationRole with access to every table and
+// connects to address as it.
+func openApplicationDB(ctx context.Context, db *sqlx.DB, address string) (*sqlx.DB, error) {
+	statements := []string{
+		fmt.Sprintf("CREATE ROLE %s LOGIN PASSWORD 'secret' NOSUPERUSER NOBYPASSRLS", applicationRole),
+		fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO %s", applicationRole),
+	}
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return nil, fmt.Errorf("failed to set up %s: %w", applicationRole, err)
+		}
+	}
+
+	dsn := fmt.Sprintf("postgres://%s:secret@%s/testdb?sslmode=disable", applicationRole, address)
+	applicationDB, err := sqlx.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := waitForDatabase(ctx, applicationDB); err != nil {
+		_ = applicationDB.Close()
+		return nil, err
 	}
 
-	return container, db, nil
+	return applicationDB, nil
 }
 
 func waitForDatabase(ctx context.Context, db *sqlx.DB) error {
@@ -170,6 +270,15 @@ func waitForDatabase(ctx context.Context, db *sqlx.DB) error {
 }
 
 func setupSchema(db *sqlx.DB) error {
+	if err := setupUsersSchema(db); err != nil {
+		return err
+	}
+	if err := setupHouseholdsSchema(db); err != nil {
+		return err
+	}
+	if err := setupApiKeysSchema(db); err != nil {
+		return err
+	}
 	if err := setupItemsSchema(db); err != nil {
 		return err
 	}
@@ -179,9 +288,21 @@ func setupSchema(db *sqlx.DB) error {
 	if err := setupTagsSchema(db); err != nil {
 		return err
 	}
+	if err := setupIdempotencyKeysSchema(db); err != nil {
+		return err
+	}
 	if err := setupTagToItemSchema(db); err != nil {
 		return err
 	}
+	if err := setupRateLimitBucketsSchema(db); err != nil {
+		return err
+	}
+	if err := setupAuditEventsSchema(db); err != nil {
+		return err
+	}
+	if err := setupRowLevelSecurity(db); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -190,15 +311,23 @@ func setupItemsSchema(db *sqlx.DB) error {
 	return setupTable(db, "items", `
 		CREATE TABLE items (
 			id UUID PRIMARY KEY,
-			name TEXT NOT NULL UNIQUE,
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
 			price NUMERIC(16, 2) NOT NULL DEFAULT 0 CHECK (price >= 0),
 			description TEXT NULL,
 			is_active BOOLEAN NOT NULL DEFAULT FALSE,
 			created_at TIMESTAMP NOT NULL DEFAULT now(),
 			updated_at TIMESTAMP NULL,
 			cashback INTEGER NOT NULL DEFAULT 0,
-			category TEXT NOT NULL DEFAULT 'None'
+			category TEXT NOT NULL DEFAULT 'None',
+			version INTEGER NOT NULL DEFAULT 1,
+			deleted_at TIMESTAMP NULL,
+			CONSTRAINT uq_items_household_id_id UNIQUE (household_id, id)
 		);
+
+		CREATE UNIQUE INDEX uq_items_household_id_name
+			ON items (household_id, name)
+			WHERE deleted_at IS NULL;
 	`)
 }
 
@@ -206,11 +335,14 @@ func setupPriceHistorySchema(db *sqlx.DB) error {
 	return setupTable(db, "price_history", `
 		CREATE TABLE price_history (
 			id UUID PRIMARY KEY,
-			item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+			household_id UUID NOT NULL,
+			item_id UUID NOT NULL,
 			recorded_at DATE NOT NULL,
 			value NUMERIC(16, 2) NOT NULL CHECK (value >= 0),
 			CONSTRAINT uq_price_history_item_id_recorded_at
-				UNIQUE (item_id, recorded_at)
+				UNIQUE (item_id, recorded_at),
+			CONSTRAINT fk_price_history_household_id_item_id
+				FOREIGN KEY (household_id, item_id) REFERENCES items(household_id, id) ON DELETE CASCADE
 		);
 
 		CREATE INDEX idx_price_history_item_id
@@ -222,8 +354,37 @@ func setupTagsSchema(db *sqlx.DB) error {
 	return setupTable(db, "tags", `
 		CREATE TABLE tags (
 			id UUID PRIMARY KEY,
-			name TEXT NOT NULL UNIQUE,
-			is_active BOOLEAN NOT NULL DEFAULT FALSE
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			is_active BOOLEAN NOT NULL DEFAULT FALSE,
+			version INTEGER NOT NULL DEFAULT 1,
+			parent_id UUID NULL,
+			color VARCHAR(7) NULL,
+			icon VARCHAR(64) NULL,
+			description TEXT NOT NULL DEFAULT '',
+			CONSTRAINT uq_tags_household_id_name UNIQUE (household_id, name),
+			CONSTRAINT uq_tags_household_id_id UNIQUE (household_id, id),
+			CONSTRAINT ck_tags_parent_id_not_id CHECK (parent_id <> id),
+			CONSTRAINT fk_tags_household_id_parent_id
+				FOREIGN KEY (household_id, parent_id) REFERENCES tags(household_id, id),
+			CONSTRAINT ck_tags_color CHECK (color ~* '^#[0-9a-f]{6}$'),
+			CONSTRAINT ck_tags_icon CHECK (icon ~ '^[a-z0-9]+(-[a-z0-9]+)*$')
+		);
+	`)
+}
+
+func setupIdempotencyKeysSchema(db *sqlx.DB) error {
+	return setupTable(db, "idempotency_keys", `
+		CREATE TABLE idempotency_keys (
+			scope TEXT NOT NULL,
+			key TEXT NOT NULL,
+			request_hash TEXT NOT NULL,
+			status_code INTEGER NULL,
+			resource_id UUID NULL,
+			response_body BYTEA NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			expires_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (scope, key)
 		);
 	`)
 }
@@ -231,14 +392,132 @@ func setupTagsSchema(db *sqlx.DB) error {
 func setupTagToItemSchema(db *sqlx.DB) error {
 	return setupTable(db, "tag_to_item", `
 		CREATE TABLE tag_to_item (
-			tag_id UUID REFERENCES tags(id) ON DELETE CASCADE,
-			item_id UUID REFERENCES items(id) ON DELETE CASCADE,
+			household_id UUID NOT NULL,
+			tag_id UUID,
+			item_id UUID,
+
+			PRIMARY KEY (item_id, tag_id),
+			CONSTRAINT fk_tag_to_item_household_id_item_id
+				FOREIGN KEY (household_id, item_id) REFERENCES items(household_id, id) ON DELETE CASCADE,
+			CONSTRAINT fk_tag_to_item_household_id_tag_id
+				FOREIGN KEY (household_id, tag_id) REFERENCES tags(household_id, id) ON DELETE CASCADE
+		);
+	`)
+}
+
+func setupUsersSchema(db *sqlx.DB) error {
+	return setupTable(db, "users", `
+		CREATE TABLE users (
+			id UUID PRIMARY KEY,
+			email TEXT NOT NULL UNIQUE,
+			password_hash TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			updated_at TIMESTAMP NULL
+		);
+	`)
+}
+
+func setupHouseholdsSchema(db *sqlx.DB) error {
+	return setupTable(db, "households", `
+		CREATE TABLE households (
+			id UUID PRIMARY KEY,
+			owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			is_personal BOOLEAN NOT NULL DEFAULT false,
+			created_at TIMESTAMP NOT NULL DEFAULT now()
+		);
+
+		CREATE UNIQUE INDEX uq_households_personal_owner_id
+			ON households (owner_id)
+			WHERE is_personal;
 
-			PRIMARY KEY (item_id, tag_id)
+		CREATE TABLE household_members (
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			role TEXT NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			PRIMARY KEY (household_id, user_id)
+		);
+
+		CREATE TABLE invitations (
+			id UUID PRIMARY KEY,
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			role TEXT NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+			token_hash TEXT NOT NULL UNIQUE,
+			invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			expires_at TIMESTAMP NOT NULL,
+			accepted_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+			accepted_at TIMESTAMP NULL
+		);
+	`)
+}
+
+func setupApiKeysSchema(db *sqlx.DB) error {
+	return setupTable(db, "api_keys", `
+		CREATE TABLE api_keys (
+			id UUID PRIMARY KEY,
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			prefix TEXT NOT NULL,
+			key_hash TEXT NOT NULL UNIQUE,
+			scopes TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			expires_at TIMESTAMP NULL,
+			last_used_at TIMESTAMP NULL,
+			CONSTRAINT uq_api_keys_user_id_name UNIQUE (user_id, name)
+		);
+	`)
+}
+
+func setupAuditEventsSchema(db *sqlx.DB) error {
+	return setupTable(db, "audit_events", `
+		CREATE TABLE audit_events (
+			id UUID PRIMARY KEY,
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			actor_id UUID NOT NULL,
+			api_key_id UUID NULL,
+			operation TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id UUID NOT NULL,
+			changes JSONB NOT NULL,
+			trace_id TEXT NULL,
+			occurred_at TIMESTAMP NOT NULL DEFAULT now()
+		);
+	`)
+}
+
+func setupRateLimitBucketsSchema(db *sqlx.DB) error {
+	return setupTable(db, "rate_limit_buckets", `
+		CREATE UNLOGGED TABLE rate_limit_buckets (
+			key TEXT PRIMARY KEY,
+			tokens DOUBLE PRECISION NOT NULL,
+			allowed BOOLEAN NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
 		);
 	`)
 }
 
+// setupRowLevelSecurity mirrors migrations 000013_row_level_security and
+// 000015_audit_events.
+func setupRowLevelSecurity(db *sqlx.DB) error {
+	for _, table := range []string{"items", "tags", "tag_to_item", "price_history", "audit_events"} {
+		err := setupTable(db, table+" row-level security", fmt.Sprintf(`
+			ALTER TABLE %[1]s
+				ENABLE ROW LEVEL SECURITY,
+				FORCE ROW LEVEL SECURITY;
+			CREATE POLICY tenant_isolation ON %[1]s
+				USING (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+				WITH CHECK (household_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+		`, table))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func setupTable(db *sqlx.DB, name string, schema string) error {
 	if _, err := db.Exec(schema); err != nil {
 		return fmt.Errorf("failed to create %s schema: %w", name, err)
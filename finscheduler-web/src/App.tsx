@@ -1,11 +1,21 @@
 import {Flex} from '@chakra-ui/react';
 import {motion} from 'framer-motion';
+import {Navigate, useLocation} from 'react-router-dom';
+import {loginPath} from './features/routes.ts';
+import {useSession} from './hooks/useSession.ts';
 import Sidebar from './layout/sidebar/Sidebar.tsx';
 import Main from './layout/main/Main.tsx';
 
 const MotionFlex = motion.create(Flex);
 
 export default function App() {
+    const session = useSession();
+    const location = useLocation();
+
+    if (!session) {
+        return <Navigate to={loginPath} replace state={{from: location}} />;
+    }
+
     return (
         <MotionFlex layout w="100vw" h="100vh" bg={'background'} minHeight={0}>
             <Sidebar />
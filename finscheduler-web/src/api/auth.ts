@@ -0,0 +1,27 @@
+import type {Credentials, TokenDto} from './auth.types.ts';
+import {FinschedulerApiClient} from './finscheduler-api-client.ts';
+
+export default class AuthService extends FinschedulerApiClient {
+    async login(credentials: Credentials): Promise<TokenDto> {
+        const response = await fetch(`${this.baseUrl}/auth/login`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({
+                email: credentials.email,
+                password: credentials.password,
+            }),
+        });
+
+        if (response.status === 401) {
+            throw new Error('Неверный email или пароль');
+        }
+
+        if (!response.ok) {
+            throw new Error(`Failed to log in: ${response.statusText}`);
+        }
+
+        return response.json();
+    }
+}
@@ -0,0 +1,11 @@
+export interface Credentials {
+    email: string;
+    password: string;
+}
+
+export interface TokenDto {
+    accessToken: string;
+    refreshToken: string;
+    tokenType: string;
+    expiresIn: number;
+}
@@ -1,10 +1,20 @@
-import {describe, expect, it} from 'vitest';
+import {afterEach, describe, expect, it, vi} from 'vitest';
+import {clearSession, getSession, saveSession} from '../auth/session.ts';
+import {API_BASE_URL} from '../config/api.ts';
 import {FinschedulerApiClient} from './finscheduler-api-client.ts';
 
 class TestApiClient extends FinschedulerApiClient {
     public build(params: Record<string, unknown>) {
         return this.buildQueryString(params);
     }
+
+    public get(url: string) {
+        return this.send(url, {method: 'GET'});
+    }
+}
+
+function storeSession(accessToken: string, refreshToken: string) {
+    saveSession({accessToken, refreshToken, tokenType: 'Bearer', expiresIn: 900});
 }
 
 describe('FinschedulerApiClient.buildQueryString', () => {
@@ -57,3 +67,107 @@ describe('FinschedulerApiClient.buildQueryString', () => {
         expect(params.getAll('categories')).toEqual(['FoodDrinks', 'Transport']);
     });
 });
+
+describe('FinschedulerApiClient.send', () => {
+    const client = new TestApiClient();
+
+    afterEach(() => {
+        vi.unstubAllGlobals();
+        clearSession();
+    });
+
+    it('sends the access token of the session', async () => {
+        // Arrange
+        storeSession('access-1', 'refresh-1');
+        const fetchMock = vi.fn().mockResolvedValue(new Response(null, {status: 200}));
+
+        vi.stubGlobal('fetch', fetchMock);
+
+        // Act
+        await client.get(`${API_BASE_URL}/items`);
+
+        // Assert
+        expect(fetchMock).toHaveBeenCalledWith(`${API_BASE_URL}/items`, {
+            method: 'GET',
+            headers: {
+                Authorization: 'Bearer access-1',
+            },
+        });
+    });
+
+    it('refreshes the session once on 401 and repeats the request', async () => {
+        // Arrange
+        storeSession('access-1', 'refresh-1');
+        const fetchMock = vi
+            .fn()
+            .mockResolvedValueOnce(new Response(null, {status: 401}))
+            .mockResolvedValueOnce(
+                new Response(
+                    JSON.stringify({
+                        accessToken: 'access-2',
+                        refreshToken: 'refresh-2',
+                        tokenType: 'Bearer',
+                        expiresIn: 900,
+                    }),
+                    {status: 200, headers: {'Content-Type': 'application/json'}},
+                ),
+            )
+            .mockResolvedValueOnce(new Response(null, {status: 200}));
+
+        vi.stubGlobal('fetch', fetchMock);
+
+        // Act
+        const response = await client.get(`${API_BASE_URL}/items`);
+
+        // Assert
+        expect(response.status).toBe(200);
+        expect(fetchMock).toHaveBeenNthCalledWith(2, `${API_BASE_URL}/auth/refresh`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({refreshToken: 'refresh-1'}),
+        });
+        expect(fetchMock).toHaveBeenNthCalledWith(3, `${API_BASE_URL}/items`, {
+            method: 'GET',
+            headers: {
+                Authorization: 'Bearer access-2',
+            },
+        });
+        expect(getSession()).toEqual({accessToken: 'access-2', refreshToken: 'refresh-2'});
+    });
+
+    it('clears the session when the refresh is rejected', async () => {
+        // Arrange
+        storeSession('access-1', 'refresh-1');
+        const fetchMock = vi
+            .fn()
+            .mockResolvedValueOnce(new Response(null, {status: 401}))
+            .mockResolvedValueOnce(new Response(null, {status: 401}));
+
+        vi.stubGlobal('fetch', fetchMock);
+
+        // Act
+        const response = await client.get(`${API_BASE_URL}/items`);
+
+        // Assert
+        expect(response.status).toBe(401);
+        expect(fetchMock).toHaveBeenCalledTimes(2);
+        expect(getSession()).toBeNull();
+    });
+
+    it('does not refresh without a session', async () => {
+        // Arrange
+        const fetchMock = vi.fn().mockResolvedValue(new Response(null, {status: 401}));
+
+        vi.stubGlobal('fetch', fetchMock);
+
+        // Act
+        const response = await client.get(`${API_BASE_URL}/items`);
+
+        // Assert
+        expect(response.status).toBe(401);
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock).toHaveBeenCalledWith(`${API_BASE_URL}/items`, {method: 'GET'});
+    });
+});
@@ -1,4 +1,6 @@
+import {clearSession, getSession, saveSession} from '../auth/session.ts';
 import {API_BASE_URL} from '../config/api.ts';
+import type {TokenDto} from './auth.types.ts';
 
 type QueryParamPrimitive = string | number | boolean;
 type QueryParamValue = QueryParamPrimitive | null | undefined | readonly QueryParamPrimitive[];
@@ -13,13 +15,95 @@ type DateRange = {
     to: string | null;
 };
 
+type RequestOptions = Omit<RequestInit, 'headers'> & {
+    headers?: Record<string, string>;
+};
+
+// PreconditionFailedError means the API rejected an edit with 412: the
+// resource changed after the version sent in If-Match was read.
+export class PreconditionFailedError extends Error {
+    constructor(message: string) {
+        super(message);
+        this.name = 'PreconditionFailedError';
+    }
+}
+
 export class FinschedulerApiClient {
+    // One refresh is shared by every request that got 401 at the same time,
+    // so the refresh token is only sent once.
+    private static refreshing: Promise<boolean> | null = null;
+
     protected baseUrl: string;
 
     constructor() {
         this.baseUrl = API_BASE_URL;
     }
 
+    // send makes an API request with the access token of the session. On 401
+    // it refreshes the session once and repeats the request; when the refresh
+    // fails the session is cleared and the 401 response is returned.
+    protected async send(url: string, options: RequestOptions = {}): Promise<Response> {
+        const response = await fetch(url, this.authorize(options));
+
+        if (response.status !== 401 || !(await this.refreshSession())) {
+            return response;
+        }
+
+        return fetch(url, this.authorize(options));
+    }
+
+    private authorize(options: RequestOptions): RequestOptions {
+        const session = getSession();
+
+        if (!session) {
+            return options;
+        }
+
+        return {
+            ...options,
+            headers: {
+                ...options.headers,
+                Authorization: `Bearer ${session.accessToken}`,
+            },
+        };
+    }
+
+    private refreshSession(): Promise<boolean> {
+        const session = getSession();
+
+        if (!session) {
+            return Promise.resolve(false);
+        }
+
+        FinschedulerApiClient.refreshing ??= this.requestRefresh(session.refreshToken).finally(
+            () => {
+                FinschedulerApiClient.refreshing = null;
+            },
+        );
+
+        return FinschedulerApiClient.refreshing;
+    }
+
+    private async requestRefresh(refreshToken: string): Promise<boolean> {
+        const response = await fetch(`${this.baseUrl}/auth/refresh`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({refreshToken}),
+        });
+
+        if (!response.ok) {
+            clearSession();
+            return false;
+        }
+
+        const token: TokenDto = await response.json();
+        saveSession(token);
+
+        return true;
+    }
+
     protected buildQueryString<T extends object>(params: T): string {
         const searchParams = new URLSearchParams();
 
@@ -37,6 +121,10 @@ export class FinschedulerApiClient {
         return queryString ? `?${queryString}` : '';
     }
 
+    protected readETag(response: Response): string {
+        return response.headers.get('ETag') ?? '';
+    }
+
     static buildNonNegativeRange(fromValue: string, toValue: string): NumericRange {
         const from = this.parseNonNegativeNumberValue(fromValue);
         const to = this.parseNonNegativeNumberValue(toValue);
@@ -1,5 +1,6 @@
 import {afterEach, describe, expect, it, vi} from 'vitest';
 import {API_BASE_URL} from '../config/api.ts';
+import {PreconditionFailedError} from './finscheduler-api-client.ts';
 import ItemsService, {buildItemFilter} from './items.ts';
 
 describe('items api', () => {
@@ -181,7 +182,7 @@ describe('items api', () => {
         const fetchMock = vi.fn().mockResolvedValue(
             new Response(JSON.stringify({id: 'item-1', name: 'Coffee'}), {
                 status: 200,
-                headers: {'Content-Type': 'application/json'},
+                headers: {'Content-Type': 'application/json', ETag: '"3"'},
             }),
         );
 
@@ -191,13 +192,13 @@ describe('items api', () => {
         const item = await service.getDetailedInfo('item-1');
 
         // Assert
-        expect(fetchMock).toHaveBeenCalledWith(`${API_BASE_URL}/items/item-1`, {
+        expect(fetchMock).toHaveBeenCalledWith(`${API_BASE_URL}/items/item-1?moneyFormat=number`, {
             method: 'GET',
             headers: {
                 'Content-Type': 'application/json',
             },
         });
-        expect(item).toEqual({id: 'item-1', name: 'Coffee'});
+        expect(item).toEqual({id: 'item-1', name: 'Coffee', etag: '"3"'});
     });
 
     it('getDetailedInfo returns null when the item endpoint responds with 404', async () => {
@@ -274,4 +275,64 @@ describe('items api', () => {
             }),
         });
     });
+
+    it('updateItem sends the ETag of the edited version in If-Match', async () => {
+        // Arrange
+        const service = new ItemsService();
+        const fetchMock = vi.fn().mockResolvedValue(new Response(null, {status: 204}));
+
+        vi.stubGlobal('fetch', fetchMock);
+
+        // Act
+        await service.updateItem(
+            'item-1',
+            {
+                name: 'Coffee',
+                price: 199.5,
+                description: '',
+                isActive: true,
+                cashback: 5,
+                category: 'FoodDrinks',
+                tagIds: [],
+            },
+            '"3"',
+        );
+
+        // Assert
+        expect(fetchMock).toHaveBeenCalledWith(
+            `${API_BASE_URL}/items/item-1`,
+            expect.objectContaining({
+                method: 'PUT',
+                headers: {
+                    'Content-Type': 'application/json',
+                    'If-Match': '"3"',
+                },
+            }),
+        );
+    });
+
+    it('deleteItem throws PreconditionFailedError when the item changed', async () => {
+        // Arrange
+        const service = new ItemsService();
+        const fetchMock = vi.fn().mockResolvedValue(
+            new Response(null, {
+                status: 412,
+                statusText: 'Precondition Failed',
+            }),
+        );
+
+        vi.stubGlobal('fetch', fetchMock);
+
+        // Act
+        const deletion = service.deleteItem('item-1', '"3"');
+
+        // Assert
+        await expect(deletion).rejects.toBeInstanceOf(PreconditionFailedError);
+        expect(fetchMock).toHaveBeenCalledWith(`${API_BASE_URL}/items/item-1`, {
+            method: 'DELETE',
+            headers: {
+                'If-Match': '"3"',
+            },
+        });
+    });
 });
@@ -1,5 +1,5 @@
-import {FinschedulerApiClient} from './finscheduler-api-client.ts';
-import type {PaginatedList} from './types.ts';
+import {FinschedulerApiClient, PreconditionFailedError} from './finscheduler-api-client.ts';
+import type {PaginatedList, Versioned} from './types.ts';
 import type {
     ItemDateFilterValue,
     ItemDetailedDto,
@@ -9,6 +9,10 @@ import type {
     ItemStatusFilter,
 } from './items.types.ts';
 
+// The UI still works with plain numeric amounts, so it asks the API for the
+// legacy representation instead of {amount, currency} objects.
+const legacyMoneyFormat = 'number';
+
 const itemDateFilterFields = {
     created: {
         from: 'createdFrom',
@@ -94,8 +98,8 @@ export function buildItemFilter(params: {
 
 export default class ItemsService extends FinschedulerApiClient {
     async getListingInfo(filter?: ItemFilter): Promise<PaginatedList<ItemListingDto>> {
-        const queryString = filter ? this.buildQueryString(filter) : '';
-        const response = await fetch(`${this.baseUrl}/items${queryString}`, {
+        const queryString = this.buildQueryString({...filter, moneyFormat: legacyMoneyFormat});
+        const response = await this.send(`${this.baseUrl}/items${queryString}`, {
             method: 'GET',
             headers: {
                 'Content-Type': 'application/json',
@@ -109,8 +113,8 @@ export default class ItemsService extends FinschedulerApiClient {
         return response.json();
     }
 
-    async getDetailedInfo(id: string): Promise<ItemDetailedDto | null> {
-        const response = await fetch(`${this.baseUrl}/items/${id}`, {
+    async getDetailedInfo(id: string): Promise<Versioned<ItemDetailedDto> | null> {
+        const response = await this.send(`${this.baseUrl}/items/${id}?moneyFormat=${legacyMoneyFormat}`, {
             method: 'GET',
             headers: {
                 'Content-Type': 'application/json',
@@ -125,11 +129,13 @@ export default class ItemsService extends FinschedulerApiClient {
             throw new Error(`Failed to fetch item: ${response.statusText}`);
         }
 
-        return response.json();
+        const item: ItemDetailedDto = await response.json();
+
+        return {...item, etag: this.readETag(response)};
     }
 
     async createItem(item: ItemModification): Promise<string> {
-        const response = await fetch(`${this.baseUrl}/items`, {
+        const response = await this.send(`${this.baseUrl}/items`, {
             method: 'POST',
             headers: {
                 'Content-Type': 'application/json',
@@ -152,11 +158,12 @@ export default class ItemsService extends FinschedulerApiClient {
         return response.json();
     }
 
-    async updateItem(id: string, item: ItemModification): Promise<void> {
-        const response = await fetch(`${this.baseUrl}/items/${id}`, {
+    async updateItem(id: string, item: ItemModification, etag: string): Promise<void> {
+        const response = await this.send(`${this.baseUrl}/items/${id}`, {
             method: 'PUT',
             headers: {
                 'Content-Type': 'application/json',
+                'If-Match': etag,
             },
             body: JSON.stringify({
                 name: item.name,
@@ -169,13 +176,17 @@ export default class ItemsService extends FinschedulerApiClient {
             }),
         });
 
+        if (response.status === 412) {
+            throw new PreconditionFailedError('Предмет был изменён, загружена актуальная версия');
+        }
+
         if (!response.ok) {
             throw new Error(`Failed to update item: ${response.statusText}`);
         }
     }
 
     async updateCashbackByTag(tagId: string, cashback: number): Promise<void> {
-        const response = await fetch(`${this.baseUrl}/items/cashback/tag`, {
+        const response = await this.send(`${this.baseUrl}/items/cashback/tag`, {
             method: 'PATCH',
             headers: {
                 'Content-Type': 'application/json',
@@ -192,7 +203,7 @@ export default class ItemsService extends FinschedulerApiClient {
     }
 
     async updateCashbackByItems(itemIds: string[], cashback: number): Promise<void> {
-        const response = await fetch(`${this.baseUrl}/items/cashback/items`, {
+        const response = await this.send(`${this.baseUrl}/items/cashback/items`, {
             method: 'PATCH',
             headers: {
                 'Content-Type': 'application/json',
@@ -208,11 +219,18 @@ export default class ItemsService extends FinschedulerApiClient {
         }
     }
 
-    async deleteItem(id: string): Promise<void> {
-        const response = await fetch(`${this.baseUrl}/items/${id}`, {
+    async deleteItem(id: string, etag: string): Promise<void> {
+        const response = await this.send(`${this.baseUrl}/items/${id}`, {
             method: 'DELETE',
+            headers: {
+                'If-Match': etag,
+            },
         });
 
+        if (response.status === 412) {
+            throw new PreconditionFailedError('Предмет был изменён, удаление отменено');
+        }
+
         if (!response.ok) {
             throw new Error(`Failed to delete item: ${response.statusText}`);
         }
@@ -1,5 +1,6 @@
 import {afterEach, describe, expect, it, vi} from 'vitest';
 import {API_BASE_URL} from '../config/api.ts';
+import {PreconditionFailedError} from './finscheduler-api-client.ts';
 import TagsService, {buildTagFilter} from './tags.ts';
 
 describe('tags api', () => {
@@ -53,7 +54,7 @@ describe('tags api', () => {
         const fetchMock = vi.fn().mockResolvedValue(
             new Response(JSON.stringify({id: 'tag-1', name: 'Food'}), {
                 status: 200,
-                headers: {'Content-Type': 'application/json'},
+                headers: {'Content-Type': 'application/json', ETag: '"2"'},
             }),
         );
 
@@ -69,7 +70,7 @@ describe('tags api', () => {
                 'Content-Type': 'application/json',
             },
         });
-        expect(tag).toEqual({id: 'tag-1', name: 'Food'});
+        expect(tag).toEqual({id: 'tag-1', name: 'Food', etag: '"2"'});
     });
 
     it('getDetailedInfo returns null when the tag endpoint responds with 404', async () => {
@@ -90,4 +91,34 @@ describe('tags api', () => {
         // Assert
         expect(tag).toBeNull();
     });
+
+    it('updateTag throws PreconditionFailedError when the tag changed', async () => {
+        // Arrange
+        const service = new TagsService();
+        const fetchMock = vi.fn().mockResolvedValue(
+            new Response(null, {
+                status: 412,
+                statusText: 'Precondition Failed',
+            }),
+        );
+
+        vi.stubGlobal('fetch', fetchMock);
+
+        // Act
+        const update = service.updateTag('tag-1', {name: 'Food', isActive: true}, '"2"');
+
+        // Assert
+        await expect(update).rejects.toBeInstanceOf(PreconditionFailedError);
+        expect(fetchMock).toHaveBeenCalledWith(`${API_BASE_URL}/tags/tag-1`, {
+            method: 'PUT',
+            headers: {
+                'Content-Type': 'application/json',
+                'If-Match': '"2"',
+            },
+            body: JSON.stringify({
+                name: 'Food',
+                isActive: true,
+            }),
+        });
+    });
 });
@@ -1,4 +1,4 @@
-import type {Lookup, PaginatedList} from './types';
+import type {Lookup, PaginatedList, Versioned} from './types';
 import type {
     TagDetailedDto,
     TagFilter,
@@ -7,7 +7,7 @@ import type {
     TagModification,
     TagStatusFilter,
 } from './tags.types.ts';
-import {FinschedulerApiClient} from './finscheduler-api-client.ts';
+import {FinschedulerApiClient, PreconditionFailedError} from './finscheduler-api-client.ts';
 
 export function buildTagFilter(params: {
     page: number;
@@ -34,7 +34,7 @@ export function buildTagFilter(params: {
 export default class TagsService extends FinschedulerApiClient {
     async getListingInfo(filter?: TagFilter): Promise<PaginatedList<TagListingDto>> {
         const queryString = filter ? this.buildQueryString(filter) : '';
-        const response = await fetch(`${this.baseUrl}/tags${queryString}`, {
+        const response = await this.send(`${this.baseUrl}/tags${queryString}`, {
             method: 'GET',
             headers: {
                 'Content-Type': 'application/json',
@@ -48,8 +48,8 @@ export default class TagsService extends FinschedulerApiClient {
         return response.json();
     }
 
-    async getDetailedInfo(id: string): Promise<TagDetailedDto | null> {
-        const response = await fetch(`${this.baseUrl}/tags/${id}`, {
+    async getDetailedInfo(id: string): Promise<Versioned<TagDetailedDto> | null> {
+        const response = await this.send(`${this.baseUrl}/tags/${id}`, {
             method: 'GET',
             headers: {
                 'Content-Type': 'application/json',
@@ -64,11 +64,13 @@ export default class TagsService extends FinschedulerApiClient {
             throw new Error(`Failed to fetch tag: ${response.statusText}`);
         }
 
-        return response.json();
+        const tag: TagDetailedDto = await response.json();
+
+        return {...tag, etag: this.readETag(response)};
     }
 
     async createTag(item: TagModification): Promise<string> {
-        const response = await fetch(`${this.baseUrl}/tags`, {
+        const response = await this.send(`${this.baseUrl}/tags`, {
             method: 'POST',
             headers: {
                 'Content-Type': 'application/json',
@@ -86,11 +88,12 @@ export default class TagsService extends FinschedulerApiClient {
         return response.json();
     }
 
-    async updateTag(id: string, item: TagModification): Promise<void> {
-        const response = await fetch(`${this.baseUrl}/tags/${id}`, {
+    async updateTag(id: string, item: TagModification, etag: string): Promise<void> {
+        const response = await this.send(`${this.baseUrl}/tags/${id}`, {
             method: 'PUT',
             headers: {
                 'Content-Type': 'application/json',
+                'If-Match': etag,
             },
             body: JSON.stringify({
                 name: item.name,
@@ -98,6 +101,10 @@ export default class TagsService extends FinschedulerApiClient {
             }),
         });
 
+        if (response.status === 412) {
+            throw new PreconditionFailedError('Тег был изменён, загружена актуальная версия');
+        }
+
         if (!response.ok) {
             throw new Error(`Failed to update item: ${response.statusText}`);
         }
@@ -105,7 +112,7 @@ export default class TagsService extends FinschedulerApiClient {
 
     async getLookup(filter?: TagLookupFilter): Promise<PaginatedList<Lookup>> {
         const queryString = filter ? this.buildQueryString(filter) : '';
-        const response = await fetch(`${this.baseUrl}/tags/lookup${queryString}`, {
+        const response = await this.send(`${this.baseUrl}/tags/lookup${queryString}`, {
             method: 'GET',
             headers: {
                 'Content-Type': 'application/json',
@@ -7,3 +7,9 @@ export interface Lookup {
     value: string;
     label?: string;
 }
+
+// Versioned is a resource read together with the ETag of its current version,
+// which edits send back in If-Match.
+export type Versioned<T> = T & {
+    etag: string;
+};
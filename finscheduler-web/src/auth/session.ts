@@ -0,0 +1,80 @@
+import type {TokenDto} from '../api/auth.types.ts';
+
+// The tokens are kept in localStorage so that a reload or a second tab stays
+// logged in; the access token is short-lived and refreshed on 401.
+const sessionStorageKey = 'finscheduler.session';
+
+export interface Session {
+    accessToken: string;
+    refreshToken: string;
+}
+
+type SessionListener = () => void;
+
+const listeners = new Set<SessionListener>();
+let cachedValue: string | null | undefined;
+let cachedSession: Session | null = null;
+
+function parseSession(value: string | null): Session | null {
+    if (!value) {
+        return null;
+    }
+
+    try {
+        const parsed = JSON.parse(value) as Partial<Session>;
+
+        if (typeof parsed.accessToken !== 'string' || typeof parsed.refreshToken !== 'string') {
+            return null;
+        }
+
+        return {accessToken: parsed.accessToken, refreshToken: parsed.refreshToken};
+    } catch {
+        return null;
+    }
+}
+
+function notifyListeners() {
+    listeners.forEach((listener) => listener());
+}
+
+export function getSession(): Session | null {
+    const value = localStorage.getItem(sessionStorageKey);
+
+    if (value !== cachedValue) {
+        cachedValue = value;
+        cachedSession = parseSession(value);
+    }
+
+    return cachedSession;
+}
+
+export function saveSession(token: TokenDto): void {
+    const session: Session = {
+        accessToken: token.accessToken,
+        refreshToken: token.refreshToken,
+    };
+
+    localStorage.setItem(sessionStorageKey, JSON.stringify(session));
+    notifyListeners();
+}
+
+export function clearSession(): void {
+    localStorage.removeItem(sessionStorageKey);
+    notifyListeners();
+}
+
+export function subscribeSession(listener: SessionListener): () => void {
+    const handleStorage = (event: StorageEvent) => {
+        if (event.key === sessionStorageKey || event.key === null) {
+            listener();
+        }
+    };
+
+    listeners.add(listener);
+    window.addEventListener('storage', handleStorage);
+
+    return () => {
+        listeners.delete(listener);
+        window.removeEventListener('storage', handleStorage);
+    };
+}
@@ -4,6 +4,7 @@ interface TextFieldProps {
     label: string;
     value: string;
     onChange: (value: string) => void;
+    type?: 'text' | 'email' | 'password';
     placeholder?: string;
     required?: boolean;
     invalid?: boolean;
@@ -14,6 +15,7 @@ export default function TextField({
     label,
     value,
     onChange,
+    type = 'text',
     placeholder,
     required = false,
     invalid = false,
@@ -25,6 +27,7 @@ export default function TextField({
         <Field.Root required={required} invalid={isInvalid} gap={1}>
             <Field.Label color="neon.blue">{label}</Field.Label>
             <Input
+                type={type}
                 value={value}
                 onChange={(e) => onChange(e.target.value)}
                 bg="bg.layer2"
@@ -0,0 +1,85 @@
+import {screen, waitFor} from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import {http, HttpResponse} from 'msw';
+import type {RouteObject} from 'react-router-dom';
+import {afterEach, describe, expect, it} from 'vitest';
+import type {Credentials} from '../../api/auth.types.ts';
+import {clearSession, getSession} from '../../auth/session.ts';
+import {API_BASE_URL} from '../../config/api.ts';
+import {renderWithDataRouter} from '../../test/renderDataRouter.tsx';
+import {server} from '../../test/msw/server.ts';
+import {dashboardPath, loginPath} from '../routes.ts';
+import LoginPage from './LoginPage.tsx';
+
+function renderLoginRoutes() {
+    const routes: RouteObject[] = [
+        {path: loginPath, element: <LoginPage />},
+        {path: dashboardPath, element: <div>Dashboard Page</div>},
+    ];
+
+    return renderWithDataRouter(routes, {
+        initialEntries: [loginPath],
+    });
+}
+
+describe('LoginPage integration', () => {
+    afterEach(() => {
+        clearSession();
+    });
+
+    it('stores the session and opens the dashboard after logging in', async () => {
+        // Arrange
+        let credentials: Credentials | null = null;
+
+        server.use(
+            http.post(`${API_BASE_URL}/auth/login`, async ({request}) => {
+                credentials = (await request.json()) as Credentials;
+
+                return HttpResponse.json({
+                    accessToken: 'access-token',
+                    refreshToken: 'refresh-token',
+                    tokenType: 'Bearer',
+                    expiresIn: 900,
+                });
+            }),
+        );
+
+        const user = userEvent.setup();
+
+        renderLoginRoutes();
+
+        // Act
+        await user.type(screen.getByLabelText('Email'), 'owner@example.com');
+        await user.type(screen.getByLabelText('Пароль'), 'correct-horse');
+        await user.click(screen.getByRole('button', {name: 'Войти'}));
+
+        // Assert
+        expect(await screen.findByText('Dashboard Page')).toBeInTheDocument();
+        expect(credentials).toEqual({email: 'owner@example.com', password: 'correct-horse'});
+        expect(getSession()).toEqual({accessToken: 'access-token', refreshToken: 'refresh-token'});
+    });
+
+    it('shows an error and stays logged out when the credentials are rejected', async () => {
+        // Arrange
+        server.use(
+            http.post(`${API_BASE_URL}/auth/login`, () => {
+                return new HttpResponse(null, {status: 401, statusText: 'Unauthorized'});
+            }),
+        );
+
+        const user = userEvent.setup();
+
+        renderLoginRoutes();
+
+        // Act
+        await user.type(screen.getByLabelText('Email'), 'owner@example.com');
+        await user.type(screen.getByLabelText('Пароль'), 'wrong');
+        await user.click(screen.getByRole('button', {name: 'Войти'}));
+
+        // Assert
+        expect(await screen.findByText('Неверный email или пароль')).toBeInTheDocument();
+        await waitFor(() => {
+            expect(getSession()).toBeNull();
+        });
+    });
+});
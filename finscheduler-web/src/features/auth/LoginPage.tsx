@@ -0,0 +1,80 @@
+import {Button, Card, Flex, Stack, Text} from '@chakra-ui/react';
+import {type FormEvent, useState} from 'react';
+import {Navigate, useLocation, type Location} from 'react-router-dom';
+import TextField from '../../components/formFields/TextField.tsx';
+import {useSession} from '../../hooks/useSession.ts';
+import {dashboardPath} from '../routes.ts';
+import {useLoginMutation} from './queries.ts';
+
+interface LoginLocationState {
+    from?: Location;
+}
+
+export default function LoginPage() {
+    const session = useSession();
+    const location = useLocation();
+    const loginMutation = useLoginMutation();
+    const [email, setEmail] = useState('');
+    const [password, setPassword] = useState('');
+    const [error, setError] = useState<string | null>(null);
+
+    if (session) {
+        const from = (location.state as LoginLocationState | null)?.from;
+
+        return <Navigate to={from ?? dashboardPath} replace />;
+    }
+
+    const handleSubmit = async (event: FormEvent<HTMLFormElement>) => {
+        event.preventDefault();
+        setError(null);
+
+        if (!email.trim() || !password) {
+            setError('Введите email и пароль');
+            return;
+        }
+
+        try {
+            await loginMutation.mutateAsync({email: email.trim(), password});
+        } catch (err) {
+            setError(err instanceof Error ? err.message : 'Не удалось войти');
+        }
+    };
+
+    return (
+        <Flex w="100vw" h="100vh" align="center" justify="center" bg="background" px="4">
+            <Card.Root width="100%" maxW="400px">
+                <Card.Header>
+                    <Card.Title>Вход в FinScheduler</Card.Title>
+                </Card.Header>
+                <Card.Body>
+                    <form onSubmit={handleSubmit} noValidate>
+                        <Stack gap={4}>
+                            <TextField
+                                label="Email"
+                                type="email"
+                                value={email}
+                                required
+                                onChange={setEmail}
+                            />
+                            <TextField
+                                label="Пароль"
+                                type="password"
+                                value={password}
+                                required
+                                onChange={setPassword}
+                            />
+                            {error ? (
+                                <Text color="fg.error" fontWeight="semibold">
+                                    {error}
+                                </Text>
+                            ) : null}
+                            <Button type="submit" loading={loginMutation.isPending}>
+                                Войти
+                            </Button>
+                        </Stack>
+                    </form>
+                </Card.Body>
+            </Card.Root>
+        </Flex>
+    );
+}
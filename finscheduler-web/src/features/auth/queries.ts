@@ -0,0 +1,18 @@
+import {useMutation, useQueryClient} from '@tanstack/react-query';
+import AuthService from '../../api/auth.ts';
+import type {Credentials} from '../../api/auth.types.ts';
+import {saveSession} from '../../auth/session.ts';
+
+const authService = new AuthService();
+
+export function useLoginMutation() {
+    const queryClient = useQueryClient();
+
+    return useMutation({
+        mutationFn: (credentials: Credentials) => authService.login(credentials),
+        onSuccess: (token) => {
+            queryClient.clear();
+            saveSession(token);
+        },
+    });
+}
@@ -122,23 +122,28 @@ describe('ItemDetailsPage integration', () => {
     it('updates an existing item and returns to the list after save and close', async () => {
         // Arrange
         let updatedPayload: ItemModification | null = null;
+        let ifMatch: string | null = null;
 
         server.use(
             http.get(`${API_BASE_URL}/items/item-1`, () => {
-                return HttpResponse.json({
-                    id: 'item-1',
-                    name: 'Old Item',
-                    description: 'Morning drink',
-                    price: 199.5,
-                    cashback: 5,
-                    isActive: true,
-                    category: 'FoodDrinks',
-                    tags: [],
-                    priceHistory: [],
-                });
+                return HttpResponse.json(
+                    {
+                        id: 'item-1',
+                        name: 'Old Item',
+                        description: 'Morning drink',
+                        price: 199.5,
+                        cashback: 5,
+                        isActive: true,
+                        category: 'FoodDrinks',
+                        tags: [],
+                        priceHistory: [],
+                    },
+                    {headers: {ETag: '"3"'}},
+                );
             }),
             http.put(`${API_BASE_URL}/items/item-1`, async ({request}) => {
                 updatedPayload = (await request.json()) as ItemModification;
+                ifMatch = request.headers.get('If-Match');
 
                 return new HttpResponse(null, {status: 200});
             }),
@@ -168,9 +173,55 @@ describe('ItemDetailsPage integration', () => {
                 tagIds: [],
             });
         });
+        expect(ifMatch).toBe('"3"');
         expect(await screen.findByText('Items Listing Page')).toBeInTheDocument();
     });
 
+    it('shows a version conflict and reloads the item after a 412 response', async () => {
+        // Arrange
+        let detailRequests = 0;
+
+        server.use(
+            http.get(`${API_BASE_URL}/items/item-1`, () => {
+                detailRequests += 1;
+
+                return HttpResponse.json(
+                    {
+                        id: 'item-1',
+                        name: detailRequests === 1 ? 'Old Item' : 'Changed Elsewhere',
+                        description: 'Morning drink',
+                        price: 199.5,
+                        cashback: 5,
+                        isActive: true,
+                        category: 'FoodDrinks',
+                        tags: [],
+                        priceHistory: [],
+                    },
+                    {headers: {ETag: `"${detailRequests + 2}"`}},
+                );
+            }),
+            http.put(`${API_BASE_URL}/items/item-1`, () => {
+                return new HttpResponse(null, {status: 412, statusText: 'Precondition Failed'});
+            }),
+        );
+
+        const user = userEvent.setup();
+
+        // Act
+        renderItemDetailsRoutes([buildEditItemPath('item-1')]);
+        await screen.findByText('Редактирование предмета');
+        await user.clear(screen.getByLabelText('Название'));
+        await user.type(screen.getByLabelText('Название'), 'Updated Item');
+        await user.click(screen.getByRole('button', {name: 'Сохранить'}));
+
+        // Assert
+        expect(await screen.findByText('Конфликт версий')).toBeInTheDocument();
+        await waitFor(() => {
+            expect(screen.getByLabelText('Название')).toHaveValue('Changed Elsewhere');
+        });
+        expect(detailRequests).toBe(2);
+    });
+
     it('renders the price history chart and summary for an existing item', async () => {
         // Arrange
         server.use(
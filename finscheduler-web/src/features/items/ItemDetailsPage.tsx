@@ -2,6 +2,7 @@ import {Box, Card, Flex, SimpleGrid, Spinner, Stack} from '@chakra-ui/react';
 import {useEffect, useMemo, useState} from 'react';
 import {Controller, useForm, useWatch} from 'react-hook-form';
 import {useNavigate, useParams} from 'react-router-dom';
+import {PreconditionFailedError} from '../../api/finscheduler-api-client.ts';
 import AsyncSelectField from '../../components/formFields/AsyncSelectField.tsx';
 import NumberField from '../../components/formFields/NumberField.tsx';
 import PriceHistoryChart from '../../components/priceHistoryChart/PriceHistoryChart.tsx';
@@ -116,11 +117,11 @@ export default function ItemDetailsPage({mode}: ItemDetailsPageProps) {
                 return;
             }
 
-            if (!itemId) {
+            if (!itemId || !item) {
                 throw new Error('Не удалось определить предмет для сохранения');
             }
 
-            await updateItemMutation.mutateAsync({itemId, item: payload});
+            await updateItemMutation.mutateAsync({itemId, item: payload, etag: item.etag});
             reset(normalizedFormData);
 
             toaster.create({
@@ -133,6 +134,11 @@ export default function ItemDetailsPage({mode}: ItemDetailsPageProps) {
                 scheduleNavigation(itemsListPath);
             }
         } catch (err) {
+            if (err instanceof PreconditionFailedError) {
+                setStatus({title: 'Конфликт версий', description: err.message});
+                return;
+            }
+
             setStatus(err instanceof Error ? err.message : 'Ошибка при сохранении');
         }
     };
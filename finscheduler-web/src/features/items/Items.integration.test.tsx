@@ -499,6 +499,7 @@ describe('Items integration', () => {
             buildItem({id: 'item-2', name: 'Tea'}),
         ];
         const deletedIds: string[] = [];
+        const deleteVersions: (string | null)[] = [];
 
         server.use(
             http.get(`${API_BASE_URL}/items`, () => {
@@ -507,11 +508,18 @@ describe('Items integration', () => {
                     count: currentItems.length,
                 });
             }),
-            http.delete(`${API_BASE_URL}/items/:id`, ({params}) => {
+            http.get(`${API_BASE_URL}/items/:id`, ({params}) => {
+                return HttpResponse.json(
+                    {id: String(params.id), name: 'Coffee'},
+                    {headers: {ETag: '"7"'}},
+                );
+            }),
+            http.delete(`${API_BASE_URL}/items/:id`, ({params, request}) => {
                 const id = String(params.id);
                 const itemIndex = currentItems.findIndex((item) => item.id === id);
 
                 deletedIds.push(id);
+                deleteVersions.push(request.headers.get('If-Match'));
 
                 if (itemIndex >= 0) {
                     currentItems.splice(itemIndex, 1);
@@ -541,6 +549,7 @@ describe('Items integration', () => {
         await waitFor(() => {
             expect(deletedIds).toEqual(['item-1']);
         });
+        expect(deleteVersions).toEqual(['"7"']);
         expect(screen.queryByText('Coffee')).not.toBeInTheDocument();
         expect(await screen.findByText('Tea')).toBeInTheDocument();
     });
@@ -1,6 +1,8 @@
 import {useMutation, useQuery, useQueryClient} from '@tanstack/react-query';
+import {PreconditionFailedError} from '../../api/finscheduler-api-client.ts';
 import ItemsService from '../../api/items.ts';
 import type {ItemDetailedDto, ItemFilter, ItemModification} from '../../api/items.types.ts';
+import type {Versioned} from '../../api/types.ts';
 
 const itemsService = new ItemsService();
 
@@ -20,7 +22,7 @@ export function useItemsListQuery(filter: ItemFilter) {
 }
 
 export function useItemDetailsQuery(itemId?: string) {
-    return useQuery<ItemDetailedDto | null>({
+    return useQuery<Versioned<ItemDetailedDto> | null>({
         queryKey: itemsQueryKeys.detail(itemId ?? ''),
         queryFn: () => itemsService.getDetailedInfo(itemId!),
         enabled: Boolean(itemId),
@@ -42,8 +44,8 @@ export function useUpdateItemMutation() {
     const queryClient = useQueryClient();
 
     return useMutation({
-        mutationFn: async (params: {itemId: string; item: ItemModification}) => {
-            await itemsService.updateItem(params.itemId, params.item);
+        mutationFn: async (params: {itemId: string; item: ItemModification; etag: string}) => {
+            await itemsService.updateItem(params.itemId, params.item, params.etag);
             return params.itemId;
         },
         onSuccess: async (itemId) => {
@@ -52,6 +54,13 @@ export function useUpdateItemMutation() {
                 queryClient.invalidateQueries({queryKey: itemsQueryKeys.detail(itemId)}),
             ]);
         },
+        onError: async (error, params) => {
+            if (error instanceof PreconditionFailedError) {
+                await queryClient.invalidateQueries({
+                    queryKey: itemsQueryKeys.detail(params.itemId),
+                });
+            }
+        },
     });
 }
 
@@ -60,10 +69,24 @@ export function useDeleteItemsMutation() {
 
     return useMutation({
         mutationFn: async (itemIds: string[]) => {
-            await Promise.all(itemIds.map((itemId) => itemsService.deleteItem(itemId)));
+            // The listing carries no versions, so each item is deleted at the
+            // version of its cached detail, read once if it is not cached yet.
+            await Promise.all(
+                itemIds.map(async (itemId) => {
+                    const item = await queryClient.fetchQuery({
+                        queryKey: itemsQueryKeys.detail(itemId),
+                        queryFn: () => itemsService.getDetailedInfo(itemId),
+                        staleTime: Infinity,
+                    });
+
+                    if (item) {
+                        await itemsService.deleteItem(itemId, item.etag);
+                    }
+                }),
+            );
             return itemIds;
         },
-        onSuccess: async () => {
+        onSettled: async () => {
             await queryClient.invalidateQueries({queryKey: itemsQueryKeys.all});
         },
     });
@@ -1,4 +1,5 @@
 export const dashboardPath = '/';
+export const loginPath = '/login';
 
 export const itemsListPath = '/items';
 export const newItemPath = '/items/new';
@@ -106,17 +106,22 @@ describe('TagDetailsPage integration', () => {
     it('updates an existing tag and returns to the list after save and close', async () => {
         // Arrange
         let updatedPayload: TagModification | null = null;
+        let ifMatch: string | null = null;
 
         server.use(
             http.get(`${API_BASE_URL}/tags/tag-1`, () => {
-                return HttpResponse.json({
-                    id: 'tag-1',
-                    name: 'Old Tag',
-                    isActive: true,
-                });
+                return HttpResponse.json(
+                    {
+                        id: 'tag-1',
+                        name: 'Old Tag',
+                        isActive: true,
+                    },
+                    {headers: {ETag: '"2"'}},
+                );
             }),
             http.put(`${API_BASE_URL}/tags/tag-1`, async ({request}) => {
                 updatedPayload = (await request.json()) as TagModification;
+                ifMatch = request.headers.get('If-Match');
 
                 return new HttpResponse(null, {status: 200});
             }),
@@ -141,6 +146,7 @@ describe('TagDetailsPage integration', () => {
                 isActive: true,
             });
         });
+        expect(ifMatch).toBe('"2"');
         expect(await screen.findByText('Tags Listing Page')).toBeInTheDocument();
     });
 
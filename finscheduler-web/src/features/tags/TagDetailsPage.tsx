@@ -12,6 +12,7 @@ import {
 import {useEffect, useMemo, useState} from 'react';
 import {Controller, useForm, useWatch} from 'react-hook-form';
 import {useNavigate, useParams} from 'react-router-dom';
+import {PreconditionFailedError} from '../../api/finscheduler-api-client.ts';
 import SwitchField from '../../components/formFields/SwitchField.tsx';
 import TextField from '../../components/formFields/TextField.tsx';
 import UnsavedChangesDialog from '../../components/unsavedChanges/UnsavedChangesDialog.tsx';
@@ -123,11 +124,11 @@ export default function TagDetailsPage({mode}: TagDetailsPageProps) {
                 return;
             }
 
-            if (!tagId) {
+            if (!tagId || !tag) {
                 throw new Error('Не удалось определить тег для сохранения');
             }
 
-            await updateTagMutation.mutateAsync({tagId, tag: payload});
+            await updateTagMutation.mutateAsync({tagId, tag: payload, etag: tag.etag});
             reset(normalizedFormData);
 
             toaster.create({
@@ -140,6 +141,11 @@ export default function TagDetailsPage({mode}: TagDetailsPageProps) {
                 scheduleNavigation(tagsListPath);
             }
         } catch (err) {
+            if (err instanceof PreconditionFailedError) {
+                setStatus({title: 'Конфликт версий', description: err.message});
+                return;
+            }
+
             setStatus(err instanceof Error ? err.message : 'Ошибка при сохранении');
         }
     };
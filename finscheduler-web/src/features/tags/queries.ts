@@ -7,6 +7,8 @@ import type {
     TagModification,
 } from '../../api/tags.types.ts';
 import TagsService from '../../api/tags.ts';
+import {PreconditionFailedError} from '../../api/finscheduler-api-client.ts';
+import type {Versioned} from '../../api/types.ts';
 import {mapLookupsToSelectOptions} from '../shared.ts';
 import {itemsQueryKeys} from '../items/queries.ts';
 
@@ -30,7 +32,7 @@ export function useTagsListQuery(filter: TagFilter) {
 }
 
 export function useTagDetailsQuery(tagId?: string) {
-    return useQuery<TagDetailedDto | null>({
+    return useQuery<Versioned<TagDetailedDto> | null>({
         queryKey: tagsQueryKeys.detail(tagId ?? ''),
         queryFn: () => tagsService.getDetailedInfo(tagId!),
         enabled: Boolean(tagId),
@@ -55,8 +57,8 @@ export function useUpdateTagMutation() {
     const queryClient = useQueryClient();
 
     return useMutation({
-        mutationFn: async (params: {tagId: string; tag: TagModification}) => {
-            await tagsService.updateTag(params.tagId, params.tag);
+        mutationFn: async (params: {tagId: string; tag: TagModification; etag: string}) => {
+            await tagsService.updateTag(params.tagId, params.tag, params.etag);
             return params.tagId;
         },
         onSuccess: async (tagId) => {
@@ -67,6 +69,11 @@ export function useUpdateTagMutation() {
                 queryClient.invalidateQueries({queryKey: itemsQueryKeys.all}),
             ]);
         },
+        onError: async (error, params) => {
+            if (error instanceof PreconditionFailedError) {
+                await queryClient.invalidateQueries({queryKey: tagsQueryKeys.detail(params.tagId)});
+            }
+        },
     });
 }
 
@@ -0,0 +1,6 @@
+import {useSyncExternalStore} from 'react';
+import {getSession, subscribeSession} from '../auth/session.ts';
+
+export function useSession() {
+    return useSyncExternalStore(subscribeSession, getSession);
+}
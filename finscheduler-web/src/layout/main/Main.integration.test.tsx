@@ -1,11 +1,13 @@
 import {screen} from '@testing-library/react';
 import userEvent from '@testing-library/user-event';
-import {describe, expect, it, vi} from 'vitest';
+import {afterEach, beforeEach, describe, expect, it, vi} from 'vitest';
+import {clearSession, saveSession} from '../../auth/session.ts';
 import {
     buildEditItemPath,
     buildEditTagPath,
     dashboardPath,
     itemsListPath,
+    loginPath,
     tagsListPath,
 } from '../../features/routes.ts';
 import {
@@ -94,7 +96,39 @@ vi.mock('../../components/ui/toaster.tsx', () => {
     };
 });
 
+vi.mock('../../features/auth/LoginPage.tsx', () => ({
+    default: function LoginPageMock() {
+        return <div>Login Page</div>;
+    },
+}));
+
 describe('Main and Sidebar integration', () => {
+    beforeEach(() => {
+        saveSession({
+            accessToken: 'access-token',
+            refreshToken: 'refresh-token',
+            tokenType: 'Bearer',
+            expiresIn: 900,
+        });
+    });
+
+    afterEach(() => {
+        clearSession();
+    });
+
+    it('redirects to the login page without a session', () => {
+        // Arrange
+        clearSession();
+
+        // Act
+        const {router} = renderWithDataRouter(appRoutes, {initialEntries: [itemsListPath]});
+
+        // Assert
+        expect(screen.getByText('Login Page')).toBeInTheDocument();
+        expect(router.state.location.pathname).toBe(loginPath);
+        expect(screen.queryByText('Items Page')).not.toBeInTheDocument();
+    });
+
     it('renders the current route title, page content, and active sidebar link', () => {
         // Arrange
         renderWithDataRouter(appRoutes, {initialEntries: [itemsListPath]});
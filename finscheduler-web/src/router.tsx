@@ -1,8 +1,14 @@
 import {createBrowserRouter, type RouteObject} from 'react-router-dom';
 import App from './App.tsx';
+import LoginPage from './features/auth/LoginPage.tsx';
+import {loginPath} from './features/routes.ts';
 import {mainRouteChildren} from './layout/main/routes.tsx';
 
 export const appRoutes: RouteObject[] = [
+    {
+        path: loginPath,
+        element: <LoginPage />,
+    },
     {
         element: <App />,
         children: mainRouteChildren,
@@ -102,11 +102,25 @@ check_secret_files() {
   done
 }
 
+check_secret_keys() {
+  local secret_file="$1"
+  shift
+
+  local key
+  for key in "$@"; do
+    if ! grep -Eq "^[[:space:]]+${key}:[[:space:]]*[^[:space:]]" "${secret_file}"; then
+      echo "Missing ${key} in ${secret_file}"
+      return 1
+    fi
+  done
+}
+
 init_script
 check_secret_files \
   "${REPO_ROOT}/k8s/base/api/secret.yaml" \
   "${REPO_ROOT}/k8s/base/storage/minio/secret.yaml" \
   "${REPO_ROOT}/k8s/base/observability/grafana/admin-secret.yaml" \
   "${REPO_ROOT}/k8s/base/observability/grafana/db-secret.yaml"
+check_secret_keys "${REPO_ROOT}/k8s/base/api/secret.yaml" CONNECTION_STRING AUTH_SIGNING_KEY
 
 echo "Kubernetes secret files look ready"